@@ -14,9 +14,12 @@ import (
 )
 
 type Config struct {
-	App     *AppConfig         `toml:"application"`
-	Auth    *AuthServiceConfig `toml:"authentication-service"`
-	Storage *StorageConfig     `toml:"storage" comment:"Choose one of storage config as prefer storage. If you provide multiple settings, the system uses them in priority order: 'storage.postgres'."`
+	App       *AppConfig         `toml:"application"`
+	Auth      *AuthServiceConfig `toml:"authentication-service"`
+	SignUp    *SignUpConfig      `toml:"sign-up"`
+	Icons     *IconsConfig       `toml:"icons"`
+	Storage   *StorageConfig     `toml:"storage" comment:"Choose one of storage config as prefer storage. If you provide multiple settings, the system uses them in priority order: 'storage.postgres'."`
+	Scheduler *SchedulerConfig   `toml:"scheduler"`
 }
 
 type AppConfig struct {
@@ -34,6 +37,19 @@ type AuthServiceConfig struct {
 	AccessTokenExpireAfter  encoding.Duration `toml:"access-token-expire-after" comment:"Period of the access token expiration. If the value is not provided, the default is 10 minutes."`
 }
 
+type SignUpConfig struct {
+	Locale       string `toml:"locale" comment:"Locale of the built-in template of the default categories and accounts created for the new users, one of 'zh-TW' and 'en'. If the value is not provided, the default is 'zh-TW'."`
+	TemplatePath string `toml:"template-path" comment:"Path to the TOML template of the default categories and accounts, it overrides the built-in template of the locale if it is provided."`
+}
+
+type IconsConfig struct {
+	CatalogPath string `toml:"catalog-path" comment:"Path to the TOML catalog of the icons which the accounts and the categories can use, it overrides the built-in catalog if it is provided."`
+}
+
+type SchedulerConfig struct {
+	RepeatingItemsInterval encoding.Duration `toml:"repeating-items-interval" comment:"Interval to create the due daily items of the repeating items. If the value is not provided, the default is 1 hour."`
+}
+
 type StorageConfig struct {
 	Postgres *postgres.Config `toml:"postgres" comment:"Connection settings of postgres."`
 }
@@ -70,6 +86,13 @@ func CreateDefaultConfig(path string) (err error) {
 			AccessTokenSigningKey:   "THIS_IS_UNSECURE_SIGNED_KEY",
 			AccessTokenExpireAfter:  encoding.Duration(10 * time.Minute),
 		},
+		SignUp: &SignUpConfig{
+			Locale:       "zh-TW",
+			TemplatePath: "",
+		},
+		Icons: &IconsConfig{
+			CatalogPath: "",
+		},
 		Storage: &StorageConfig{
 			Postgres: &postgres.Config{
 				Host:            "",
@@ -84,6 +107,9 @@ func CreateDefaultConfig(path string) (err error) {
 				MaxOpenConns:    2,
 			},
 		},
+		Scheduler: &SchedulerConfig{
+			RepeatingItemsInterval: encoding.Duration(time.Hour),
+		},
 	}
 
 	f, err := os.Create(path)
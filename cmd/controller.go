@@ -2,19 +2,43 @@ package main
 
 import (
 	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/budgets"
+	"github.com/n101661/maney/server/calendar"
 	"github.com/n101661/maney/server/categories"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
 	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/icons"
 	"github.com/n101661/maney/server/impl/iris"
+	"github.com/n101661/maney/server/ledger"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/reports"
 	"github.com/n101661/maney/server/shops"
+	"github.com/n101661/maney/server/statements"
+	"github.com/n101661/maney/server/tags"
+	"github.com/n101661/maney/server/transfers"
+	"github.com/n101661/maney/server/unitconversions"
 	"github.com/n101661/maney/server/users"
 )
 
 func newIrisController(services *Services) *iris.Controllers {
 	return &iris.Controllers{
-		User:     users.NewIrisController(services.User),
-		Account:  accounts.NewIrisController(services.Account),
-		Category: categories.NewIrisController(services.Category),
-		Shop:     shops.NewIrisController(services.Shop),
-		Fee:      fees.NewIrisController(services.Fee),
+		User:           users.NewIrisController(services.User),
+		Account:        accounts.NewIrisController(services.Account),
+		Category:       categories.NewIrisController(services.Category),
+		Icon:           icons.NewIrisController(services.Icon),
+		Shop:           shops.NewIrisController(services.Shop),
+		Tag:            tags.NewIrisController(services.Tag),
+		UnitConversion: unitconversions.NewIrisController(services.UnitConversion),
+		Fee:            fees.NewIrisController(services.Fee),
+		DailyItem:      dailyitems.NewIrisController(services.DailyItem),
+		Calendar:       calendar.NewIrisController(services.Calendar),
+		RepeatingItem:  repeatingitems.NewIrisController(services.RepeatingItem),
+		Transfer:       transfers.NewIrisController(services.Transfer),
+		Statement:      statements.NewIrisController(services.Statement),
+		Budget:         budgets.NewIrisController(services.Budget),
+		ExchangeRate:   exchangerates.NewIrisController(services.ExchangeRate),
+		Report:         reports.NewIrisController(services.Report),
+		Ledger:         ledger.NewIrisController(services.Ledger),
 	}
 }
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
+
+	"github.com/kataras/golog"
 
 	"github.com/n101661/maney/server/impl/iris"
+	"github.com/n101661/maney/server/repeatingitems"
 )
 
 const configPath = "config.toml"
@@ -31,12 +36,25 @@ func main() {
 	}
 	defer repos.Close()
 
-	services, err := newServices(repos, config.Auth)
+	services, err := newServices(repos, config.Auth, config.SignUp, config.Icons)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var repeatingItemsInterval time.Duration
+	if config.Scheduler != nil {
+		repeatingItemsInterval = time.Duration(config.Scheduler.RepeatingItemsInterval)
+	}
+	go repeatingitems.NewScheduler(
+		services.RepeatingItem,
+		repeatingitems.WithSchedulerInterval(repeatingItemsInterval),
+		repeatingitems.WithSchedulerLogger(golog.Default),
+	).Run(ctx)
+
 	s := iris.NewServer(config.App.Config, newIrisController(services))
 	if err := s.ListenAndServe(fmt.Sprintf("%s:%d", config.App.Host, config.App.Port)); err != nil {
 		fmt.Printf("failed to listen and serve: %v", err)
@@ -10,20 +10,38 @@ import (
 	"xorm.io/xorm/names"
 
 	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/budgets"
 	"github.com/n101661/maney/server/categories"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
 	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/ledger"
+	"github.com/n101661/maney/server/repeatingitems"
 	"github.com/n101661/maney/server/repository"
 	"github.com/n101661/maney/server/repository/postgres"
 	"github.com/n101661/maney/server/shops"
+	"github.com/n101661/maney/server/statements"
+	"github.com/n101661/maney/server/tags"
+	"github.com/n101661/maney/server/transfers"
+	"github.com/n101661/maney/server/unitconversions"
 	"github.com/n101661/maney/server/users"
 )
 
 type Repositories struct {
-	User     repository.UserRepository
-	Account  repository.AccountRepository
-	Category repository.CategoryRepository
-	Shop     repository.ShopRepository
-	Fee      repository.FeeRepository
+	User           repository.UserRepository
+	Account        repository.AccountRepository
+	Category       repository.CategoryRepository
+	Shop           repository.ShopRepository
+	Tag            repository.TagRepository
+	UnitConversion repository.UnitConversionRepository
+	Fee            repository.FeeRepository
+	DailyItem      repository.DailyItemRepository
+	RepeatingItem  repository.RepeatingItemRepository
+	Transfer       repository.TransferRepository
+	Statement      repository.StatementRepository
+	Budget         repository.BudgetRepository
+	ExchangeRate   repository.ExchangeRateRepository
+	Ledger         repository.LedgerRepository
 
 	closer io.Closer
 }
@@ -81,18 +99,72 @@ func newPostgresRepositories(config *postgres.Config) (*Repositories, error) {
 		return nil, fmt.Errorf("failed to initial shop repository: %v", err)
 	}
 
+	tagRepo, err := tags.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial tag repository: %v", err)
+	}
+
+	unitConversionRepo, err := unitconversions.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial unit conversion repository: %v", err)
+	}
+
 	feeRepo, err := fees.NewPostgresRepository(engine)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initial fee repository: %v", err)
 	}
 
+	dailyItemRepo, err := dailyitems.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial daily item repository: %v", err)
+	}
+
+	repeatingItemRepo, err := repeatingitems.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial repeating item repository: %v", err)
+	}
+
+	transferRepo, err := transfers.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial transfer repository: %v", err)
+	}
+
+	statementRepo, err := statements.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial statement repository: %v", err)
+	}
+
+	budgetRepo, err := budgets.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial budget repository: %v", err)
+	}
+
+	exchangeRateRepo, err := exchangerates.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial exchange rate repository: %v", err)
+	}
+
+	ledgerRepo, err := ledger.NewPostgresRepository(engine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial ledger repository: %v", err)
+	}
+
 	return &Repositories{
-		User:     userRepo,
-		Account:  accountRepo,
-		Category: categoryRepo,
-		Shop:     shopRepo,
-		Fee:      feeRepo,
-		closer:   engine,
+		User:           userRepo,
+		Account:        accountRepo,
+		Category:       categoryRepo,
+		Shop:           shopRepo,
+		Tag:            tagRepo,
+		UnitConversion: unitConversionRepo,
+		Fee:            feeRepo,
+		DailyItem:      dailyItemRepo,
+		RepeatingItem:  repeatingItemRepo,
+		Transfer:       transferRepo,
+		Statement:      statementRepo,
+		Budget:         budgetRepo,
+		ExchangeRate:   exchangeRateRepo,
+		Ledger:         ledgerRepo,
+		closer:         engine,
 	}, nil
 }
 
@@ -119,17 +191,15 @@ func newXormEngine(driverName, dataSourceName string, opts *xormEngineOptions) (
 	engine.SetMaxIdleConns(opts.MaxIdleConns)
 	engine.SetMaxOpenConns(opts.MaxOpenConns)
 
-	err = engine.Sync(
-		postgres.UsersModel{},
-		postgres.TokensModel{},
-		postgres.AccountsModel{},
-		postgres.CategoriesModel{},
-		postgres.ShopsModel{},
-		postgres.FeesModel{},
-	)
-	if err != nil {
+	if err := postgres.SyncTables(engine); err != nil {
 		return nil, fmt.Errorf("failed to sync tables: %v", err)
 	}
+	if err := postgres.CreateSearchIndexes(engine); err != nil {
+		return nil, err
+	}
+	if err := postgres.CreateForeignKeys(engine); err != nil {
+		return nil, err
+	}
 
 	return engine, nil
 }
@@ -5,21 +5,65 @@ import (
 	"time"
 
 	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/budgets"
+	"github.com/n101661/maney/server/calendar"
 	"github.com/n101661/maney/server/categories"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
 	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/icons"
+	"github.com/n101661/maney/server/ledger"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/reports"
 	"github.com/n101661/maney/server/shops"
+	"github.com/n101661/maney/server/statements"
+	"github.com/n101661/maney/server/tags"
+	"github.com/n101661/maney/server/transfers"
+	"github.com/n101661/maney/server/unitconversions"
 	"github.com/n101661/maney/server/users"
 )
 
 type Services struct {
-	User     users.Service
-	Account  accounts.Service
-	Category categories.Service
-	Shop     shops.Service
-	Fee      fees.Service
+	User           users.Service
+	Account        accounts.Service
+	Category       categories.Service
+	Icon           icons.Service
+	Shop           shops.Service
+	Tag            tags.Service
+	UnitConversion unitconversions.Service
+	Fee            fees.Service
+	DailyItem      dailyitems.Service
+	Calendar       calendar.Service
+	RepeatingItem  repeatingitems.Service
+	Transfer       transfers.Service
+	Statement      statements.Service
+	Budget         budgets.Service
+	ExchangeRate   exchangerates.Service
+	Report         reports.Service
+	Ledger         ledger.Service
 }
 
-func newServices(repos *Repositories, authConfig *AuthServiceConfig) (*Services, error) {
+func newServices(
+	repos *Repositories,
+	authConfig *AuthServiceConfig,
+	signUpConfig *SignUpConfig,
+	iconsConfig *IconsConfig,
+) (*Services, error) {
+	iconCatalog, err := loadIconCatalog(iconsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the icon catalog: %v", err)
+	}
+
+	icon, err := icons.NewService(iconCatalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the icon service: %v", err)
+	}
+
+	signUpTemplate, err := loadSignUpTemplate(signUpConfig, icon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the sign-up template: %v", err)
+	}
+
 	user, err := users.NewService(
 		repos.User,
 		[]byte(authConfig.RefreshTokenSigningKey),
@@ -27,17 +71,23 @@ func newServices(repos *Repositories, authConfig *AuthServiceConfig) (*Services,
 		users.WithRefreshTokenExpireAfter(time.Duration(authConfig.RefreshTokenExpireAfter)),
 		users.WithAccessTokenExpireAfter(time.Duration(authConfig.AccessTokenExpireAfter)),
 		users.WithSaltPasswordRound(authConfig.SaltPasswordRound),
+		users.WithSignUpTemplate(signUpTemplate),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initial the user service: %v", err)
 	}
 
-	account, err := accounts.NewService(repos.Account)
+	exchangeRate, err := exchangerates.NewService(repos.ExchangeRate, repos.User)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the exchange rate service: %v", err)
+	}
+
+	account, err := accounts.NewService(repos.Account, exchangeRate, icon)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initial the account service: %v", err)
 	}
 
-	category, err := categories.NewService(repos.Category)
+	category, err := categories.NewService(repos.Category, icon)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initial the category service: %v", err)
 	}
@@ -47,16 +97,113 @@ func newServices(repos *Repositories, authConfig *AuthServiceConfig) (*Services,
 		return nil, fmt.Errorf("failed to initial the shop service: %v", err)
 	}
 
+	tag, err := tags.NewService(repos.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the tag service: %v", err)
+	}
+
+	unitConversion, err := unitconversions.NewService(repos.UnitConversion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the unit conversion service: %v", err)
+	}
+
 	fee, err := fees.NewService(repos.Fee)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initial the fee service: %v", err)
 	}
 
+	dailyItem, err := dailyitems.NewService(repos.DailyItem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the daily item service: %v", err)
+	}
+
+	repeatingItem, err := repeatingitems.NewService(repos.RepeatingItem, repos.DailyItem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the repeating item service: %v", err)
+	}
+
+	transfer, err := transfers.NewService(repos.Transfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the transfer service: %v", err)
+	}
+
+	statement, err := statements.NewService(repos.Statement, repos.Account, repos.DailyItem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the statement service: %v", err)
+	}
+
+	budget, err := budgets.NewService(repos.Budget, repos.DailyItem, repos.Category, exchangeRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the budget service: %v", err)
+	}
+
+	calendarService, err := calendar.NewService(repos.DailyItem, exchangeRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the calendar service: %v", err)
+	}
+
+	report, err := reports.NewService(repos.DailyItem, repos.Category, repos.Account, exchangeRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the report service: %v", err)
+	}
+
+	ledgerService, err := ledger.NewService(&ledger.Repositories{
+		User:           repos.User,
+		Account:        repos.Account,
+		Category:       repos.Category,
+		Shop:           repos.Shop,
+		Tag:            repos.Tag,
+		Fee:            repos.Fee,
+		DailyItem:      repos.DailyItem,
+		RepeatingItem:  repos.RepeatingItem,
+		Transfer:       repos.Transfer,
+		Statement:      repos.Statement,
+		Budget:         repos.Budget,
+		ExchangeRate:   repos.ExchangeRate,
+		UnitConversion: repos.UnitConversion,
+		Ledger:         repos.Ledger,
+	}, icon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initial the ledger service: %v", err)
+	}
+
 	return &Services{
-		User:     user,
-		Account:  account,
-		Category: category,
-		Shop:     shop,
-		Fee:      fee,
+		User:           user,
+		Account:        account,
+		Category:       category,
+		Icon:           icon,
+		Shop:           shop,
+		Tag:            tag,
+		UnitConversion: unitConversion,
+		Fee:            fee,
+		DailyItem:      dailyItem,
+		Calendar:       calendarService,
+		RepeatingItem:  repeatingItem,
+		Transfer:       transfer,
+		Statement:      statement,
+		Budget:         budget,
+		ExchangeRate:   exchangeRate,
+		Report:         report,
+		Ledger:         ledgerService,
 	}, nil
 }
+
+func loadSignUpTemplate(config *SignUpConfig, iconValidator icons.Validator) (*users.SignUpTemplate, error) {
+	if config == nil {
+		return users.LoadSignUpTemplate(users.DefaultLocale, iconValidator)
+	}
+	if config.TemplatePath != "" {
+		return users.ReadSignUpTemplate(config.TemplatePath, iconValidator)
+	}
+	if config.Locale != "" {
+		return users.LoadSignUpTemplate(config.Locale, iconValidator)
+	}
+	return users.LoadSignUpTemplate(users.DefaultLocale, iconValidator)
+}
+
+func loadIconCatalog(config *IconsConfig) (*icons.Catalog, error) {
+	if config != nil && config.CatalogPath != "" {
+		return icons.ReadCatalog(config.CatalogPath)
+	}
+	return icons.LoadCatalog()
+}
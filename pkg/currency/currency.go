@@ -0,0 +1,21 @@
+// Package currency handles ISO-4217 currency codes.
+package currency
+
+import (
+	"fmt"
+
+	"golang.org/x/text/currency"
+)
+
+// Normalize returns the upper case of the ISO-4217 currency code, it returns error
+// if the code is not a recognized currency. The empty code is returned as is.
+func Normalize(code string) (string, error) {
+	if code == "" {
+		return "", nil
+	}
+	unit, err := currency.ParseISO(code)
+	if err != nil || unit.String() == "XXX" {
+		return "", fmt.Errorf("invalid currency[%s]", code)
+	}
+	return unit.String(), nil
+}
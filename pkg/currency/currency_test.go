@@ -0,0 +1,25 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert := assert.New(t)
+
+	code, err := Normalize("twd")
+	assert.NoError(err)
+	assert.Equal("TWD", code)
+
+	code, err = Normalize("")
+	assert.NoError(err)
+	assert.Empty(code)
+
+	_, err = Normalize("ABC")
+	assert.Error(err)
+
+	_, err = Normalize("US")
+	assert.Error(err)
+}
@@ -3,13 +3,17 @@ package accounts
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kataras/iris/v12"
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 
+	"github.com/n101661/maney/pkg/currency"
 	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/exchangerates"
 	"github.com/n101661/maney/server/models"
+	"github.com/n101661/maney/server/repository"
 )
 
 type IrisController struct {
@@ -17,7 +21,7 @@ type IrisController struct {
 	*irisController.SimpleCreateTemplate[models.BasicAccount, CreateRequest, CreateReply, models.ObjectId]
 	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Account]
 	*irisController.SimpleUpdateTemplate[models.BasicAccount, UpdateRequest, UpdateReply]
-	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+	*irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]
 }
 
 func NewIrisController(s Service) *IrisController {
@@ -26,22 +30,20 @@ func NewIrisController(s Service) *IrisController {
 		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicAccount, CreateRequest, CreateReply, models.ObjectId]{
 			Service: s,
 			ParseServiceRequest: func(userID string, r *models.BasicAccount) (*CreateRequest, error) {
-				initialBalance, err := decimal.NewFromString(r.InitialBalance)
+				account, err := toServiceBaseAccount(r)
 				if err != nil {
-					return nil, fmt.Errorf("invalid decimal[%s]", r.InitialBalance)
+					return nil, err
 				}
 				return &CreateRequest{
-					UserID: userID,
-					Account: &BaseAccount{
-						Name:           r.Name,
-						IconID:         int32(r.IconId),
-						InitialBalance: initialBalance,
-					},
+					UserID:  userID,
+					Account: account,
 				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidAccount),
+					errors.Is(err, ErrInvalidIcon):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
@@ -55,8 +57,33 @@ func NewIrisController(s Service) *IrisController {
 		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Account]{
 			Service: s,
 			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				var convertTo *ConvertTarget
+				if c.URLParamExists("currency") {
+					currencyCode, err := currency.Normalize(c.URLParam("currency"))
+					if err != nil {
+						return nil, err
+					}
+					date, err := time.Parse(time.DateOnly, c.URLParamDefault("date", time.Now().Format(time.DateOnly)))
+					if err != nil {
+						return nil, fmt.Errorf("invalid date[%s]", c.URLParam("date"))
+					}
+					convertTo = &ConvertTarget{
+						Currency: currencyCode,
+						Date:     date,
+					}
+				}
+				var type_ *AccountType
+				if c.URLParamExists("type") {
+					t, err := repository.ToAccountType(c.URLParam("type"))
+					if err != nil {
+						return nil, err
+					}
+					type_ = &t
+				}
 				return &ListRequest{
-					UserID: userID,
+					UserID:    userID,
+					Type:      type_,
+					ConvertTo: convertTo,
 				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
@@ -64,17 +91,30 @@ func NewIrisController(s Service) *IrisController {
 				case errors.Is(err, ErrDataInsufficient):
 					return iris.StatusBadRequest, true
 				}
-				return 0, false
+				return exchangerates.BadConversion(err)
 			},
 			ParseAPIResponse: func(reply *ListReply) (*[]*models.Account, error) {
 				return lo.ToPtr(lo.Map(reply.Accounts, func(item *Account, _ int) *models.Account {
-					return &models.Account{
-						Id:             lo.ToPtr(models.Id(item.PublicID)),
-						Name:           item.Name,
-						IconId:         models.IconId(item.IconID),
-						InitialBalance: item.InitialBalance.String(),
-						Balance:        lo.ToPtr(item.Balance.String()),
+					account := &models.Account{
+						Id:                  lo.ToPtr(models.Id(item.PublicID)),
+						Name:                item.Name,
+						IconId:              models.IconId(item.IconID),
+						InitialBalance:      item.InitialBalance.String(),
+						Balance:             lo.ToPtr(item.Balance.String()),
+						Currency:            lo.EmptyableToPtr(item.Currency),
+						Type:                lo.ToPtr(models.AccountType(item.Type.String())),
+						BankName:            lo.EmptyableToPtr(item.BankName),
+						LastDigits:          lo.EmptyableToPtr(item.LastDigits),
+						StatementClosingDay: lo.EmptyableToPtr(item.StatementClosingDay),
+						PaymentDueDay:       lo.EmptyableToPtr(item.PaymentDueDay),
+					}
+					if item.CreditLimit != nil {
+						account.CreditLimit = lo.ToPtr(item.CreditLimit.String())
+					}
+					if item.ConvertedBalance != nil {
+						account.ConvertedBalance = lo.ToPtr(item.ConvertedBalance.String())
 					}
+					return account
 				})), nil
 			},
 		},
@@ -82,44 +122,46 @@ func NewIrisController(s Service) *IrisController {
 			Placeholder: "accountId",
 			Service:     s,
 			ParseServiceRequest: func(userID string, publicID string, r *models.BasicAccount) (*UpdateRequest, error) {
-				initialBalance, err := decimal.NewFromString(r.InitialBalance)
+				account, err := toServiceBaseAccount(r)
 				if err != nil {
-					return nil, fmt.Errorf("invalid decimal[%s]", r.InitialBalance)
+					return nil, err
 				}
 				return &UpdateRequest{
 					UserID:          userID,
 					AccountPublicID: publicID,
-					Account: &BaseAccount{
-						Name:           r.Name,
-						IconID:         int32(r.IconId),
-						InitialBalance: initialBalance,
-					},
+					Account:         account,
 				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
 				case errors.Is(err, ErrAccountNotFound):
 					return iris.StatusNotFound, true
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrAccountInUse):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidAccount),
+					errors.Is(err, ErrInvalidIcon):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
 			},
 		},
-		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+		PolicyDeleteTemplate: &irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]{
 			Placeholder: "accountId",
 			Service:     s,
-			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+			ParseServiceRequest: func(userID string, publicID string, policy DeletePolicy, replacementID string) *DeleteRequest {
 				return &DeleteRequest{
-					UserID:          userID,
-					AccountPublicID: publicID,
+					UserID:              userID,
+					AccountPublicID:     publicID,
+					Policy:              policy,
+					ReplacementPublicID: replacementID,
 				}
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
 				case errors.Is(err, ErrAccountNotFound):
 					return iris.StatusNotFound, true
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrInvalidReplacement):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
@@ -127,3 +169,41 @@ func NewIrisController(s Service) *IrisController {
 		},
 	}
 }
+
+func toServiceBaseAccount(r *models.BasicAccount) (*BaseAccount, error) {
+	initialBalance, err := decimal.NewFromString(r.InitialBalance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decimal[%s]", r.InitialBalance)
+	}
+	currencyCode, err := currency.Normalize(lo.FromPtr(r.Currency))
+	if err != nil {
+		return nil, err
+	}
+	type_ := repository.AccountTypeCash
+	if r.Type != nil {
+		type_, err = repository.ToAccountType(string(*r.Type))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var creditLimit *decimal.Decimal
+	if r.CreditLimit != nil {
+		v, err := decimal.NewFromString(*r.CreditLimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decimal[%s]", *r.CreditLimit)
+		}
+		creditLimit = &v
+	}
+	return &BaseAccount{
+		Name:                r.Name,
+		IconID:              int32(r.IconId),
+		InitialBalance:      initialBalance,
+		Currency:            currencyCode,
+		Type:                type_,
+		BankName:            lo.FromPtr(r.BankName),
+		LastDigits:          lo.FromPtr(r.LastDigits),
+		CreditLimit:         creditLimit,
+		StatementClosingDay: lo.FromPtr(r.StatementClosingDay),
+		PaymentDueDay:       lo.FromPtr(r.PaymentDueDay),
+	}, nil
+}
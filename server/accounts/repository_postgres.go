@@ -2,9 +2,15 @@ package accounts
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/repeatingitems"
 	"github.com/n101661/maney/server/repository"
 	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/transfers"
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 	"xorm.io/xorm"
@@ -24,6 +30,12 @@ func (repo *postgresRepository) Create(ctx context.Context, r *repository.Create
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	return CreateWithSession(session, r)
+}
+
+// CreateWithSession is the same as Create, but it creates the accounts with the session
+// so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateAccountsRequest) ([]*repository.Account, error) {
 	rows := lo.Map(r.Accounts, func(item *repository.BaseCreateAccount, _ int) *postgres.AccountsModel {
 		return &postgres.AccountsModel{
 			PublicID: item.PublicID,
@@ -50,6 +62,11 @@ func (repo *postgresRepository) List(ctx context.Context, r *repository.ListAcco
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if r.Type != nil {
+		// the accounts created before the types are introduced are cash.
+		session.Where("COALESCE((data->>'Type')::smallint, 0) = ?", *r.Type)
+	}
+
 	var rows []*postgres.AccountsModel
 	err := session.Find(&rows, &postgres.AccountsModel{
 		PublicID: lo.FromPtr(r.AccountPublicID),
@@ -61,6 +78,17 @@ func (repo *postgresRepository) List(ctx context.Context, r *repository.ListAcco
 	if len(rows) == 0 {
 		return nil, repository.ErrDataNotFound
 	}
+
+	if r.BalanceDate != nil {
+		changes, err := repo.sumBalanceChanges(session, r.UserID, *r.BalanceDate)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			row.Balance = decimal.NewNullDecimal(row.Data.InitialBalance.Add(changes[row.ID]))
+		}
+	}
+
 	return &repository.ListAccountsReply{
 		Accounts: lo.Map(rows, func(item *postgres.AccountsModel, _ int) *repository.Account {
 			return toAccount(item)
@@ -68,10 +96,60 @@ func (repo *postgresRepository) List(ctx context.Context, r *repository.ListAcco
 	}, nil
 }
 
+// sumBalanceChanges sums the changes of the balances by the daily items and the transfers
+// until the date, and maps the ids of the accounts to the changes.
+func (repo *postgresRepository) sumBalanceChanges(session *xorm.Session, userID string, date time.Time) (map[int32]decimal.Decimal, error) {
+	var (
+		items     = repo.engine.TableName(&postgres.DailyItemsModel{}, true)
+		transfers = repo.engine.TableName(&postgres.TransfersModel{}, true)
+		until     = date.Format(time.DateOnly)
+	)
+
+	var rows []*balanceChangeRow
+	// the expense items and the transfers from the accounts debit the accounts.
+	err := session.SQL(`SELECT c.account_id, ROUND(SUM(c.amount), 6) AS amount
+FROM (
+	SELECT i.account_id, CASE WHEN i.type = ? THEN -`+postgres.DailyItemAmountExpr("i")+` ELSE `+postgres.DailyItemAmountExpr("i")+` END AS amount
+	FROM `+items+` AS i
+	WHERE i.user_id = ? AND i.account_id IS NOT NULL AND i.date <= ?
+	UNION ALL
+	SELECT t.from_account_id AS account_id, -t.amount AS amount
+	FROM `+transfers+` AS t
+	WHERE t.user_id = ? AND t.date <= ?
+	UNION ALL
+	SELECT t.to_account_id AS account_id, t.amount AS amount
+	FROM `+transfers+` AS t
+	WHERE t.user_id = ? AND t.date <= ?
+) AS c
+GROUP BY c.account_id`,
+		repository.CategoryTypeExpense, userID, until,
+		userID, until,
+		userID, until,
+	).Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[int32]decimal.Decimal, len(rows))
+	for _, row := range rows {
+		changes[row.AccountID] = row.Amount.Decimal
+	}
+	return changes, nil
+}
+
+type balanceChangeRow struct {
+	AccountID int32
+	Amount    decimal.NullDecimal
+}
+
 func (repo *postgresRepository) Update(ctx context.Context, r *repository.UpdateAccountRequest) (*repository.Account, error) {
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
 	row := postgres.AccountsModel{
 		PublicID: r.AccountPublicID,
 		UserID:   r.UserID,
@@ -86,6 +164,9 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 
 	var bean postgres.AccountsModel
 	if r.Account != nil {
+		if err := validateChange(session, &row, r.Account); err != nil {
+			return nil, err
+		}
 		row.Data = &postgres.BaseAccount{
 			BaseAccount: r.Account,
 		}
@@ -106,13 +187,57 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 		return nil, repository.ErrDataNotFound
 	}
 
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
 	return toAccount(&row), nil
 }
 
+// validateChange returns ErrDataReferenced if the currency of the account is changed while
+// it has daily items, transfers or statement payments, or the credit card is changed to
+// another type while it has statement payments. The references are only valid for the
+// original currency and type.
+func validateChange(session *xorm.Session, row *postgres.AccountsModel, account *repository.BaseAccount) error {
+	type reference struct {
+		bean  any
+		query string
+	}
+	payments := reference{&postgres.StatementPaymentsModel{}, "account_id = ?"}
+
+	var references []reference
+	if account.Currency != row.Data.Currency {
+		references = append(references,
+			reference{&postgres.DailyItemsModel{}, "account_id = ?"},
+			reference{&postgres.TransfersModel{}, "from_account_id = ? OR to_account_id = ?"},
+			payments,
+		)
+	} else if row.Data.Type == repository.AccountTypeCreditCard && account.Type != repository.AccountTypeCreditCard {
+		references = append(references, payments)
+	}
+
+	for _, reference := range references {
+		args := lo.Times(strings.Count(reference.query, "?"), func(int) any {
+			return row.ID
+		})
+		referenced, err := session.Where(reference.query, args...).Exist(reference.bean)
+		if err != nil {
+			return err
+		}
+		if referenced {
+			return repository.ErrDataReferenced
+		}
+	}
+	return nil
+}
+
 func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteAccountsRequest) ([]*repository.Account, error) {
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
 	session.Where("user_id = ?", r.UserID)
 	if len(r.AccountPublicIDs) > 0 {
 		session.In("public_id", r.AccountPublicIDs)
@@ -131,18 +256,231 @@ func (repo *postgresRepository) Delete(ctx context.Context, r *repository.Delete
 		return nil, repository.ErrDataNotFound
 	}
 
-	_, err = session.In("id", lo.Map(rows, func(item *postgres.AccountsModel, _ int) any {
+	if err := handleReferences(session, r, rows); err != nil {
+		return nil, err
+	}
+
+	ids := lo.Map(rows, func(item *postgres.AccountsModel, _ int) any {
 		return item.ID
-	})).Table(&postgres.AccountsModel{}).Delete()
+	})
+	_, err = session.In("id", ids).Table(&postgres.AccountsModel{}).Delete()
 	if err != nil {
+		if postgres.ForeignKeyViolationError(err) {
+			return nil, repository.ErrDataReferenced
+		}
 		return nil, err
 	}
 
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
 	return lo.Map(rows, func(item *postgres.AccountsModel, _ int) *repository.Account {
 		return toAccount(item)
 	}), nil
 }
 
+// handleReferences handles the daily items, the repeating items, the transfers and the
+// statement payments of the deleted accounts by the policy.
+func handleReferences(session *xorm.Session, r *repository.DeleteAccountsRequest, rows []*postgres.AccountsModel) error {
+	ids := lo.Map(rows, func(item *postgres.AccountsModel, _ int) any {
+		return item.ID
+	})
+	publicIDs := lo.Map(rows, func(item *postgres.AccountsModel, _ int) string {
+		return item.PublicID
+	})
+
+	var dailyItems []*postgres.DailyItemsModel
+	if err := session.In("account_id", ids).Asc("id").Find(&dailyItems); err != nil {
+		return err
+	}
+	var transferRows []*postgres.TransfersModel
+	err := session.
+		In("from_account_id", ids).
+		Or(fmt.Sprintf("to_account_id IN (%s)", strings.Repeat(",?", len(ids))[1:]), ids...).
+		Asc("id").
+		Find(&transferRows)
+	if err != nil {
+		return err
+	}
+	repeatingItems, err := postgres.ListReferringRepeatingItems(session, r.UserID, func(item *repository.BaseItem) bool {
+		return item.AccountPublicID != nil && lo.Contains(publicIDs, *item.AccountPublicID)
+	})
+	if err != nil {
+		return err
+	}
+	var payments []*postgres.StatementPaymentsModel
+	if err := session.In("account_id", ids).Asc("id").Find(&payments); err != nil {
+		return err
+	}
+
+	switch r.Policy {
+	case repository.DeletePolicyRestrict:
+		return postgres.NewReferencedError(&postgres.References{
+			DailyItems:        dailyItems,
+			RepeatingItems:    repeatingItems,
+			Transfers:         transferRows,
+			StatementPayments: payments,
+		})
+	case repository.DeletePolicyReassign:
+		replacement, err := getReplacement(session, r, rows, transferRows, payments)
+		if err != nil {
+			return err
+		}
+
+		_, err = session.
+			Table(&postgres.DailyItemsModel{}).
+			In("account_id", ids).
+			Update(map[string]any{"account_id": replacement.ID})
+		if err != nil {
+			return err
+		}
+		for _, column := range []string{"from_account_id", "to_account_id"} {
+			_, err := session.
+				Table(&postgres.TransfersModel{}).
+				In(column, ids).
+				Update(map[string]any{column: replacement.ID})
+			if err != nil {
+				return err
+			}
+		}
+		_, err = session.
+			Table(&postgres.StatementPaymentsModel{}).
+			In("account_id", ids).
+			Update(map[string]any{"account_id": replacement.ID})
+		if err != nil {
+			return err
+		}
+		for _, row := range repeatingItems {
+			row.Data.Item.AccountPublicID = &replacement.PublicID
+		}
+		if err := postgres.UpdateRepeatingItemsData(session, repeatingItems); err != nil {
+			return err
+		}
+
+		// the balance is the initial balance with the daily items and the transfers.
+		delta := decimal.Zero
+		for _, row := range rows {
+			delta = delta.Add(row.Balance.Decimal.Sub(row.Data.InitialBalance))
+		}
+		if delta.IsZero() {
+			return nil
+		}
+		_, err = session.
+			Incr("balance", delta).
+			Update(&postgres.AccountsModel{}, &postgres.AccountsModel{
+				ID: replacement.ID,
+			})
+		return err
+	case repository.DeletePolicyCascade:
+		// the fee items of the transfers are deleted with the daily items.
+		if len(dailyItems) > 0 {
+			_, err := dailyitems.DeleteWithSession(session, &repository.DeleteDailyItemsRequest{
+				UserID: r.UserID,
+				DailyItemPublicIDs: lo.Map(dailyItems, func(item *postgres.DailyItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(repeatingItems) > 0 {
+			_, err := repeatingitems.DeleteWithSession(session, &repository.DeleteRepeatingItemsRequest{
+				UserID: r.UserID,
+				RepeatingItemPublicIDs: lo.Map(repeatingItems, func(item *postgres.RepeatingItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(transferRows) > 0 {
+			_, err := transfers.DeleteWithSession(session, &repository.DeleteTransfersRequest{
+				UserID: r.UserID,
+				TransferPublicIDs: lo.Map(transferRows, func(item *postgres.TransfersModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		// the payments with the transfers are deleted with the transfers.
+		_, err := session.In("account_id", ids).Delete(&postgres.StatementPaymentsModel{})
+		return err
+	}
+	return fmt.Errorf("unknown delete policy[%v]", r.Policy)
+}
+
+// getReplacement returns error:
+//   - ErrReferenceNotFound if the replacement does not exist,
+//   - ErrInvalidReference if it is any of the deleted accounts, or any of the transfers is
+//     between the replacement and the deleted accounts, or the payments cannot be moved to
+//     it, see validatePaymentsReplacement,
+//   - ErrCurrencyMismatch if its currency is not the currency of the deleted accounts.
+func getReplacement(session *xorm.Session, r *repository.DeleteAccountsRequest, rows []*postgres.AccountsModel, transferRows []*postgres.TransfersModel, payments []*postgres.StatementPaymentsModel) (*postgres.AccountsModel, error) {
+	replacement := postgres.AccountsModel{
+		PublicID: r.ReplacementPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&replacement)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrReferenceNotFound
+	}
+
+	replaced := lo.SliceToMap(rows, func(item *postgres.AccountsModel) (int32, bool) {
+		return item.ID, true
+	})
+	if replaced[replacement.ID] {
+		return nil, repository.ErrInvalidReference
+	}
+	replaced[replacement.ID] = true
+	for _, transfer := range transferRows {
+		if replaced[transfer.FromAccountID] && replaced[transfer.ToAccountID] {
+			return nil, repository.ErrInvalidReference
+		}
+	}
+	for _, row := range rows {
+		if row.Data.Currency != replacement.Data.Currency {
+			return nil, repository.ErrCurrencyMismatch
+		}
+	}
+	if err := validatePaymentsReplacement(session, &replacement, payments); err != nil {
+		return nil, err
+	}
+	return &replacement, nil
+}
+
+// validatePaymentsReplacement returns ErrInvalidReference if the statement payments are
+// moved to an account which is not a credit card, or any two of the payments of the
+// replacement are of the same statement.
+func validatePaymentsReplacement(session *xorm.Session, replacement *postgres.AccountsModel, payments []*postgres.StatementPaymentsModel) error {
+	if len(payments) == 0 {
+		return nil
+	}
+	if replacement.Data.Type != repository.AccountTypeCreditCard {
+		return repository.ErrInvalidReference
+	}
+
+	var existing []*postgres.StatementPaymentsModel
+	if err := session.Where("account_id = ?", replacement.ID).Find(&existing); err != nil {
+		return err
+	}
+	closingDates := make(map[string]bool, len(existing)+len(payments))
+	for _, payment := range append(existing, payments...) {
+		closingDate := payment.ClosingDate.Format(time.DateOnly)
+		if closingDates[closingDate] {
+			return repository.ErrInvalidReference
+		}
+		closingDates[closingDate] = true
+	}
+	return nil
+}
+
 func toAccount(item *postgres.AccountsModel) *repository.Account {
 	return &repository.Account{
 		ID:          item.ID,
@@ -0,0 +1,269 @@
+package accounts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/repository/postgres/postgrestest"
+)
+
+const testUserID = "user-id"
+
+var (
+	jan1 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan2 = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	jan3 = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+)
+
+func newTestAccount(publicID string, balance int64) *postgres.AccountsModel {
+	return &postgres.AccountsModel{
+		PublicID: publicID,
+		UserID:   testUserID,
+		Data: &postgres.BaseAccount{BaseAccount: &repository.BaseAccount{
+			Name:           publicID,
+			InitialBalance: decimal.NewFromInt(100),
+		}},
+		Balance: decimal.NewNullDecimal(decimal.NewFromInt(balance)),
+	}
+}
+
+func newTestDailyItem(publicID string, date time.Time, typ repository.CategoryType, account *postgres.AccountsModel, price int64) *postgres.DailyItemsModel {
+	return &postgres.DailyItemsModel{
+		PublicID:  publicID,
+		UserID:    testUserID,
+		Date:      date,
+		Name:      publicID,
+		Type:      typ,
+		AccountID: sql.NullInt32{Int32: account.ID, Valid: true},
+		Price:     decimal.NewNullDecimal(decimal.NewFromInt(price)),
+		Total:     decimal.NewNullDecimal(decimal.NewFromInt(price)),
+	}
+}
+
+func newTestTransfer(publicID string, date time.Time, from, to *postgres.AccountsModel, amount int64) *postgres.TransfersModel {
+	return &postgres.TransfersModel{
+		PublicID:      publicID,
+		UserID:        testUserID,
+		Date:          date,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        decimal.NewNullDecimal(decimal.NewFromInt(amount)),
+	}
+}
+
+func Test_postgresRepository_List(t *testing.T) {
+	t.Run("balances at the end of the date", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine := postgrestest.NewEngine(t)
+		// bank: 100 - 10 (jan1) - 20 (jan2) + 5 (jan3), cash: 100 + 20 (jan2).
+		bank, cash := newTestAccount("bankID", 75), newTestAccount("cashID", 120)
+		postgrestest.Insert(t, engine, bank, cash)
+		postgrestest.Insert(t, engine,
+			newTestDailyItem("lunchID", jan1, repository.CategoryTypeExpense, bank, 10),
+			newTestDailyItem("salaryID", jan3, repository.CategoryTypeIncome, bank, 5),
+			newTestTransfer("transferID", jan2, bank, cash, 20),
+		)
+		repo := &postgresRepository{engine: engine}
+
+		reply, err := repo.List(context.Background(), &repository.ListAccountsRequest{
+			UserID:      testUserID,
+			BalanceDate: &jan2,
+		})
+		assert.NoError(err)
+		balances := lo.SliceToMap(reply.Accounts, func(item *repository.Account) (string, decimal.Decimal) {
+			return item.PublicID, item.Balance
+		})
+		assert.True(decimal.NewFromInt(70).Equal(balances["bankID"]))
+		assert.True(decimal.NewFromInt(120).Equal(balances["cashID"]))
+
+		reply, err = repo.List(context.Background(), &repository.ListAccountsRequest{
+			UserID: testUserID,
+		})
+		assert.NoError(err)
+		balances = lo.SliceToMap(reply.Accounts, func(item *repository.Account) (string, decimal.Decimal) {
+			return item.PublicID, item.Balance
+		})
+		assert.True(decimal.NewFromInt(75).Equal(balances["bankID"]))
+	})
+}
+
+func Test_postgresRepository_Delete(t *testing.T) {
+	// bank: 100 - 10 (lunch) - 10 (transfer), cash: 100 + 10 (transfer).
+	insertAccounts := func(t *testing.T) (engine *xorm.Engine, bank, cash, wallet *postgres.AccountsModel) {
+		engine = postgrestest.NewEngine(t)
+		bank, cash, wallet = newTestAccount("bankID", 80), newTestAccount("cashID", 110), newTestAccount("walletID", 100)
+		postgrestest.Insert(t, engine, bank, cash, wallet)
+		postgrestest.Insert(t, engine,
+			newTestDailyItem("lunchID", jan1, repository.CategoryTypeExpense, bank, 10),
+			newTestTransfer("transferID", jan2, bank, cash, 10),
+		)
+		return engine, bank, cash, wallet
+	}
+
+	t.Run("balance is moved to the replacement", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine, _, cash, wallet := insertAccounts(t)
+		repo := &postgresRepository{engine: engine}
+
+		_, err := repo.Delete(context.Background(), &repository.DeleteAccountsRequest{
+			UserID:              testUserID,
+			AccountPublicIDs:    []string{"bankID"},
+			Policy:              repository.DeletePolicyReassign,
+			ReplacementPublicID: "walletID",
+		})
+		assert.NoError(err)
+
+		balance := postgrestest.Get(t, engine, &postgres.AccountsModel{ID: wallet.ID}).Balance.Decimal
+		assert.True(decimal.NewFromInt(80).Equal(balance), balance.String())
+		balance = postgrestest.Get(t, engine, &postgres.AccountsModel{ID: cash.ID}).Balance.Decimal
+		assert.True(decimal.NewFromInt(110).Equal(balance), balance.String())
+
+		item := postgrestest.Get(t, engine, &postgres.DailyItemsModel{PublicID: "lunchID"})
+		assert.Equal(wallet.ID, item.AccountID.Int32)
+		transfer := postgrestest.Get(t, engine, &postgres.TransfersModel{PublicID: "transferID"})
+		assert.Equal(wallet.ID, transfer.FromAccountID)
+		assert.Equal(cash.ID, transfer.ToAccountID)
+	})
+	t.Run("transfers are cascaded", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine, _, cash, _ := insertAccounts(t)
+		repo := &postgresRepository{engine: engine}
+
+		_, err := repo.Delete(context.Background(), &repository.DeleteAccountsRequest{
+			UserID:           testUserID,
+			AccountPublicIDs: []string{"bankID"},
+			Policy:           repository.DeletePolicyCascade,
+		})
+		assert.NoError(err)
+
+		// the transfer from the deleted account is reverted.
+		balance := postgrestest.Get(t, engine, &postgres.AccountsModel{ID: cash.ID}).Balance.Decimal
+		assert.True(decimal.NewFromInt(100).Equal(balance), balance.String())
+
+		for _, bean := range []any{&postgres.DailyItemsModel{}, &postgres.TransfersModel{}} {
+			count, err := engine.Where("user_id = ?", testUserID).Count(bean)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Zero(count, "%T", bean)
+		}
+	})
+	t.Run("statement payments", func(t *testing.T) {
+		newCreditCard := func(publicID string) *postgres.AccountsModel {
+			account := newTestAccount(publicID, 100)
+			account.Data.Type = repository.AccountTypeCreditCard
+			return account
+		}
+		newPayment := func(account *postgres.AccountsModel, closingDate time.Time) *postgres.StatementPaymentsModel {
+			return &postgres.StatementPaymentsModel{
+				UserID:      testUserID,
+				AccountID:   account.ID,
+				ClosingDate: closingDate,
+				Date:        closingDate,
+				Amount:      decimal.NewNullDecimal(decimal.NewFromInt(10)),
+			}
+		}
+		tests := []struct {
+			name        string
+			policy      repository.DeletePolicy
+			replacement string
+			// paid is the closing date of the payment of the replacement card.
+			paid *time.Time
+			// moved reports whether the payment is moved to the replacement card.
+			moved   bool
+			wantErr error
+		}{
+			{name: "restricted", policy: repository.DeletePolicyRestrict, wantErr: repository.ErrDataReferenced},
+			{name: "moved to the replacement", policy: repository.DeletePolicyReassign, replacement: "newCardID", moved: true},
+			{name: "replacement is not a credit card", policy: repository.DeletePolicyReassign, replacement: "cashID", wantErr: repository.ErrInvalidReference},
+			{name: "replacement has paid the statement", policy: repository.DeletePolicyReassign, replacement: "newCardID", paid: &jan1, wantErr: repository.ErrInvalidReference},
+			{name: "cascaded", policy: repository.DeletePolicyCascade},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				engine := postgrestest.NewEngine(t)
+				card, newCard, cash := newCreditCard("cardID"), newCreditCard("newCardID"), newTestAccount("cashID", 100)
+				postgrestest.Insert(t, engine, card, newCard, cash)
+				postgrestest.Insert(t, engine, newPayment(card, jan1))
+				if tt.paid != nil {
+					postgrestest.Insert(t, engine, newPayment(newCard, *tt.paid))
+				}
+				repo := &postgresRepository{engine: engine}
+
+				_, err := repo.Delete(context.Background(), &repository.DeleteAccountsRequest{
+					UserID:              testUserID,
+					AccountPublicIDs:    []string{"cardID"},
+					Policy:              tt.policy,
+					ReplacementPublicID: tt.replacement,
+				})
+				if tt.wantErr != nil {
+					assert.ErrorIs(err, tt.wantErr)
+					var referenced *repository.ReferencedError
+					if errors.As(err, &referenced) {
+						assert.Equal([]*repository.Reference{
+							{Type: repository.ReferenceTypeStatementPayment, PublicID: "2025-01-01"},
+						}, referenced.References)
+					}
+					return
+				}
+				assert.NoError(err)
+
+				var payments []*postgres.StatementPaymentsModel
+				if err := engine.Where("user_id = ?", testUserID).Find(&payments); err != nil {
+					t.Fatal(err)
+				}
+				if !tt.moved {
+					assert.Empty(payments)
+					return
+				}
+				if assert.Len(payments, 1) {
+					assert.Equal(newCard.ID, payments[0].AccountID)
+				}
+			})
+		}
+	})
+}
+
+func Test_postgresRepository_Update(t *testing.T) {
+	t.Run("currency of referenced account", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine := postgrestest.NewEngine(t)
+		bank, cash := newTestAccount("bankID", 90), newTestAccount("cashID", 100)
+		postgrestest.Insert(t, engine, bank, cash)
+		postgrestest.Insert(t, engine, newTestDailyItem("lunchID", jan1, repository.CategoryTypeExpense, bank, 10))
+		repo := &postgresRepository{engine: engine}
+
+		for publicID, expected := range map[string]error{"bankID": repository.ErrDataReferenced, "cashID": nil} {
+			_, err := repo.Update(context.Background(), &repository.UpdateAccountRequest{
+				UserID:          testUserID,
+				AccountPublicID: publicID,
+				Account: &repository.BaseAccount{
+					Name:           publicID,
+					InitialBalance: decimal.NewFromInt(100),
+					Currency:       "TWD",
+				},
+			})
+			if expected == nil {
+				assert.NoError(err, publicID)
+			} else {
+				assert.ErrorIs(err, expected, publicID)
+			}
+		}
+	})
+}
@@ -3,27 +3,50 @@ package accounts
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/repository"
 )
 
 var (
-	ErrDataInsufficient = fmt.Errorf("data insufficient")
-	ErrAccountNotFound  = fmt.Errorf("account not found")
+	ErrDataInsufficient   = fmt.Errorf("data insufficient")
+	ErrAccountNotFound    = fmt.Errorf("account not found")
+	ErrInvalidAccount     = fmt.Errorf("invalid account")
+	ErrInvalidIcon        = fmt.Errorf("invalid icon")
+	ErrAccountReferenced  = fmt.Errorf("account is referenced")
+	ErrInvalidReplacement = fmt.Errorf("invalid replacement")
+	ErrAccountInUse       = fmt.Errorf("currency or type of the account cannot be changed while it has items, transfers or statement payments")
 )
 
 type Service interface {
-	// Create returns ErrDataInsufficient if any of fields of CreateRequest is zero-value.
+	// Create returns error:
+	//  - ErrDataInsufficient if any of fields of CreateRequest is zero-value,
+	//  - ErrInvalidAccount if the attributes are not of the type of the account or out of range,
+	//  - ErrInvalidIcon if the icon is not an account icon in the catalog.
 	Create(context.Context, *CreateRequest) (*CreateReply, error)
-	// List returns ErrDataInsufficient if any of fields of ListRequest is zero-value,
+	// List returns ErrDataInsufficient if any of required fields of ListRequest is zero-value,
+	// or returns any error of exchangerates.Converter if the balances fail to be converted.
 	List(context.Context, *ListRequest) (*ListReply, error)
 	// Update returns error:
 	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
-	//  - ErrAccountNotFound if the account does not exist.
+	//  - ErrInvalidAccount if the attributes are not of the type of the account or out of range,
+	//  - ErrInvalidIcon if the icon is not an account icon in the catalog,
+	//  - ErrAccountNotFound if the account does not exist,
+	//  - ErrAccountInUse if the currency is changed while the account has daily items,
+	//    transfers or statement payments, or the type of the credit card is changed while
+	//    it has statement payments.
 	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
-	// Delete returns error:
-	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
-	//  - ErrAccountNotFound if the account does not exist.
+	// Delete deletes the account and handles its references by the policy, it returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value, or the
+	//    replacement is missing while the policy is DeletePolicyReassign,
+	//  - ErrAccountNotFound if the account does not exist,
+	//  - ErrAccountReferenced wrapping *repository.ReferencedError if the account is referenced
+	//    while the policy is DeletePolicyRestrict,
+	//  - ErrInvalidReplacement if the replacement does not exist, is not another account in
+	//    the same currency, or any of the transfers would be from and to the replacement.
+	// The payments of the statements of the account are deleted.
 	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
 }
 
@@ -31,14 +54,33 @@ type BaseAccount struct {
 	Name           string
 	IconID         int32
 	InitialBalance decimal.Decimal
+	// Currency is the ISO-4217 code of the balance, it is empty for the home currency
+	// of the user.
+	Currency string
+
+	Type AccountType
+	// BankName and LastDigits are only for bank accounts and credit cards, LastDigits
+	// has 4 digits at most.
+	BankName   string
+	LastDigits string
+	// CreditLimit, StatementClosingDay and PaymentDueDay are only for credit cards. The
+	// days are between 1 and 31, or zero if they are not provided.
+	CreditLimit         *decimal.Decimal
+	StatementClosingDay int
+	PaymentDueDay       int
 }
 
+type AccountType = repository.AccountType
+
 type Account struct {
 	ID       int32
 	PublicID string
 	*BaseAccount
 	// Balance is the current balance.
 	Balance decimal.Decimal
+	// ConvertedBalance is the balance in the currency of ListRequest.ConvertTo, it is
+	// nil if the balance is not converted.
+	ConvertedBalance *decimal.Decimal
 }
 
 type CreateRequest struct {
@@ -52,6 +94,17 @@ type CreateReply struct {
 
 type ListRequest struct {
 	UserID string
+	// Type lists the accounts of the type only if it is provided.
+	Type *AccountType
+	// ConvertTo converts the balances into the currency if it is provided.
+	ConvertTo *ConvertTarget
+}
+
+type ConvertTarget struct {
+	// Currency is empty for the home currency of the user.
+	Currency string
+	// Date is the date of the exchange rates.
+	Date time.Time
 }
 
 type ListReply struct {
@@ -71,6 +124,13 @@ type UpdateReply struct {
 type DeleteRequest struct {
 	UserID          string
 	AccountPublicID string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the account which the references are reassigned to, it is
+	// required while the policy is DeletePolicyReassign.
+	ReplacementPublicID string
 }
 
+type DeletePolicy = repository.DeletePolicy
+
 type DeleteReply struct{}
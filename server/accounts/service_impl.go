@@ -4,27 +4,37 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/n101661/maney/pkg/currency"
 	"github.com/n101661/maney/pkg/utils"
 	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/icons"
 	"github.com/n101661/maney/server/repository"
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 )
 
 type service struct {
-	repository repository.AccountRepository
+	repository    repository.AccountRepository
+	converter     exchangerates.Converter
+	iconValidator icons.Validator
 
 	opts *accountServiceOptions
 }
 
 func NewService(
 	repository repository.AccountRepository,
+	converter exchangerates.Converter,
+	iconValidator icons.Validator,
 	opts ...utils.Option[accountServiceOptions],
 ) (Service, error) {
 	return &service{
-		repository: repository,
-		opts:       utils.ApplyOptions(defaultAccountServiceOptions(), opts),
+		repository:    repository,
+		converter:     converter,
+		iconValidator: iconValidator,
+		opts:          utils.ApplyOptions(defaultAccountServiceOptions(), opts),
 	}, nil
 }
 
@@ -35,6 +45,12 @@ func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, e
 	if r.Account == nil {
 		return nil, fmt.Errorf("%w: missing account", ErrDataInsufficient)
 	}
+	if err := validateAccount(r.Account); err != nil {
+		return nil, err
+	}
+	if err := s.validateIcon(ctx, r.Account.IconID); err != nil {
+		return nil, err
+	}
 
 	rows, err := s.repository.Create(ctx, &repository.CreateAccountsRequest{
 		UserID: r.UserID,
@@ -58,6 +74,7 @@ func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error)
 
 	reply, err := s.repository.List(ctx, &repository.ListAccountsRequest{
 		UserID: r.UserID,
+		Type:   r.Type,
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrDataNotFound) {
@@ -68,10 +85,27 @@ func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error)
 		return nil, err
 	}
 
+	accounts := lo.Map(reply.Accounts, func(item *repository.Account, _ int) *Account {
+		return parseAccount(item)
+	})
+	if r.ConvertTo != nil {
+		for _, account := range accounts {
+			converted, err := s.converter.Convert(ctx, &exchangerates.ConvertRequest{
+				UserID:       r.UserID,
+				Amount:       account.Balance,
+				FromCurrency: account.Currency,
+				ToCurrency:   r.ConvertTo.Currency,
+				Date:         r.ConvertTo.Date,
+			})
+			if err != nil {
+				return nil, err
+			}
+			account.ConvertedBalance = lo.ToPtr(converted.Amount)
+		}
+	}
+
 	return &ListReply{
-		Accounts: lo.Map(reply.Accounts, func(item *repository.Account, _ int) *Account {
-			return parseAccount(item)
-		}),
+		Accounts: accounts,
 	}, nil
 }
 
@@ -85,6 +119,12 @@ func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, e
 	if r.Account == nil {
 		return nil, fmt.Errorf("%w: missing account", ErrDataInsufficient)
 	}
+	if err := validateAccount(r.Account); err != nil {
+		return nil, err
+	}
+	if err := s.validateIcon(ctx, r.Account.IconID); err != nil {
+		return nil, err
+	}
 
 	origin, err := s.repository.List(ctx, &repository.ListAccountsRequest{
 		UserID:          r.UserID,
@@ -111,6 +151,9 @@ func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, e
 		if errors.Is(err, repository.ErrDataNotFound) {
 			return nil, ErrAccountNotFound
 		}
+		if errors.Is(err, repository.ErrDataReferenced) {
+			return nil, ErrAccountInUse
+		}
 		return nil, err
 	}
 
@@ -126,28 +169,92 @@ func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, e
 	if r.AccountPublicID == "" {
 		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
 	}
+	if r.Policy == repository.DeletePolicyReassign && r.ReplacementPublicID == "" {
+		return nil, fmt.Errorf("%w: missing replacement id", ErrDataInsufficient)
+	}
 
 	_, err := s.repository.Delete(ctx, &repository.DeleteAccountsRequest{
-		AccountPublicIDs: []string{r.AccountPublicID},
-		UserID:           r.UserID,
+		AccountPublicIDs:    []string{r.AccountPublicID},
+		UserID:              r.UserID,
+		Policy:              r.Policy,
+		ReplacementPublicID: r.ReplacementPublicID,
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrDataNotFound) {
 			return nil, ErrAccountNotFound
 		}
+		if errors.Is(err, repository.ErrDataReferenced) {
+			return nil, fmt.Errorf("%w: %w", ErrAccountReferenced, err)
+		}
+		if errors.Is(err, repository.ErrReferenceNotFound) ||
+			errors.Is(err, repository.ErrInvalidReference) ||
+			errors.Is(err, repository.ErrCurrencyMismatch) {
+			return nil, ErrInvalidReplacement
+		}
 		return nil, err
 	}
 	return &DeleteReply{}, nil
 }
 
+// validateAccount checks the attributes are of the type of the account.
+func validateAccount(v *BaseAccount) error {
+	if code, err := currency.Normalize(v.Currency); err != nil || code != v.Currency {
+		return fmt.Errorf("%w: currency must be an upper case ISO-4217 code", ErrInvalidAccount)
+	}
+	if _, err := repository.ToAccountType(v.Type.String()); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAccount, err)
+	}
+	if v.Type != repository.AccountTypeBank && v.Type != repository.AccountTypeCreditCard {
+		if v.BankName != "" || v.LastDigits != "" {
+			return fmt.Errorf("%w: bank name and last digits are only for bank accounts and credit cards", ErrInvalidAccount)
+		}
+	}
+	if len(v.LastDigits) > 4 || strings.Trim(v.LastDigits, "0123456789") != "" {
+		return fmt.Errorf("%w: last digits must be 4 digits at most", ErrInvalidAccount)
+	}
+	if v.Type != repository.AccountTypeCreditCard {
+		if v.CreditLimit != nil || v.StatementClosingDay != 0 || v.PaymentDueDay != 0 {
+			return fmt.Errorf("%w: credit limit, statement closing day and payment due day are only for credit cards", ErrInvalidAccount)
+		}
+	}
+	if v.CreditLimit != nil && v.CreditLimit.IsNegative() {
+		return fmt.Errorf("%w: negative credit limit", ErrInvalidAccount)
+	}
+	if v.StatementClosingDay < 0 || v.StatementClosingDay > 31 {
+		return fmt.Errorf("%w: statement closing day must be between 1 and 31", ErrInvalidAccount)
+	}
+	if v.PaymentDueDay < 0 || v.PaymentDueDay > 31 {
+		return fmt.Errorf("%w: payment due day must be between 1 and 31", ErrInvalidAccount)
+	}
+	return nil
+}
+
+func (s *service) validateIcon(ctx context.Context, iconID int32) error {
+	err := s.iconValidator.Validate(ctx, &icons.ValidateRequest{
+		Kind:   icons.KindAccount,
+		IconID: iconID,
+	})
+	if errors.Is(err, icons.ErrIconNotFound) {
+		return fmt.Errorf("%w: %v", ErrInvalidIcon, err)
+	}
+	return err
+}
+
 func parseAccount(v *repository.Account) *Account {
 	return &Account{
 		ID:       v.ID,
 		PublicID: v.PublicID,
 		BaseAccount: &BaseAccount{
-			Name:           v.Name,
-			IconID:         v.IconID,
-			InitialBalance: v.InitialBalance,
+			Name:                v.Name,
+			IconID:              v.IconID,
+			InitialBalance:      v.InitialBalance,
+			Currency:            v.Currency,
+			Type:                v.Type,
+			BankName:            v.BankName,
+			LastDigits:          v.LastDigits,
+			CreditLimit:         v.CreditLimit,
+			StatementClosingDay: v.StatementClosingDay,
+			PaymentDueDay:       v.PaymentDueDay,
 		},
 		Balance: v.Balance,
 	}
@@ -158,9 +265,16 @@ func parseBaseAccount(v *BaseAccount) *repository.BaseAccount {
 		return nil
 	}
 	return &repository.BaseAccount{
-		Name:           v.Name,
-		IconID:         v.IconID,
-		InitialBalance: v.InitialBalance,
+		Name:                v.Name,
+		IconID:              v.IconID,
+		InitialBalance:      v.InitialBalance,
+		Currency:            v.Currency,
+		Type:                v.Type,
+		BankName:            v.BankName,
+		LastDigits:          v.LastDigits,
+		CreditLimit:         v.CreditLimit,
+		StatementClosingDay: v.StatementClosingDay,
+		PaymentDueDay:       v.PaymentDueDay,
 	}
 }
 
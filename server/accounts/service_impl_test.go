@@ -3,12 +3,15 @@ package accounts
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/icons"
 	"github.com/n101661/maney/server/repository"
 )
 
@@ -58,8 +61,15 @@ func Test_service_Create(t *testing.T) {
 					},
 				}, nil),
 		)
-
-		s, err := NewService(mockRepo, WithAccountServiceGenPublicID(func() string {
+		mockValidator := icons.NewMockValidator(controller)
+		mockValidator.EXPECT().
+			Validate(gomock.Any(), &icons.ValidateRequest{
+				Kind:   icons.KindAccount,
+				IconID: iconID,
+			}).
+			Return(nil)
+
+		s, err := NewService(mockRepo, nil, mockValidator, WithAccountServiceGenPublicID(func() string {
 			return publicID
 		}))
 		if err != nil {
@@ -88,6 +98,117 @@ func Test_service_Create(t *testing.T) {
 			},
 		}, reply)
 	})
+	t.Run("invalid account", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			account *BaseAccount
+		}{
+			{
+				name: "unknown type",
+				account: &BaseAccount{
+					Name: "A",
+					Type: 99,
+				},
+			},
+			{
+				name: "bank name of cash",
+				account: &BaseAccount{
+					Name:     "A",
+					Type:     repository.AccountTypeCash,
+					BankName: "B",
+				},
+			},
+			{
+				name: "too many last digits",
+				account: &BaseAccount{
+					Name:       "A",
+					Type:       repository.AccountTypeBank,
+					LastDigits: "12345",
+				},
+			},
+			{
+				name: "credit limit of bank account",
+				account: &BaseAccount{
+					Name:        "A",
+					Type:        repository.AccountTypeBank,
+					CreditLimit: lo.ToPtr(decimal.NewFromInt(100)),
+				},
+			},
+			{
+				name: "negative credit limit",
+				account: &BaseAccount{
+					Name:        "A",
+					Type:        repository.AccountTypeCreditCard,
+					CreditLimit: lo.ToPtr(decimal.NewFromInt(-1)),
+				},
+			},
+			{
+				name: "statement closing day out of range",
+				account: &BaseAccount{
+					Name:                "A",
+					Type:                repository.AccountTypeCreditCard,
+					StatementClosingDay: 32,
+				},
+			},
+			{
+				name: "unknown currency",
+				account: &BaseAccount{
+					Name:     "A",
+					Currency: "ABC",
+				},
+			},
+			{
+				name: "lower case currency",
+				account: &BaseAccount{
+					Name:     "A",
+					Currency: "usd",
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				controller := gomock.NewController(t)
+				s, err := NewService(repository.NewMockAccountRepository(controller), nil, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Create(context.Background(), &CreateRequest{
+					UserID:  "user-id",
+					Account: tt.account,
+				})
+				assert.ErrorIs(t, err, ErrInvalidAccount)
+				assert.Nil(t, reply)
+			})
+		}
+	})
+	t.Run("invalid icon", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockValidator := icons.NewMockValidator(controller)
+		mockValidator.EXPECT().
+			Validate(gomock.Any(), &icons.ValidateRequest{
+				Kind:   icons.KindAccount,
+				IconID: 99,
+			}).
+			Return(icons.ErrIconNotFound)
+
+		s, err := NewService(repository.NewMockAccountRepository(controller), nil, mockValidator)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Account: &BaseAccount{
+				Name:   "A",
+				IconID: 99,
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidIcon)
+		assert.Nil(reply)
+	})
 }
 
 func Test_service_List(t *testing.T) {
@@ -148,7 +269,7 @@ func Test_service_List(t *testing.T) {
 				}, nil),
 		)
 
-		s, err := NewService(mockRepo)
+		s, err := NewService(mockRepo, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -182,6 +303,87 @@ func Test_service_List(t *testing.T) {
 			},
 		}, reply)
 	})
+	t.Run("convert the balances", func(t *testing.T) {
+		const (
+			userID   = "user-id"
+			publicID = "publicID"
+			currency = "USD"
+		)
+		var (
+			date            = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+			balance         = decimal.NewFromInt(100)
+			convertedAmount = decimal.NewFromInt(3200)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockAccountRepository(controller)
+		mockConverter := exchangerates.NewMockConverter(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListAccountsRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListAccountsReply{
+					Accounts: []*repository.Account{
+						{
+							ID:       1,
+							PublicID: publicID,
+							BaseAccount: &repository.BaseAccount{
+								Name:           "A",
+								IconID:         11,
+								InitialBalance: decimal.Zero,
+								Currency:       currency,
+							},
+							Balance: balance,
+						},
+					},
+				}, nil),
+			mockConverter.EXPECT().
+				Convert(gomock.Any(), &exchangerates.ConvertRequest{
+					UserID:       userID,
+					Amount:       balance,
+					FromCurrency: currency,
+					ToCurrency:   "",
+					Date:         date,
+				}).
+				Return(&exchangerates.ConvertReply{
+					Amount: convertedAmount,
+					Rate:   decimal.NewFromInt(32),
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockConverter, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: userID,
+			ConvertTo: &ConvertTarget{
+				Currency: "",
+				Date:     date,
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Accounts: []*Account{
+				{
+					ID:       1,
+					PublicID: publicID,
+					BaseAccount: &BaseAccount{
+						Name:           "A",
+						IconID:         11,
+						InitialBalance: decimal.Zero,
+						Currency:       currency,
+					},
+					Balance:          balance,
+					ConvertedBalance: &convertedAmount,
+				},
+			},
+		}, reply)
+	})
 	t.Run("no account", func(t *testing.T) {
 		assert := assert.New(t)
 
@@ -191,7 +393,7 @@ func Test_service_List(t *testing.T) {
 			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
 		)
 
-		s, err := NewService(mockRepo)
+		s, err := NewService(mockRepo, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -267,8 +469,15 @@ func Test_service_Update(t *testing.T) {
 					Balance: newBalance,
 				}, nil),
 		)
-
-		s, err := NewService(mockRepo)
+		mockValidator := icons.NewMockValidator(controller)
+		mockValidator.EXPECT().
+			Validate(gomock.Any(), &icons.ValidateRequest{
+				Kind:   icons.KindAccount,
+				IconID: iconID,
+			}).
+			Return(nil)
+
+		s, err := NewService(mockRepo, nil, mockValidator)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -304,8 +513,10 @@ func Test_service_Update(t *testing.T) {
 		gomock.InOrder(
 			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
 		)
+		mockValidator := icons.NewMockValidator(controller)
+		mockValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
 
-		s, err := NewService(mockRepo)
+		s, err := NewService(mockRepo, nil, mockValidator)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -322,6 +533,43 @@ func Test_service_Update(t *testing.T) {
 		assert.ErrorIs(err, ErrAccountNotFound)
 		assert.Nil(reply)
 	})
+	t.Run("currency of referenced account", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockAccountRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListAccountsReply{
+				Accounts: []*repository.Account{
+					{
+						ID:          1,
+						PublicID:    "1",
+						BaseAccount: &repository.BaseAccount{Name: "accountName", Currency: "USD"},
+					},
+				},
+			}, nil),
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataReferenced),
+		)
+		mockValidator := icons.NewMockValidator(controller)
+		mockValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil)
+
+		s, err := NewService(mockRepo, nil, mockValidator)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:          "user-id",
+			AccountPublicID: "1",
+			Account: &BaseAccount{
+				Name:     "accountName",
+				IconID:   2,
+				Currency: "TWD",
+			},
+		})
+		assert.ErrorIs(err, ErrAccountInUse)
+		assert.Nil(reply)
+	})
 }
 
 func Test_service_Delete(t *testing.T) {
@@ -363,7 +611,7 @@ func Test_service_Delete(t *testing.T) {
 				}, nil),
 		)
 
-		s, err := NewService(mockRepo)
+		s, err := NewService(mockRepo, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -384,7 +632,7 @@ func Test_service_Delete(t *testing.T) {
 			mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
 		)
 
-		s, err := NewService(mockRepo)
+		s, err := NewService(mockRepo, nil, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -396,4 +644,65 @@ func Test_service_Delete(t *testing.T) {
 		assert.ErrorIs(err, ErrAccountNotFound)
 		assert.Nil(reply)
 	})
+	t.Run("delete policies", func(t *testing.T) {
+		referenced := &repository.ReferencedError{References: []*repository.Reference{
+			{Type: repository.ReferenceTypeDailyItem, PublicID: "dailyItemID"},
+			{Type: repository.ReferenceTypeTransfer, PublicID: "transferID"},
+		}}
+		tests := []struct {
+			name        string
+			policy      repository.DeletePolicy
+			replacement string
+			// repoErr is returned by the repository, which is not called if the request is
+			// insufficient.
+			repoErr error
+			wantErr error
+		}{
+			{name: "referenced account is restricted", policy: repository.DeletePolicyRestrict, repoErr: referenced, wantErr: ErrAccountReferenced},
+			{name: "missing replacement", policy: repository.DeletePolicyReassign, wantErr: ErrDataInsufficient},
+			{name: "invalid replacement", policy: repository.DeletePolicyReassign, replacement: "2", repoErr: repository.ErrCurrencyMismatch, wantErr: ErrInvalidReplacement},
+			{name: "references are cascaded", policy: repository.DeletePolicyCascade},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				controller := gomock.NewController(t)
+				mockRepo := repository.NewMockAccountRepository(controller)
+				if tt.wantErr != ErrDataInsufficient {
+					mockRepo.EXPECT().
+						Delete(gomock.Any(), &repository.DeleteAccountsRequest{
+							UserID:              "userID",
+							AccountPublicIDs:    []string{"1"},
+							Policy:              tt.policy,
+							ReplacementPublicID: tt.replacement,
+						}).
+						Return(nil, tt.repoErr)
+				}
+
+				s, err := NewService(mockRepo, nil, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Delete(context.Background(), &DeleteRequest{
+					UserID:              "userID",
+					AccountPublicID:     "1",
+					Policy:              tt.policy,
+					ReplacementPublicID: tt.replacement,
+				})
+				if tt.wantErr == nil {
+					assert.NoError(err)
+					assert.Equal(&DeleteReply{}, reply)
+					return
+				}
+				assert.ErrorIs(err, tt.wantErr)
+				if tt.repoErr == referenced {
+					// the references are kept for the response of the conflict.
+					assert.ErrorIs(err, referenced)
+				}
+				assert.Nil(reply)
+			})
+		}
+	})
 }
@@ -0,0 +1,193 @@
+package budgets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/models"
+)
+
+// monthLayout is the layout of models.Month.
+const monthLayout = "2006-01"
+
+type IrisController struct {
+	*irisController.SimpleCreateTemplate[models.BasicBudget, CreateRequest, CreateReply, models.ObjectId]
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Budget]
+	*irisController.SimpleUpdateTemplate[models.BasicBudget, UpdateRequest, UpdateReply]
+	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+
+	Progress *irisController.SimpleGetTemplate[ProgressRequest, ProgressReply, []*models.BudgetProgress]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicBudget, CreateRequest, CreateReply, models.ObjectId]{
+			Service: s,
+			ParseServiceRequest: func(userID string, r *models.BasicBudget) (*CreateRequest, error) {
+				budget, err := toServiceBaseBudget(r)
+				if err != nil {
+					return nil, err
+				}
+				return &CreateRequest{
+					UserID: userID,
+					Budget: budget,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrBudgetExists):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrReferenceNotFound),
+					errors.Is(err, ErrCategoryTypeMismatch),
+					errors.Is(err, ErrInvalidLimit):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *CreateReply) (*models.ObjectId, error) {
+				return &models.ObjectId{
+					Id: lo.ToPtr(models.Id(reply.Budget.PublicID)),
+				}, nil
+			},
+		},
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Budget]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				return &ListRequest{
+					UserID: userID,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.Budget, error) {
+				return lo.ToPtr(lo.Map(reply.Budgets, func(item *Budget, _ int) *models.Budget {
+					return toAPIBudget(item)
+				})), nil
+			},
+		},
+		SimpleUpdateTemplate: &irisController.SimpleUpdateTemplate[models.BasicBudget, UpdateRequest, UpdateReply]{
+			Placeholder: "budgetId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string, r *models.BasicBudget) (*UpdateRequest, error) {
+				budget, err := toServiceBaseBudget(r)
+				if err != nil {
+					return nil, err
+				}
+				return &UpdateRequest{
+					UserID:         userID,
+					BudgetPublicID: publicID,
+					Budget:         budget,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrBudgetNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrBudgetExists):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrReferenceNotFound),
+					errors.Is(err, ErrCategoryTypeMismatch),
+					errors.Is(err, ErrInvalidLimit):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+			Placeholder: "budgetId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+				return &DeleteRequest{
+					UserID:         userID,
+					BudgetPublicID: publicID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrBudgetNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		Progress: &irisController.SimpleGetTemplate[ProgressRequest, ProgressReply, []*models.BudgetProgress]{
+			Handle: s.Progress,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ProgressRequest, error) {
+				month := time.Now()
+				if v := c.URLParam("month"); v != "" {
+					var err error
+					month, err = time.Parse(monthLayout, v)
+					if err != nil {
+						return nil, fmt.Errorf("invalid month[%s]", v)
+					}
+				}
+				return &ProgressRequest{
+					UserID: userID,
+					Month:  month,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return exchangerates.BadConversion(err)
+			},
+			ParseAPIResponse: func(reply *ProgressReply) (*[]*models.BudgetProgress, error) {
+				return lo.ToPtr(lo.Map(reply.Budgets, func(item *BudgetProgress, _ int) *models.BudgetProgress {
+					return &models.BudgetProgress{
+						Budget:      *toAPIBudget(item.Budget),
+						Carried:     item.Carried.String(),
+						Available:   item.Available.String(),
+						Spent:       item.Spent.String(),
+						Remaining:   item.Remaining.String(),
+						PercentUsed: item.PercentUsed.String(),
+					}
+				})), nil
+			},
+		},
+	}
+}
+
+func toServiceBaseBudget(r *models.BasicBudget) (*BaseBudget, error) {
+	limit, err := decimal.NewFromString(r.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decimal[%s]", r.Limit)
+	}
+	startMonth, err := time.Parse(monthLayout, r.StartMonth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month[%s]", r.StartMonth)
+	}
+	return &BaseBudget{
+		CategoryPublicID: r.CategoryId,
+		Limit:            limit,
+		Rollover:         lo.FromPtr(r.Rollover),
+		StartMonth:       startMonth,
+	}, nil
+}
+
+func toAPIBudget(v *Budget) *models.Budget {
+	return &models.Budget{
+		Id:         lo.ToPtr(models.Id(v.PublicID)),
+		CategoryId: v.CategoryPublicID,
+		Limit:      v.Limit.String(),
+		Rollover:   lo.ToPtr(v.Rollover),
+		StartMonth: v.StartMonth.Format(monthLayout),
+	}
+}
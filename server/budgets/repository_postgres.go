@@ -0,0 +1,221 @@
+package budgets
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.BudgetRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Create(ctx context.Context, r *repository.CreateBudgetRequest) (*repository.Budget, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	return CreateWithSession(session, r)
+}
+
+// CreateWithSession is the same as Create, but it creates the budget with the session
+// so that it can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateBudgetRequest) (*repository.Budget, error) {
+	categoryID, err := resolveCategory(session, r.UserID, r.Budget.CategoryPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &postgres.BudgetsModel{
+		PublicID:   r.PublicID,
+		UserID:     r.UserID,
+		CategoryID: categoryID,
+		Amount:     decimal.NewNullDecimal(r.Budget.Limit),
+		Rollover:   r.Budget.Rollover,
+		StartMonth: r.Budget.StartMonth,
+	}
+	if _, err := session.Insert(row); err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	return &repository.Budget{
+		ID:         row.ID,
+		PublicID:   row.PublicID,
+		BaseBudget: r.Budget,
+	}, nil
+}
+
+func (repo *postgresRepository) List(ctx context.Context, r *repository.ListBudgetsRequest) (*repository.ListBudgetsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var rows []*postgres.BudgetsModel
+	err := session.Asc("id").Find(&rows, &postgres.BudgetsModel{
+		PublicID: lo.FromPtr(r.BudgetPublicID),
+		UserID:   r.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	budgets, err := loadBudgets(session, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.ListBudgetsReply{
+		Budgets: budgets,
+	}, nil
+}
+
+func (repo *postgresRepository) Update(ctx context.Context, r *repository.UpdateBudgetRequest) (*repository.Budget, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	row := postgres.BudgetsModel{
+		PublicID: r.BudgetPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&row)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrDataNotFound
+	}
+
+	categoryID, err := resolveCategory(session, r.UserID, r.Budget.CategoryPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	row.CategoryID = categoryID
+	row.Amount = decimal.NewNullDecimal(r.Budget.Limit)
+	row.Rollover = r.Budget.Rollover
+	row.StartMonth = r.Budget.StartMonth
+
+	affected, err := session.
+		Cols("category_id", "amount", "rollover", "start_month").
+		Update(&row, &postgres.BudgetsModel{
+			ID: row.ID,
+		})
+	if err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	return &repository.Budget{
+		ID:         row.ID,
+		PublicID:   row.PublicID,
+		BaseBudget: r.Budget,
+	}, nil
+}
+
+func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteBudgetsRequest) ([]*repository.Budget, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	session.Where("user_id = ?", r.UserID)
+	if len(r.BudgetPublicIDs) > 0 {
+		session.In("public_id", r.BudgetPublicIDs)
+	}
+
+	var rows []*postgres.BudgetsModel
+	err := session.Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.BudgetPublicIDs) > 0 && len(rows) != len(r.BudgetPublicIDs) {
+		return nil, repository.ErrDataNotFound
+	}
+	if len(r.BudgetPublicIDs) == 0 && len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	budgets, err := loadBudgets(session, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = session.In("id", lo.Map(rows, func(item *postgres.BudgetsModel, _ int) any {
+		return item.ID
+	})).Delete(&postgres.BudgetsModel{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+// resolveCategory returns the id of the expense category of the user.
+func resolveCategory(session *xorm.Session, userID, publicID string) (int32, error) {
+	category := postgres.CategoriesModel{
+		PublicID: publicID,
+		UserID:   userID,
+	}
+	has, err := session.Get(&category)
+	if err != nil {
+		return 0, err
+	}
+	if !has {
+		return 0, repository.ErrReferenceNotFound
+	}
+	if category.Type != repository.CategoryTypeExpense {
+		return 0, repository.ErrInvalidReference
+	}
+	return category.ID, nil
+}
+
+// loadBudgets fills the referenced public ids of the rows.
+func loadBudgets(session *xorm.Session, rows []*postgres.BudgetsModel) ([]*repository.Budget, error) {
+	var categories []*postgres.CategoriesModel
+	err := session.In("id", lo.Uniq(lo.Map(rows, func(item *postgres.BudgetsModel, _ int) int32 {
+		return item.CategoryID
+	}))).Find(&categories)
+	if err != nil {
+		return nil, err
+	}
+	categoryPublicIDs := lo.SliceToMap(categories, func(item *postgres.CategoriesModel) (int32, string) {
+		return item.ID, item.PublicID
+	})
+
+	return lo.Map(rows, func(item *postgres.BudgetsModel, _ int) *repository.Budget {
+		return &repository.Budget{
+			ID:       item.ID,
+			PublicID: item.PublicID,
+			BaseBudget: &repository.BaseBudget{
+				CategoryPublicID: categoryPublicIDs[item.CategoryID],
+				Limit:            item.Amount.Decimal,
+				Rollover:         item.Rollover,
+				StartMonth:       item.StartMonth,
+			},
+		}
+	}), nil
+}
@@ -0,0 +1,126 @@
+package budgets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrDataInsufficient     = fmt.Errorf("data insufficient")
+	ErrBudgetNotFound       = fmt.Errorf("budget not found")
+	ErrBudgetExists         = fmt.Errorf("the category has had a budget")
+	ErrReferenceNotFound    = fmt.Errorf("referenced category not found")
+	ErrCategoryTypeMismatch = fmt.Errorf("category of the budget is not an expense category")
+	ErrInvalidLimit         = fmt.Errorf("limit must be positive")
+)
+
+type Service interface {
+	// Create returns error:
+	//  - ErrDataInsufficient if any of required fields of CreateRequest is zero-value,
+	//  - ErrInvalidLimit if the limit is not positive,
+	//  - ErrReferenceNotFound if the referenced category does not exist,
+	//  - ErrCategoryTypeMismatch if the category is not an expense category,
+	//  - ErrBudgetExists if the category has had a budget.
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	// List returns ErrDataInsufficient if any of fields of ListRequest is zero-value,
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Update returns error:
+	//  - ErrDataInsufficient if any of required fields of UpdateRequest is zero-value,
+	//  - ErrInvalidLimit if the limit is not positive,
+	//  - ErrReferenceNotFound if the referenced category does not exist,
+	//  - ErrCategoryTypeMismatch if the category is not an expense category,
+	//  - ErrBudgetExists if the category has had another budget,
+	//  - ErrBudgetNotFound if the budget does not exist.
+	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
+	// Delete returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
+	//  - ErrBudgetNotFound if the budget does not exist.
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+	// Progress returns the spent and the remaining amount of each budget in the month,
+	// the budgets which start after the month are skipped. The expense of the item which
+	// has multiple categories is attributed by its splits, or split equally to each of them
+	// if it has no split. The budget of a category includes the expense of all of its
+	// sub-categories. It returns ErrDataInsufficient if any of fields of ProgressRequest is
+	// zero-value, or returns any error of exchangerates.Converter if the expense in foreign
+	// currencies fails to be converted.
+	Progress(context.Context, *ProgressRequest) (*ProgressReply, error)
+}
+
+type BaseBudget struct {
+	CategoryPublicID string
+	// Limit is the monthly limit of the expense of the category.
+	Limit decimal.Decimal
+	// Rollover carries the unused amount of the previous months over to the next month.
+	Rollover bool
+	// StartMonth is the month which the budget starts from, only its year and month are used.
+	StartMonth time.Time
+}
+
+type Budget struct {
+	ID       int32
+	PublicID string
+	*BaseBudget
+}
+
+type CreateRequest struct {
+	UserID string
+	Budget *BaseBudget
+}
+
+type CreateReply struct {
+	Budget *Budget
+}
+
+type ListRequest struct {
+	UserID string
+}
+
+type ListReply struct {
+	Budgets []*Budget
+}
+
+type UpdateRequest struct {
+	UserID         string
+	BudgetPublicID string
+	Budget         *BaseBudget
+}
+
+type UpdateReply struct {
+	Budget *Budget
+}
+
+type DeleteRequest struct {
+	UserID         string
+	BudgetPublicID string
+}
+
+type DeleteReply struct{}
+
+type ProgressRequest struct {
+	UserID string
+	// Month is the month to be computed, only its year and month are used.
+	Month time.Time
+}
+
+type ProgressReply struct {
+	Budgets []*BudgetProgress
+}
+
+type BudgetProgress struct {
+	Budget *Budget
+	// Carried is the unused amount carried over from the previous months, it is
+	// always zero if the budget does not roll over.
+	Carried decimal.Decimal
+	// Available is the sum of the limit and the carried amount.
+	Available decimal.Decimal
+	// Spent is the expense of the category in the month.
+	Spent decimal.Decimal
+	// Remaining is the available amount minus the spent amount, it is negative if
+	// the budget is overspent.
+	Remaining decimal.Decimal
+	// PercentUsed is the spent amount in percentage of the available amount.
+	PercentUsed decimal.Decimal
+}
@@ -0,0 +1,355 @@
+package budgets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	repository          repository.BudgetRepository
+	dailyItemRepository repository.DailyItemRepository
+	categoryRepository  repository.CategoryRepository
+	converter           exchangerates.Converter
+
+	opts *BudgetServiceOptions
+}
+
+func NewService(
+	repository repository.BudgetRepository,
+	dailyItemRepository repository.DailyItemRepository,
+	categoryRepository repository.CategoryRepository,
+	converter exchangerates.Converter,
+	opts ...utils.Option[BudgetServiceOptions],
+) (Service, error) {
+	return &service{
+		repository:          repository,
+		dailyItemRepository: dailyItemRepository,
+		categoryRepository:  categoryRepository,
+		converter:           converter,
+		opts:                utils.ApplyOptions(defaultBudgetServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if err := validateBaseBudget(r.Budget); err != nil {
+		return nil, err
+	}
+
+	row, err := s.repository.Create(ctx, &repository.CreateBudgetRequest{
+		UserID:   r.UserID,
+		PublicID: s.opts.genPublicID(),
+		Budget:   parseBaseBudget(r.Budget),
+	})
+	if err != nil {
+		return nil, parseRepositoryError(err)
+	}
+
+	return &CreateReply{
+		Budget: parseBudget(row),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListBudgetsRequest{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ListReply{
+				Budgets: []*Budget{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &ListReply{
+		Budgets: lo.Map(reply.Budgets, func(item *repository.Budget, _ int) *Budget {
+			return parseBudget(item)
+		}),
+	}, nil
+}
+
+func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.BudgetPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+	if err := validateBaseBudget(r.Budget); err != nil {
+		return nil, err
+	}
+
+	row, err := s.repository.Update(ctx, &repository.UpdateBudgetRequest{
+		UserID:         r.UserID,
+		BudgetPublicID: r.BudgetPublicID,
+		Budget:         parseBaseBudget(r.Budget),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrBudgetNotFound
+		}
+		return nil, parseRepositoryError(err)
+	}
+
+	return &UpdateReply{
+		Budget: parseBudget(row),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.BudgetPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.Delete(ctx, &repository.DeleteBudgetsRequest{
+		UserID:          r.UserID,
+		BudgetPublicIDs: []string{r.BudgetPublicID},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrBudgetNotFound
+		}
+		return nil, err
+	}
+	return &DeleteReply{}, nil
+}
+
+func (s *service) Progress(ctx context.Context, r *ProgressRequest) (*ProgressReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Month.IsZero() {
+		return nil, fmt.Errorf("%w: missing month", ErrDataInsufficient)
+	}
+	month := toMonth(r.Month)
+
+	reply, err := s.repository.List(ctx, &repository.ListBudgetsRequest{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ProgressReply{
+				Budgets: []*BudgetProgress{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	budgets := lo.Filter(reply.Budgets, func(item *repository.Budget, _ int) bool {
+		return !toMonth(item.StartMonth).After(month)
+	})
+	if len(budgets) == 0 {
+		return &ProgressReply{
+			Budgets: []*BudgetProgress{},
+		}, nil
+	}
+
+	// the unused amounts are carried month by month from the earliest rollover budget.
+	from := month
+	for _, budget := range budgets {
+		if start := toMonth(budget.StartMonth); budget.Rollover && start.Before(from) {
+			from = start
+		}
+	}
+
+	// the expense of all months is summed at once, so that the cost does not grow with the
+	// months which the rollover budgets last.
+	spent, err := s.sumExpense(ctx, r.UserID, from, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rollUp(ctx, r.UserID, spent); err != nil {
+		return nil, err
+	}
+
+	carried := make(map[string]decimal.Decimal, len(budgets))
+	for m := from; m.Before(month); m = m.AddDate(0, 1, 0) {
+		for _, budget := range budgets {
+			if !budget.Rollover || toMonth(budget.StartMonth).After(m) {
+				continue
+			}
+			remaining := budget.Limit.Add(carried[budget.PublicID]).Sub(spent[m][budget.CategoryPublicID])
+			carried[budget.PublicID] = decimal.Max(remaining, decimal.Zero)
+		}
+	}
+
+	return &ProgressReply{
+		Budgets: lo.Map(budgets, func(item *repository.Budget, _ int) *BudgetProgress {
+			return newBudgetProgress(parseBudget(item), carried[item.PublicID], spent[month][item.CategoryPublicID])
+		}),
+	}, nil
+}
+
+// sumExpense returns the expense of each category in each month of the period, the
+// expense in foreign currencies is converted into the home currency with the exchange
+// rates at the end of its month.
+func (s *service) sumExpense(ctx context.Context, userID string, from, to time.Time) (map[time.Time]map[string]decimal.Decimal, error) {
+	reply, err := s.dailyItemRepository.SumByCategory(ctx, &repository.SumDailyItemsByCategoryRequest{
+		UserID:  userID,
+		Type:    repository.CategoryTypeExpense,
+		From:    from,
+		To:      to,
+		Monthly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[time.Time]map[string]decimal.Decimal{}
+	for _, category := range reply.Categories {
+		month := toMonth(category.Month)
+		amount := category.Amount
+		if category.Currency != "" {
+			converted, err := s.converter.Convert(ctx, &exchangerates.ConvertRequest{
+				UserID:       userID,
+				Amount:       amount,
+				FromCurrency: category.Currency,
+				Date:         month.AddDate(0, 1, -1),
+			})
+			if err != nil {
+				return nil, err
+			}
+			amount = converted.Amount
+		}
+		if result[month] == nil {
+			result[month] = map[string]decimal.Decimal{}
+		}
+		result[month][category.CategoryPublicID] = result[month][category.CategoryPublicID].Add(amount)
+	}
+	return result, nil
+}
+
+// rollUp adds the expense of the categories into all of their ancestors in each month, so
+// that the budget of a category includes the expense of its sub-categories.
+func (s *service) rollUp(ctx context.Context, userID string, spent map[time.Time]map[string]decimal.Decimal) error {
+	if len(spent) == 0 {
+		return nil
+	}
+
+	reply, err := s.categoryRepository.List(ctx, &repository.ListCategoriesRequest{
+		UserID: userID,
+		Type:   repository.CategoryTypeExpense,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+	parents := lo.SliceToMap(reply.Categories, func(item *repository.Category) (string, *string) {
+		return item.PublicID, item.ParentPublicID
+	})
+
+	for _, amounts := range spent {
+		// only the own expense of the categories is added.
+		owns := maps.Clone(amounts)
+		for categoryPublicID, own := range owns {
+			for parent := parents[categoryPublicID]; parent != nil; parent = parents[*parent] {
+				amounts[*parent] = amounts[*parent].Add(own)
+			}
+		}
+	}
+	return nil
+}
+
+func newBudgetProgress(budget *Budget, carried, spent decimal.Decimal) *BudgetProgress {
+	available := budget.Limit.Add(carried)
+	return &BudgetProgress{
+		Budget:      budget,
+		Carried:     carried,
+		Available:   available,
+		Spent:       spent,
+		Remaining:   available.Sub(spent),
+		PercentUsed: spent.Div(available).Mul(decimal.NewFromInt(100)).Round(2),
+	}
+}
+
+// toMonth truncates the time to the first day of the month in UTC.
+func toMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func validateBaseBudget(v *BaseBudget) error {
+	if v == nil {
+		return fmt.Errorf("%w: missing budget", ErrDataInsufficient)
+	}
+	if v.CategoryPublicID == "" {
+		return fmt.Errorf("%w: missing budget.categoryId", ErrDataInsufficient)
+	}
+	if v.StartMonth.IsZero() {
+		return fmt.Errorf("%w: missing budget.startMonth", ErrDataInsufficient)
+	}
+	if !v.Limit.IsPositive() {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+func parseRepositoryError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrDataExists):
+		return ErrBudgetExists
+	case errors.Is(err, repository.ErrReferenceNotFound):
+		return ErrReferenceNotFound
+	case errors.Is(err, repository.ErrInvalidReference):
+		return ErrCategoryTypeMismatch
+	}
+	return err
+}
+
+func parseBudget(v *repository.Budget) *Budget {
+	return &Budget{
+		ID:         v.ID,
+		PublicID:   v.PublicID,
+		BaseBudget: lo.ToPtr(BaseBudget(*v.BaseBudget)),
+	}
+}
+
+func parseBaseBudget(v *BaseBudget) *repository.BaseBudget {
+	return &repository.BaseBudget{
+		CategoryPublicID: v.CategoryPublicID,
+		Limit:            v.Limit,
+		Rollover:         v.Rollover,
+		StartMonth:       toMonth(v.StartMonth),
+	}
+}
+
+type BudgetServiceOptions struct {
+	genPublicID func() string
+}
+
+func defaultBudgetServiceOptions() *BudgetServiceOptions {
+	return &BudgetServiceOptions{
+		genPublicID: func() string {
+			return slugid.New("bgt", 11)
+		},
+	}
+}
+
+func WithBudgetServiceGenPublicID(f func() string) utils.Option[BudgetServiceOptions] {
+	return func(o *BudgetServiceOptions) {
+		o.genPublicID = f
+	}
+}
@@ -0,0 +1,530 @@
+package budgets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Create(t *testing.T) {
+	t.Run("create successful", func(t *testing.T) {
+		const (
+			userID     = "user-id"
+			publicID   = "publicID"
+			categoryID = "categoryID"
+
+			returnedBudgetID = 9
+		)
+		var (
+			limit      = decimal.NewFromInt(5000)
+			startMonth = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Create(gomock.Any(), &repository.CreateBudgetRequest{
+					UserID:   userID,
+					PublicID: publicID,
+					Budget: &repository.BaseBudget{
+						CategoryPublicID: categoryID,
+						Limit:            limit,
+						Rollover:         true,
+						StartMonth:       startMonth,
+					},
+				}).
+				Return(&repository.Budget{
+					ID:       returnedBudgetID,
+					PublicID: publicID,
+					BaseBudget: &repository.BaseBudget{
+						CategoryPublicID: categoryID,
+						Limit:            limit,
+						Rollover:         true,
+						StartMonth:       startMonth,
+					},
+				}, nil),
+		)
+
+		s, err := NewService(
+			mockRepo,
+			repository.NewMockDailyItemRepository(controller),
+			repository.NewMockCategoryRepository(controller),
+			nil,
+			WithBudgetServiceGenPublicID(func() string {
+				return publicID
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Budget: &BaseBudget{
+				CategoryPublicID: categoryID,
+				Limit:            limit,
+				Rollover:         true,
+				StartMonth:       time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&CreateReply{
+			Budget: &Budget{
+				ID:       returnedBudgetID,
+				PublicID: publicID,
+				BaseBudget: &BaseBudget{
+					CategoryPublicID: categoryID,
+					Limit:            limit,
+					Rollover:         true,
+					StartMonth:       startMonth,
+				},
+			},
+		}, reply)
+	})
+	t.Run("limit is not positive", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(
+			repository.NewMockBudgetRepository(controller),
+			repository.NewMockDailyItemRepository(controller),
+			repository.NewMockCategoryRepository(controller),
+			nil,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Budget: &BaseBudget{
+				CategoryPublicID: "categoryID",
+				Limit:            decimal.Zero,
+				StartMonth:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidLimit)
+		assert.Nil(reply)
+	})
+	t.Run("the category has had a budget", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataExists),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Budget: &BaseBudget{
+				CategoryPublicID: "categoryID",
+				Limit:            decimal.NewFromInt(1),
+				StartMonth:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		})
+		assert.ErrorIs(err, ErrBudgetExists)
+		assert.Nil(reply)
+	})
+	t.Run("category is not an expense category", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrInvalidReference),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Budget: &BaseBudget{
+				CategoryPublicID: "incomeCategoryID",
+				Limit:            decimal.NewFromInt(1),
+				StartMonth:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		})
+		assert.ErrorIs(err, ErrCategoryTypeMismatch)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Update(t *testing.T) {
+	t.Run("budget not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:         "user-id",
+			BudgetPublicID: "publicID",
+			Budget: &BaseBudget{
+				CategoryPublicID: "categoryID",
+				Limit:            decimal.NewFromInt(1),
+				StartMonth:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		})
+		assert.ErrorIs(err, ErrBudgetNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Delete(t *testing.T) {
+	t.Run("budget not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:         "user-id",
+			BudgetPublicID: "publicID",
+		})
+		assert.ErrorIs(err, ErrBudgetNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Progress(t *testing.T) {
+	t.Run("progress with rollover", func(t *testing.T) {
+		const (
+			userID      = "user-id"
+			foodID      = "foodID"
+			transportID = "transportID"
+		)
+		var (
+			jan = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			feb = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+			mar = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+			food = &repository.Budget{
+				ID:       1,
+				PublicID: "food",
+				BaseBudget: &repository.BaseBudget{
+					CategoryPublicID: foodID,
+					Limit:            decimal.NewFromInt(100),
+					Rollover:         true,
+					StartMonth:       jan,
+				},
+			}
+			transport = &repository.Budget{
+				ID:       2,
+				PublicID: "transport",
+				BaseBudget: &repository.BaseBudget{
+					CategoryPublicID: transportID,
+					Limit:            decimal.NewFromInt(50),
+					StartMonth:       jan,
+				},
+			}
+			future = &repository.Budget{
+				ID:       3,
+				PublicID: "future",
+				BaseBudget: &repository.BaseBudget{
+					CategoryPublicID: "otherID",
+					Limit:            decimal.NewFromInt(10),
+					StartMonth:       time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+				},
+			}
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockCategoryRepo := repository.NewMockCategoryRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListBudgetsRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListBudgetsReply{
+					Budgets: []*repository.Budget{food, transport, future},
+				}, nil),
+			mockDailyItemRepo.EXPECT().
+				SumByCategory(gomock.Any(), &repository.SumDailyItemsByCategoryRequest{
+					UserID:  userID,
+					Type:    repository.CategoryTypeExpense,
+					From:    jan,
+					To:      time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+					Monthly: true,
+				}).
+				Return(&repository.SumDailyItemsByCategoryReply{
+					Categories: []*repository.CategorySum{
+						{Month: jan, CategoryPublicID: foodID, Amount: decimal.NewFromInt(70), Count: 3},
+						{Month: jan, CategoryPublicID: transportID, Amount: decimal.NewFromInt(80), Count: 2},
+						// overspent, nothing is carried to March.
+						{Month: feb, CategoryPublicID: foodID, Amount: decimal.NewFromInt(150), Count: 5},
+						{Month: mar, CategoryPublicID: foodID, Amount: decimal.NewFromInt(25), Count: 1},
+					},
+				}, nil),
+			mockCategoryRepo.EXPECT().
+				List(gomock.Any(), &repository.ListCategoriesRequest{
+					UserID: userID,
+					Type:   repository.CategoryTypeExpense,
+				}).
+				Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, mockCategoryRepo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Progress(context.Background(), &ProgressRequest{
+			UserID: userID,
+			Month:  time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.Len(reply.Budgets, 2)
+		assert.Equal(parseBudget(food), reply.Budgets[0].Budget)
+		assert.True(decimal.Zero.Equal(reply.Budgets[0].Carried))
+		assert.True(decimal.NewFromInt(100).Equal(reply.Budgets[0].Available))
+		assert.True(decimal.NewFromInt(25).Equal(reply.Budgets[0].Spent))
+		assert.True(decimal.NewFromInt(75).Equal(reply.Budgets[0].Remaining))
+		assert.True(decimal.NewFromInt(25).Equal(reply.Budgets[0].PercentUsed))
+		assert.Equal(parseBudget(transport), reply.Budgets[1].Budget)
+		assert.True(decimal.Zero.Equal(reply.Budgets[1].Carried))
+		assert.True(decimal.Zero.Equal(reply.Budgets[1].Spent))
+		assert.True(decimal.NewFromInt(50).Equal(reply.Budgets[1].Remaining))
+		assert.True(decimal.Zero.Equal(reply.Budgets[1].PercentUsed))
+	})
+	t.Run("unused amount is carried over", func(t *testing.T) {
+		const userID = "user-id"
+		var (
+			jan = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			feb = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockCategoryRepo := repository.NewMockCategoryRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListBudgetsReply{
+				Budgets: []*repository.Budget{
+					{
+						ID:       1,
+						PublicID: "publicID",
+						BaseBudget: &repository.BaseBudget{
+							CategoryPublicID: "categoryID",
+							Limit:            decimal.NewFromInt(100),
+							Rollover:         true,
+							StartMonth:       jan,
+						},
+					},
+				},
+			}, nil),
+			mockDailyItemRepo.EXPECT().SumByCategory(gomock.Any(), gomock.Any()).Return(&repository.SumDailyItemsByCategoryReply{
+				Categories: []*repository.CategorySum{
+					{Month: jan, CategoryPublicID: "categoryID", Amount: decimal.NewFromInt(60), Count: 1},
+					{Month: feb, CategoryPublicID: "categoryID", Amount: decimal.NewFromInt(210), Count: 4},
+				},
+			}, nil),
+			mockCategoryRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, mockCategoryRepo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Progress(context.Background(), &ProgressRequest{
+			UserID: userID,
+			Month:  feb,
+		})
+		assert.NoError(err)
+		assert.Len(reply.Budgets, 1)
+		assert.True(decimal.NewFromInt(40).Equal(reply.Budgets[0].Carried))
+		assert.True(decimal.NewFromInt(140).Equal(reply.Budgets[0].Available))
+		assert.True(decimal.NewFromInt(-70).Equal(reply.Budgets[0].Remaining))
+		assert.True(decimal.NewFromInt(150).Equal(reply.Budgets[0].PercentUsed))
+	})
+	t.Run("expense in foreign currency is converted", func(t *testing.T) {
+		const userID = "user-id"
+		var jan = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockCategoryRepo := repository.NewMockCategoryRepository(controller)
+		mockConverter := exchangerates.NewMockConverter(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListBudgetsReply{
+				Budgets: []*repository.Budget{
+					{
+						ID:       1,
+						PublicID: "publicID",
+						BaseBudget: &repository.BaseBudget{
+							CategoryPublicID: "categoryID",
+							Limit:            decimal.NewFromInt(1000),
+							StartMonth:       jan,
+						},
+					},
+				},
+			}, nil),
+			mockDailyItemRepo.EXPECT().SumByCategory(gomock.Any(), gomock.Any()).Return(&repository.SumDailyItemsByCategoryReply{
+				Categories: []*repository.CategorySum{
+					{Month: jan, CategoryPublicID: "categoryID", Amount: decimal.NewFromInt(100), Count: 1},
+					{Month: jan, CategoryPublicID: "categoryID", Currency: "USD", Amount: decimal.NewFromInt(10), Count: 1},
+				},
+			}, nil),
+			mockConverter.EXPECT().
+				Convert(gomock.Any(), &exchangerates.ConvertRequest{
+					UserID:       userID,
+					Amount:       decimal.NewFromInt(10),
+					FromCurrency: "USD",
+					Date:         time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+				}).
+				Return(&exchangerates.ConvertReply{
+					Amount: decimal.NewFromInt(320),
+					Rate:   decimal.NewFromInt(32),
+				}, nil),
+			mockCategoryRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, mockCategoryRepo, mockConverter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Progress(context.Background(), &ProgressRequest{
+			UserID: userID,
+			Month:  jan,
+		})
+		assert.NoError(err)
+		assert.Len(reply.Budgets, 1)
+		assert.True(decimal.NewFromInt(420).Equal(reply.Budgets[0].Spent))
+		assert.True(decimal.NewFromInt(580).Equal(reply.Budgets[0].Remaining))
+	})
+	t.Run("expense of sub-categories is rolled up", func(t *testing.T) {
+		const userID = "user-id"
+		var mar = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockCategoryRepo := repository.NewMockCategoryRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListBudgetsReply{
+				Budgets: []*repository.Budget{
+					{
+						ID:       1,
+						PublicID: "food",
+						BaseBudget: &repository.BaseBudget{
+							CategoryPublicID: "foodID",
+							Limit:            decimal.NewFromInt(100),
+							StartMonth:       mar,
+						},
+					},
+					{
+						ID:       2,
+						PublicID: "lunch",
+						BaseBudget: &repository.BaseBudget{
+							CategoryPublicID: "lunchID",
+							Limit:            decimal.NewFromInt(50),
+							StartMonth:       mar,
+						},
+					},
+				},
+			}, nil),
+			mockDailyItemRepo.EXPECT().SumByCategory(gomock.Any(), gomock.Any()).Return(&repository.SumDailyItemsByCategoryReply{
+				Categories: []*repository.CategorySum{
+					{Month: mar, CategoryPublicID: "foodID", Amount: decimal.NewFromInt(10), Count: 1},
+					{Month: mar, CategoryPublicID: "lunchID", Amount: decimal.NewFromInt(20), Count: 1},
+					{Month: mar, CategoryPublicID: "bentoID", Amount: decimal.NewFromInt(30), Count: 1},
+				},
+			}, nil),
+			mockCategoryRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListCategoriesReply{
+				Categories: []*repository.Category{
+					{PublicID: "foodID", BaseCategory: &repository.BaseCategory{Name: "food"}},
+					{PublicID: "lunchID", BaseCategory: &repository.BaseCategory{Name: "lunch", ParentPublicID: lo.ToPtr("foodID")}},
+					{PublicID: "bentoID", BaseCategory: &repository.BaseCategory{Name: "bento", ParentPublicID: lo.ToPtr("lunchID")}},
+				},
+			}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, mockCategoryRepo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Progress(context.Background(), &ProgressRequest{
+			UserID: userID,
+			Month:  mar,
+		})
+		assert.NoError(err)
+		assert.Len(reply.Budgets, 2)
+		assert.True(decimal.NewFromInt(60).Equal(reply.Budgets[0].Spent))
+		assert.True(decimal.NewFromInt(40).Equal(reply.Budgets[0].Remaining))
+		assert.True(decimal.NewFromInt(50).Equal(reply.Budgets[1].Spent))
+		assert.True(decimal.Zero.Equal(reply.Budgets[1].Remaining))
+	})
+	t.Run("no budget", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockBudgetRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Progress(context.Background(), &ProgressRequest{
+			UserID: "user-id",
+			Month:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.Equal(&ProgressReply{
+			Budgets: []*BudgetProgress{},
+		}, reply)
+	})
+}
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	Month *irisController.SimpleGetTemplate[MonthRequest, MonthReply, models.CalendarMonth]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		Month: &irisController.SimpleGetTemplate[MonthRequest, MonthReply, models.CalendarMonth]{
+			Handle: s.Month,
+			ParseServiceRequest: func(c iris.Context, userID string) (*MonthRequest, error) {
+				year, err := c.Params().GetInt("year")
+				if err != nil {
+					return nil, fmt.Errorf("invalid year[%s]", c.Params().Get("year"))
+				}
+				month, err := c.Params().GetInt("month")
+				if err != nil {
+					return nil, fmt.Errorf("invalid month[%s]", c.Params().Get("month"))
+				}
+				return &MonthRequest{
+					UserID:    userID,
+					Year:      year,
+					Month:     time.Month(month),
+					WithItems: c.URLParamBoolDefault("withItems", false),
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrInvalidMonth):
+					return iris.StatusBadRequest, true
+				}
+				return exchangerates.BadConversion(err)
+			},
+			ParseAPIResponse: func(reply *MonthReply) (*models.CalendarMonth, error) {
+				return &models.CalendarMonth{
+					Days: lo.Map(reply.Days, func(item *Day, _ int) models.CalendarDay {
+						return models.CalendarDay{
+							Date:    openapi_types.Date{Time: item.Date},
+							Income:  item.Income.String(),
+							Expense: item.Expense.String(),
+							Net:     item.Net.String(),
+							Count:   item.Count,
+							Items: lo.IfF(item.Items != nil, func() *[]models.DailyItem {
+								return lo.ToPtr(lo.Map(item.Items, func(item *DailyItem, _ int) models.DailyItem {
+									return *dailyitems.ToDailyItem(item)
+								}))
+							}).Else(nil),
+						}
+					}),
+				}, nil
+			},
+		},
+	}
+}
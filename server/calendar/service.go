@@ -0,0 +1,53 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/dailyitems"
+)
+
+var (
+	ErrDataInsufficient = fmt.Errorf("data insufficient")
+	ErrInvalidMonth     = fmt.Errorf("invalid month")
+)
+
+type Service interface {
+	// Month returns the summaries of all days of the month. The amounts in foreign
+	// currencies are converted into the home currency with the exchange rates on their
+	// dates. It returns error:
+	//  - ErrDataInsufficient if any of required fields of MonthRequest is zero-value,
+	//  - ErrInvalidMonth if the month is not between 1 and 12,
+	//  - any error of exchangerates.Converter if the amounts fail to be converted.
+	Month(context.Context, *MonthRequest) (*MonthReply, error)
+}
+
+type MonthRequest struct {
+	UserID string
+	Year   int
+	Month  time.Month
+	// WithItems includes the daily items of each day in the reply.
+	WithItems bool
+}
+
+type MonthReply struct {
+	// Days are from the first to the last day of the month.
+	Days []*Day
+}
+
+type Day struct {
+	Date    time.Time
+	Income  decimal.Decimal
+	Expense decimal.Decimal
+	// Net is the income minus the expense.
+	Net decimal.Decimal
+	// Count is the number of the daily items of the day.
+	Count int64
+	// Items are sorted by id, they are nil unless MonthRequest.WithItems is true.
+	Items []*DailyItem
+}
+
+type DailyItem = dailyitems.DailyItem
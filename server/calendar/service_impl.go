@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	dailyItemRepository repository.DailyItemRepository
+	converter           exchangerates.Converter
+}
+
+func NewService(
+	dailyItemRepository repository.DailyItemRepository,
+	converter exchangerates.Converter,
+) (Service, error) {
+	return &service{
+		dailyItemRepository: dailyItemRepository,
+		converter:           converter,
+	}, nil
+}
+
+func (s *service) Month(ctx context.Context, r *MonthRequest) (*MonthReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Year == 0 {
+		return nil, fmt.Errorf("%w: missing year", ErrDataInsufficient)
+	}
+	if r.Month < time.January || r.Month > time.December {
+		return nil, ErrInvalidMonth
+	}
+	from := time.Date(r.Year, r.Month, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	days := []*Day{}
+	for date := from; date.Before(to); date = date.AddDate(0, 0, 1) {
+		days = append(days, &Day{
+			Date: date,
+		})
+	}
+
+	reply, err := s.dailyItemRepository.SumByDate(ctx, &repository.SumDailyItemsByDateRequest{
+		UserID: r.UserID,
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, sum := range reply.Days {
+		amount := sum.Amount
+		if sum.Currency != "" {
+			converted, err := s.converter.Convert(ctx, &exchangerates.ConvertRequest{
+				UserID:       r.UserID,
+				Amount:       amount,
+				FromCurrency: sum.Currency,
+				Date:         sum.Date,
+			})
+			if err != nil {
+				return nil, err
+			}
+			amount = converted.Amount
+		}
+
+		day := days[sum.Date.Day()-1]
+		if sum.Type == repository.CategoryTypeIncome {
+			day.Income = day.Income.Add(amount)
+		} else {
+			day.Expense = day.Expense.Add(amount)
+		}
+		day.Count += sum.Count
+	}
+	for _, day := range days {
+		day.Net = day.Income.Sub(day.Expense)
+	}
+
+	if r.WithItems {
+		if err := s.fillItems(ctx, r.UserID, days, from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	return &MonthReply{
+		Days: days,
+	}, nil
+}
+
+func (s *service) fillItems(ctx context.Context, userID string, days []*Day, from, to time.Time) error {
+	for _, day := range days {
+		day.Items = []*DailyItem{}
+	}
+
+	reply, err := s.dailyItemRepository.List(ctx, &repository.ListDailyItemsRequest{
+		UserID: userID,
+		From:   &from,
+		To:     &to,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+	for _, item := range reply.Items {
+		day := days[item.Date.Day()-1]
+		day.Items = append(day.Items, &DailyItem{
+			ID:              item.ID,
+			PublicID:        item.PublicID,
+			NormalizedPrice: item.NormalizedPrice,
+			BaseDailyItem:   lo.ToPtr(dailyitems.BaseDailyItem(*item.BaseDailyItem)),
+		})
+	}
+	return nil
+}
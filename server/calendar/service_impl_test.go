@@ -0,0 +1,154 @@
+package calendar
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Month(t *testing.T) {
+	const userID = "user-id"
+	var (
+		feb1 = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		feb3 = time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+		mar1 = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("summarize days", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockConverter := exchangerates.NewMockConverter(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().
+				SumByDate(gomock.Any(), &repository.SumDailyItemsByDateRequest{
+					UserID: userID,
+					From:   feb1,
+					To:     mar1,
+				}).
+				Return(&repository.SumDailyItemsByDateReply{
+					Days: []*repository.DateSum{
+						{Date: feb3, Type: repository.CategoryTypeExpense, Amount: decimal.NewFromInt(100), Count: 2},
+						{Date: feb3, Type: repository.CategoryTypeExpense, Currency: "USD", Amount: decimal.NewFromInt(1), Count: 1},
+						{Date: feb3, Type: repository.CategoryTypeIncome, Amount: decimal.NewFromInt(500), Count: 1},
+					},
+				}, nil),
+			mockConverter.EXPECT().
+				Convert(gomock.Any(), &exchangerates.ConvertRequest{
+					UserID:       userID,
+					Amount:       decimal.NewFromInt(1),
+					FromCurrency: "USD",
+					Date:         feb3,
+				}).
+				Return(&exchangerates.ConvertReply{
+					Amount: decimal.NewFromInt(30),
+					Rate:   decimal.NewFromInt(30),
+				}, nil),
+		)
+
+		s, err := NewService(mockDailyItemRepo, mockConverter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Month(context.Background(), &MonthRequest{
+			UserID: userID,
+			Year:   2025,
+			Month:  time.February,
+		})
+		assert.NoError(err)
+		assert.Len(reply.Days, 28)
+		assert.Equal(feb1, reply.Days[0].Date)
+		assert.True(reply.Days[0].Net.IsZero())
+		assert.Nil(reply.Days[0].Items)
+
+		day := reply.Days[2]
+		assert.Equal(feb3, day.Date)
+		assert.True(decimal.NewFromInt(500).Equal(day.Income))
+		assert.True(decimal.NewFromInt(130).Equal(day.Expense))
+		assert.True(decimal.NewFromInt(370).Equal(day.Net))
+		assert.EqualValues(4, day.Count)
+	})
+	t.Run("with items", func(t *testing.T) {
+		assert := assert.New(t)
+
+		item := &repository.DailyItem{
+			ID:       1,
+			PublicID: "publicID",
+			BaseDailyItem: &repository.BaseDailyItem{
+				Date: feb3,
+				BaseItem: &repository.BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					Price:             decimal.NewFromInt(100),
+				},
+			},
+		}
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().SumByDate(gomock.Any(), gomock.Any()).Return(&repository.SumDailyItemsByDateReply{
+				Days: []*repository.DateSum{
+					{Date: feb3, Type: repository.CategoryTypeExpense, Amount: decimal.NewFromInt(100), Count: 1},
+				},
+			}, nil),
+			mockDailyItemRepo.EXPECT().
+				List(gomock.Any(), &repository.ListDailyItemsRequest{
+					UserID: userID,
+					From:   &feb1,
+					To:     &mar1,
+				}).
+				Return(&repository.ListDailyItemsReply{
+					Items: []*repository.DailyItem{item},
+				}, nil),
+		)
+
+		s, err := NewService(mockDailyItemRepo, exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Month(context.Background(), &MonthRequest{
+			UserID:    userID,
+			Year:      2025,
+			Month:     time.February,
+			WithItems: true,
+		})
+		assert.NoError(err)
+		assert.Empty(reply.Days[0].Items)
+		assert.NotNil(reply.Days[0].Items)
+		assert.Len(reply.Days[2].Items, 1)
+		assert.Equal(item.PublicID, reply.Days[2].Items[0].PublicID)
+		assert.Equal(item.BaseItem, reply.Days[2].Items[0].BaseItem)
+	})
+	t.Run("invalid month", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(
+			repository.NewMockDailyItemRepository(controller),
+			exchangerates.NewMockConverter(controller),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Month(context.Background(), &MonthRequest{
+			UserID: userID,
+			Year:   2025,
+			Month:  13,
+		})
+		assert.ErrorIs(err, ErrInvalidMonth)
+		assert.Nil(reply)
+	})
+}
@@ -16,7 +16,7 @@ type IrisController struct {
 	*irisController.SimpleCreateTemplate[models.CreatingCategory, CreateRequest, CreateReply, models.ObjectId]
 	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Category]
 	*irisController.SimpleUpdateTemplate[models.BasicCategory, UpdateRequest, UpdateReply]
-	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+	*irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]
 }
 
 func NewIrisController(s Service) *IrisController {
@@ -33,14 +33,19 @@ func NewIrisController(s Service) *IrisController {
 					UserID: userID,
 					Type:   type_,
 					Category: &BaseCategory{
-						Name:   r.Name,
-						IconID: int32(lo.FromPtrOr(r.IconId, 0)),
+						Name:           r.Name,
+						IconID:         int32(lo.FromPtrOr(r.IconId, 0)),
+						ParentPublicID: (*string)(r.ParentId),
 					},
 				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrParentNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidParent),
+					errors.Is(err, ErrInvalidIcon):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
@@ -71,12 +76,8 @@ func NewIrisController(s Service) *IrisController {
 				return 0, false
 			},
 			ParseAPIResponse: func(reply *ListReply) (*[]*models.Category, error) {
-				return lo.ToPtr(lo.Map(reply.Categories, func(item *Category, _ int) *models.Category {
-					return &models.Category{
-						Id:     lo.ToPtr(models.Id(item.PublicID)),
-						Name:   item.Name,
-						IconId: lo.ToPtr(models.IconId(item.IconID)),
-					}
+				return lo.ToPtr(lo.Map(reply.Categories, func(item *CategoryNode, _ int) *models.Category {
+					return lo.ToPtr(toAPICategory(item))
 				})), nil
 			},
 		},
@@ -88,35 +89,40 @@ func NewIrisController(s Service) *IrisController {
 					UserID:           userID,
 					CategoryPublicID: publicID,
 					Category: &BaseCategory{
-						Name:   r.Name,
-						IconID: int32(lo.FromPtrOr(r.IconId, 0)),
+						Name:           r.Name,
+						IconID:         int32(lo.FromPtrOr(r.IconId, 0)),
+						ParentPublicID: (*string)(r.ParentId),
 					},
 				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
-				case errors.Is(err, ErrCategoryNotFound):
+				case errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrParentNotFound):
 					return iris.StatusNotFound, true
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidParent),
+					errors.Is(err, ErrInvalidIcon):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
 			},
 		},
-		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+		PolicyDeleteTemplate: &irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]{
 			Placeholder: "categoryId",
 			Service:     s,
-			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+			ParseServiceRequest: func(userID string, publicID string, policy DeletePolicy, replacementID string) *DeleteRequest {
 				return &DeleteRequest{
-					UserID:           userID,
-					CategoryPublicID: publicID,
+					UserID:              userID,
+					CategoryPublicID:    publicID,
+					Policy:              policy,
+					ReplacementPublicID: replacementID,
 				}
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
 				case errors.Is(err, ErrCategoryNotFound):
 					return iris.StatusNotFound, true
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrInvalidReplacement):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
@@ -125,6 +131,18 @@ func NewIrisController(s Service) *IrisController {
 	}
 }
 
+func toAPICategory(node *CategoryNode) models.Category {
+	return models.Category{
+		Id:       lo.ToPtr(models.Id(node.PublicID)),
+		Name:     node.Name,
+		IconId:   lo.ToPtr(models.IconId(node.IconID)),
+		ParentId: (*models.Id)(node.ParentPublicID),
+		Children: lo.Map(node.Children, func(item *CategoryNode, _ int) models.Category {
+			return toAPICategory(item)
+		}),
+	}
+}
+
 func parseType(s string) (Type, error) {
 	return repository.ToCategoryType(s)
 }
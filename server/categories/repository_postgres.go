@@ -2,10 +2,15 @@ package categories
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/repeatingitems"
 	"github.com/n101661/maney/server/repository"
 	"github.com/n101661/maney/server/repository/postgres"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 	"xorm.io/xorm"
 )
 
@@ -23,8 +28,17 @@ func (repo *postgresRepository) Create(ctx context.Context, r *repository.Create
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
-	rows := lo.Map(r.Categories, func(item *repository.BaseCreateCategory, _ int) *postgres.CategoriesModel {
-		return &postgres.CategoriesModel{
+	return CreateWithSession(session, r)
+}
+
+// CreateWithSession is the same as Create, but it creates the categories with the session
+// so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateCategoriesRequest) ([]*repository.Category, error) {
+	// created maps the public ids of the created categories to their ids.
+	created := map[string]int32{}
+	result := make([]*repository.Category, len(r.Categories))
+	for i, item := range r.Categories {
+		row := &postgres.CategoriesModel{
 			PublicID: item.PublicID,
 			UserID:   r.UserID,
 			Type:     r.Type,
@@ -32,17 +46,33 @@ func (repo *postgresRepository) Create(ctx context.Context, r *repository.Create
 				BaseCategory: item.BaseCategory,
 			},
 		}
-	})
-	_, err := session.Insert(rows)
-	if err != nil {
-		if postgres.UniqueViolationError(err) {
-			return nil, repository.ErrDataExists
+		if item.ParentPublicID != nil {
+			parentID, ok := created[*item.ParentPublicID]
+			if !ok {
+				parent, err := getParent(session, r.UserID, r.Type, *item.ParentPublicID)
+				if err != nil {
+					return nil, err
+				}
+				parentID = parent.ID
+			}
+			row.ParentID = postgres.ToNullInt32(&parentID)
+		}
+
+		if _, err := session.Insert(row); err != nil {
+			if postgres.UniqueViolationError(err) {
+				return nil, repository.ErrDataExists
+			}
+			return nil, err
+		}
+		created[row.PublicID] = row.ID
+
+		result[i] = &repository.Category{
+			ID:           row.ID,
+			PublicID:     row.PublicID,
+			BaseCategory: item.BaseCategory,
 		}
-		return nil, err
 	}
-	return lo.Map(rows, func(item *postgres.CategoriesModel, _ int) *repository.Category {
-		return toCategory(item)
-	}), nil
+	return result, nil
 }
 
 func (repo *postgresRepository) List(ctx context.Context, r *repository.ListCategoriesRequest) (*repository.ListCategoriesReply, error) {
@@ -60,9 +90,14 @@ func (repo *postgresRepository) List(ctx context.Context, r *repository.ListCate
 	if len(rows) == 0 {
 		return nil, repository.ErrDataNotFound
 	}
+
+	// the parent is always the same type.
+	publicIDs := lo.SliceToMap(rows, func(item *postgres.CategoriesModel) (int32, string) {
+		return item.ID, item.PublicID
+	})
 	return &repository.ListCategoriesReply{
 		Categories: lo.Map(rows, func(item *postgres.CategoriesModel, _ int) *repository.Category {
-			return toCategory(item)
+			return toCategory(item, publicIDs)
 		}),
 	}, nil
 }
@@ -84,8 +119,21 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 	}
 	row.Data.BaseCategory = r.Category
 
-	affected, err := session.Update(&postgres.CategoriesModel{
-		Data: row.Data,
+	row.ParentID = sql.NullInt32{}
+	if r.Category.ParentPublicID != nil {
+		parent, err := getParent(session, r.UserID, row.Type, *r.Category.ParentPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCycle(session, &row, parent); err != nil {
+			return nil, err
+		}
+		row.ParentID = postgres.ToNullInt32(&parent.ID)
+	}
+
+	affected, err := session.Cols("data", "parent_id").Update(&postgres.CategoriesModel{
+		Data:     row.Data,
+		ParentID: row.ParentID,
 	}, &postgres.CategoriesModel{
 		ID: row.ID,
 	})
@@ -96,13 +144,21 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 		return nil, repository.ErrDataNotFound
 	}
 
-	return toCategory(&row), nil
+	return &repository.Category{
+		ID:           row.ID,
+		PublicID:     row.PublicID,
+		BaseCategory: r.Category,
+	}, nil
 }
 
 func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteCategoriesRequest) ([]*repository.Category, error) {
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
 	session.Where("user_id = ?", r.UserID)
 	if len(r.CategoryPublicIDs) > 0 {
 		session.In("public_id", r.CategoryPublicIDs)
@@ -121,22 +177,316 @@ func (repo *postgresRepository) Delete(ctx context.Context, r *repository.Delete
 		return nil, repository.ErrDataNotFound
 	}
 
-	_, err = session.In("id", lo.Map(rows, func(item *postgres.CategoriesModel, _ int) any {
+	if err := handleReferences(session, r, rows); err != nil {
+		return nil, err
+	}
+
+	ids := lo.Map(rows, func(item *postgres.CategoriesModel, _ int) any {
 		return item.ID
-	})).Table(&postgres.CategoriesModel{}).Delete()
+	})
+	// the children become the top-level categories.
+	_, err = session.
+		In("parent_id", ids).
+		Cols("parent_id").
+		Update(&postgres.CategoriesModel{})
 	if err != nil {
 		return nil, err
 	}
+	_, err = session.In("id", ids).Table(&postgres.CategoriesModel{}).Delete()
+	if err != nil {
+		if postgres.ForeignKeyViolationError(err) {
+			return nil, repository.ErrDataReferenced
+		}
+		return nil, err
+	}
 
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
 	return lo.Map(rows, func(item *postgres.CategoriesModel, _ int) *repository.Category {
-		return toCategory(item)
+		return toCategory(item, nil)
 	}), nil
 }
 
-func toCategory(item *postgres.CategoriesModel) *repository.Category {
-	return &repository.Category{
-		ID:           item.ID,
-		PublicID:     item.PublicID,
-		BaseCategory: item.Data.BaseCategory,
+// handleReferences handles the daily items, the repeating items and the budgets of the
+// deleted categories by the policy.
+func handleReferences(session *xorm.Session, r *repository.DeleteCategoriesRequest, rows []*postgres.CategoriesModel) error {
+	ids := lo.Map(rows, func(item *postgres.CategoriesModel, _ int) any {
+		return item.ID
+	})
+	publicIDs := lo.Map(rows, func(item *postgres.CategoriesModel, _ int) string {
+		return item.PublicID
+	})
+
+	var links []*postgres.DailyItemCategoriesModel
+	if err := session.In("category_id", ids).Find(&links); err != nil {
+		return err
+	}
+	var dailyItems []*postgres.DailyItemsModel
+	if len(links) > 0 {
+		err := session.In("id", lo.Uniq(lo.Map(links, func(item *postgres.DailyItemCategoriesModel, _ int) any {
+			return item.DailyItemID
+		}))).Asc("id").Find(&dailyItems)
+		if err != nil {
+			return err
+		}
+	}
+	repeatingItems, err := postgres.ListReferringRepeatingItems(session, r.UserID, func(item *repository.BaseItem) bool {
+		return lo.Some(item.CategoryPublicIDs, publicIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	var budgets []*postgres.BudgetsModel
+	if err := session.In("category_id", ids).Asc("id").Find(&budgets); err != nil {
+		return err
+	}
+
+	switch r.Policy {
+	case repository.DeletePolicyRestrict:
+		return postgres.NewReferencedError(&postgres.References{
+			DailyItems:     dailyItems,
+			RepeatingItems: repeatingItems,
+			Budgets:        budgets,
+		})
+	case repository.DeletePolicyReassign:
+		replacement, err := getReplacement(session, r, rows)
+		if err != nil {
+			return err
+		}
+		if err := reassignLinks(session, links, ids, replacement.ID); err != nil {
+			return err
+		}
+		if err := reassignBudgets(session, budgets, replacement.ID); err != nil {
+			return err
+		}
+		for _, row := range repeatingItems {
+			row.Data.Item.ReassignCategories(publicIDs, replacement.PublicID)
+		}
+		return postgres.UpdateRepeatingItemsData(session, repeatingItems)
+	case repository.DeletePolicyCascade:
+		if len(dailyItems) > 0 {
+			_, err := dailyitems.DeleteWithSession(session, &repository.DeleteDailyItemsRequest{
+				UserID: r.UserID,
+				DailyItemPublicIDs: lo.Map(dailyItems, func(item *postgres.DailyItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(repeatingItems) > 0 {
+			_, err := repeatingitems.DeleteWithSession(session, &repository.DeleteRepeatingItemsRequest{
+				UserID: r.UserID,
+				RepeatingItemPublicIDs: lo.Map(repeatingItems, func(item *postgres.RepeatingItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(budgets) > 0 {
+			_, err := session.In("id", lo.Map(budgets, func(item *postgres.BudgetsModel, _ int) any {
+				return item.ID
+			})).Delete(&postgres.BudgetsModel{})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown delete policy[%v]", r.Policy)
+}
+
+// reassignBudgets moves the budgets to the replacement category. The category has one
+// budget at most, so the limits are added to the budget of the replacement if it has one,
+// or to the first of the moved budgets otherwise, and the other budgets are deleted.
+func reassignBudgets(session *xorm.Session, budgets []*postgres.BudgetsModel, replacementID int32) error {
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	target := postgres.BudgetsModel{
+		UserID:     budgets[0].UserID,
+		CategoryID: replacementID,
+	}
+	has, err := session.Get(&target)
+	if err != nil {
+		return err
+	}
+	if !has {
+		target, budgets = *budgets[0], budgets[1:]
+		target.CategoryID = replacementID
+		_, err := session.Cols("category_id").Update(&target, &postgres.BudgetsModel{
+			ID: target.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	_, err = session.In("id", lo.Map(budgets, func(item *postgres.BudgetsModel, _ int) any {
+		return item.ID
+	})).Delete(&postgres.BudgetsModel{})
+	if err != nil {
+		return err
+	}
+	for _, budget := range budgets {
+		target.Amount.Decimal = target.Amount.Decimal.Add(budget.Amount.Decimal)
+	}
+	_, err = session.Cols("amount").Update(&target, &postgres.BudgetsModel{
+		ID: target.ID,
+	})
+	return err
+}
+
+// getReplacement returns ErrReferenceNotFound if the replacement does not exist, or
+// ErrInvalidReference if it is any of the deleted categories or it is not the same type.
+func getReplacement(session *xorm.Session, r *repository.DeleteCategoriesRequest, rows []*postgres.CategoriesModel) (*postgres.CategoriesModel, error) {
+	replacement := postgres.CategoriesModel{
+		PublicID: r.ReplacementPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&replacement)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrReferenceNotFound
+	}
+	for _, row := range rows {
+		if row.ID == replacement.ID || row.Type != replacement.Type {
+			return nil, repository.ErrInvalidReference
+		}
+	}
+	return &replacement, nil
+}
+
+// reassignLinks moves the links of the daily items to the replacement category, the split
+// amounts are summed if the item is in more than one of the deleted and the replacement
+// categories, and so are the percentages if all of them are by percentage.
+func reassignLinks(session *xorm.Session, links []*postgres.DailyItemCategoriesModel, categoryIDs []any, replacementID int32) error {
+	if len(links) == 0 {
+		return nil
+	}
+	dailyItemIDs := lo.Uniq(lo.Map(links, func(item *postgres.DailyItemCategoriesModel, _ int) any {
+		return item.DailyItemID
+	}))
+
+	var existing []*postgres.DailyItemCategoriesModel
+	err := session.
+		Where("category_id = ?", replacementID).
+		In("daily_item_id", dailyItemIDs).
+		Find(&existing)
+	if err != nil {
+		return err
+	}
+
+	merged := lo.SliceToMap(existing, func(item *postgres.DailyItemCategoriesModel) (int32, *postgres.DailyItemCategoriesModel) {
+		return item.DailyItemID, item
+	})
+	for _, link := range links {
+		m, ok := merged[link.DailyItemID]
+		if !ok {
+			merged[link.DailyItemID] = &postgres.DailyItemCategoriesModel{
+				DailyItemID: link.DailyItemID,
+				CategoryID:  replacementID,
+				Amount:      link.Amount,
+				Percentage:  link.Percentage,
+			}
+			continue
+		}
+		m.Amount = sumNullDecimals(m.Amount, link.Amount)
+		m.Percentage = sumNullDecimals(m.Percentage, link.Percentage)
+	}
+
+	_, err = session.
+		In("category_id", append([]any{replacementID}, categoryIDs...)).
+		In("daily_item_id", dailyItemIDs).
+		Delete(&postgres.DailyItemCategoriesModel{})
+	if err != nil {
+		return err
+	}
+	for _, id := range dailyItemIDs {
+		if _, err := session.Insert(merged[id.(int32)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sumNullDecimals returns null if either of them is null.
+func sumNullDecimals(a, b decimal.NullDecimal) decimal.NullDecimal {
+	if !a.Valid || !b.Valid {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NewNullDecimal(a.Decimal.Add(b.Decimal))
+}
+
+// getParent returns ErrReferenceNotFound if the parent does not exist, or ErrInvalidReference
+// if the parent is not the same type.
+func getParent(session *xorm.Session, userID string, type_ repository.CategoryType, publicID string) (*postgres.CategoriesModel, error) {
+	parent := postgres.CategoriesModel{
+		PublicID: publicID,
+		UserID:   userID,
+	}
+	has, err := session.Get(&parent)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrReferenceNotFound
+	}
+	if parent.Type != type_ {
+		return nil, repository.ErrInvalidReference
+	}
+	return &parent, nil
+}
+
+// checkCycle returns ErrCircularReference if the parent is the category or any of its
+// descendants.
+func checkCycle(session *xorm.Session, category, parent *postgres.CategoriesModel) error {
+	var rows []*postgres.CategoriesModel
+	err := session.Cols("id", "parent_id").Find(&rows, &postgres.CategoriesModel{
+		UserID: category.UserID,
+		Type:   category.Type,
+	})
+	if err != nil {
+		return err
+	}
+	parentIDs := lo.SliceToMap(rows, func(item *postgres.CategoriesModel) (int32, sql.NullInt32) {
+		return item.ID, item.ParentID
+	})
+
+	for id, ok := parent.ID, true; ok; {
+		if id == category.ID {
+			return repository.ErrCircularReference
+		}
+		next := parentIDs[id]
+		id, ok = next.Int32, next.Valid
+	}
+	return nil
+}
+
+// toCategory fills the parent public id by publicIDs which maps ids of the categories to
+// their public ids, the parent is absent if publicIDs is nil.
+func toCategory(item *postgres.CategoriesModel, publicIDs map[int32]string) *repository.Category {
+	category := &repository.Category{
+		ID:       item.ID,
+		PublicID: item.PublicID,
+		BaseCategory: &repository.BaseCategory{
+			Name:   item.Data.Name,
+			IconID: item.Data.IconID,
+		},
+	}
+	if item.ParentID.Valid && publicIDs != nil {
+		category.ParentPublicID = lo.ToPtr(publicIDs[item.ParentID.Int32])
 	}
+	return category
 }
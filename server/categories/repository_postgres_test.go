@@ -0,0 +1,185 @@
+package categories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/repository/postgres/postgrestest"
+)
+
+func Test_postgresRepository_Delete(t *testing.T) {
+	const userID = "user-id"
+
+	newCategory := func(publicID string) *postgres.CategoriesModel {
+		return &postgres.CategoriesModel{
+			PublicID: publicID,
+			UserID:   userID,
+			Type:     repository.CategoryTypeExpense,
+			Data: &postgres.BaseCategory{BaseCategory: &repository.BaseCategory{
+				Name: publicID,
+			}},
+		}
+	}
+	newDailyItem := func(publicID string) *postgres.DailyItemsModel {
+		return &postgres.DailyItemsModel{
+			PublicID: publicID,
+			UserID:   userID,
+			Date:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Name:     publicID,
+			Type:     repository.CategoryTypeExpense,
+			Price:    decimal.NewNullDecimal(decimal.NewFromInt(10)),
+			Total:    decimal.NewNullDecimal(decimal.NewFromInt(10)),
+		}
+	}
+	// split is the amount and the percentage of the category of the item, the empty string
+	// means null.
+	type split struct {
+		amount, percentage string
+	}
+	toNullDecimal := func(v string) decimal.NullDecimal {
+		if v == "" {
+			return decimal.NullDecimal{}
+		}
+		return decimal.NewNullDecimal(decimal.RequireFromString(v))
+	}
+
+	t.Run("splits are merged into the replacement", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine := postgrestest.NewEngine(t)
+		food, lunch := newCategory("foodID"), newCategory("lunchID")
+		postgrestest.Insert(t, engine, food, lunch)
+
+		tests := []struct {
+			publicID string
+			// food is nil if the item is not in the replacement.
+			food, lunch, merged *split
+		}{
+			{publicID: "amountsID", food: &split{"3", ""}, lunch: &split{"7", ""}, merged: &split{"10", ""}},
+			{publicID: "percentagesID", food: &split{"4", "40"}, lunch: &split{"6", "60"}, merged: &split{"10", "100"}},
+			{publicID: "mixedID", food: &split{"3", ""}, lunch: &split{"7", "70"}, merged: &split{"10", ""}},
+			{publicID: "noSplitID", lunch: &split{}, merged: &split{}},
+		}
+		items := make([]*postgres.DailyItemsModel, len(tests))
+		for i, tt := range tests {
+			items[i] = newDailyItem(tt.publicID)
+			postgrestest.Insert(t, engine, items[i])
+			for category, v := range map[int32]*split{food.ID: tt.food, lunch.ID: tt.lunch} {
+				if v == nil {
+					continue
+				}
+				postgrestest.Insert(t, engine, &postgres.DailyItemCategoriesModel{
+					DailyItemID: items[i].ID,
+					CategoryID:  category,
+					Amount:      toNullDecimal(v.amount),
+					Percentage:  toNullDecimal(v.percentage),
+				})
+			}
+		}
+		repo := &postgresRepository{engine: engine}
+
+		_, err := repo.Delete(context.Background(), &repository.DeleteCategoriesRequest{
+			UserID:              userID,
+			CategoryPublicIDs:   []string{"lunchID"},
+			Policy:              repository.DeletePolicyReassign,
+			ReplacementPublicID: "foodID",
+		})
+		assert.NoError(err)
+
+		for i, tt := range tests {
+			var links []*postgres.DailyItemCategoriesModel
+			if err := engine.Where("daily_item_id = ?", items[i].ID).Find(&links); err != nil {
+				t.Fatal(err)
+			}
+			if !assert.Len(links, 1, tt.publicID) {
+				continue
+			}
+			amount, percentage := toNullDecimal(tt.merged.amount), toNullDecimal(tt.merged.percentage)
+			assert.Equal(food.ID, links[0].CategoryID, tt.publicID)
+			assert.Equal(amount.Valid, links[0].Amount.Valid, tt.publicID)
+			assert.True(amount.Decimal.Equal(links[0].Amount.Decimal), tt.publicID)
+			assert.Equal(percentage.Valid, links[0].Percentage.Valid, tt.publicID)
+			assert.True(percentage.Decimal.Equal(links[0].Percentage.Decimal), tt.publicID)
+		}
+	})
+	t.Run("budgets", func(t *testing.T) {
+		newBudget := func(publicID string, category *postgres.CategoriesModel, amount int64) *postgres.BudgetsModel {
+			return &postgres.BudgetsModel{
+				PublicID:   publicID,
+				UserID:     userID,
+				CategoryID: category.ID,
+				Amount:     decimal.NewNullDecimal(decimal.NewFromInt(amount)),
+				StartMonth: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			}
+		}
+		tests := []struct {
+			name   string
+			policy repository.DeletePolicy
+			// foodBudget is the limit of the budget of the replacement, it has no budget if it
+			// is zero.
+			foodBudget int64
+			// expected is the limit of the budget of the replacement, none of the budgets
+			// is left if it is zero.
+			expected int64
+			wantErr  error
+		}{
+			{name: "restricted", policy: repository.DeletePolicyRestrict, wantErr: repository.ErrDataReferenced},
+			{name: "moved to the replacement", policy: repository.DeletePolicyReassign, expected: 30},
+			{name: "merged into the budget of the replacement", policy: repository.DeletePolicyReassign, foodBudget: 100, expected: 130},
+			{name: "cascaded", policy: repository.DeletePolicyCascade},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				engine := postgrestest.NewEngine(t)
+				food, lunch, dinner := newCategory("foodID"), newCategory("lunchID"), newCategory("dinnerID")
+				postgrestest.Insert(t, engine, food, lunch, dinner)
+				postgrestest.Insert(t, engine, newBudget("lunchBudgetID", lunch, 10), newBudget("dinnerBudgetID", dinner, 20))
+				if tt.foodBudget != 0 {
+					postgrestest.Insert(t, engine, newBudget("foodBudgetID", food, tt.foodBudget))
+				}
+				repo := &postgresRepository{engine: engine}
+
+				_, err := repo.Delete(context.Background(), &repository.DeleteCategoriesRequest{
+					UserID:              userID,
+					CategoryPublicIDs:   []string{"lunchID", "dinnerID"},
+					Policy:              tt.policy,
+					ReplacementPublicID: lo.Ternary(tt.policy == repository.DeletePolicyReassign, "foodID", ""),
+				})
+				if tt.wantErr != nil {
+					assert.ErrorIs(err, tt.wantErr)
+					var referenced *repository.ReferencedError
+					if assert.ErrorAs(err, &referenced) {
+						assert.Equal([]*repository.Reference{
+							{Type: repository.ReferenceTypeBudget, PublicID: "lunchBudgetID"},
+							{Type: repository.ReferenceTypeBudget, PublicID: "dinnerBudgetID"},
+						}, referenced.References)
+					}
+					return
+				}
+				assert.NoError(err)
+
+				var budgets []*postgres.BudgetsModel
+				if err := engine.Where("user_id = ?", userID).Find(&budgets); err != nil {
+					t.Fatal(err)
+				}
+				if tt.expected == 0 {
+					assert.Empty(budgets)
+					return
+				}
+				if assert.Len(budgets, 1) {
+					assert.Equal(food.ID, budgets[0].CategoryID)
+					assert.True(decimal.NewFromInt(tt.expected).Equal(budgets[0].Amount.Decimal), budgets[0].Amount.Decimal.String())
+				}
+			})
+		}
+	})
+}
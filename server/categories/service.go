@@ -8,22 +8,43 @@ import (
 )
 
 var (
-	ErrDataInsufficient = fmt.Errorf("data insufficient")
-	ErrCategoryNotFound = fmt.Errorf("category not found")
+	ErrDataInsufficient   = fmt.Errorf("data insufficient")
+	ErrCategoryNotFound   = fmt.Errorf("category not found")
+	ErrParentNotFound     = fmt.Errorf("parent category not found")
+	ErrInvalidParent      = fmt.Errorf("invalid parent category")
+	ErrInvalidIcon        = fmt.Errorf("invalid icon")
+	ErrCategoryReferenced = fmt.Errorf("category is referenced")
+	ErrInvalidReplacement = fmt.Errorf("invalid replacement")
 )
 
 type Service interface {
-	// Create returns ErrDataInsufficient if any of fields of CreateRequest is zero-value.
+	// Create returns error:
+	//  - ErrDataInsufficient if any of fields of CreateRequest is zero-value,
+	//  - ErrParentNotFound if the parent category does not exist,
+	//  - ErrInvalidParent if the parent category is not the same type,
+	//  - ErrInvalidIcon if the icon is not an icon of the type in the catalog.
 	Create(context.Context, *CreateRequest) (*CreateReply, error)
-	// List returns ErrDataInsufficient if any of fields of ListRequest is zero-value,
+	// List returns the top-level categories with their children, and returns
+	// ErrDataInsufficient if any of fields of ListRequest is zero-value.
 	List(context.Context, *ListRequest) (*ListReply, error)
 	// Update returns error:
 	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
-	//  - ErrCategoryNotFound if the category does not exist.
+	//  - ErrCategoryNotFound if the category does not exist,
+	//  - ErrParentNotFound if the parent category does not exist,
+	//  - ErrInvalidParent if the parent category is not the same type, or it is the
+	//    category itself or any of its descendants,
+	//  - ErrInvalidIcon if the icon is not an icon of the type of the category in the catalog.
 	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
-	// Delete returns error:
-	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
-	//  - ErrCategoryNotFound if the category does not exist.
+	// Delete deletes the category and handles its references by the policy, it returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value, or the
+	//    replacement is missing while the policy is DeletePolicyReassign,
+	//  - ErrCategoryNotFound if the category does not exist,
+	//  - ErrCategoryReferenced wrapping *repository.ReferencedError if the category is referenced
+	//    while the policy is DeletePolicyRestrict,
+	//  - ErrInvalidReplacement if the replacement does not exist or is not another category
+	//    of the same type.
+	// The budgets of the category are deleted and its children become the top-level
+	// categories.
 	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
 }
 
@@ -46,7 +67,12 @@ type ListRequest struct {
 }
 
 type ListReply struct {
-	Categories []*Category
+	Categories []*CategoryNode
+}
+
+type CategoryNode struct {
+	*Category
+	Children []*CategoryNode
 }
 
 type UpdateRequest struct {
@@ -63,8 +89,15 @@ type UpdateReply struct {
 type DeleteRequest struct {
 	UserID           string
 	CategoryPublicID string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the category which the references are reassigned to, it is
+	// required while the policy is DeletePolicyReassign.
+	ReplacementPublicID string
 }
 
+type DeletePolicy = repository.DeletePolicy
+
 type DeleteReply struct{}
 
 type Category = repository.Category
@@ -5,24 +5,30 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/samber/lo"
+
 	"github.com/n101661/maney/pkg/utils"
 	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/icons"
 	"github.com/n101661/maney/server/repository"
 )
 
 type service struct {
-	repository repository.CategoryRepository
+	repository    repository.CategoryRepository
+	iconValidator icons.Validator
 
 	opts *categoryServiceOptions
 }
 
 func NewService(
 	repository repository.CategoryRepository,
+	iconValidator icons.Validator,
 	opts ...utils.Option[categoryServiceOptions],
 ) (Service, error) {
 	return &service{
-		repository: repository,
-		opts:       utils.ApplyOptions(defaultCategoryServiceOptions(), opts),
+		repository:    repository,
+		iconValidator: iconValidator,
+		opts:          utils.ApplyOptions(defaultCategoryServiceOptions(), opts),
 	}, nil
 }
 
@@ -33,6 +39,9 @@ func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, e
 	if r.Category == nil {
 		return nil, fmt.Errorf("%w: missing category", ErrDataInsufficient)
 	}
+	if err := s.validateIcon(ctx, r.Type, r.Category.IconID); err != nil {
+		return nil, err
+	}
 
 	rows, err := s.repository.Create(ctx, &repository.CreateCategoriesRequest{
 		UserID: r.UserID,
@@ -45,7 +54,7 @@ func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, e
 		},
 	})
 	if err != nil {
-		return nil, err
+		return nil, toParentError(err)
 	}
 
 	return &CreateReply{
@@ -66,17 +75,39 @@ func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error)
 	if err != nil {
 		if errors.Is(err, repository.ErrDataNotFound) {
 			return &ListReply{
-				Categories: []*Category{},
+				Categories: []*CategoryNode{},
 			}, nil
 		}
 		return nil, err
 	}
 
 	return &ListReply{
-		Categories: reply.Categories,
+		Categories: buildTree(reply.Categories),
 	}, nil
 }
 
+// buildTree keeps the order of the categories in each level.
+func buildTree(categories []*Category) []*CategoryNode {
+	nodes := lo.SliceToMap(categories, func(item *Category) (string, *CategoryNode) {
+		return item.PublicID, &CategoryNode{
+			Category: item,
+			Children: []*CategoryNode{},
+		}
+	})
+
+	roots := []*CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.PublicID]
+		parent, ok := nodes[lo.FromPtr(category.ParentPublicID)]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots
+}
+
 func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, error) {
 	if r.UserID == "" {
 		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
@@ -87,6 +118,16 @@ func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, e
 	if r.Category == nil {
 		return nil, fmt.Errorf("%w: missing category", ErrDataInsufficient)
 	}
+	if r.Category.IconID != 0 {
+		// the icons of the expense and the income categories are different.
+		t, err := s.typeOf(ctx, r.UserID, r.CategoryPublicID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.validateIcon(ctx, t, r.Category.IconID); err != nil {
+			return nil, err
+		}
+	}
 
 	row, err := s.repository.Update(ctx, &repository.UpdateCategoryRequest{
 		UserID:           r.UserID,
@@ -97,7 +138,7 @@ func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, e
 		if errors.Is(err, repository.ErrDataNotFound) {
 			return nil, ErrCategoryNotFound
 		}
-		return nil, err
+		return nil, toParentError(err)
 	}
 	return &UpdateReply{
 		Category: row,
@@ -111,20 +152,80 @@ func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, e
 	if r.CategoryPublicID == "" {
 		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
 	}
+	if r.Policy == repository.DeletePolicyReassign && r.ReplacementPublicID == "" {
+		return nil, fmt.Errorf("%w: missing replacement id", ErrDataInsufficient)
+	}
 
 	_, err := s.repository.Delete(ctx, &repository.DeleteCategoriesRequest{
-		UserID:            r.UserID,
-		CategoryPublicIDs: []string{r.CategoryPublicID},
+		UserID:              r.UserID,
+		Policy:              r.Policy,
+		ReplacementPublicID: r.ReplacementPublicID,
+		CategoryPublicIDs:   []string{r.CategoryPublicID},
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrDataNotFound) {
 			return nil, ErrCategoryNotFound
 		}
+		if errors.Is(err, repository.ErrDataReferenced) {
+			return nil, fmt.Errorf("%w: %w", ErrCategoryReferenced, err)
+		}
+		if errors.Is(err, repository.ErrReferenceNotFound) ||
+			errors.Is(err, repository.ErrInvalidReference) {
+			return nil, ErrInvalidReplacement
+		}
 		return nil, err
 	}
 	return &DeleteReply{}, nil
 }
 
+func toParentError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrReferenceNotFound):
+		return ErrParentNotFound
+	case errors.Is(err, repository.ErrInvalidReference), errors.Is(err, repository.ErrCircularReference):
+		return ErrInvalidParent
+	}
+	return err
+}
+
+// typeOf returns ErrCategoryNotFound if the category does not exist.
+func (s *service) typeOf(ctx context.Context, userID, publicID string) (Type, error) {
+	for _, t := range []Type{repository.CategoryTypeExpense, repository.CategoryTypeIncome} {
+		reply, err := s.repository.List(ctx, &repository.ListCategoriesRequest{
+			UserID: userID,
+			Type:   t,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrDataNotFound) {
+				continue
+			}
+			return repository.CategoryTypeNone, err
+		}
+		if lo.ContainsBy(reply.Categories, func(item *Category) bool {
+			return item.PublicID == publicID
+		}) {
+			return t, nil
+		}
+	}
+	return repository.CategoryTypeNone, ErrCategoryNotFound
+}
+
+func (s *service) validateIcon(ctx context.Context, t Type, iconID int32) error {
+	kind := icons.KindExpense
+	if t == repository.CategoryTypeIncome {
+		kind = icons.KindIncome
+	}
+
+	err := s.iconValidator.Validate(ctx, &icons.ValidateRequest{
+		Kind:   kind,
+		IconID: iconID,
+	})
+	if errors.Is(err, icons.ErrIconNotFound) {
+		return fmt.Errorf("%w: %v", ErrInvalidIcon, err)
+	}
+	return err
+}
+
 type categoryServiceOptions struct {
 	genPublicID func() string
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/n101661/maney/server/icons"
 	"github.com/n101661/maney/server/repository"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -51,8 +53,15 @@ func Test_service_Create(t *testing.T) {
 					},
 				}, nil),
 		)
+		validator := icons.NewMockValidator(controller)
+		validator.EXPECT().
+			Validate(gomock.Any(), &icons.ValidateRequest{
+				Kind:   icons.KindExpense,
+				IconID: iconID,
+			}).
+			Return(nil)
 
-		s, err := NewService(repo, WithAccountServiceGenPublicID(func() string {
+		s, err := NewService(repo, validator, WithAccountServiceGenPublicID(func() string {
 			return publicID
 		}))
 		if err != nil {
@@ -80,6 +89,34 @@ func Test_service_Create(t *testing.T) {
 			},
 		}, reply)
 	})
+	t.Run("invalid icon", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		validator := icons.NewMockValidator(controller)
+		validator.EXPECT().
+			Validate(gomock.Any(), &icons.ValidateRequest{
+				Kind:   icons.KindIncome,
+				IconID: 99,
+			}).
+			Return(icons.ErrIconNotFound)
+
+		s, err := NewService(repository.NewMockCategoryRepository(controller), validator)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user",
+			Type:   repository.CategoryTypeIncome,
+			Category: &BaseCategory{
+				Name:   "name",
+				IconID: 99,
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidIcon)
+		assert.Nil(reply)
+	})
 }
 
 func Test_service_List(t *testing.T) {
@@ -123,15 +160,16 @@ func Test_service_List(t *testing.T) {
 							ID:       id1,
 							PublicID: publicID1,
 							BaseCategory: &repository.BaseCategory{
-								Name:   categoryName1,
-								IconID: iconID1,
+								Name:           categoryName1,
+								IconID:         iconID1,
+								ParentPublicID: lo.ToPtr(publicID0),
 							},
 						},
 					},
 				}, nil),
 		)
 
-		s, err := NewService(repo)
+		s, err := NewService(repo, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -142,21 +180,29 @@ func Test_service_List(t *testing.T) {
 		})
 		assert.NoError(err)
 		assert.Equal(&ListReply{
-			Categories: []*Category{
+			Categories: []*CategoryNode{
 				{
-					ID:       id0,
-					PublicID: publicID0,
-					BaseCategory: &BaseCategory{
-						Name:   categoryName0,
-						IconID: iconID0,
+					Category: &Category{
+						ID:       id0,
+						PublicID: publicID0,
+						BaseCategory: &BaseCategory{
+							Name:   categoryName0,
+							IconID: iconID0,
+						},
 					},
-				},
-				{
-					ID:       id1,
-					PublicID: publicID1,
-					BaseCategory: &BaseCategory{
-						Name:   categoryName1,
-						IconID: iconID1,
+					Children: []*CategoryNode{
+						{
+							Category: &Category{
+								ID:       id1,
+								PublicID: publicID1,
+								BaseCategory: &BaseCategory{
+									Name:           categoryName1,
+									IconID:         iconID1,
+									ParentPublicID: lo.ToPtr(publicID0),
+								},
+							},
+							Children: []*CategoryNode{},
+						},
 					},
 				},
 			},
@@ -171,7 +217,7 @@ func Test_service_List(t *testing.T) {
 			repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
 		)
 
-		s, err := NewService(repo)
+		s, err := NewService(repo, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -182,7 +228,7 @@ func Test_service_List(t *testing.T) {
 		})
 		assert.NoError(err)
 		assert.Equal(&ListReply{
-			Categories: []*Category{},
+			Categories: []*CategoryNode{},
 		}, reply)
 	})
 }
@@ -203,7 +249,28 @@ func Test_service_Update(t *testing.T) {
 
 		controller := gomock.NewController(t)
 		repo := repository.NewMockCategoryRepository(controller)
+		validator := icons.NewMockValidator(controller)
 		gomock.InOrder(
+			repo.EXPECT().
+				List(gomock.Any(), &repository.ListCategoriesRequest{
+					UserID: userID,
+					Type:   repository.CategoryTypeExpense,
+				}).
+				Return(&repository.ListCategoriesReply{
+					Categories: []*repository.Category{{
+						ID:       id,
+						PublicID: publicID,
+						BaseCategory: &repository.BaseCategory{
+							Name: categoryName,
+						},
+					}},
+				}, nil),
+			validator.EXPECT().
+				Validate(gomock.Any(), &icons.ValidateRequest{
+					Kind:   icons.KindExpense,
+					IconID: iconID,
+				}).
+				Return(nil),
 			repo.EXPECT().
 				Update(gomock.Any(), &repository.UpdateCategoryRequest{
 					UserID:           userID,
@@ -223,7 +290,7 @@ func Test_service_Update(t *testing.T) {
 				}, nil),
 		)
 
-		s, err := NewService(repo)
+		s, err := NewService(repo, validator)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -257,7 +324,7 @@ func Test_service_Update(t *testing.T) {
 			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
 		)
 
-		s, err := NewService(repo)
+		s, err := NewService(repo, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -273,6 +340,107 @@ func Test_service_Update(t *testing.T) {
 		assert.ErrorIs(err, ErrCategoryNotFound)
 		assert.Nil(reply)
 	})
+	t.Run("parent not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repo := repository.NewMockCategoryRepository(controller)
+		gomock.InOrder(
+			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrReferenceNotFound),
+		)
+
+		s, err := NewService(repo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:           "user",
+			CategoryPublicID: "0",
+			Category: &BaseCategory{
+				Name:           "name",
+				ParentPublicID: lo.ToPtr("1"),
+			},
+		})
+		assert.ErrorIs(err, ErrParentNotFound)
+		assert.Nil(reply)
+	})
+	t.Run("circular parent", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repo := repository.NewMockCategoryRepository(controller)
+		gomock.InOrder(
+			repo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrCircularReference),
+		)
+
+		s, err := NewService(repo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:           "user",
+			CategoryPublicID: "0",
+			Category: &BaseCategory{
+				Name:           "name",
+				ParentPublicID: lo.ToPtr("1"),
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidParent)
+		assert.Nil(reply)
+	})
+	t.Run("invalid icon of income category", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repo := repository.NewMockCategoryRepository(controller)
+		validator := icons.NewMockValidator(controller)
+		gomock.InOrder(
+			repo.EXPECT().
+				List(gomock.Any(), &repository.ListCategoriesRequest{
+					UserID: "user",
+					Type:   repository.CategoryTypeExpense,
+				}).
+				Return(nil, repository.ErrDataNotFound),
+			repo.EXPECT().
+				List(gomock.Any(), &repository.ListCategoriesRequest{
+					UserID: "user",
+					Type:   repository.CategoryTypeIncome,
+				}).
+				Return(&repository.ListCategoriesReply{
+					Categories: []*repository.Category{{
+						ID:       1,
+						PublicID: "0",
+						BaseCategory: &repository.BaseCategory{
+							Name: "name",
+						},
+					}},
+				}, nil),
+			validator.EXPECT().
+				Validate(gomock.Any(), &icons.ValidateRequest{
+					Kind:   icons.KindIncome,
+					IconID: 99,
+				}).
+				Return(icons.ErrIconNotFound),
+		)
+
+		s, err := NewService(repo, validator)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:           "user",
+			CategoryPublicID: "0",
+			Category: &BaseCategory{
+				Name:   "name",
+				IconID: 99,
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidIcon)
+		assert.Nil(reply)
+	})
 }
 
 func Test_service_Delete(t *testing.T) {
@@ -306,7 +474,7 @@ func Test_service_Delete(t *testing.T) {
 				}}, nil),
 		)
 
-		s, err := NewService(repo)
+		s, err := NewService(repo, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -327,7 +495,7 @@ func Test_service_Delete(t *testing.T) {
 			repo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
 		)
 
-		s, err := NewService(repo)
+		s, err := NewService(repo, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -339,4 +507,65 @@ func Test_service_Delete(t *testing.T) {
 		assert.ErrorIs(err, ErrCategoryNotFound)
 		assert.Nil(reply)
 	})
+	t.Run("delete policies", func(t *testing.T) {
+		referenced := &repository.ReferencedError{References: []*repository.Reference{
+			{Type: repository.ReferenceTypeDailyItem, PublicID: "dailyItemID"},
+			{Type: repository.ReferenceTypeRepeatingItem, PublicID: "repeatingItemID"},
+		}}
+		tests := []struct {
+			name        string
+			policy      repository.DeletePolicy
+			replacement string
+			// repoErr is returned by the repository, which is not called if the request is
+			// insufficient.
+			repoErr error
+			wantErr error
+		}{
+			{name: "referenced category is restricted", policy: repository.DeletePolicyRestrict, repoErr: referenced, wantErr: ErrCategoryReferenced},
+			{name: "missing replacement", policy: repository.DeletePolicyReassign, wantErr: ErrDataInsufficient},
+			{name: "invalid replacement", policy: repository.DeletePolicyReassign, replacement: "2", repoErr: repository.ErrInvalidReference, wantErr: ErrInvalidReplacement},
+			{name: "references are cascaded", policy: repository.DeletePolicyCascade},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				controller := gomock.NewController(t)
+				repo := repository.NewMockCategoryRepository(controller)
+				if tt.wantErr != ErrDataInsufficient {
+					repo.EXPECT().
+						Delete(gomock.Any(), &repository.DeleteCategoriesRequest{
+							UserID:              "user",
+							CategoryPublicIDs:   []string{"1"},
+							Policy:              tt.policy,
+							ReplacementPublicID: tt.replacement,
+						}).
+						Return(nil, tt.repoErr)
+				}
+
+				s, err := NewService(repo, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Delete(context.Background(), &DeleteRequest{
+					UserID:              "user",
+					CategoryPublicID:    "1",
+					Policy:              tt.policy,
+					ReplacementPublicID: tt.replacement,
+				})
+				if tt.wantErr == nil {
+					assert.NoError(err)
+					assert.Equal(&DeleteReply{}, reply)
+					return
+				}
+				assert.ErrorIs(err, tt.wantErr)
+				if tt.repoErr == referenced {
+					// the references are kept for the response of the conflict.
+					assert.ErrorIs(err, referenced)
+				}
+				assert.Nil(reply)
+			})
+		}
+	})
 }
@@ -2,10 +2,12 @@ package iris
 
 import (
 	"context"
+	"errors"
 
 	"github.com/kataras/iris/v12"
 
 	"github.com/n101661/maney/server/models"
+	"github.com/n101661/maney/server/repository"
 )
 
 type SimpleCreateTemplate[RequestBody, ServiceRequest, ServiceReply, ResponseBody any] struct {
@@ -102,6 +104,7 @@ func (t *SimpleListTemplate[ServiceRequest, ServiceReply, ResponseBody]) List(c
 		} else {
 			c.StopWithText(iris.StatusBadRequest, err.Error())
 		}
+		return
 	}
 
 	reply, err := t.Service.List(c.Request().Context(), sr)
@@ -222,3 +225,159 @@ func (t *SimpleDeleteTemplate[ServiceRequest, ServiceReply]) Delete(c iris.Conte
 
 	c.StopWithJSON(iris.StatusOK, &models.EmptyResponse{})
 }
+
+// PolicyDeleteTemplate deletes the data which may be referenced by the other data, the
+// references are handled by the policy in the query parameters. It writes 409 status code
+// with the references if the error returned from Service is repository.ErrDataReferenced.
+type PolicyDeleteTemplate[ServiceRequest, ServiceReply any] struct {
+	// Placeholder is the ID of the placeholder in API path.
+	Placeholder string
+	Service     interface {
+		Delete(context.Context, *ServiceRequest) (*ServiceReply, error)
+	}
+
+	ParseServiceRequest func(userID string, publicID string, policy repository.DeletePolicy, replacementID string) *ServiceRequest
+	// BadRequest checks if the error returned from Service is http bad request or not.
+	BadRequest func(err error) (httpCode int, yes bool)
+}
+
+func (t *PolicyDeleteTemplate[ServiceRequest, ServiceReply]) Delete(c iris.Context) {
+	publicID := c.Params().GetString(t.Placeholder)
+
+	user := c.User()
+	if user == nil {
+		c.StopWithJSON(iris.StatusUnauthorized, &models.EmptyResponse{})
+		return
+	}
+
+	userID, err := user.GetID()
+	if err != nil {
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	policy := repository.DeletePolicyRestrict
+	if v := c.URLParam("policy"); v != "" {
+		policy, err = repository.ToDeletePolicy(v)
+		if err != nil {
+			c.StopWithText(iris.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	sr := t.ParseServiceRequest(userID, publicID, policy, c.URLParam("replacementId"))
+
+	_, err = t.Service.Delete(c.Request().Context(), sr)
+	if err != nil {
+		if errors.Is(err, repository.ErrDataReferenced) {
+			c.StopWithJSON(iris.StatusConflict, toDeleteConflictResponse(err))
+			return
+		}
+		if code, y := t.BadRequest(err); y {
+			c.StopWithText(code, err.Error())
+			return
+		}
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	c.StopWithJSON(iris.StatusOK, &models.EmptyResponse{})
+}
+
+func toDeleteConflictResponse(err error) *models.DeleteConflictResponse {
+	resp := &models.DeleteConflictResponse{
+		References: []models.Reference{},
+	}
+
+	var referenced *repository.ReferencedError
+	if errors.As(err, &referenced) {
+		for _, reference := range referenced.References {
+			resp.References = append(resp.References, models.Reference{
+				Type: models.ReferenceType(reference.Type),
+				Id:   reference.PublicID,
+			})
+		}
+	}
+	return resp
+}
+
+// SimpleGetTemplate handles the request which reads the data by the query parameters
+// of the user, such as reports.
+type SimpleGetTemplate[ServiceRequest, ServiceReply, ResponseBody any] struct {
+	// Handle is the service method to handle the request.
+	Handle func(context.Context, *ServiceRequest) (*ServiceReply, error)
+
+	// ParseServiceRequest the returned error is considered as user bad request and write 400 status code.
+	// If you want to write 500 status code, wrap the error by InternalError function.
+	ParseServiceRequest func(c iris.Context, userID string) (*ServiceRequest, error)
+	// BadRequest checks if the error returned from Service is http bad request or not.
+	BadRequest       func(err error) (httpCode int, yes bool)
+	ParseAPIResponse func(*ServiceReply) (*ResponseBody, error)
+}
+
+func (t *SimpleGetTemplate[ServiceRequest, ServiceReply, ResponseBody]) Get(c iris.Context) {
+	h := &SimpleHandleTemplate[ServiceRequest, ServiceReply, ResponseBody]{
+		Service:             t.Handle,
+		ParseServiceRequest: t.ParseServiceRequest,
+		BadRequest:          t.BadRequest,
+		ParseAPIResponse:    t.ParseAPIResponse,
+	}
+	h.Handle(c)
+}
+
+// SimpleHandleTemplate handles the request of the user which does not fit the other
+// templates, such as the actions on the data. The request is parsed from the path, the
+// query parameters or the body by ParseServiceRequest.
+type SimpleHandleTemplate[ServiceRequest, ServiceReply, ResponseBody any] struct {
+	// Service is the service method to handle the request.
+	Service func(context.Context, *ServiceRequest) (*ServiceReply, error)
+
+	// ParseServiceRequest the returned error is considered as user bad request and write 400 status code.
+	// If you want to write 500 status code, wrap the error by InternalError function.
+	ParseServiceRequest func(c iris.Context, userID string) (*ServiceRequest, error)
+	// BadRequest checks if the error returned from Service is http bad request or not.
+	BadRequest       func(err error) (httpCode int, yes bool)
+	ParseAPIResponse func(*ServiceReply) (*ResponseBody, error)
+}
+
+func (t *SimpleHandleTemplate[ServiceRequest, ServiceReply, ResponseBody]) Handle(c iris.Context) {
+	user := c.User()
+	if user == nil {
+		c.StopWithJSON(iris.StatusUnauthorized, &models.EmptyResponse{})
+		return
+	}
+
+	userID, err := user.GetID()
+	if err != nil {
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	sr, err := t.ParseServiceRequest(c, userID)
+	if err != nil {
+		if e, ok := err.(*internalError); ok {
+			c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(e.err))
+		} else {
+			c.StopWithText(iris.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	reply, err := t.Service(c.Request().Context(), sr)
+	if err != nil {
+		if code, y := t.BadRequest(err); y {
+			c.StopWithText(code, err.Error())
+			return
+		}
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	resp, err := t.ParseAPIResponse(reply)
+	if err != nil {
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	c.StopWithJSON(iris.StatusOK, resp)
+}
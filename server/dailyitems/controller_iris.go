@@ -0,0 +1,397 @@
+package dailyitems
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/pkg/currency"
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	*irisController.SimpleCreateTemplate[models.BasicDailyItem, CreateRequest, CreateReply, models.ObjectId]
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.DailyItem]
+	*irisController.SimpleUpdateTemplate[models.BasicDailyItem, UpdateRequest, UpdateReply]
+	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+	Search *irisController.SimpleGetTemplate[SearchRequest, SearchReply, models.DailyItemPage]
+}
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 100
+)
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicDailyItem, CreateRequest, CreateReply, models.ObjectId]{
+			Service: s,
+			ParseServiceRequest: func(userID string, r *models.BasicDailyItem) (*CreateRequest, error) {
+				item, err := toServiceBaseDailyItem(r)
+				if err != nil {
+					return nil, err
+				}
+				return &CreateRequest{
+					UserID: userID,
+					Item:   item,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrReferenceNotFound),
+					errors.Is(err, ErrCategoryTypeMismatch),
+					errors.Is(err, ErrCurrencyMismatch),
+					errors.Is(err, ErrInvalidSplit),
+					errors.Is(err, ErrInvalidUnit):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *CreateReply) (*models.ObjectId, error) {
+				return &models.ObjectId{
+					Id: lo.ToPtr(models.Id(reply.Item.PublicID)),
+				}, nil
+			},
+		},
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.DailyItem]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				date, err := parseDate(c.URLParamDefault("date", time.Now().Format(time.DateOnly)))
+				if err != nil {
+					return nil, err
+				}
+				return &ListRequest{
+					UserID:      userID,
+					Date:        date,
+					TagPublicID: lo.EmptyableToPtr(c.URLParam("tagId")),
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.DailyItem, error) {
+				return lo.ToPtr(lo.Map(reply.Items, func(item *DailyItem, _ int) *models.DailyItem {
+					return ToDailyItem(item)
+				})), nil
+			},
+		},
+		SimpleUpdateTemplate: &irisController.SimpleUpdateTemplate[models.BasicDailyItem, UpdateRequest, UpdateReply]{
+			Placeholder: "dailyItemId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string, r *models.BasicDailyItem) (*UpdateRequest, error) {
+				item, err := toServiceBaseDailyItem(r)
+				if err != nil {
+					return nil, err
+				}
+				return &UpdateRequest{
+					UserID:            userID,
+					DailyItemPublicID: publicID,
+					Item:              item,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDailyItemNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrTransferFeeItem):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrReferenceNotFound),
+					errors.Is(err, ErrCategoryTypeMismatch),
+					errors.Is(err, ErrCurrencyMismatch),
+					errors.Is(err, ErrInvalidSplit),
+					errors.Is(err, ErrInvalidUnit):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+			Placeholder: "dailyItemId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+				return &DeleteRequest{
+					UserID:            userID,
+					DailyItemPublicID: publicID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDailyItemNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrTransferFeeItem):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		Search: &irisController.SimpleGetTemplate[SearchRequest, SearchReply, models.DailyItemPage]{
+			Handle: s.Search,
+			ParseServiceRequest: func(c iris.Context, userID string) (*SearchRequest, error) {
+				from, err := parseOptionalDate(c, "from")
+				if err != nil {
+					return nil, err
+				}
+				to, err := parseOptionalDate(c, "to")
+				if err != nil {
+					return nil, err
+				}
+				minAmount, err := parseOptionalDecimal(lo.EmptyableToPtr(c.URLParam("minAmount")))
+				if err != nil {
+					return nil, err
+				}
+				maxAmount, err := parseOptionalDecimal(lo.EmptyableToPtr(c.URLParam("maxAmount")))
+				if err != nil {
+					return nil, err
+				}
+				limit := c.URLParamIntDefault("limit", defaultSearchLimit)
+				if limit < 1 || limit > maxSearchLimit {
+					return nil, fmt.Errorf("invalid limit[%s]", c.URLParam("limit"))
+				}
+				return &SearchRequest{
+					UserID:           userID,
+					Keyword:          c.URLParam("keyword"),
+					From:             from,
+					To:               to,
+					CategoryPublicID: lo.EmptyableToPtr(c.URLParam("categoryId")),
+					ShopPublicID:     lo.EmptyableToPtr(c.URLParam("shopId")),
+					AccountPublicID:  lo.EmptyableToPtr(c.URLParam("accountId")),
+					TagPublicID:      lo.EmptyableToPtr(c.URLParam("tagId")),
+					MinAmount:        minAmount,
+					MaxAmount:        maxAmount,
+					Cursor:           c.URLParam("cursor"),
+					Limit:            limit,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidRange),
+					errors.Is(err, ErrInvalidCursor):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *SearchReply) (*models.DailyItemPage, error) {
+				return &models.DailyItemPage{
+					Items: lo.Map(reply.Items, func(item *DailyItem, _ int) models.DailyItem {
+						return *ToDailyItem(item)
+					}),
+					NextCursor: lo.EmptyableToPtr(reply.NextCursor),
+				}, nil
+			},
+		},
+	}
+}
+
+func parseDate(s string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date[%s]", s)
+	}
+	return date, nil
+}
+
+func parseOptionalDate(c iris.Context, name string) (*time.Time, error) {
+	if !c.URLParamExists(name) {
+		return nil, nil
+	}
+	date, err := parseDate(c.URLParam(name))
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func parseDecimal(s string) (decimal.Decimal, error) {
+	v, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid decimal[%s]", s)
+	}
+	return v, nil
+}
+
+func parseOptionalDecimal(s *string) (*decimal.Decimal, error) {
+	if s == nil {
+		return nil, nil
+	}
+	v, err := parseDecimal(*s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func toServiceBaseDailyItem(r *models.BasicDailyItem) (*BaseDailyItem, error) {
+	item, err := ParseBasicItem(&models.BasicItem{
+		Name:        r.Name,
+		CategoryIds: r.CategoryIds,
+		Splits:      r.Splits,
+		ShopId:      r.ShopId,
+		AccountId:   r.AccountId,
+		Quantity:    r.Quantity,
+		FeeId:       r.FeeId,
+		Fee:         r.Fee,
+		Price:       r.Price,
+		Currency:    r.Currency,
+		Memo:        r.Memo,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BaseDailyItem{
+		Date:         r.Date.Time,
+		TagPublicIDs: lo.FromPtr(r.TagIds),
+		BaseItem:     item,
+	}, nil
+}
+
+// ParseBasicItem converts the item of API request to BaseItem.
+func ParseBasicItem(r *models.BasicItem) (*BaseItem, error) {
+	price, err := parseDecimal(r.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := parseOptionalDecimal(r.Fee)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		quantity *decimal.Decimal
+		unit     Unit
+	)
+	if r.Quantity != nil {
+		quantity, err = parseOptionalDecimal(r.Quantity.Value)
+		if err != nil {
+			return nil, err
+		}
+		unit = Unit(lo.FromPtr(r.Quantity.Unit))
+	}
+
+	currencyCode, err := currency.Normalize(lo.FromPtr(r.Currency))
+	if err != nil {
+		return nil, err
+	}
+
+	var splits []*ItemSplit
+	if r.Splits != nil {
+		splits = make([]*ItemSplit, len(*r.Splits))
+		for i, split := range *r.Splits {
+			amount, err := parseOptionalDecimal(split.Amount)
+			if err != nil {
+				return nil, err
+			}
+			percentage, err := parseOptionalDecimal(split.Percentage)
+			if err != nil {
+				return nil, err
+			}
+			splits[i] = &ItemSplit{
+				CategoryPublicID: split.CategoryId,
+				Amount:           amount,
+				Percentage:       percentage,
+			}
+		}
+	}
+
+	return &BaseItem{
+		Name:              r.Name,
+		CategoryPublicIDs: r.CategoryIds,
+		Splits:            splits,
+		ShopPublicID:      r.ShopId,
+		AccountPublicID:   r.AccountId,
+		Quantity:          quantity,
+		Unit:              unit,
+		FeePublicID:       r.FeeId,
+		Fee:               fee,
+		Price:             price,
+		Currency:          currencyCode,
+		Memo:              lo.FromPtr(r.Memo),
+	}, nil
+}
+
+// ToBasicItem converts BaseItem to the item of API response.
+func ToBasicItem(v *BaseItem) *models.BasicItem {
+	return &models.BasicItem{
+		Name:        v.Name,
+		CategoryIds: v.CategoryPublicIDs,
+		Splits: lo.IfF(len(v.Splits) > 0, func() *[]models.ItemSplit {
+			return lo.ToPtr(lo.Map(v.Splits, func(split *ItemSplit, _ int) models.ItemSplit {
+				return models.ItemSplit{
+					CategoryId: split.CategoryPublicID,
+					Amount: lo.IfF(split.Amount != nil, func() *models.Decimal {
+						return lo.ToPtr(models.Decimal(split.Amount.String()))
+					}).Else(nil),
+					Percentage: lo.IfF(split.Percentage != nil, func() *models.Decimal {
+						return lo.ToPtr(models.Decimal(split.Percentage.String()))
+					}).Else(nil),
+				}
+			}))
+		}).Else(nil),
+		ShopId:    v.ShopPublicID,
+		AccountId: v.AccountPublicID,
+		Quantity: lo.IfF(v.Quantity != nil, func() *models.WrappedQuantity {
+			return &models.WrappedQuantity{
+				Value: lo.ToPtr(models.Decimal(v.Quantity.String())),
+				Unit:  lo.EmptyableToPtr(models.Unit(v.Unit)),
+			}
+		}).Else(nil),
+		FeeId: v.FeePublicID,
+		Fee: lo.IfF(v.Fee != nil, func() *models.Decimal {
+			return lo.ToPtr(models.Decimal(v.Fee.String()))
+		}).Else(nil),
+		Price:    v.Price.String(),
+		Currency: lo.EmptyableToPtr(v.Currency),
+		Memo:     lo.ToPtr(v.Memo),
+	}
+}
+
+// ToDailyItem converts DailyItem to the daily item of API response.
+func ToDailyItem(v *DailyItem) *models.DailyItem {
+	item := ToBasicItem(v.BaseItem)
+	return &models.DailyItem{
+		Id:          lo.ToPtr(models.Id(v.PublicID)),
+		Date:        openapi_types.Date{Time: v.Date},
+		Name:        item.Name,
+		CategoryIds: item.CategoryIds,
+		Splits:      item.Splits,
+		ShopId:      item.ShopId,
+		AccountId:   item.AccountId,
+		Quantity:    item.Quantity,
+		FeeId:       item.FeeId,
+		Fee:         item.Fee,
+		Price:       item.Price,
+		Currency:    item.Currency,
+		Memo:        item.Memo,
+		TagIds: lo.IfF(len(v.TagPublicIDs) > 0, func() *[]models.Id {
+			return &v.TagPublicIDs
+		}).Else(nil),
+		Total: lo.ToPtr(models.Decimal(v.Amount().String())),
+		NormalizedPrice: lo.IfF(v.NormalizedPrice != nil, func() *models.NormalizedPrice {
+			return ToNormalizedPrice(v.NormalizedPrice)
+		}).Else(nil),
+	}
+}
+
+// ToNormalizedPrice converts NormalizedPrice to the normalized price of API response.
+func ToNormalizedPrice(v *NormalizedPrice) *models.NormalizedPrice {
+	return &models.NormalizedPrice{
+		Price:    v.Price.String(),
+		Quantity: v.Quantity.String(),
+		Unit:     models.Unit(v.Unit),
+	}
+}
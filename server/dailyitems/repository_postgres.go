@@ -0,0 +1,1262 @@
+package dailyitems
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.DailyItemRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Create(ctx context.Context, r *repository.CreateDailyItemsRequest) ([]*repository.DailyItem, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	result, err := CreateWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWithSession is the same as Create, but it creates the daily items with the session
+// so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateDailyItemsRequest) ([]*repository.DailyItem, error) {
+	refs, err := resolveReferences(session, r.UserID, lo.Map(r.Items, func(item *repository.BaseCreateDailyItem, _ int) *repository.BaseDailyItem {
+		return item.BaseDailyItem
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*repository.DailyItem, len(r.Items))
+	rows := make([]*postgres.DailyItemsModel, len(r.Items))
+	for i, item := range r.Items {
+		row, err := toPostgresDailyItem(r.UserID, item.BaseDailyItem, refs)
+		if err != nil {
+			return nil, err
+		}
+		row.PublicID = item.PublicID
+		rows[i] = row
+		if _, err := session.Insert(row); err != nil {
+			if postgres.UniqueViolationError(err) {
+				return nil, repository.ErrDataExists
+			}
+			return nil, err
+		}
+
+		splits, err := insertCategoryLinks(session, row, item.BaseDailyItem, refs)
+		if err != nil {
+			return nil, err
+		}
+		if err := insertTagLinks(session, row.ID, item.TagPublicIDs, refs); err != nil {
+			return nil, err
+		}
+		if err := applyBalance(session, row, false); err != nil {
+			return nil, err
+		}
+
+		result[i] = &repository.DailyItem{
+			ID:              row.ID,
+			PublicID:        row.PublicID,
+			Type:            row.Type,
+			NormalizedPrice: postgres.ToNormalizedPrice(row.NormalizedPrice, row.NormalizedUnit),
+			BaseDailyItem:   withComputedFee(item.BaseDailyItem, row, splits),
+		}
+	}
+
+	if err := recordItemPrices(session, r.UserID, rows); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (repo *postgresRepository) List(ctx context.Context, r *repository.ListDailyItemsRequest) (*repository.ListDailyItemsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var accountID int32
+	if r.AccountPublicID != nil {
+		account := postgres.AccountsModel{PublicID: *r.AccountPublicID, UserID: r.UserID}
+		has, err := session.Cols("id").Get(&account)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, repository.ErrDataNotFound
+		}
+		accountID = account.ID
+	}
+	var tagID int32
+	if r.TagPublicID != nil {
+		tag := postgres.TagsModel{PublicID: *r.TagPublicID, UserID: r.UserID}
+		has, err := session.Cols("id").Get(&tag)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, repository.ErrDataNotFound
+		}
+		tagID = tag.ID
+	}
+
+	session.Where("user_id = ?", r.UserID)
+	if r.DailyItemPublicID != nil {
+		session.And("public_id = ?", *r.DailyItemPublicID)
+	}
+	if r.Date != nil {
+		session.And("date = ?", r.Date.Format(time.DateOnly))
+	}
+	if r.From != nil {
+		session.And("date >= ?", r.From.Format(time.DateOnly))
+	}
+	if r.To != nil {
+		session.And("date < ?", r.To.Format(time.DateOnly))
+	}
+	if r.AccountPublicID != nil {
+		session.And("account_id = ?", accountID)
+	}
+	if r.TagPublicID != nil {
+		tags := repo.engine.TableName(&postgres.DailyItemTagsModel{}, true)
+		session.And("id IN (SELECT daily_item_id FROM "+tags+" WHERE tag_id = ?)", tagID)
+	}
+
+	var rows []*postgres.DailyItemsModel
+	err := session.Asc("date", "id").Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	items, err := loadDailyItems(session, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.ListDailyItemsReply{
+		Items: items,
+	}, nil
+}
+
+func (repo *postgresRepository) Update(ctx context.Context, r *repository.UpdateDailyItemRequest) (*repository.DailyItem, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	row := postgres.DailyItemsModel{
+		PublicID: r.DailyItemPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&row)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrDataNotFound
+	}
+	// the fee item is changed with its transfer.
+	if row.TransferID.Valid {
+		return nil, repository.ErrDataManaged
+	}
+
+	refs, err := resolveReferences(session, r.UserID, []*repository.BaseDailyItem{r.Item})
+	if err != nil {
+		return nil, err
+	}
+
+	bean, err := toPostgresDailyItem(r.UserID, r.Item, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyBalance(session, &row, true); err != nil {
+		return nil, err
+	}
+	if err := applyBalance(session, bean, false); err != nil {
+		return nil, err
+	}
+
+	affected, err := session.
+		Cols("date", "name", "type", "shop_id", "account_id", "quantity", "unit", "fee_id", "fee", "price", "currency", "memo", "total", "normalized_price", "normalized_unit").
+		Update(bean, &postgres.DailyItemsModel{
+			ID: row.ID,
+		})
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	_, err = session.Delete(&postgres.DailyItemCategoriesModel{
+		DailyItemID: row.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	bean.ID = row.ID
+	splits, err := insertCategoryLinks(session, bean, r.Item, refs)
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.Delete(&postgres.DailyItemTagsModel{
+		DailyItemID: row.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := insertTagLinks(session, row.ID, r.Item.TagPublicIDs, refs); err != nil {
+		return nil, err
+	}
+
+	if err := deleteItemPrices(session, []any{row.ID}); err != nil {
+		return nil, err
+	}
+	if err := recordItemPrices(session, r.UserID, []*postgres.DailyItemsModel{bean}); err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return &repository.DailyItem{
+		ID:              row.ID,
+		PublicID:        row.PublicID,
+		Type:            bean.Type,
+		NormalizedPrice: postgres.ToNormalizedPrice(bean.NormalizedPrice, bean.NormalizedUnit),
+		BaseDailyItem:   withComputedFee(r.Item, bean, splits),
+	}, nil
+}
+
+func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteDailyItemsRequest) ([]*repository.DailyItem, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	// the fee items are deleted with their transfers.
+	session.Where("user_id = ?", r.UserID).And("transfer_id IS NOT NULL")
+	if len(r.DailyItemPublicIDs) > 0 {
+		session.In("public_id", r.DailyItemPublicIDs)
+	}
+	managed, err := session.Exist(&postgres.DailyItemsModel{})
+	if err != nil {
+		return nil, err
+	}
+	if managed {
+		return nil, repository.ErrDataManaged
+	}
+
+	items, err := DeleteWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// DeleteWithSession is the same as Delete, but it deletes the daily items with the session
+// so that they can be deleted in the transaction of the session.
+func DeleteWithSession(session *xorm.Session, r *repository.DeleteDailyItemsRequest) ([]*repository.DailyItem, error) {
+	session.Where("user_id = ?", r.UserID)
+	if len(r.DailyItemPublicIDs) > 0 {
+		session.In("public_id", r.DailyItemPublicIDs)
+	}
+
+	var rows []*postgres.DailyItemsModel
+	err := session.Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.DailyItemPublicIDs) > 0 && len(rows) != len(r.DailyItemPublicIDs) {
+		return nil, repository.ErrDataNotFound
+	}
+	if len(r.DailyItemPublicIDs) == 0 && len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	items, err := loadDailyItems(session, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if err := applyBalance(session, row, true); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := lo.Map(rows, func(item *postgres.DailyItemsModel, _ int) any {
+		return item.ID
+	})
+	_, err = session.In("daily_item_id", ids).Delete(&postgres.DailyItemCategoriesModel{})
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.In("daily_item_id", ids).Delete(&postgres.DailyItemTagsModel{})
+	if err != nil {
+		return nil, err
+	}
+	if err := deleteItemPrices(session, ids); err != nil {
+		return nil, err
+	}
+	_, err = session.In("id", ids).Delete(&postgres.DailyItemsModel{})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *postgresRepository) SumByCategory(ctx context.Context, r *repository.SumDailyItemsByCategoryRequest) (*repository.SumDailyItemsByCategoryReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var (
+		items      = repo.engine.TableName(&postgres.DailyItemsModel{}, true)
+		categories = repo.engine.TableName(&postgres.DailyItemCategoriesModel{}, true)
+	)
+	// the sums of each month are grouped by the first day of the month.
+	month := "NULL::date"
+	if r.Monthly {
+		month = "DATE_TRUNC('month', i.date)::date"
+	}
+
+	var rows []*categorySumRow
+	// the amount of the item is attributed by its split, or split equally if it has no split.
+	// The fee is attributed in proportion to the split amount.
+	err := session.SQL(`SELECT `+month+` AS month, c.category_id, i.currency, ROUND(SUM(COALESCE(c.amount, `+postgres.DailyItemAmountExpr("i")+` / s.n)), 6) AS amount, ROUND(SUM(COALESCE(i.fee, 0) * COALESCE(c.amount / NULLIF(`+postgres.DailyItemAmountExpr("i")+`, 0), 1.0 / s.n)), 6) AS fee, COUNT(*) AS count
+FROM `+items+` AS i
+INNER JOIN `+categories+` AS c ON c.daily_item_id = i.id
+INNER JOIN (SELECT daily_item_id, COUNT(*) AS n FROM `+categories+` GROUP BY daily_item_id) AS s ON s.daily_item_id = i.id
+WHERE i.user_id = ? AND i.type = ? AND i.date >= ? AND i.date < ?
+GROUP BY 1, c.category_id, i.currency
+ORDER BY 1, c.category_id, i.currency`,
+		r.UserID, r.Type, r.From.Format(time.DateOnly), r.To.Format(time.DateOnly),
+	).Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryPublicIDs := map[int32]string{}
+	if len(rows) > 0 {
+		var categories []*postgres.CategoriesModel
+		err = session.In("id", lo.Map(rows, func(item *categorySumRow, _ int) int32 {
+			return item.CategoryID
+		})).Find(&categories)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			categoryPublicIDs[category.ID] = category.PublicID
+		}
+	}
+
+	return &repository.SumDailyItemsByCategoryReply{
+		Categories: lo.Map(rows, func(item *categorySumRow, _ int) *repository.CategorySum {
+			return &repository.CategorySum{
+				Month:            item.Month.Time,
+				CategoryPublicID: categoryPublicIDs[item.CategoryID],
+				Currency:         item.Currency,
+				Amount:           item.Amount.Decimal,
+				Fee:              item.Fee.Decimal,
+				Count:            item.Count,
+			}
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) SumByTag(ctx context.Context, r *repository.SumDailyItemsByTagRequest) (*repository.SumDailyItemsByTagReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var (
+		items = repo.engine.TableName(&postgres.DailyItemsModel{}, true)
+		tags  = repo.engine.TableName(&postgres.DailyItemTagsModel{}, true)
+	)
+	var rows []*tagSumRow
+	err := session.SQL(`SELECT t.tag_id, i.currency, ROUND(SUM(`+postgres.DailyItemAmountExpr("i")+`), 6) AS amount, COUNT(*) AS count
+FROM `+items+` AS i
+INNER JOIN `+tags+` AS t ON t.daily_item_id = i.id
+WHERE i.user_id = ? AND i.type = ? AND i.date >= ? AND i.date < ?
+GROUP BY t.tag_id, i.currency
+ORDER BY t.tag_id, i.currency`,
+		r.UserID, r.Type, r.From.Format(time.DateOnly), r.To.Format(time.DateOnly),
+	).Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	tagPublicIDs := map[int32]string{}
+	if len(rows) > 0 {
+		var tags []*postgres.TagsModel
+		err = session.In("id", lo.Uniq(lo.Map(rows, func(item *tagSumRow, _ int) int32 {
+			return item.TagID
+		}))).Find(&tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagPublicIDs[tag.ID] = tag.PublicID
+		}
+	}
+
+	return &repository.SumDailyItemsByTagReply{
+		Tags: lo.Map(rows, func(item *tagSumRow, _ int) *repository.TagSum {
+			return &repository.TagSum{
+				TagPublicID: tagPublicIDs[item.TagID],
+				Currency:    item.Currency,
+				Amount:      item.Amount.Decimal,
+				Count:       item.Count,
+			}
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) ListShopPrices(ctx context.Context, r *repository.ListItemShopPricesRequest) (*repository.ListItemShopPricesReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var rows []*postgres.AdvanceItemsModel
+	err := session.
+		Where("user_id = ? AND name = ?", r.UserID, r.ItemName).
+		And("date >= ? AND date < ?", r.From.Format(time.DateOnly), r.To.Format(time.DateOnly)).
+		Asc("date", "daily_item_id").
+		Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	var shops []*postgres.ShopsModel
+	err = session.In("id", lo.Uniq(lo.Map(rows, func(item *postgres.AdvanceItemsModel, _ int) int32 {
+		return item.ShopID
+	}))).Find(&shops)
+	if err != nil {
+		return nil, err
+	}
+	shopPublicIDs := lo.SliceToMap(shops, func(item *postgres.ShopsModel) (int32, string) {
+		return item.ID, item.PublicID
+	})
+
+	return &repository.ListItemShopPricesReply{
+		Prices: lo.Map(rows, func(item *postgres.AdvanceItemsModel, _ int) *repository.ItemShopPrice {
+			return &repository.ItemShopPrice{
+				ShopPublicID:    shopPublicIDs[item.ShopID],
+				Date:            item.Date,
+				UnitPrice:       item.UnitPrice.Decimal,
+				Currency:        item.Currency,
+				NormalizedPrice: postgres.ToNormalizedPrice(item.NormalizedPrice, item.NormalizedUnit),
+			}
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) Search(ctx context.Context, r *repository.SearchDailyItemsRequest) (*repository.SearchDailyItemsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	// the filters of the referenced resources are resolved first, no item is satisfied
+	// if any of them does not exist.
+	var categoryIDs []int32
+	if r.CategoryPublicID != nil {
+		ids, err := categoryWithDescendants(session, r.UserID, *r.CategoryPublicID)
+		if err != nil {
+			return nil, err
+		}
+		categoryIDs = ids
+	}
+	var shopID int32
+	if r.ShopPublicID != nil {
+		shop := postgres.ShopsModel{PublicID: *r.ShopPublicID, UserID: r.UserID}
+		has, err := session.Cols("id").Get(&shop)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, repository.ErrDataNotFound
+		}
+		shopID = shop.ID
+	}
+	var accountID int32
+	if r.AccountPublicID != nil {
+		account := postgres.AccountsModel{PublicID: *r.AccountPublicID, UserID: r.UserID}
+		has, err := session.Cols("id").Get(&account)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, repository.ErrDataNotFound
+		}
+		accountID = account.ID
+	}
+	var tagID int32
+	if r.TagPublicID != nil {
+		tag := postgres.TagsModel{PublicID: *r.TagPublicID, UserID: r.UserID}
+		has, err := session.Cols("id").Get(&tag)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, repository.ErrDataNotFound
+		}
+		tagID = tag.ID
+	}
+
+	session.Alias("i").Where("i.user_id = ?", r.UserID)
+	if r.Keyword != "" {
+		pattern := "%" + escapeLike(r.Keyword) + "%"
+		session.And("(i.name ILIKE ? OR i.memo ILIKE ?)", pattern, pattern)
+	}
+	if r.From != nil {
+		session.And("i.date >= ?", r.From.Format(time.DateOnly))
+	}
+	if r.To != nil {
+		session.And("i.date < ?", r.To.Format(time.DateOnly))
+	}
+	if r.CategoryPublicID != nil {
+		categories := repo.engine.TableName(&postgres.DailyItemCategoriesModel{}, true)
+		session.And("EXISTS (SELECT 1 FROM "+categories+" AS c WHERE c.daily_item_id = i.id AND c.category_id = ANY(?))", pq.Array(categoryIDs))
+	}
+	if r.ShopPublicID != nil {
+		session.And("i.shop_id = ?", shopID)
+	}
+	if r.AccountPublicID != nil {
+		session.And("i.account_id = ?", accountID)
+	}
+	if r.TagPublicID != nil {
+		tags := repo.engine.TableName(&postgres.DailyItemTagsModel{}, true)
+		session.And("EXISTS (SELECT 1 FROM "+tags+" AS t WHERE t.daily_item_id = i.id AND t.tag_id = ?)", tagID)
+	}
+	if r.MinAmount != nil {
+		session.And(postgres.DailyItemAmountExpr("i")+" >= ?", r.MinAmount.String())
+	}
+	if r.MaxAmount != nil {
+		session.And(postgres.DailyItemAmountExpr("i")+" <= ?", r.MaxAmount.String())
+	}
+	if r.After != nil {
+		session.And("(i.date, i.id) < (?, ?)", r.After.Date.Format(time.DateOnly), r.After.ID)
+	}
+
+	var rows []*postgres.DailyItemsModel
+	err := session.Desc("i.date", "i.id").Limit(r.Limit).Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	items, err := loadDailyItems(session, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.SearchDailyItemsReply{
+		Items: items,
+	}, nil
+}
+
+// categoryWithDescendants returns the ids of the category and all of its descendants. It
+// returns ErrDataNotFound if the category does not exist.
+func categoryWithDescendants(session *xorm.Session, userID, publicID string) ([]int32, error) {
+	var rows []*postgres.CategoriesModel
+	err := session.Cols("id", "public_id", "parent_id").Find(&rows, &postgres.CategoriesModel{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	category, ok := lo.Find(rows, func(item *postgres.CategoriesModel) bool {
+		return item.PublicID == publicID
+	})
+	if !ok {
+		return nil, repository.ErrDataNotFound
+	}
+
+	children := map[int32][]int32{}
+	for _, row := range rows {
+		if row.ParentID.Valid {
+			children[row.ParentID.Int32] = append(children[row.ParentID.Int32], row.ID)
+		}
+	}
+	ids := []int32{category.ID}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+	return ids, nil
+}
+
+// escapeLike escapes the wildcards of the LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (repo *postgresRepository) SumByDate(ctx context.Context, r *repository.SumDailyItemsByDateRequest) (*repository.SumDailyItemsByDateReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	items := repo.engine.TableName(&postgres.DailyItemsModel{}, true)
+	var rows []*dateSumRow
+	err := session.SQL(`SELECT i.date, i.type, i.currency, ROUND(SUM(`+postgres.DailyItemAmountExpr("i")+`), 6) AS amount, COUNT(*) AS count
+FROM `+items+` AS i
+WHERE i.user_id = ? AND i.date >= ? AND i.date < ?
+GROUP BY i.date, i.type, i.currency
+ORDER BY i.date, i.type, i.currency`,
+		r.UserID, r.From.Format(time.DateOnly), r.To.Format(time.DateOnly),
+	).Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SumDailyItemsByDateReply{
+		Days: lo.Map(rows, func(item *dateSumRow, _ int) *repository.DateSum {
+			return &repository.DateSum{
+				Date:     item.Date,
+				Type:     item.Type,
+				Currency: item.Currency,
+				Amount:   item.Amount.Decimal,
+				Count:    item.Count,
+			}
+		}),
+	}, nil
+}
+
+type dateSumRow struct {
+	Date     time.Time
+	Type     repository.CategoryType
+	Currency string
+	Amount   decimal.NullDecimal
+	Count    int64
+}
+
+type tagSumRow struct {
+	TagID    int32
+	Currency string
+	Amount   decimal.NullDecimal
+	Count    int64
+}
+
+type categorySumRow struct {
+	Month      sql.NullTime
+	CategoryID int32
+	Currency   string
+	Amount     decimal.NullDecimal
+	Fee        decimal.NullDecimal
+	Count      int64
+}
+
+// references maps public ids of the referenced resources to their ids.
+type references struct {
+	categories    map[string]int32
+	categoryTypes map[string]repository.CategoryType
+	tags          map[string]int32
+	shops         map[string]int32
+	accounts      map[string]int32
+	// accountCurrencies maps public ids of the accounts to their currencies.
+	accountCurrencies map[string]string
+	// repeatingItems maps public ids of the repeating items to their ids.
+	repeatingItems map[string]int32
+	// fees maps public ids of the fees to the fees.
+	fees map[string]*postgres.FeesModel
+	// unitConversions are the unit conversions of the user, they are loaded only if any
+	// of the items has the unit.
+	unitConversions []*repository.BaseUnitConversion
+}
+
+func resolveReferences(session *xorm.Session, userID string, items []*repository.BaseDailyItem) (*references, error) {
+	refs := &references{
+		categories:        map[string]int32{},
+		categoryTypes:     map[string]repository.CategoryType{},
+		tags:              map[string]int32{},
+		shops:             map[string]int32{},
+		accounts:          map[string]int32{},
+		accountCurrencies: map[string]string{},
+		repeatingItems:    map[string]int32{},
+		fees:              map[string]*postgres.FeesModel{},
+	}
+
+	categoryPublicIDs := lo.Uniq(lo.FlatMap(items, func(item *repository.BaseDailyItem, _ int) []string {
+		return item.CategoryPublicIDs
+	}))
+	if len(categoryPublicIDs) > 0 {
+		var rows []*postgres.CategoriesModel
+		err := session.Where("user_id = ?", userID).In("public_id", categoryPublicIDs).Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != len(categoryPublicIDs) {
+			return nil, repository.ErrReferenceNotFound
+		}
+		for _, row := range rows {
+			refs.categories[row.PublicID] = row.ID
+			refs.categoryTypes[row.PublicID] = row.Type
+		}
+	}
+
+	tagPublicIDs := lo.Uniq(lo.FlatMap(items, func(item *repository.BaseDailyItem, _ int) []string {
+		return item.TagPublicIDs
+	}))
+	if len(tagPublicIDs) > 0 {
+		var rows []*postgres.TagsModel
+		err := session.Where("user_id = ?", userID).In("public_id", tagPublicIDs).Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != len(tagPublicIDs) {
+			return nil, repository.ErrReferenceNotFound
+		}
+		for _, row := range rows {
+			refs.tags[row.PublicID] = row.ID
+		}
+	}
+
+	shopPublicIDs := lo.Uniq(lo.FilterMap(items, func(item *repository.BaseDailyItem, _ int) (string, bool) {
+		return lo.FromPtr(item.ShopPublicID), item.ShopPublicID != nil
+	}))
+	if len(shopPublicIDs) > 0 {
+		var rows []*postgres.ShopsModel
+		err := session.Where("user_id = ?", userID).In("public_id", shopPublicIDs).Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != len(shopPublicIDs) {
+			return nil, repository.ErrReferenceNotFound
+		}
+		for _, row := range rows {
+			refs.shops[row.PublicID] = row.ID
+		}
+	}
+
+	accountPublicIDs := lo.Uniq(lo.FilterMap(items, func(item *repository.BaseDailyItem, _ int) (string, bool) {
+		return lo.FromPtr(item.AccountPublicID), item.AccountPublicID != nil
+	}))
+	if len(accountPublicIDs) > 0 {
+		var rows []*postgres.AccountsModel
+		err := session.Where("user_id = ?", userID).In("public_id", accountPublicIDs).Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != len(accountPublicIDs) {
+			return nil, repository.ErrReferenceNotFound
+		}
+		for _, row := range rows {
+			refs.accounts[row.PublicID] = row.ID
+			refs.accountCurrencies[row.PublicID] = row.Data.Currency
+		}
+	}
+
+	repeatingItemPublicIDs := lo.Uniq(lo.FilterMap(items, func(item *repository.BaseDailyItem, _ int) (string, bool) {
+		return lo.FromPtr(item.RepeatingItemPublicID), item.RepeatingItemPublicID != nil
+	}))
+	if len(repeatingItemPublicIDs) > 0 {
+		var rows []*postgres.RepeatingItemsModel
+		err := session.Where("user_id = ?", userID).In("public_id", repeatingItemPublicIDs).Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != len(repeatingItemPublicIDs) {
+			return nil, repository.ErrReferenceNotFound
+		}
+		for _, row := range rows {
+			refs.repeatingItems[row.PublicID] = row.ID
+		}
+	}
+
+	feePublicIDs := lo.Uniq(lo.FilterMap(items, func(item *repository.BaseDailyItem, _ int) (string, bool) {
+		return lo.FromPtr(item.FeePublicID), item.FeePublicID != nil
+	}))
+	if len(feePublicIDs) > 0 {
+		var rows []*postgres.FeesModel
+		err := session.Where("user_id = ?", userID).In("public_id", feePublicIDs).Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) != len(feePublicIDs) {
+			return nil, repository.ErrReferenceNotFound
+		}
+		for _, row := range rows {
+			refs.fees[row.PublicID] = row
+		}
+	}
+
+	if lo.SomeBy(items, func(item *repository.BaseDailyItem) bool {
+		return item.Unit != repository.UnitNone
+	}) {
+		var rows []*postgres.UnitConversionsModel
+		err := session.Where("user_id = ?", userID).Find(&rows)
+		if err != nil {
+			return nil, err
+		}
+		refs.unitConversions = lo.Map(rows, func(item *postgres.UnitConversionsModel, _ int) *repository.BaseUnitConversion {
+			return &repository.BaseUnitConversion{
+				Unit:   item.Unit,
+				ToUnit: item.ToUnit,
+				Factor: item.Factor.Decimal,
+			}
+		})
+	}
+
+	return refs, nil
+}
+
+// itemType returns the type of the categories of the item. It returns ErrInvalidReference
+// if the categories are not the same type.
+func (refs *references) itemType(item *repository.BaseDailyItem) (repository.CategoryType, error) {
+	types := lo.Uniq(lo.Map(item.CategoryPublicIDs, func(publicID string, _ int) repository.CategoryType {
+		return refs.categoryTypes[publicID]
+	}))
+	if len(types) != 1 {
+		return repository.CategoryTypeNone, repository.ErrInvalidReference
+	}
+	return types[0], nil
+}
+
+// itemCurrency returns the currency of the item, which is the currency of its account if
+// it is not provided. It returns ErrCurrencyMismatch if the currencies are different.
+func (refs *references) itemCurrency(item *repository.BaseDailyItem) (string, error) {
+	if item.AccountPublicID == nil {
+		return item.Currency, nil
+	}
+	currency := refs.accountCurrencies[*item.AccountPublicID]
+	if item.Currency != "" && item.Currency != currency {
+		return "", repository.ErrCurrencyMismatch
+	}
+	return currency, nil
+}
+
+// itemFee returns the fee of the item, which is computed by the referenced fee on the
+// subtotal of the item if the item references a fee.
+func (refs *references) itemFee(item *repository.BaseDailyItem) (*decimal.Decimal, *int32) {
+	if item.FeePublicID == nil {
+		return item.Fee, nil
+	}
+	fee := refs.fees[*item.FeePublicID]
+	charged := postgres.FromBaseFee(fee.Name, fee.Data).Charge(item.Subtotal()).Round(6)
+	return &charged, &fee.ID
+}
+
+// applyBalance debits the amount of the expense item from its account or credits the
+// amount of the income item to its account. If revert is true, it does the opposite.
+func applyBalance(session *xorm.Session, item *postgres.DailyItemsModel, revert bool) error {
+	if !item.AccountID.Valid {
+		return nil
+	}
+
+	delta := toBaseDailyItem(item).Amount()
+	if item.Type == repository.CategoryTypeExpense {
+		delta = delta.Neg()
+	}
+	if revert {
+		delta = delta.Neg()
+	}
+	if delta.IsZero() {
+		return nil
+	}
+
+	affected, err := session.
+		Incr("balance", delta).
+		Update(&postgres.AccountsModel{}, &postgres.AccountsModel{
+			ID: item.AccountID.Int32,
+		})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrReferenceNotFound
+	}
+	return nil
+}
+
+// insertCategoryLinks links the inserted row to the categories of the item with the split
+// amounts, it returns the resolved splits of the item.
+func insertCategoryLinks(session *xorm.Session, row *postgres.DailyItemsModel, item *repository.BaseDailyItem, refs *references) ([]*repository.ItemSplit, error) {
+	// the splits are resolved with the computed fee.
+	splits, err := withComputedFee(item, row, nil).ResolveSplits()
+	if err != nil {
+		return nil, err
+	}
+	itemSplits := lo.SliceToMap(splits, func(split *repository.ItemSplit) (string, *repository.ItemSplit) {
+		return split.CategoryPublicID, split
+	})
+
+	links := lo.Map(lo.Uniq(item.CategoryPublicIDs), func(publicID string, _ int) *postgres.DailyItemCategoriesModel {
+		link := &postgres.DailyItemCategoriesModel{
+			DailyItemID: row.ID,
+			CategoryID:  refs.categories[publicID],
+		}
+		if split, ok := itemSplits[publicID]; ok {
+			link.Amount = postgres.ToNullDecimal(split.Amount)
+			link.Percentage = postgres.ToNullDecimal(split.Percentage)
+		}
+		return link
+	})
+	if len(links) == 0 {
+		return splits, nil
+	}
+	if _, err := session.Insert(links); err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+func insertTagLinks(session *xorm.Session, itemID int32, tagPublicIDs []string, refs *references) error {
+	links := lo.Map(lo.Uniq(tagPublicIDs), func(publicID string, _ int) *postgres.DailyItemTagsModel {
+		return &postgres.DailyItemTagsModel{
+			DailyItemID: itemID,
+			TagID:       refs.tags[publicID],
+		}
+	})
+	if len(links) == 0 {
+		return nil
+	}
+	_, err := session.Insert(links)
+	return err
+}
+
+// recordItemPrices records the unit prices of the expense items bought at the shops if the
+// user compares the items in the same shop or in different shops.
+func recordItemPrices(session *xorm.Session, userID string, rows []*postgres.DailyItemsModel) error {
+	config, err := loadUserConfig(session, userID)
+	if err != nil {
+		return err
+	}
+
+	rows = lo.Filter(rows, func(item *postgres.DailyItemsModel, _ int) bool {
+		return item.ShopID.Valid && item.Type == repository.CategoryTypeExpense
+	})
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if config.CompareItemsInSameShop {
+		_, err := session.Insert(lo.Map(rows, func(item *postgres.DailyItemsModel, _ int) *postgres.ShopItemsModel {
+			return &postgres.ShopItemsModel{
+				DailyItemID:     item.ID,
+				UserID:          item.UserID,
+				ShopID:          item.ShopID.Int32,
+				Name:            item.Name,
+				Date:            item.Date,
+				UnitPrice:       item.Price,
+				Currency:        item.Currency,
+				NormalizedPrice: item.NormalizedPrice,
+				NormalizedUnit:  item.NormalizedUnit,
+			}
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	if config.CompareItemsInDifferentShop {
+		_, err := session.Insert(lo.Map(rows, func(item *postgres.DailyItemsModel, _ int) *postgres.AdvanceItemsModel {
+			return &postgres.AdvanceItemsModel{
+				DailyItemID:     item.ID,
+				UserID:          item.UserID,
+				Name:            item.Name,
+				Date:            item.Date,
+				ShopID:          item.ShopID.Int32,
+				UnitPrice:       item.Price,
+				Currency:        item.Currency,
+				NormalizedPrice: item.NormalizedPrice,
+				NormalizedUnit:  item.NormalizedUnit,
+			}
+		}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteItemPrices deletes the recorded unit prices of the daily items.
+func deleteItemPrices(session *xorm.Session, dailyItemIDs []any) error {
+	_, err := session.In("daily_item_id", dailyItemIDs).Delete(&postgres.ShopItemsModel{})
+	if err != nil {
+		return err
+	}
+	_, err = session.In("daily_item_id", dailyItemIDs).Delete(&postgres.AdvanceItemsModel{})
+	return err
+}
+
+// loadUserConfig returns the empty config if the user has no config.
+func loadUserConfig(session *xorm.Session, userID string) (*repository.UserConfig, error) {
+	user := postgres.UsersModel{
+		ID: userID,
+	}
+	has, err := session.Cols("config").Get(&user)
+	if err != nil {
+		return nil, err
+	}
+	if !has || user.Config == nil || user.Config.UserConfig == nil {
+		return &repository.UserConfig{}, nil
+	}
+	return user.Config.UserConfig, nil
+}
+
+// loadDailyItems fills the referenced public ids of the rows.
+func loadDailyItems(session *xorm.Session, rows []*postgres.DailyItemsModel) ([]*repository.DailyItem, error) {
+	var links []*postgres.DailyItemCategoriesModel
+	err := session.In("daily_item_id", lo.Map(rows, func(item *postgres.DailyItemsModel, _ int) any {
+		return item.ID
+	})).Find(&links)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryPublicIDs := map[int32]string{}
+	if len(links) > 0 {
+		var categories []*postgres.CategoriesModel
+		err = session.In("id", lo.Uniq(lo.Map(links, func(item *postgres.DailyItemCategoriesModel, _ int) int32 {
+			return item.CategoryID
+		}))).Find(&categories)
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			categoryPublicIDs[category.ID] = category.PublicID
+		}
+	}
+
+	var tagLinks []*postgres.DailyItemTagsModel
+	err = session.In("daily_item_id", lo.Map(rows, func(item *postgres.DailyItemsModel, _ int) any {
+		return item.ID
+	})).Find(&tagLinks)
+	if err != nil {
+		return nil, err
+	}
+
+	tagPublicIDs := map[int32]string{}
+	if len(tagLinks) > 0 {
+		var tags []*postgres.TagsModel
+		err = session.In("id", lo.Uniq(lo.Map(tagLinks, func(item *postgres.DailyItemTagsModel, _ int) int32 {
+			return item.TagID
+		}))).Find(&tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagPublicIDs[tag.ID] = tag.PublicID
+		}
+	}
+
+	accountPublicIDs := map[int32]string{}
+	if accountIDs := lo.Uniq(lo.FilterMap(rows, func(item *postgres.DailyItemsModel, _ int) (int32, bool) {
+		return item.AccountID.Int32, item.AccountID.Valid
+	})); len(accountIDs) > 0 {
+		var accounts []*postgres.AccountsModel
+		err = session.In("id", accountIDs).Find(&accounts)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range accounts {
+			accountPublicIDs[account.ID] = account.PublicID
+		}
+	}
+
+	feePublicIDs := map[int32]string{}
+	if feeIDs := lo.Uniq(lo.FilterMap(rows, func(item *postgres.DailyItemsModel, _ int) (int32, bool) {
+		return item.FeeID.Int32, item.FeeID.Valid
+	})); len(feeIDs) > 0 {
+		var fees []*postgres.FeesModel
+		err = session.In("id", feeIDs).Find(&fees)
+		if err != nil {
+			return nil, err
+		}
+		for _, fee := range fees {
+			feePublicIDs[fee.ID] = fee.PublicID
+		}
+	}
+
+	repeatingItemPublicIDs := map[int32]string{}
+	if repeatingItemIDs := lo.Uniq(lo.FilterMap(rows, func(item *postgres.DailyItemsModel, _ int) (int32, bool) {
+		return item.RepeatingItemID.Int32, item.RepeatingItemID.Valid
+	})); len(repeatingItemIDs) > 0 {
+		var repeatingItems []*postgres.RepeatingItemsModel
+		err = session.In("id", repeatingItemIDs).Find(&repeatingItems)
+		if err != nil {
+			return nil, err
+		}
+		for _, repeatingItem := range repeatingItems {
+			repeatingItemPublicIDs[repeatingItem.ID] = repeatingItem.PublicID
+		}
+	}
+
+	shopPublicIDs := map[int32]string{}
+	if shopIDs := lo.Uniq(lo.FilterMap(rows, func(item *postgres.DailyItemsModel, _ int) (int32, bool) {
+		return item.ShopID.Int32, item.ShopID.Valid
+	})); len(shopIDs) > 0 {
+		var shops []*postgres.ShopsModel
+		err = session.In("id", shopIDs).Find(&shops)
+		if err != nil {
+			return nil, err
+		}
+		for _, shop := range shops {
+			shopPublicIDs[shop.ID] = shop.PublicID
+		}
+	}
+
+	transferPublicIDs := map[int32]string{}
+	if transferIDs := lo.Uniq(lo.FilterMap(rows, func(item *postgres.DailyItemsModel, _ int) (int32, bool) {
+		return item.TransferID.Int32, item.TransferID.Valid
+	})); len(transferIDs) > 0 {
+		var transfers []*postgres.TransfersModel
+		err = session.In("id", transferIDs).Find(&transfers)
+		if err != nil {
+			return nil, err
+		}
+		for _, transfer := range transfers {
+			transferPublicIDs[transfer.ID] = transfer.PublicID
+		}
+	}
+
+	itemCategories := lo.GroupBy(links, func(item *postgres.DailyItemCategoriesModel) int32 {
+		return item.DailyItemID
+	})
+	itemTags := lo.GroupBy(tagLinks, func(item *postgres.DailyItemTagsModel) int32 {
+		return item.DailyItemID
+	})
+	return lo.Map(rows, func(item *postgres.DailyItemsModel, _ int) *repository.DailyItem {
+		base := toBaseDailyItem(item)
+		base.CategoryPublicIDs = lo.Map(itemCategories[item.ID], func(link *postgres.DailyItemCategoriesModel, _ int) string {
+			return categoryPublicIDs[link.CategoryID]
+		})
+		base.Splits = lo.FilterMap(itemCategories[item.ID], func(link *postgres.DailyItemCategoriesModel, _ int) (*repository.ItemSplit, bool) {
+			return &repository.ItemSplit{
+				CategoryPublicID: categoryPublicIDs[link.CategoryID],
+				Amount:           postgres.FromNullDecimal(link.Amount),
+				Percentage:       postgres.FromNullDecimal(link.Percentage),
+			}, link.Amount.Valid
+		})
+		base.TagPublicIDs = lo.Map(itemTags[item.ID], func(link *postgres.DailyItemTagsModel, _ int) string {
+			return tagPublicIDs[link.TagID]
+		})
+		base.ShopPublicID = lo.IfF(item.ShopID.Valid, func() *string {
+			return lo.ToPtr(shopPublicIDs[item.ShopID.Int32])
+		}).Else(nil)
+		base.AccountPublicID = lo.IfF(item.AccountID.Valid, func() *string {
+			return lo.ToPtr(accountPublicIDs[item.AccountID.Int32])
+		}).Else(nil)
+		base.RepeatingItemPublicID = lo.IfF(item.RepeatingItemID.Valid, func() *string {
+			return lo.ToPtr(repeatingItemPublicIDs[item.RepeatingItemID.Int32])
+		}).Else(nil)
+		base.FeePublicID = lo.IfF(item.FeeID.Valid, func() *string {
+			return lo.ToPtr(feePublicIDs[item.FeeID.Int32])
+		}).Else(nil)
+		return &repository.DailyItem{
+			ID:              item.ID,
+			PublicID:        item.PublicID,
+			Type:            item.Type,
+			NormalizedPrice: postgres.ToNormalizedPrice(item.NormalizedPrice, item.NormalizedUnit),
+			TransferPublicID: lo.IfF(item.TransferID.Valid, func() *string {
+				return lo.ToPtr(transferPublicIDs[item.TransferID.Int32])
+			}).Else(nil),
+			BaseDailyItem: base,
+		}
+	}), nil
+}
+
+// toBaseDailyItem converts the row without the referenced public ids.
+func toBaseDailyItem(item *postgres.DailyItemsModel) *repository.BaseDailyItem {
+	return &repository.BaseDailyItem{
+		Date: item.Date,
+		BaseItem: &repository.BaseItem{
+			Name:     item.Name,
+			Quantity: postgres.FromNullDecimal(item.Quantity),
+			Unit:     item.Unit,
+			Fee:      postgres.FromNullDecimal(item.Fee),
+			Price:    item.Price.Decimal,
+			Currency: item.Currency,
+			Memo:     item.Memo,
+		},
+	}
+}
+
+func toPostgresDailyItem(userID string, item *repository.BaseDailyItem, refs *references) (*postgres.DailyItemsModel, error) {
+	type_, err := refs.itemType(item)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := refs.itemCurrency(item)
+	if err != nil {
+		return nil, err
+	}
+	fee, feeID := refs.itemFee(item)
+	total := item.Subtotal()
+	if fee != nil {
+		total = total.Add(*fee)
+	}
+	normalizedPrice, normalizedUnit := postgres.FromNormalizedPrice(item.NormalizedPrice(refs.unitConversions))
+	return &postgres.DailyItemsModel{
+		UserID: userID,
+		Date:   item.Date,
+		Name:   item.Name,
+		Type:   type_,
+		ShopID: postgres.ToNullInt32(lo.IfF(item.ShopPublicID != nil, func() *int32 {
+			return lo.ToPtr(refs.shops[*item.ShopPublicID])
+		}).Else(nil)),
+		AccountID: postgres.ToNullInt32(lo.IfF(item.AccountPublicID != nil, func() *int32 {
+			return lo.ToPtr(refs.accounts[*item.AccountPublicID])
+		}).Else(nil)),
+		RepeatingItemID: postgres.ToNullInt32(lo.IfF(item.RepeatingItemPublicID != nil, func() *int32 {
+			return lo.ToPtr(refs.repeatingItems[*item.RepeatingItemPublicID])
+		}).Else(nil)),
+		Quantity:        postgres.ToNullDecimal(item.Quantity),
+		Unit:            item.Unit,
+		FeeID:           postgres.ToNullInt32(feeID),
+		Fee:             postgres.ToNullDecimal(fee),
+		Price:           decimal.NewNullDecimal(item.Price),
+		Currency:        currency,
+		Memo:            item.Memo,
+		Total:           decimal.NewNullDecimal(total),
+		NormalizedPrice: normalizedPrice,
+		NormalizedUnit:  normalizedUnit,
+	}, nil
+}
+
+// withComputedFee returns a copy of the item whose fee is replaced with the fee of the row,
+// and whose splits are replaced with the resolved splits if they are provided.
+func withComputedFee(item *repository.BaseDailyItem, row *postgres.DailyItemsModel, splits []*repository.ItemSplit) *repository.BaseDailyItem {
+	base := *item.BaseItem
+	base.Fee = postgres.FromNullDecimal(row.Fee)
+	if splits != nil {
+		base.Splits = splits
+	}
+	result := *item
+	result.BaseItem = &base
+	return &result
+}
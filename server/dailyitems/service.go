@@ -0,0 +1,150 @@
+package dailyitems
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+var (
+	ErrDataInsufficient     = fmt.Errorf("data insufficient")
+	ErrDailyItemNotFound    = fmt.Errorf("daily item not found")
+	ErrReferenceNotFound    = fmt.Errorf("referenced category, tag, shop, account or fee not found")
+	ErrCategoryTypeMismatch = fmt.Errorf("categories of the item are not the same type")
+	ErrCurrencyMismatch     = fmt.Errorf("currency of the item is not the currency of its account")
+	ErrInvalidSplit         = fmt.Errorf("splits must cover each category of the item once and sum to the item total")
+	ErrInvalidUnit          = fmt.Errorf("invalid unit of the quantity")
+	ErrInvalidRange         = fmt.Errorf("the end of the range is before the start")
+	ErrInvalidCursor        = fmt.Errorf("invalid cursor")
+	ErrTransferFeeItem      = fmt.Errorf("the fee item of a transfer must be changed by the transfer")
+)
+
+type Service interface {
+	// Create returns error:
+	//  - ErrDataInsufficient if any of required fields of CreateRequest is zero-value,
+	//  - ErrReferenceNotFound if any of referenced categories, tags, shop, account or fee does not exist,
+	//  - ErrCategoryTypeMismatch if the categories of the item are not the same type,
+	//  - ErrCurrencyMismatch if the currency of the item is not the currency of its account,
+	//  - ErrInvalidSplit if the splits are invalid, see repository.BaseItem.ResolveSplits,
+	//  - ErrInvalidUnit if the unit is not supported or it is provided without the quantity.
+	// The balance of the account of the item is debited for expense or credited for income.
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	// List returns ErrDataInsufficient if any of fields of ListRequest is zero-value,
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Update returns error:
+	//  - ErrDataInsufficient if any of required fields of UpdateRequest is zero-value,
+	//  - ErrDailyItemNotFound if the daily item does not exist,
+	//  - ErrReferenceNotFound if any of referenced categories, tags, shop, account or fee does not exist,
+	//  - ErrCategoryTypeMismatch if the categories of the item are not the same type,
+	//  - ErrCurrencyMismatch if the currency of the item is not the currency of its account,
+	//  - ErrInvalidSplit if the splits are invalid, see repository.BaseItem.ResolveSplits,
+	//  - ErrInvalidUnit if the unit is not supported or it is provided without the quantity,
+	//  - ErrTransferFeeItem if the daily item is the fee item of a transfer.
+	// The original item is reverted from its account before the new one is applied.
+	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
+	// Delete returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
+	//  - ErrDailyItemNotFound if the daily item does not exist,
+	//  - ErrTransferFeeItem if the daily item is the fee item of a transfer.
+	// The item is reverted from its account.
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+	// Search returns a page of the daily items whose name or memo contains the keyword and
+	// which satisfy all of the other filters, the items are sorted from the newest to the
+	// oldest. The next page is read by NextCursor of the reply. It returns error:
+	//  - ErrDataInsufficient if any of required fields of SearchRequest is zero-value,
+	//  - ErrInvalidRange if the end of the period or the amount range is before the start,
+	//  - ErrInvalidCursor if the cursor is not returned by Search.
+	Search(context.Context, *SearchRequest) (*SearchReply, error)
+}
+
+type BaseDailyItem struct {
+	Date time.Time
+	// RepeatingItemPublicID is the repeating item which the item is created from.
+	RepeatingItemPublicID *string
+	// TagPublicIDs are the tags of the item, the item may have no tag.
+	TagPublicIDs []string
+	*BaseItem
+}
+
+type BaseItem = repository.BaseItem
+
+type ItemSplit = repository.ItemSplit
+
+type Unit = repository.Unit
+
+type DailyItem struct {
+	ID       int32
+	PublicID string
+	// NormalizedPrice is the subtotal per the quantity of the base unit, such as NT$ per
+	// 100 g. It is nil if the item has no unit, see repository.BaseItem.NormalizedPrice.
+	NormalizedPrice *NormalizedPrice
+	*BaseDailyItem
+}
+
+type NormalizedPrice = repository.NormalizedPrice
+
+type CreateRequest struct {
+	UserID string
+	Item   *BaseDailyItem
+}
+
+type CreateReply struct {
+	Item *DailyItem
+}
+
+type ListRequest struct {
+	UserID string
+	Date   time.Time
+	// TagPublicID lists the items with the tag only if it is provided.
+	TagPublicID *string
+}
+
+type ListReply struct {
+	Items []*DailyItem
+}
+
+type UpdateRequest struct {
+	UserID            string
+	DailyItemPublicID string
+	Item              *BaseDailyItem
+}
+
+type UpdateReply struct {
+	Item *DailyItem
+}
+
+type DeleteRequest struct {
+	UserID            string
+	DailyItemPublicID string
+}
+
+type DeleteReply struct{}
+
+type SearchRequest struct {
+	UserID  string
+	Keyword string
+	// From is the first date of the period, inclusive.
+	From *time.Time
+	// To is the last date of the period, inclusive.
+	To               *time.Time
+	CategoryPublicID *string
+	ShopPublicID     *string
+	AccountPublicID  *string
+	TagPublicID      *string
+	// MinAmount and MaxAmount are the inclusive range of the amount, see BaseItem.Amount.
+	MinAmount *decimal.Decimal
+	MaxAmount *decimal.Decimal
+	// Cursor is NextCursor of the previous page, it is empty for the first page.
+	Cursor string
+	Limit  int
+}
+
+type SearchReply struct {
+	Items []*DailyItem
+	// NextCursor is empty if there is no more item.
+	NextCursor string
+}
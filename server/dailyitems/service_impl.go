@@ -0,0 +1,342 @@
+package dailyitems
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	repository repository.DailyItemRepository
+
+	opts *DailyItemServiceOptions
+}
+
+func NewService(
+	repository repository.DailyItemRepository,
+	opts ...utils.Option[DailyItemServiceOptions],
+) (Service, error) {
+	return &service{
+		repository: repository,
+		opts:       utils.ApplyOptions(defaultDailyItemServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if err := ValidateBaseDailyItem(r.Item); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repository.Create(ctx, &repository.CreateDailyItemsRequest{
+		UserID: r.UserID,
+		Items: []*repository.BaseCreateDailyItem{
+			parseBaseCreateDailyItem(r.Item, s.opts.genPublicID),
+		},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrReferenceNotFound) {
+			return nil, ErrReferenceNotFound
+		}
+		if errors.Is(err, repository.ErrInvalidReference) {
+			return nil, ErrCategoryTypeMismatch
+		}
+		if errors.Is(err, repository.ErrCurrencyMismatch) {
+			return nil, ErrCurrencyMismatch
+		}
+		if errors.Is(err, repository.ErrInvalidSplit) {
+			return nil, ErrInvalidSplit
+		}
+		return nil, err
+	}
+
+	return &CreateReply{
+		Item: parseDailyItem(rows[0]),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Date.IsZero() {
+		return nil, fmt.Errorf("%w: missing date", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListDailyItemsRequest{
+		UserID:      r.UserID,
+		Date:        lo.ToPtr(r.Date),
+		TagPublicID: r.TagPublicID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ListReply{
+				Items: []*DailyItem{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &ListReply{
+		Items: lo.Map(reply.Items, func(item *repository.DailyItem, _ int) *DailyItem {
+			return parseDailyItem(item)
+		}),
+	}, nil
+}
+
+func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.DailyItemPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+	if err := ValidateBaseDailyItem(r.Item); err != nil {
+		return nil, err
+	}
+
+	row, err := s.repository.Update(ctx, &repository.UpdateDailyItemRequest{
+		UserID:            r.UserID,
+		DailyItemPublicID: r.DailyItemPublicID,
+		Item:              parseBaseDailyItem(r.Item),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrDailyItemNotFound
+		}
+		if errors.Is(err, repository.ErrReferenceNotFound) {
+			return nil, ErrReferenceNotFound
+		}
+		if errors.Is(err, repository.ErrInvalidReference) {
+			return nil, ErrCategoryTypeMismatch
+		}
+		if errors.Is(err, repository.ErrCurrencyMismatch) {
+			return nil, ErrCurrencyMismatch
+		}
+		if errors.Is(err, repository.ErrInvalidSplit) {
+			return nil, ErrInvalidSplit
+		}
+		if errors.Is(err, repository.ErrDataManaged) {
+			return nil, ErrTransferFeeItem
+		}
+		return nil, err
+	}
+
+	return &UpdateReply{
+		Item: parseDailyItem(row),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.DailyItemPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.Delete(ctx, &repository.DeleteDailyItemsRequest{
+		UserID:             r.UserID,
+		DailyItemPublicIDs: []string{r.DailyItemPublicID},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrDailyItemNotFound
+		}
+		if errors.Is(err, repository.ErrDataManaged) {
+			return nil, ErrTransferFeeItem
+		}
+		return nil, err
+	}
+	return &DeleteReply{}, nil
+}
+
+func (s *service) Search(ctx context.Context, r *SearchRequest) (*SearchReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Limit <= 0 {
+		return nil, fmt.Errorf("%w: missing limit", ErrDataInsufficient)
+	}
+	if r.From != nil && r.To != nil && r.To.Before(*r.From) {
+		return nil, ErrInvalidRange
+	}
+	if r.MinAmount != nil && r.MaxAmount != nil && r.MaxAmount.LessThan(*r.MinAmount) {
+		return nil, ErrInvalidRange
+	}
+
+	var after *repository.DailyItemCursor
+	if r.Cursor != "" {
+		cursor, err := decodeCursor(r.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+	var to *time.Time
+	if r.To != nil {
+		to = lo.ToPtr(r.To.AddDate(0, 0, 1))
+	}
+
+	// one more item is read to know whether there is the next page.
+	reply, err := s.repository.Search(ctx, &repository.SearchDailyItemsRequest{
+		UserID:           r.UserID,
+		Keyword:          r.Keyword,
+		From:             r.From,
+		To:               to,
+		CategoryPublicID: r.CategoryPublicID,
+		ShopPublicID:     r.ShopPublicID,
+		AccountPublicID:  r.AccountPublicID,
+		TagPublicID:      r.TagPublicID,
+		MinAmount:        r.MinAmount,
+		MaxAmount:        r.MaxAmount,
+		After:            after,
+		Limit:            r.Limit + 1,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &SearchReply{
+				Items: []*DailyItem{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	items, nextCursor := reply.Items, ""
+	if len(items) > r.Limit {
+		items = items[:r.Limit]
+		last := items[len(items)-1]
+		nextCursor = encodeCursor(&repository.DailyItemCursor{
+			Date: last.Date,
+			ID:   last.ID,
+		})
+	}
+	return &SearchReply{
+		Items: lo.Map(items, func(item *repository.DailyItem, _ int) *DailyItem {
+			return parseDailyItem(item)
+		}),
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// encodeCursor encodes the cursor as an opaque string, the clients should not rely on
+// its format.
+func encodeCursor(v *repository.DailyItemCursor) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%s,%d", v.Date.Format(time.DateOnly), v.ID)),
+	)
+}
+
+func decodeCursor(s string) (*repository.DailyItemCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	date, id, ok := strings.Cut(string(data), ",")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	v := &repository.DailyItemCursor{}
+	if v.Date, err = time.Parse(time.DateOnly, date); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	v.ID = int32(n)
+	return v, nil
+}
+
+// ValidateBaseDailyItem checks the required fields, the unit and the splits of the item.
+// It is shared with the importer of the ledger.
+func ValidateBaseDailyItem(v *BaseDailyItem) error {
+	if v == nil {
+		return fmt.Errorf("%w: missing item", ErrDataInsufficient)
+	}
+	if v.Date.IsZero() {
+		return fmt.Errorf("%w: missing item.date", ErrDataInsufficient)
+	}
+	if v.BaseItem == nil {
+		return fmt.Errorf("%w: missing item", ErrDataInsufficient)
+	}
+	if len(v.CategoryPublicIDs) == 0 {
+		return fmt.Errorf("%w: missing item.categoryIds", ErrDataInsufficient)
+	}
+	if v.Unit != repository.UnitNone && (!v.Unit.Valid() || v.Quantity == nil) {
+		return ErrInvalidUnit
+	}
+	return validateSplits(v.BaseItem)
+}
+
+// validateSplits returns ErrInvalidSplit if the splits of the item are invalid. The splits
+// of the item which references a fee are validated by the repository since the fee is
+// computed there.
+func validateSplits(v *BaseItem) error {
+	if v.FeePublicID != nil {
+		return nil
+	}
+	if _, err := v.ResolveSplits(); err != nil {
+		if errors.Is(err, repository.ErrInvalidSplit) {
+			return ErrInvalidSplit
+		}
+		return err
+	}
+	return nil
+}
+
+func parseDailyItem(v *repository.DailyItem) *DailyItem {
+	return &DailyItem{
+		ID:              v.ID,
+		PublicID:        v.PublicID,
+		NormalizedPrice: v.NormalizedPrice,
+		BaseDailyItem:   lo.ToPtr(BaseDailyItem(*v.BaseDailyItem)),
+	}
+}
+
+func parseBaseDailyItem(v *BaseDailyItem) *repository.BaseDailyItem {
+	if v == nil {
+		return nil
+	}
+	return lo.ToPtr(repository.BaseDailyItem(*v))
+}
+
+func parseBaseCreateDailyItem(v *BaseDailyItem, genPublicID func() string) *repository.BaseCreateDailyItem {
+	if v == nil {
+		return nil
+	}
+	return &repository.BaseCreateDailyItem{
+		PublicID:      genPublicID(),
+		BaseDailyItem: parseBaseDailyItem(v),
+	}
+}
+
+type DailyItemServiceOptions struct {
+	genPublicID func() string
+}
+
+func defaultDailyItemServiceOptions() *DailyItemServiceOptions {
+	return &DailyItemServiceOptions{
+		genPublicID: func() string {
+			return slugid.New("itm", 11)
+		},
+	}
+}
+
+func WithDailyItemServiceGenPublicID(f func() string) utils.Option[DailyItemServiceOptions] {
+	return func(o *DailyItemServiceOptions) {
+		o.genPublicID = f
+	}
+}
@@ -0,0 +1,1000 @@
+package dailyitems
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Create(t *testing.T) {
+	t.Run("create successful", func(t *testing.T) {
+		const (
+			userID     = "user-id"
+			publicID   = "publicID"
+			itemName   = "A"
+			categoryID = "categoryID"
+			shopID     = "shopID"
+			accountID  = "accountID"
+			memo       = "memo"
+
+			returnedItemID = 9
+		)
+		var (
+			date     = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			quantity = decimal.NewFromInt(2)
+			price    = decimal.NewFromInt(50)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Create(gomock.Any(), &repository.CreateDailyItemsRequest{
+					UserID: userID,
+					Items: []*repository.BaseCreateDailyItem{
+						{
+							PublicID: publicID,
+							BaseDailyItem: &repository.BaseDailyItem{
+								Date: date,
+								BaseItem: &repository.BaseItem{
+									Name:              itemName,
+									CategoryPublicIDs: []string{categoryID},
+									ShopPublicID:      lo.ToPtr(shopID),
+									AccountPublicID:   lo.ToPtr(accountID),
+									Quantity:          lo.ToPtr(quantity),
+									Price:             price,
+									Memo:              memo,
+								},
+							},
+						},
+					},
+				}).
+				Return([]*repository.DailyItem{
+					{
+						ID:       returnedItemID,
+						PublicID: publicID,
+						BaseDailyItem: &repository.BaseDailyItem{
+							Date: date,
+							BaseItem: &repository.BaseItem{
+								Name:              itemName,
+								CategoryPublicIDs: []string{categoryID},
+								ShopPublicID:      lo.ToPtr(shopID),
+								AccountPublicID:   lo.ToPtr(accountID),
+								Quantity:          lo.ToPtr(quantity),
+								Price:             price,
+								Memo:              memo,
+							},
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, WithDailyItemServiceGenPublicID(func() string {
+			return publicID
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Item: &BaseDailyItem{
+				Date: date,
+				BaseItem: &BaseItem{
+					Name:              itemName,
+					CategoryPublicIDs: []string{categoryID},
+					ShopPublicID:      lo.ToPtr(shopID),
+					AccountPublicID:   lo.ToPtr(accountID),
+					Quantity:          lo.ToPtr(quantity),
+					Price:             price,
+					Memo:              memo,
+				},
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&CreateReply{
+			Item: &DailyItem{
+				ID:       returnedItemID,
+				PublicID: publicID,
+				BaseDailyItem: &BaseDailyItem{
+					Date: date,
+					BaseItem: &BaseItem{
+						Name:              itemName,
+						CategoryPublicIDs: []string{categoryID},
+						ShopPublicID:      lo.ToPtr(shopID),
+						AccountPublicID:   lo.ToPtr(accountID),
+						Quantity:          lo.ToPtr(quantity),
+						Price:             price,
+						Memo:              memo,
+					},
+				},
+			},
+		}, reply)
+	})
+	t.Run("missing categories", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseDailyItem{
+				Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseItem: &BaseItem{
+					Name:  "A",
+					Price: decimal.NewFromInt(1),
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrDataInsufficient)
+		assert.Nil(reply)
+	})
+	t.Run("reference not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrReferenceNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseDailyItem{
+				Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseItem: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					Price:             decimal.NewFromInt(1),
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrReferenceNotFound)
+		assert.Nil(reply)
+	})
+	t.Run("categories are not the same type", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrInvalidReference),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseDailyItem{
+				Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseItem: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"expenseCategoryID", "incomeCategoryID"},
+					Price:             decimal.NewFromInt(1),
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrCategoryTypeMismatch)
+		assert.Nil(reply)
+	})
+	t.Run("currency is not the currency of the account", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrCurrencyMismatch),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseDailyItem{
+				Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseItem: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					AccountPublicID:   lo.ToPtr("accountID"),
+					Price:             decimal.NewFromInt(1),
+					Currency:          "USD",
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrCurrencyMismatch)
+		assert.Nil(reply)
+	})
+	t.Run("invalid unit", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			quantity *decimal.Decimal
+			unit     Unit
+		}{
+			{name: "unsupported unit", quantity: lo.ToPtr(decimal.NewFromInt(1)), unit: "box"},
+			{name: "unit without the quantity", unit: repository.UnitGram},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				controller := gomock.NewController(t)
+				mockRepo := repository.NewMockDailyItemRepository(controller)
+
+				s, err := NewService(mockRepo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Create(context.Background(), &CreateRequest{
+					UserID: "user-id",
+					Item: &BaseDailyItem{
+						Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						BaseItem: &BaseItem{
+							Name:              "milk",
+							CategoryPublicIDs: []string{"categoryID"},
+							Quantity:          tt.quantity,
+							Unit:              tt.unit,
+							Price:             decimal.NewFromInt(90),
+						},
+					},
+				})
+				assert.ErrorIs(err, ErrInvalidUnit)
+				assert.Nil(reply)
+			})
+		}
+	})
+	t.Run("create with splits", func(t *testing.T) {
+		assert := assert.New(t)
+
+		item := &BaseDailyItem{
+			Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			BaseItem: &BaseItem{
+				Name:              "receipt",
+				CategoryPublicIDs: []string{"groceriesID", "householdID"},
+				Splits: []*ItemSplit{
+					{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(70))},
+					{CategoryPublicID: "householdID", Percentage: lo.ToPtr(decimal.NewFromInt(30))},
+				},
+				Price: decimal.NewFromInt(100),
+			},
+		}
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), &repository.CreateDailyItemsRequest{
+				UserID: "user-id",
+				Items: []*repository.BaseCreateDailyItem{{
+					PublicID:      "publicID",
+					BaseDailyItem: lo.ToPtr(repository.BaseDailyItem(*item)),
+				}},
+			}).Return([]*repository.DailyItem{{
+				ID:            1,
+				PublicID:      "publicID",
+				Type:          repository.CategoryTypeExpense,
+				BaseDailyItem: lo.ToPtr(repository.BaseDailyItem(*item)),
+			}}, nil),
+		)
+
+		s, err := NewService(mockRepo, WithDailyItemServiceGenPublicID(func() string {
+			return "publicID"
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item:   item,
+		})
+		assert.NoError(err)
+		assert.Equal(item.Splits, reply.Item.Splits)
+	})
+	t.Run("invalid splits", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			splits []*ItemSplit
+		}{
+			{
+				name: "amounts do not sum to the total",
+				splits: []*ItemSplit{
+					{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(70))},
+					{CategoryPublicID: "householdID", Amount: lo.ToPtr(decimal.NewFromInt(20))},
+				},
+			},
+			{
+				name: "percentages do not sum to 100",
+				splits: []*ItemSplit{
+					{CategoryPublicID: "groceriesID", Percentage: lo.ToPtr(decimal.NewFromInt(70))},
+					{CategoryPublicID: "householdID", Percentage: lo.ToPtr(decimal.NewFromInt(20))},
+				},
+			},
+			{
+				name: "missing the split of the category",
+				splits: []*ItemSplit{
+					{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(100))},
+				},
+			},
+			{
+				name: "duplicate categories",
+				splits: []*ItemSplit{
+					{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(50))},
+					{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(50))},
+				},
+			},
+			{
+				name: "missing the amount",
+				splits: []*ItemSplit{
+					{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(100))},
+					{CategoryPublicID: "householdID"},
+				},
+			},
+			{
+				name: "negative amount",
+				splits: []*ItemSplit{
+					{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(110))},
+					{CategoryPublicID: "householdID", Amount: lo.ToPtr(decimal.NewFromInt(-10))},
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				controller := gomock.NewController(t)
+				mockRepo := repository.NewMockDailyItemRepository(controller)
+
+				s, err := NewService(mockRepo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Create(context.Background(), &CreateRequest{
+					UserID: "user-id",
+					Item: &BaseDailyItem{
+						Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+						BaseItem: &BaseItem{
+							Name:              "receipt",
+							CategoryPublicIDs: []string{"groceriesID", "householdID"},
+							Splits:            tt.splits,
+							Price:             decimal.NewFromInt(100),
+						},
+					},
+				})
+				assert.ErrorIs(err, ErrInvalidSplit)
+				assert.Nil(reply)
+			})
+		}
+	})
+	t.Run("splits do not sum to the total with the computed fee", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrInvalidSplit),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseDailyItem{
+				Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseItem: &BaseItem{
+					Name:              "receipt",
+					CategoryPublicIDs: []string{"groceriesID", "householdID"},
+					Splits: []*ItemSplit{
+						{CategoryPublicID: "groceriesID", Amount: lo.ToPtr(decimal.NewFromInt(70))},
+						{CategoryPublicID: "householdID", Amount: lo.ToPtr(decimal.NewFromInt(30))},
+					},
+					FeePublicID: lo.ToPtr("feeID"),
+					Price:       decimal.NewFromInt(100),
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidSplit)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_List(t *testing.T) {
+	t.Run("list some of daily items", func(t *testing.T) {
+		const (
+			userID = "user-id"
+
+			itemID0    = 1
+			publicID0  = "publicID0"
+			itemName0  = "A"
+			categoryID = "categoryID"
+
+			itemID1   = 2
+			publicID1 = "publicID1"
+			itemName1 = "B"
+		)
+		var (
+			date   = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			price0 = decimal.NewFromInt(10)
+			price1 = decimal.NewFromInt(20)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListDailyItemsRequest{
+					UserID: userID,
+					Date:   lo.ToPtr(date),
+				}).
+				Return(&repository.ListDailyItemsReply{
+					Items: []*repository.DailyItem{
+						{
+							ID:       itemID0,
+							PublicID: publicID0,
+							BaseDailyItem: &repository.BaseDailyItem{
+								Date: date,
+								BaseItem: &repository.BaseItem{
+									Name:              itemName0,
+									CategoryPublicIDs: []string{categoryID},
+									Price:             price0,
+								},
+							},
+						},
+						{
+							ID:       itemID1,
+							PublicID: publicID1,
+							BaseDailyItem: &repository.BaseDailyItem{
+								Date: date,
+								BaseItem: &repository.BaseItem{
+									Name:              itemName1,
+									CategoryPublicIDs: []string{categoryID},
+									Price:             price1,
+								},
+							},
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: userID,
+			Date:   date,
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Items: []*DailyItem{
+				{
+					ID:       itemID0,
+					PublicID: publicID0,
+					BaseDailyItem: &BaseDailyItem{
+						Date: date,
+						BaseItem: &BaseItem{
+							Name:              itemName0,
+							CategoryPublicIDs: []string{categoryID},
+							Price:             price0,
+						},
+					},
+				},
+				{
+					ID:       itemID1,
+					PublicID: publicID1,
+					BaseDailyItem: &BaseDailyItem{
+						Date: date,
+						BaseItem: &BaseItem{
+							Name:              itemName1,
+							CategoryPublicIDs: []string{categoryID},
+							Price:             price1,
+						},
+					},
+				},
+			},
+		}, reply)
+	})
+	t.Run("no daily item", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: "user-id",
+			Date:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Items: []*DailyItem{},
+		}, reply)
+	})
+	t.Run("list daily items with the tag", func(t *testing.T) {
+		const (
+			userID = "user-id"
+			tagID  = "tagID"
+		)
+		date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListDailyItemsRequest{
+					UserID:      userID,
+					Date:        lo.ToPtr(date),
+					TagPublicID: lo.ToPtr(tagID),
+				}).
+				Return(&repository.ListDailyItemsReply{
+					Items: []*repository.DailyItem{
+						{
+							ID:       1,
+							PublicID: "publicID",
+							BaseDailyItem: &repository.BaseDailyItem{
+								Date:         date,
+								TagPublicIDs: []string{tagID},
+								BaseItem: &repository.BaseItem{
+									Name:              "A",
+									CategoryPublicIDs: []string{"categoryID"},
+									Price:             decimal.NewFromInt(10),
+								},
+							},
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID:      userID,
+			Date:        date,
+			TagPublicID: lo.ToPtr(tagID),
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Items: []*DailyItem{
+				{
+					ID:       1,
+					PublicID: "publicID",
+					BaseDailyItem: &BaseDailyItem{
+						Date:         date,
+						TagPublicIDs: []string{tagID},
+						BaseItem: &BaseItem{
+							Name:              "A",
+							CategoryPublicIDs: []string{"categoryID"},
+							Price:             decimal.NewFromInt(10),
+						},
+					},
+				},
+			},
+		}, reply)
+	})
+}
+
+func Test_service_Update(t *testing.T) {
+	t.Run("update successful", func(t *testing.T) {
+		const (
+			userID     = "user-id"
+			itemID     = 1
+			publicID   = "publicID"
+			itemName   = "A"
+			categoryID = "categoryID"
+		)
+		var (
+			date  = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			price = decimal.NewFromInt(10)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Update(gomock.Any(), &repository.UpdateDailyItemRequest{
+					UserID:            userID,
+					DailyItemPublicID: publicID,
+					Item: &repository.BaseDailyItem{
+						Date: date,
+						BaseItem: &repository.BaseItem{
+							Name:              itemName,
+							CategoryPublicIDs: []string{categoryID},
+							Price:             price,
+						},
+					},
+				}).
+				Return(&repository.DailyItem{
+					ID:       itemID,
+					PublicID: publicID,
+					BaseDailyItem: &repository.BaseDailyItem{
+						Date: date,
+						BaseItem: &repository.BaseItem{
+							Name:              itemName,
+							CategoryPublicIDs: []string{categoryID},
+							Price:             price,
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:            userID,
+			DailyItemPublicID: publicID,
+			Item: &BaseDailyItem{
+				Date: date,
+				BaseItem: &BaseItem{
+					Name:              itemName,
+					CategoryPublicIDs: []string{categoryID},
+					Price:             price,
+				},
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&UpdateReply{
+			Item: &DailyItem{
+				ID:       itemID,
+				PublicID: publicID,
+				BaseDailyItem: &BaseDailyItem{
+					Date: date,
+					BaseItem: &BaseItem{
+						Name:              itemName,
+						CategoryPublicIDs: []string{categoryID},
+						Price:             price,
+					},
+				},
+			},
+		}, reply)
+	})
+	t.Run("daily item not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:            "user-id",
+			DailyItemPublicID: "1",
+			Item: &BaseDailyItem{
+				Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseItem: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					Price:             decimal.NewFromInt(1),
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrDailyItemNotFound)
+		assert.Nil(reply)
+	})
+	t.Run("fee item of transfer", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataManaged),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:            "user-id",
+			DailyItemPublicID: "1",
+			Item: &BaseDailyItem{
+				Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseItem: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					Price:             decimal.NewFromInt(1),
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrTransferFeeItem)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Delete(t *testing.T) {
+	t.Run("delete successful", func(t *testing.T) {
+		const (
+			userID   = "user-id"
+			publicID = "publicID"
+
+			itemID     = 1
+			itemName   = "A"
+			categoryID = "categoryID"
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Delete(gomock.Any(), &repository.DeleteDailyItemsRequest{
+					UserID:             userID,
+					DailyItemPublicIDs: []string{publicID},
+				}).
+				Return([]*repository.DailyItem{
+					{
+						ID:       itemID,
+						PublicID: publicID,
+						BaseDailyItem: &repository.BaseDailyItem{
+							Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+							BaseItem: &repository.BaseItem{
+								Name:              itemName,
+								CategoryPublicIDs: []string{categoryID},
+								Price:             decimal.NewFromInt(1),
+							},
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:            userID,
+			DailyItemPublicID: publicID,
+		})
+		assert.NoError(err)
+		assert.Equal(&DeleteReply{}, reply)
+	})
+	t.Run("daily item not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:            "userID",
+			DailyItemPublicID: "1",
+		})
+		assert.ErrorIs(err, ErrDailyItemNotFound)
+		assert.Nil(reply)
+	})
+	t.Run("fee item of transfer", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataManaged),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:            "userID",
+			DailyItemPublicID: "1",
+		})
+		assert.ErrorIs(err, ErrTransferFeeItem)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Search(t *testing.T) {
+	const (
+		userID     = "user-id"
+		keyword    = "coffee"
+		categoryID = "categoryID"
+	)
+	var (
+		jan1 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		jan2 = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	)
+	newItem := func(id int32, date time.Time) *repository.DailyItem {
+		return &repository.DailyItem{
+			ID:       id,
+			PublicID: fmt.Sprintf("publicID%d", id),
+			BaseDailyItem: &repository.BaseDailyItem{
+				Date: date,
+				BaseItem: &repository.BaseItem{
+					Name:              keyword,
+					CategoryPublicIDs: []string{categoryID},
+					Price:             decimal.NewFromInt(10),
+				},
+			},
+		}
+	}
+
+	t.Run("read pages", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Search(gomock.Any(), &repository.SearchDailyItemsRequest{
+					UserID:           userID,
+					Keyword:          keyword,
+					From:             lo.ToPtr(jan1),
+					To:               lo.ToPtr(jan2.AddDate(0, 0, 1)),
+					CategoryPublicID: lo.ToPtr(categoryID),
+					Limit:            3,
+				}).
+				Return(&repository.SearchDailyItemsReply{
+					Items: []*repository.DailyItem{newItem(3, jan2), newItem(2, jan1), newItem(1, jan1)},
+				}, nil),
+			mockRepo.EXPECT().
+				Search(gomock.Any(), &repository.SearchDailyItemsRequest{
+					UserID:           userID,
+					Keyword:          keyword,
+					From:             lo.ToPtr(jan1),
+					To:               lo.ToPtr(jan2.AddDate(0, 0, 1)),
+					CategoryPublicID: lo.ToPtr(categoryID),
+					After:            &repository.DailyItemCursor{Date: jan1, ID: 2},
+					Limit:            3,
+				}).
+				Return(&repository.SearchDailyItemsReply{
+					Items: []*repository.DailyItem{newItem(1, jan1)},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := &SearchRequest{
+			UserID:           userID,
+			Keyword:          keyword,
+			From:             lo.ToPtr(jan1),
+			To:               lo.ToPtr(jan2),
+			CategoryPublicID: lo.ToPtr(categoryID),
+			Limit:            2,
+		}
+		reply, err := s.Search(context.Background(), r)
+		assert.NoError(err)
+		assert.Equal([]*DailyItem{
+			parseDailyItem(newItem(3, jan2)),
+			parseDailyItem(newItem(2, jan1)),
+		}, reply.Items)
+		assert.NotEmpty(reply.NextCursor)
+
+		r.Cursor = reply.NextCursor
+		reply, err = s.Search(context.Background(), r)
+		assert.NoError(err)
+		assert.Equal(&SearchReply{
+			Items: []*DailyItem{parseDailyItem(newItem(1, jan1))},
+		}, reply)
+	})
+	t.Run("no daily item", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Search(context.Background(), &SearchRequest{
+			UserID:  userID,
+			Keyword: keyword,
+			Limit:   10,
+		})
+		assert.NoError(err)
+		assert.Equal(&SearchReply{
+			Items: []*DailyItem{},
+		}, reply)
+	})
+	t.Run("invalid cursor", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Search(context.Background(), &SearchRequest{
+			UserID: userID,
+			Cursor: "invalid",
+			Limit:  10,
+		})
+		assert.ErrorIs(err, ErrInvalidCursor)
+		assert.Nil(reply)
+	})
+	t.Run("invalid amount range", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Search(context.Background(), &SearchRequest{
+			UserID:    userID,
+			MinAmount: lo.ToPtr(decimal.NewFromInt(10)),
+			MaxAmount: lo.ToPtr(decimal.NewFromInt(5)),
+			Limit:     10,
+		})
+		assert.ErrorIs(err, ErrInvalidRange)
+		assert.Nil(reply)
+	})
+}
@@ -0,0 +1,194 @@
+package exchangerates
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	s Service
+	*irisController.SimpleCreateTemplate[models.BasicExchangeRate, CreateRequest, CreateReply, models.ObjectId]
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.ExchangeRate]
+	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+
+	Convert *irisController.SimpleGetTemplate[ConvertRequest, ConvertReply, models.ConvertCurrencyResponse]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		s: s,
+		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicExchangeRate, CreateRequest, CreateReply, models.ObjectId]{
+			Service: s,
+			ParseServiceRequest: func(userID string, r *models.BasicExchangeRate) (*CreateRequest, error) {
+				rate, err := decimal.NewFromString(r.Rate)
+				if err != nil {
+					return nil, fmt.Errorf("invalid decimal[%s]", r.Rate)
+				}
+				return &CreateRequest{
+					UserID: userID,
+					Rate: &BaseExchangeRate{
+						Date:          r.Date.Time,
+						BaseCurrency:  r.BaseCurrency,
+						QuoteCurrency: r.QuoteCurrency,
+						Rate:          rate,
+					},
+				}, nil
+			},
+			BadRequest: badRequest,
+			ParseAPIResponse: func(reply *CreateReply) (*models.ObjectId, error) {
+				return &models.ObjectId{
+					Id: lo.ToPtr(models.Id(reply.Rate.PublicID)),
+				}, nil
+			},
+		},
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.ExchangeRate]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				return &ListRequest{
+					UserID:        userID,
+					BaseCurrency:  lo.EmptyableToPtr(strings.ToUpper(c.URLParam("baseCurrency"))),
+					QuoteCurrency: lo.EmptyableToPtr(strings.ToUpper(c.URLParam("quoteCurrency"))),
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.ExchangeRate, error) {
+				return lo.ToPtr(lo.Map(reply.Rates, func(item *ExchangeRate, _ int) *models.ExchangeRate {
+					return &models.ExchangeRate{
+						Id:            lo.ToPtr(models.Id(item.PublicID)),
+						Date:          openapi_types.Date{Time: item.Date},
+						BaseCurrency:  item.BaseCurrency,
+						QuoteCurrency: item.QuoteCurrency,
+						Rate:          item.Rate.String(),
+					}
+				})), nil
+			},
+		},
+		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+			Placeholder: "exchangeRateId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+				return &DeleteRequest{
+					UserID:               userID,
+					ExchangeRatePublicID: publicID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrExchangeRateNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		Convert: &irisController.SimpleGetTemplate[ConvertRequest, ConvertReply, models.ConvertCurrencyResponse]{
+			Handle: s.Convert,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ConvertRequest, error) {
+				amount, err := decimal.NewFromString(c.URLParam("amount"))
+				if err != nil {
+					return nil, fmt.Errorf("invalid decimal[%s]", c.URLParam("amount"))
+				}
+				date, err := parseDate(c.URLParamDefault("date", time.Now().Format(time.DateOnly)))
+				if err != nil {
+					return nil, err
+				}
+				return &ConvertRequest{
+					UserID:       userID,
+					Amount:       amount,
+					FromCurrency: c.URLParam("from"),
+					ToCurrency:   c.URLParam("to"),
+					Date:         date,
+				}, nil
+			},
+			BadRequest: BadConversion,
+			ParseAPIResponse: func(reply *ConvertReply) (*models.ConvertCurrencyResponse, error) {
+				return &models.ConvertCurrencyResponse{
+					Amount: reply.Amount.String(),
+					Rate:   reply.Rate.String(),
+				}, nil
+			},
+		},
+	}
+}
+
+func (controller *IrisController) Import(c iris.Context) {
+	user := c.User()
+	if user == nil {
+		c.StopWithJSON(iris.StatusUnauthorized, &models.EmptyResponse{})
+		return
+	}
+
+	userID, err := user.GetID()
+	if err != nil {
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	reply, err := controller.s.Import(c.Request().Context(), &ImportRequest{
+		UserID: userID,
+		CSV:    c.Request().Body,
+	})
+	if err != nil {
+		if code, y := badRequest(err); y {
+			c.StopWithText(code, err.Error())
+			return
+		}
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	c.StopWithJSON(iris.StatusOK, &models.ImportExchangeRatesResponse{
+		ImportedCount: reply.ImportedCount,
+	})
+}
+
+// BadConversion checks if the error returned from Converter is http bad request or not.
+func BadConversion(err error) (httpCode int, yes bool) {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		return iris.StatusNotFound, true
+	case errors.Is(err, ErrDataInsufficient),
+		errors.Is(err, ErrInvalidCurrency),
+		errors.Is(err, ErrHomeCurrencyNotSet),
+		errors.Is(err, ErrExchangeRateNotFound):
+		return iris.StatusBadRequest, true
+	}
+	return 0, false
+}
+
+func parseDate(s string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date[%s]", s)
+	}
+	return date, nil
+}
+
+func badRequest(err error) (httpCode int, yes bool) {
+	switch {
+	case errors.Is(err, ErrDataInsufficient),
+		errors.Is(err, ErrInvalidCurrency),
+		errors.Is(err, ErrInvalidRate),
+		errors.Is(err, ErrSameCurrency),
+		errors.Is(err, ErrInvalidCSV):
+		return iris.StatusBadRequest, true
+	}
+	return 0, false
+}
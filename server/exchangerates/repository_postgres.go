@@ -0,0 +1,175 @@
+package exchangerates
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.ExchangeRateRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Upsert(ctx context.Context, r *repository.UpsertExchangeRatesRequest) ([]*repository.ExchangeRate, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	result, err := UpsertWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpsertWithSession is the same as Upsert, but it upserts the exchange rates with the
+// session so that they can be upserted in the transaction of the session.
+func UpsertWithSession(session *xorm.Session, r *repository.UpsertExchangeRatesRequest) ([]*repository.ExchangeRate, error) {
+	result := make([]*repository.ExchangeRate, len(r.Rates))
+	for i, rate := range r.Rates {
+		row := postgres.ExchangeRatesModel{
+			UserID:        r.UserID,
+			BaseCurrency:  rate.BaseCurrency,
+			QuoteCurrency: rate.QuoteCurrency,
+			Date:          rate.Date,
+		}
+		has, err := session.Get(&row)
+		if err != nil {
+			return nil, err
+		}
+
+		row.Rate = decimal.NewNullDecimal(rate.Rate)
+		if has {
+			_, err = session.Cols("rate").Update(&row, &postgres.ExchangeRatesModel{
+				ID: row.ID,
+			})
+		} else {
+			row.PublicID = rate.PublicID
+			_, err = session.Insert(&row)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = toExchangeRate(&row)
+	}
+	return result, nil
+}
+
+func (repo *postgresRepository) List(ctx context.Context, r *repository.ListExchangeRatesRequest) (*repository.ListExchangeRatesReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var rows []*postgres.ExchangeRatesModel
+	err := session.Asc("date", "id").Find(&rows, &postgres.ExchangeRatesModel{
+		PublicID:      lo.FromPtr(r.ExchangeRatePublicID),
+		UserID:        r.UserID,
+		BaseCurrency:  lo.FromPtr(r.BaseCurrency),
+		QuoteCurrency: lo.FromPtr(r.QuoteCurrency),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+	return &repository.ListExchangeRatesReply{
+		Rates: lo.Map(rows, func(item *postgres.ExchangeRatesModel, _ int) *repository.ExchangeRate {
+			return toExchangeRate(item)
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) GetLatest(ctx context.Context, r *repository.GetLatestExchangeRateRequest) (*repository.ExchangeRate, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var row postgres.ExchangeRatesModel
+	has, err := session.
+		Where("user_id = ?", r.UserID).
+		And("base_currency = ?", r.BaseCurrency).
+		And("quote_currency = ?", r.QuoteCurrency).
+		And("date <= ?", r.Date.Format(time.DateOnly)).
+		Desc("date").
+		Get(&row)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrDataNotFound
+	}
+	return toExchangeRate(&row), nil
+}
+
+func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteExchangeRatesRequest) ([]*repository.ExchangeRate, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	session.Where("user_id = ?", r.UserID)
+	if len(r.ExchangeRatePublicIDs) > 0 {
+		session.In("public_id", r.ExchangeRatePublicIDs)
+	}
+
+	var rows []*postgres.ExchangeRatesModel
+	err := session.Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.ExchangeRatePublicIDs) > 0 && len(rows) != len(r.ExchangeRatePublicIDs) {
+		return nil, repository.ErrDataNotFound
+	}
+	if len(r.ExchangeRatePublicIDs) == 0 && len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	_, err = session.In("id", lo.Map(rows, func(item *postgres.ExchangeRatesModel, _ int) any {
+		return item.ID
+	})).Delete(&postgres.ExchangeRatesModel{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return lo.Map(rows, func(item *postgres.ExchangeRatesModel, _ int) *repository.ExchangeRate {
+		return toExchangeRate(item)
+	}), nil
+}
+
+func toExchangeRate(v *postgres.ExchangeRatesModel) *repository.ExchangeRate {
+	return &repository.ExchangeRate{
+		ID:       v.ID,
+		PublicID: v.PublicID,
+		BaseExchangeRate: &repository.BaseExchangeRate{
+			Date:          v.Date,
+			BaseCurrency:  v.BaseCurrency,
+			QuoteCurrency: v.QuoteCurrency,
+			Rate:          v.Rate.Decimal,
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package exchangerates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrDataInsufficient     = fmt.Errorf("data insufficient")
+	ErrExchangeRateNotFound = fmt.Errorf("exchange rate not found")
+	ErrInvalidCurrency      = fmt.Errorf("invalid currency")
+	ErrInvalidRate          = fmt.Errorf("rate must be positive")
+	ErrSameCurrency         = fmt.Errorf("base and quote currencies are the same")
+	ErrInvalidCSV           = fmt.Errorf("invalid csv")
+	ErrHomeCurrencyNotSet   = fmt.Errorf("home currency is not set")
+	ErrUserNotFound         = fmt.Errorf("user not found")
+)
+
+type Service interface {
+	// Create returns error:
+	//  - ErrDataInsufficient if any of required fields of CreateRequest is zero-value,
+	//  - ErrInvalidCurrency if any of the currencies is not an ISO-4217 code,
+	//  - ErrSameCurrency if the base and the quote currencies are the same,
+	//  - ErrInvalidRate if the rate is not positive.
+	// The rate of the existing exchange rate of the same currencies and date is replaced.
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	// List returns ErrDataInsufficient if any of required fields of ListRequest is zero-value,
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Delete returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
+	//  - ErrExchangeRateNotFound if the exchange rate does not exist.
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+	// Import creates the exchange rates from CSV like Create. It returns ErrInvalidCSV
+	// or any error of Create with the line number if any of the lines is invalid, and
+	// none of the exchange rates is imported.
+	Import(context.Context, *ImportRequest) (*ImportReply, error)
+	Converter
+}
+
+// Converter converts the amount between currencies by the exchange rates of the user.
+type Converter interface {
+	// Convert converts the amount with the latest exchange rate on or before the date,
+	// the inverse of the opposite exchange rate is used if there is no such exchange rate.
+	// The empty currency is the home currency of the user. It returns error:
+	//  - ErrDataInsufficient if any of required fields of ConvertRequest is zero-value,
+	//  - ErrInvalidCurrency if any of the currencies is not an ISO-4217 code,
+	//  - ErrUserNotFound if the user does not exist,
+	//  - ErrHomeCurrencyNotSet if any of the currencies is empty and the user has not set it,
+	//  - ErrExchangeRateNotFound if there is no exchange rate of the currencies.
+	Convert(context.Context, *ConvertRequest) (*ConvertReply, error)
+}
+
+// BaseExchangeRate means 1 unit of BaseCurrency is exchanged for Rate units of
+// QuoteCurrency on the Date.
+type BaseExchangeRate struct {
+	Date          time.Time
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          decimal.Decimal
+}
+
+type ExchangeRate struct {
+	ID       int32
+	PublicID string
+	*BaseExchangeRate
+}
+
+type CreateRequest struct {
+	UserID string
+	Rate   *BaseExchangeRate
+}
+
+type CreateReply struct {
+	Rate *ExchangeRate
+}
+
+type ListRequest struct {
+	UserID        string
+	BaseCurrency  *string
+	QuoteCurrency *string
+}
+
+type ListReply struct {
+	Rates []*ExchangeRate
+}
+
+type DeleteRequest struct {
+	UserID               string
+	ExchangeRatePublicID string
+}
+
+type DeleteReply struct{}
+
+type ImportRequest struct {
+	UserID string
+	// CSV is the content of which each line is "date,baseCurrency,quoteCurrency,rate",
+	// the first line is skipped if it is the header.
+	CSV io.Reader
+}
+
+type ImportReply struct {
+	// ImportedCount is the number of the imported exchange rates.
+	ImportedCount int
+}
+
+type ConvertRequest struct {
+	UserID       string
+	Amount       decimal.Decimal
+	FromCurrency string
+	ToCurrency   string
+	Date         time.Time
+}
+
+type ConvertReply struct {
+	Amount decimal.Decimal
+	// Rate is the rate from FromCurrency to ToCurrency.
+	Rate decimal.Decimal
+}
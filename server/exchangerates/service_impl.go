@@ -0,0 +1,329 @@
+package exchangerates
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/pkg/currency"
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/repository"
+)
+
+// csvFields is the number of the fields of each line of the imported CSV.
+const csvFields = 4
+
+type service struct {
+	repository     repository.ExchangeRateRepository
+	userRepository repository.UserRepository
+
+	opts *ExchangeRateServiceOptions
+}
+
+func NewService(
+	repository repository.ExchangeRateRepository,
+	userRepository repository.UserRepository,
+	opts ...utils.Option[ExchangeRateServiceOptions],
+) (Service, error) {
+	return &service{
+		repository:     repository,
+		userRepository: userRepository,
+		opts:           utils.ApplyOptions(defaultExchangeRateServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	rate, err := parseBaseExchangeRate(r.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repository.Upsert(ctx, &repository.UpsertExchangeRatesRequest{
+		UserID: r.UserID,
+		Rates: []*repository.BaseCreateExchangeRate{
+			{
+				PublicID:         s.opts.genPublicID(),
+				BaseExchangeRate: rate,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateReply{
+		Rate: parseExchangeRate(rows[0]),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListExchangeRatesRequest{
+		UserID:        r.UserID,
+		BaseCurrency:  r.BaseCurrency,
+		QuoteCurrency: r.QuoteCurrency,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ListReply{
+				Rates: []*ExchangeRate{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &ListReply{
+		Rates: lo.Map(reply.Rates, func(item *repository.ExchangeRate, _ int) *ExchangeRate {
+			return parseExchangeRate(item)
+		}),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.ExchangeRatePublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.Delete(ctx, &repository.DeleteExchangeRatesRequest{
+		UserID:                r.UserID,
+		ExchangeRatePublicIDs: []string{r.ExchangeRatePublicID},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrExchangeRateNotFound
+		}
+		return nil, err
+	}
+	return &DeleteReply{}, nil
+}
+
+func (s *service) Import(ctx context.Context, r *ImportRequest) (*ImportReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.CSV == nil {
+		return nil, fmt.Errorf("%w: missing csv", ErrDataInsufficient)
+	}
+
+	reader := csv.NewReader(r.CSV)
+	reader.FieldsPerRecord = csvFields
+	reader.TrimLeadingSpace = true
+
+	var rates []*repository.BaseCreateExchangeRate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+		}
+		if line == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+
+		rate, err := parseCSVRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, &repository.BaseCreateExchangeRate{
+			PublicID:         s.opts.genPublicID(),
+			BaseExchangeRate: rate,
+		})
+	}
+	if len(rates) == 0 {
+		return &ImportReply{}, nil
+	}
+
+	rows, err := s.repository.Upsert(ctx, &repository.UpsertExchangeRatesRequest{
+		UserID: r.UserID,
+		Rates:  rates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ImportReply{
+		ImportedCount: len(rows),
+	}, nil
+}
+
+func (s *service) Convert(ctx context.Context, r *ConvertRequest) (*ConvertReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Date.IsZero() {
+		return nil, fmt.Errorf("%w: missing date", ErrDataInsufficient)
+	}
+
+	from, err := currency.Normalize(r.FromCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+	}
+	to, err := currency.Normalize(r.ToCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+	}
+	if from == "" || to == "" {
+		homeCurrency, err := s.homeCurrency(ctx, r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		from = lo.CoalesceOrEmpty(from, homeCurrency)
+		to = lo.CoalesceOrEmpty(to, homeCurrency)
+	}
+
+	rate, err := s.rate(ctx, r.UserID, from, to, r.Date)
+	if err != nil {
+		return nil, err
+	}
+	return &ConvertReply{
+		Amount: r.Amount.Mul(rate).Round(6),
+		Rate:   rate,
+	}, nil
+}
+
+// rate returns the rate from the currency to another currency on the date.
+func (s *service) rate(ctx context.Context, userID, from, to string, date time.Time) (decimal.Decimal, error) {
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+
+	row, err := s.repository.GetLatest(ctx, &repository.GetLatestExchangeRateRequest{
+		UserID:        userID,
+		BaseCurrency:  from,
+		QuoteCurrency: to,
+		Date:          date,
+	})
+	if err == nil {
+		return row.Rate, nil
+	}
+	if !errors.Is(err, repository.ErrDataNotFound) {
+		return decimal.Zero, err
+	}
+
+	row, err = s.repository.GetLatest(ctx, &repository.GetLatestExchangeRateRequest{
+		UserID:        userID,
+		BaseCurrency:  to,
+		QuoteCurrency: from,
+		Date:          date,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return decimal.Zero, fmt.Errorf("%w: from %s to %s", ErrExchangeRateNotFound, from, to)
+		}
+		return decimal.Zero, err
+	}
+	return decimal.NewFromInt(1).DivRound(row.Rate, 10), nil
+}
+
+func (s *service) homeCurrency(ctx context.Context, userID string) (string, error) {
+	user, err := s.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+	if user.Config == nil || lo.FromPtr(user.Config.HomeCurrency) == "" {
+		return "", ErrHomeCurrencyNotSet
+	}
+	return *user.Config.HomeCurrency, nil
+}
+
+func parseCSVRecord(record []string) (*repository.BaseExchangeRate, error) {
+	date, err := time.Parse(time.DateOnly, record[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date[%s]", ErrInvalidCSV, record[0])
+	}
+	rate, err := decimal.NewFromString(record[3])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid rate[%s]", ErrInvalidCSV, record[3])
+	}
+	return parseBaseExchangeRate(&BaseExchangeRate{
+		Date:          date,
+		BaseCurrency:  record[1],
+		QuoteCurrency: record[2],
+		Rate:          rate,
+	})
+}
+
+// parseBaseExchangeRate validates the exchange rate and normalizes its currencies.
+func parseBaseExchangeRate(v *BaseExchangeRate) (*repository.BaseExchangeRate, error) {
+	if v == nil {
+		return nil, fmt.Errorf("%w: missing rate", ErrDataInsufficient)
+	}
+	if v.Date.IsZero() {
+		return nil, fmt.Errorf("%w: missing rate.date", ErrDataInsufficient)
+	}
+	if v.BaseCurrency == "" {
+		return nil, fmt.Errorf("%w: missing rate.baseCurrency", ErrDataInsufficient)
+	}
+	if v.QuoteCurrency == "" {
+		return nil, fmt.Errorf("%w: missing rate.quoteCurrency", ErrDataInsufficient)
+	}
+
+	base, err := currency.Normalize(v.BaseCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+	}
+	quote, err := currency.Normalize(v.QuoteCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+	}
+	if base == quote {
+		return nil, ErrSameCurrency
+	}
+	if !v.Rate.IsPositive() {
+		return nil, ErrInvalidRate
+	}
+
+	return &repository.BaseExchangeRate{
+		Date:          time.Date(v.Date.Year(), v.Date.Month(), v.Date.Day(), 0, 0, 0, 0, time.UTC),
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          v.Rate,
+	}, nil
+}
+
+func parseExchangeRate(v *repository.ExchangeRate) *ExchangeRate {
+	return &ExchangeRate{
+		ID:               v.ID,
+		PublicID:         v.PublicID,
+		BaseExchangeRate: lo.ToPtr(BaseExchangeRate(*v.BaseExchangeRate)),
+	}
+}
+
+type ExchangeRateServiceOptions struct {
+	genPublicID func() string
+}
+
+func defaultExchangeRateServiceOptions() *ExchangeRateServiceOptions {
+	return &ExchangeRateServiceOptions{
+		genPublicID: func() string {
+			return slugid.New("xrt", 11)
+		},
+	}
+}
+
+func WithExchangeRateServiceGenPublicID(f func() string) utils.Option[ExchangeRateServiceOptions] {
+	return func(o *ExchangeRateServiceOptions) {
+		o.genPublicID = f
+	}
+}
@@ -0,0 +1,373 @@
+package exchangerates
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/models"
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Create(t *testing.T) {
+	t.Run("create successful", func(t *testing.T) {
+		const (
+			userID   = "user-id"
+			publicID = "publicID"
+
+			returnedRateID = 9
+		)
+		var (
+			date = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			rate = decimal.NewFromInt(32)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockExchangeRateRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Upsert(gomock.Any(), &repository.UpsertExchangeRatesRequest{
+					UserID: userID,
+					Rates: []*repository.BaseCreateExchangeRate{
+						{
+							PublicID: publicID,
+							BaseExchangeRate: &repository.BaseExchangeRate{
+								Date:          date,
+								BaseCurrency:  "USD",
+								QuoteCurrency: "TWD",
+								Rate:          rate,
+							},
+						},
+					},
+				}).
+				Return([]*repository.ExchangeRate{
+					{
+						ID:       returnedRateID,
+						PublicID: publicID,
+						BaseExchangeRate: &repository.BaseExchangeRate{
+							Date:          date,
+							BaseCurrency:  "USD",
+							QuoteCurrency: "TWD",
+							Rate:          rate,
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(
+			mockRepo,
+			repository.NewMockUserRepository(controller),
+			WithExchangeRateServiceGenPublicID(func() string {
+				return publicID
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Rate: &BaseExchangeRate{
+				Date:          time.Date(2025, 1, 1, 15, 30, 0, 0, time.UTC),
+				BaseCurrency:  "usd",
+				QuoteCurrency: "twd",
+				Rate:          rate,
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&CreateReply{
+			Rate: &ExchangeRate{
+				ID:       returnedRateID,
+				PublicID: publicID,
+				BaseExchangeRate: &BaseExchangeRate{
+					Date:          date,
+					BaseCurrency:  "USD",
+					QuoteCurrency: "TWD",
+					Rate:          rate,
+				},
+			},
+		}, reply)
+	})
+	t.Run("same currency", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(
+			repository.NewMockExchangeRateRepository(controller),
+			repository.NewMockUserRepository(controller),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Rate: &BaseExchangeRate{
+				Date:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseCurrency:  "USD",
+				QuoteCurrency: "usd",
+				Rate:          decimal.NewFromInt(1),
+			},
+		})
+		assert.ErrorIs(err, ErrSameCurrency)
+		assert.Nil(reply)
+	})
+	t.Run("invalid currency", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(
+			repository.NewMockExchangeRateRepository(controller),
+			repository.NewMockUserRepository(controller),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Rate: &BaseExchangeRate{
+				Date:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				BaseCurrency:  "ABCD",
+				QuoteCurrency: "TWD",
+				Rate:          decimal.NewFromInt(1),
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidCurrency)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Import(t *testing.T) {
+	t.Run("import successful", func(t *testing.T) {
+		const userID = "user-id"
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockExchangeRateRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Upsert(gomock.Any(), &repository.UpsertExchangeRatesRequest{
+					UserID: userID,
+					Rates: []*repository.BaseCreateExchangeRate{
+						{
+							PublicID: "publicID",
+							BaseExchangeRate: &repository.BaseExchangeRate{
+								Date:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+								BaseCurrency:  "USD",
+								QuoteCurrency: "TWD",
+								Rate:          decimal.RequireFromString("32.5"),
+							},
+						},
+						{
+							PublicID: "publicID",
+							BaseExchangeRate: &repository.BaseExchangeRate{
+								Date:          time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+								BaseCurrency:  "JPY",
+								QuoteCurrency: "TWD",
+								Rate:          decimal.RequireFromString("0.21"),
+							},
+						},
+					},
+				}).
+				Return([]*repository.ExchangeRate{{}, {}}, nil),
+		)
+
+		s, err := NewService(
+			mockRepo,
+			repository.NewMockUserRepository(controller),
+			WithExchangeRateServiceGenPublicID(func() string {
+				return "publicID"
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID: userID,
+			CSV: strings.NewReader("date,baseCurrency,quoteCurrency,rate\n" +
+				"2025-01-01,USD,TWD,32.5\n" +
+				"2025-01-02, jpy, twd, 0.21\n"),
+		})
+		assert.NoError(err)
+		assert.Equal(&ImportReply{
+			ImportedCount: 2,
+		}, reply)
+	})
+	t.Run("invalid line", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(
+			repository.NewMockExchangeRateRepository(controller),
+			repository.NewMockUserRepository(controller),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID: "user-id",
+			CSV: strings.NewReader("2025-01-01,USD,TWD,32.5\n" +
+				"2025-01-02,USD,TWD,abc\n"),
+		})
+		assert.ErrorIs(err, ErrInvalidCSV)
+		assert.ErrorContains(err, "line 2")
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Convert(t *testing.T) {
+	const userID = "user-id"
+	var date = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("convert by the exchange rate", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockExchangeRateRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				GetLatest(gomock.Any(), &repository.GetLatestExchangeRateRequest{
+					UserID:        userID,
+					BaseCurrency:  "USD",
+					QuoteCurrency: "TWD",
+					Date:          date,
+				}).
+				Return(&repository.ExchangeRate{
+					BaseExchangeRate: &repository.BaseExchangeRate{
+						Rate: decimal.NewFromInt(32),
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockUserRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Convert(context.Background(), &ConvertRequest{
+			UserID:       userID,
+			Amount:       decimal.NewFromInt(10),
+			FromCurrency: "USD",
+			ToCurrency:   "TWD",
+			Date:         date,
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(320).Equal(reply.Amount))
+		assert.True(decimal.NewFromInt(32).Equal(reply.Rate))
+	})
+	t.Run("convert by the inverse of the opposite exchange rate", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockExchangeRateRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				GetLatest(gomock.Any(), &repository.GetLatestExchangeRateRequest{
+					UserID:        userID,
+					BaseCurrency:  "TWD",
+					QuoteCurrency: "USD",
+					Date:          date,
+				}).
+				Return(nil, repository.ErrDataNotFound),
+			mockRepo.EXPECT().
+				GetLatest(gomock.Any(), &repository.GetLatestExchangeRateRequest{
+					UserID:        userID,
+					BaseCurrency:  "USD",
+					QuoteCurrency: "TWD",
+					Date:          date,
+				}).
+				Return(&repository.ExchangeRate{
+					BaseExchangeRate: &repository.BaseExchangeRate{
+						Rate: decimal.NewFromInt(32),
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockUserRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Convert(context.Background(), &ConvertRequest{
+			UserID:       userID,
+			Amount:       decimal.NewFromInt(320),
+			FromCurrency: "TWD",
+			ToCurrency:   "USD",
+			Date:         date,
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(10).Equal(reply.Amount))
+		assert.True(decimal.RequireFromString("0.03125").Equal(reply.Rate))
+	})
+	t.Run("convert into the home currency", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockExchangeRateRepository(controller)
+		mockUserRepo := repository.NewMockUserRepository(controller)
+		gomock.InOrder(
+			mockUserRepo.EXPECT().GetUser(gomock.Any(), userID).Return(&repository.UserModel{
+				ID: userID,
+				Config: &models.UserConfig{
+					HomeCurrency: lo.ToPtr("TWD"),
+				},
+			}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockUserRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Convert(context.Background(), &ConvertRequest{
+			UserID:       userID,
+			Amount:       decimal.NewFromInt(100),
+			FromCurrency: "twd",
+			Date:         date,
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(100).Equal(reply.Amount))
+		assert.True(decimal.NewFromInt(1).Equal(reply.Rate))
+	})
+	t.Run("home currency is not set", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockUserRepo := repository.NewMockUserRepository(controller)
+		gomock.InOrder(
+			mockUserRepo.EXPECT().GetUser(gomock.Any(), userID).Return(&repository.UserModel{
+				ID:     userID,
+				Config: &models.UserConfig{},
+			}, nil),
+		)
+
+		s, err := NewService(repository.NewMockExchangeRateRepository(controller), mockUserRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Convert(context.Background(), &ConvertRequest{
+			UserID:       userID,
+			Amount:       decimal.NewFromInt(100),
+			FromCurrency: "USD",
+			Date:         date,
+		})
+		assert.ErrorIs(err, ErrHomeCurrencyNotSet)
+		assert.Nil(reply)
+	})
+}
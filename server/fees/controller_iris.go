@@ -16,7 +16,8 @@ type IrisController struct {
 	*irisController.SimpleCreateTemplate[models.BasicFee, CreateRequest, CreateReply, models.ObjectId]
 	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Fee]
 	*irisController.SimpleUpdateTemplate[models.BasicFee, UpdateRequest, UpdateReply]
-	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+	*irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]
+	Evaluate *irisController.SimpleHandleTemplate[EvaluateRequest, EvaluateReply, models.FeeEvaluation]
 }
 
 func NewIrisController(s Service) *IrisController {
@@ -35,7 +36,7 @@ func NewIrisController(s Service) *IrisController {
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrInvalidFee):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
@@ -90,20 +91,49 @@ func NewIrisController(s Service) *IrisController {
 				switch {
 				case errors.Is(err, ErrFeeNotFound):
 					return iris.StatusNotFound, true
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrInvalidFee):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
 			},
 		},
-		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+		PolicyDeleteTemplate: &irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]{
 			Placeholder: "feeId",
 			Service:     s,
-			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+			ParseServiceRequest: func(userID string, publicID string, policy DeletePolicy, replacementID string) *DeleteRequest {
 				return &DeleteRequest{
-					UserID:      userID,
-					FeePublicID: publicID,
+					UserID:              userID,
+					FeePublicID:         publicID,
+					Policy:              policy,
+					ReplacementPublicID: replacementID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrFeeNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrInvalidReplacement):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		Evaluate: &irisController.SimpleHandleTemplate[EvaluateRequest, EvaluateReply, models.FeeEvaluation]{
+			Service: s.Evaluate,
+			ParseServiceRequest: func(c iris.Context, userID string) (*EvaluateRequest, error) {
+				var r models.FeeEvaluationRequest
+				if err := c.ReadJSON(&r); err != nil {
+					return nil, fmt.Errorf("invalid request: %v", err)
+				}
+				amount, err := decimal.NewFromString(r.Amount)
+				if err != nil {
+					return nil, fmt.Errorf("invalid decimal[%s]", r.Amount)
 				}
+				return &EvaluateRequest{
+					UserID:      userID,
+					FeePublicID: c.Params().Get("feeId"),
+					Amount:      amount,
+				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
@@ -114,6 +144,12 @@ func NewIrisController(s Service) *IrisController {
 				}
 				return 0, false
 			},
+			ParseAPIResponse: func(reply *EvaluateReply) (*models.FeeEvaluation, error) {
+				return &models.FeeEvaluation{
+					Fee:   reply.Fee.String(),
+					Total: reply.Total.String(),
+				}, nil
+			},
 		},
 	}
 }
@@ -124,6 +160,7 @@ func toServiceBaseFee(base *models.BasicFee) (*BaseFee, error) {
 		Type: int8(base.Type),
 	}
 
+	var err error
 	switch base.Type {
 	case models.BasicFeeTypeRate:
 		v, err := base.Value.AsBasicFeeValue0()
@@ -131,12 +168,9 @@ func toServiceBaseFee(base *models.BasicFee) (*BaseFee, error) {
 			return nil, irisController.InternalError(err)
 		}
 
-		if v.Rate != nil {
-			rate, err := decimal.NewFromString(*v.Rate)
-			if err != nil {
-				return nil, fmt.Errorf("invalid decimal[%s]", *v.Rate)
-			}
-			result.Rate = &rate
+		result.Rate, err = parseOptionalDecimal(v.Rate)
+		if err != nil {
+			return nil, err
 		}
 	case models.BasicFeeTypeFixed:
 		v, err := base.Value.AsBasicFeeValue1()
@@ -144,43 +178,129 @@ func toServiceBaseFee(base *models.BasicFee) (*BaseFee, error) {
 			return nil, irisController.InternalError(err)
 		}
 
-		if v.Fixed != nil {
-			fixed, err := decimal.NewFromString(*v.Fixed)
+		result.Fixed, err = parseOptionalDecimal(v.Fixed)
+		if err != nil {
+			return nil, err
+		}
+	case models.BasicFeeTypeCombined:
+		v, err := base.Value.AsBasicFeeValue2()
+		if err != nil {
+			return nil, irisController.InternalError(err)
+		}
+
+		result.Rate, err = parseOptionalDecimal(v.Rate)
+		if err != nil {
+			return nil, err
+		}
+		result.Fixed, err = parseOptionalDecimal(v.Fixed)
+		if err != nil {
+			return nil, err
+		}
+	case models.BasicFeeTypeTiered:
+		v, err := base.Value.AsBasicFeeValue3()
+		if err != nil {
+			return nil, irisController.InternalError(err)
+		}
+
+		for _, tier := range lo.FromPtr(v.Tiers) {
+			t, err := toServiceFeeTier(&tier)
 			if err != nil {
-				return nil, fmt.Errorf("invalid decimal[%s]", *v.Fixed)
+				return nil, err
 			}
-			result.Fixed = &fixed
+			result.Tiers = append(result.Tiers, t)
 		}
 	default:
 		return nil, fmt.Errorf("unknown fee type")
 	}
+
+	result.Min, err = parseOptionalDecimal(base.Min)
+	if err != nil {
+		return nil, err
+	}
+	result.Max, err = parseOptionalDecimal(base.Max)
+	if err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
+func toServiceFeeTier(v *models.FeeTier) (*FeeTier, error) {
+	upTo, err := parseOptionalDecimal(v.UpTo)
+	if err != nil {
+		return nil, err
+	}
+	rate, err := parseOptionalDecimal(v.Rate)
+	if err != nil {
+		return nil, err
+	}
+	fixed, err := parseOptionalDecimal(v.Fixed)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeTier{
+		UpTo:  upTo,
+		Rate:  rate,
+		Fixed: fixed,
+	}, nil
+}
+
+func parseOptionalDecimal(s *string) (*decimal.Decimal, error) {
+	if s == nil {
+		return nil, nil
+	}
+	v, err := decimal.NewFromString(*s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decimal[%s]", *s)
+	}
+	return &v, nil
+}
+
 func toFee(v *Fee) (*models.Fee, error) {
 	result := &models.Fee{
 		Id:   lo.ToPtr(models.Id(v.PublicID)),
 		Name: v.Name,
 		Type: models.FeeType(v.Type),
+		Min:  toOptionalDecimal(v.Min),
+		Max:  toOptionalDecimal(v.Max),
 	}
 
+	var err error
 	switch result.Type {
 	case models.FeeTypeRate:
-		err := result.Value.FromFeeValue0(models.FeeValue0{
-			Rate: lo.ToPtr(models.Decimal(v.BaseFee.Rate.String())),
+		err = result.Value.FromFeeValue0(models.FeeValue0{
+			Rate: toOptionalDecimal(v.Rate),
 		})
-		if err != nil {
-			return nil, err
-		}
 	case models.FeeTypeFixed:
-		err := result.Value.FromFeeValue1(models.FeeValue1{
-			Fixed: lo.ToPtr(models.Decimal(v.BaseFee.Fixed.String())),
+		err = result.Value.FromFeeValue1(models.FeeValue1{
+			Fixed: toOptionalDecimal(v.Fixed),
+		})
+	case models.FeeTypeCombined:
+		err = result.Value.FromFeeValue2(models.FeeValue2{
+			Rate:  toOptionalDecimal(v.Rate),
+			Fixed: toOptionalDecimal(v.Fixed),
+		})
+	case models.FeeTypeTiered:
+		err = result.Value.FromFeeValue3(models.FeeValue3{
+			Tiers: lo.ToPtr(lo.Map(v.Tiers, func(item *FeeTier, _ int) models.FeeTier {
+				return models.FeeTier{
+					UpTo:  toOptionalDecimal(item.UpTo),
+					Rate:  toOptionalDecimal(item.Rate),
+					Fixed: toOptionalDecimal(item.Fixed),
+				}
+			})),
 		})
-		if err != nil {
-			return nil, err
-		}
 	default:
 		return nil, fmt.Errorf("unknown fee type[%d]", result.Type)
 	}
+	if err != nil {
+		return nil, err
+	}
 	return result, nil
 }
+
+func toOptionalDecimal(v *decimal.Decimal) *models.Decimal {
+	if v == nil {
+		return nil
+	}
+	return lo.ToPtr(models.Decimal(v.String()))
+}
@@ -2,9 +2,13 @@ package fees
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/repeatingitems"
 	"github.com/n101661/maney/server/repository"
 	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/transfers"
 	"github.com/samber/lo"
 	"xorm.io/xorm"
 )
@@ -23,12 +27,18 @@ func (repo *postgresRepository) Create(ctx context.Context, r *repository.Create
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	return CreateWithSession(session, r)
+}
+
+// CreateWithSession is the same as Create, but it creates the fees with the session
+// so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateFeesRequest) ([]*repository.Fee, error) {
 	rows := lo.Map(r.Fees, func(item *repository.BaseCreateFee, _ int) *postgres.FeesModel {
 		return &postgres.FeesModel{
 			PublicID: item.PublicID,
 			UserID:   r.UserID,
 			Name:     item.Name,
-			Data:     toPostgresBaseFee(item.BaseFee),
+			Data:     postgres.ToBaseFee(item.BaseFee),
 		}
 	})
 	_, err := session.Insert(rows)
@@ -49,7 +59,8 @@ func (repo *postgresRepository) List(ctx context.Context, r *repository.ListFees
 
 	var rows []*postgres.FeesModel
 	err := session.Find(&rows, &postgres.FeesModel{
-		UserID: r.UserID,
+		PublicID: lo.FromPtr(r.FeePublicID),
+		UserID:   r.UserID,
 	})
 	if err != nil {
 		return nil, err
@@ -88,7 +99,7 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 		row.Name = r.Fee.Name
 		bean.Name = row.Name
 
-		row.Data = toPostgresBaseFee(r.Fee)
+		row.Data = postgres.ToBaseFee(r.Fee)
 		bean.Data = row.Data
 	}
 
@@ -109,6 +120,10 @@ func (repo *postgresRepository) Delete(ctx context.Context, r *repository.Delete
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
 	session.Where("user_id = ?", r.UserID)
 	if len(r.FeePublicIDs) > 0 {
 		session.In("public_id", r.FeePublicIDs)
@@ -127,35 +142,132 @@ func (repo *postgresRepository) Delete(ctx context.Context, r *repository.Delete
 		return nil, repository.ErrDataNotFound
 	}
 
+	if err := handleReferences(session, r, rows); err != nil {
+		return nil, err
+	}
+
 	_, err = session.In("id", lo.Map(rows, func(item *postgres.FeesModel, _ int) any {
 		return item.ID
 	})).Table(&postgres.FeesModel{}).Delete()
 	if err != nil {
+		if postgres.ForeignKeyViolationError(err) {
+			return nil, repository.ErrDataReferenced
+		}
 		return nil, err
 	}
 
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
 	return lo.Map(rows, func(item *postgres.FeesModel, _ int) *repository.Fee {
 		return toRepositoryFee(item)
 	}), nil
 }
 
-func toPostgresBaseFee(item *repository.BaseFee) *postgres.BaseFee {
-	return &postgres.BaseFee{
-		Type:  item.Type,
-		Rate:  item.Rate,
-		Fixed: item.Fixed,
+// handleReferences handles the daily items, the repeating items and the transfers which
+// are charged by the deleted fees by the policy.
+func handleReferences(session *xorm.Session, r *repository.DeleteFeesRequest, rows []*postgres.FeesModel) error {
+	ids := lo.Map(rows, func(item *postgres.FeesModel, _ int) any {
+		return item.ID
+	})
+	publicIDs := lo.Map(rows, func(item *postgres.FeesModel, _ int) string {
+		return item.PublicID
+	})
+
+	var dailyItems []*postgres.DailyItemsModel
+	if err := session.In("fee_id", ids).Asc("id").Find(&dailyItems); err != nil {
+		return err
+	}
+	var transferRows []*postgres.TransfersModel
+	if err := session.In("fee_id", ids).Asc("id").Find(&transferRows); err != nil {
+		return err
+	}
+	repeatingItems, err := postgres.ListReferringRepeatingItems(session, r.UserID, func(item *repository.BaseItem) bool {
+		return item.FeePublicID != nil && lo.Contains(publicIDs, *item.FeePublicID)
+	})
+	if err != nil {
+		return err
+	}
+
+	switch r.Policy {
+	case repository.DeletePolicyRestrict:
+		return postgres.NewReferencedError(&postgres.References{
+			DailyItems:     dailyItems,
+			RepeatingItems: repeatingItems,
+			Transfers:      transferRows,
+		})
+	case repository.DeletePolicyReassign:
+		replacement := postgres.FeesModel{
+			PublicID: r.ReplacementPublicID,
+			UserID:   r.UserID,
+		}
+		has, err := session.Get(&replacement)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return repository.ErrReferenceNotFound
+		}
+		if lo.Contains(publicIDs, replacement.PublicID) {
+			return repository.ErrInvalidReference
+		}
+
+		for _, bean := range []any{&postgres.DailyItemsModel{}, &postgres.TransfersModel{}} {
+			_, err := session.
+				Table(bean).
+				In("fee_id", ids).
+				Update(map[string]any{"fee_id": replacement.ID})
+			if err != nil {
+				return err
+			}
+		}
+		for _, row := range repeatingItems {
+			row.Data.Item.FeePublicID = &replacement.PublicID
+		}
+		return postgres.UpdateRepeatingItemsData(session, repeatingItems)
+	case repository.DeletePolicyCascade:
+		if len(dailyItems) > 0 {
+			_, err := dailyitems.DeleteWithSession(session, &repository.DeleteDailyItemsRequest{
+				UserID: r.UserID,
+				DailyItemPublicIDs: lo.Map(dailyItems, func(item *postgres.DailyItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(repeatingItems) > 0 {
+			_, err := repeatingitems.DeleteWithSession(session, &repository.DeleteRepeatingItemsRequest{
+				UserID: r.UserID,
+				RepeatingItemPublicIDs: lo.Map(repeatingItems, func(item *postgres.RepeatingItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(transferRows) > 0 {
+			_, err := transfers.DeleteWithSession(session, &repository.DeleteTransfersRequest{
+				UserID: r.UserID,
+				TransferPublicIDs: lo.Map(transferRows, func(item *postgres.TransfersModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
 	}
+	return fmt.Errorf("unknown delete policy[%v]", r.Policy)
 }
 
 func toRepositoryFee(item *postgres.FeesModel) *repository.Fee {
 	return &repository.Fee{
 		ID:       item.ID,
 		PublicID: item.PublicID,
-		BaseFee: &repository.BaseFee{
-			Name:  item.Name,
-			Type:  item.Data.Type,
-			Rate:  item.Data.Rate,
-			Fixed: item.Data.Fixed,
-		},
+		BaseFee:  postgres.FromBaseFee(item.Name, item.Data),
 	}
 }
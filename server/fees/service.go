@@ -5,26 +5,43 @@ import (
 	"fmt"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/repository"
 )
 
 var (
-	ErrDataInsufficient = fmt.Errorf("data insufficient")
-	ErrFeeNotFound      = fmt.Errorf("fee not found")
+	ErrDataInsufficient   = fmt.Errorf("data insufficient")
+	ErrFeeNotFound        = fmt.Errorf("fee not found")
+	ErrInvalidFee         = fmt.Errorf("invalid fee")
+	ErrFeeReferenced      = fmt.Errorf("fee is referenced")
+	ErrInvalidReplacement = fmt.Errorf("invalid replacement")
 )
 
 type Service interface {
-	// Create returns ErrDataInsufficient if any of fields of CreateRequest is zero-value.
+	// Create returns error:
+	//  - ErrDataInsufficient if any of fields of CreateRequest is zero-value,
+	//  - ErrInvalidFee if the type is unknown, the tiers are not sorted or Min is greater than Max.
 	Create(context.Context, *CreateRequest) (*CreateReply, error)
 	// List returns ErrDataInsufficient if any of fields of ListRequest is zero-value,
 	List(context.Context, *ListRequest) (*ListReply, error)
 	// Update returns error:
 	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
+	//  - ErrInvalidFee if the type is unknown, the tiers are not sorted or Min is greater than Max,
 	//  - ErrFeeNotFound if the fee does not exist.
 	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
-	// Delete returns error:
-	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
-	//  - ErrFeeNotFound if the fee does not exist.
+	// Delete deletes the fee and handles its references by the policy, it returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value, or the
+	//    replacement is missing while the policy is DeletePolicyReassign,
+	//  - ErrFeeNotFound if the fee does not exist,
+	//  - ErrFeeReferenced wrapping *repository.ReferencedError if the fee is referenced
+	//    while the policy is DeletePolicyRestrict,
+	//  - ErrInvalidReplacement if the replacement does not exist or is not another fee.
+	// The fees of the references are not recomputed.
 	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+	// Evaluate previews the fee which the fee charges on the amount, it returns error:
+	//  - ErrDataInsufficient if any of required fields of EvaluateRequest is zero-value,
+	//  - ErrFeeNotFound if the fee does not exist.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateReply, error)
 }
 
 type BaseFee struct {
@@ -33,11 +50,20 @@ type BaseFee struct {
 	// Type determines fee type:
 	//  - 0: Rate
 	//  - 1: Fixed
+	//  - 2: Combined, the fixed value plus amount * rate
+	//  - 3: Tiered, the charge of the tier which the amount falls in
 	Type  int8
 	Rate  *decimal.Decimal
 	Fixed *decimal.Decimal
+	// Tiers are required by tiered fee, they must be sorted by UpTo in ascending order.
+	Tiers []*FeeTier
+	// Min and Max bound the charged fee of any type if they are provided.
+	Min *decimal.Decimal
+	Max *decimal.Decimal
 }
 
+type FeeTier = repository.FeeTier
+
 type Fee struct {
 	ID       int32
 	PublicID string
@@ -74,6 +100,26 @@ type UpdateReply struct {
 type DeleteRequest struct {
 	UserID      string
 	FeePublicID string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the fee which the references are reassigned to, it is
+	// required while the policy is DeletePolicyReassign.
+	ReplacementPublicID string
 }
 
+type DeletePolicy = repository.DeletePolicy
+
 type DeleteReply struct{}
+
+type EvaluateRequest struct {
+	UserID      string
+	FeePublicID string
+	Amount      decimal.Decimal
+}
+
+type EvaluateReply struct {
+	// Fee is rounded to 6 decimal places.
+	Fee decimal.Decimal
+	// Total is the amount plus the fee.
+	Total decimal.Decimal
+}
@@ -35,15 +35,8 @@ func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, e
 	if r.Fee == nil {
 		return nil, fmt.Errorf("%w: missing fee", ErrDataInsufficient)
 	}
-	switch models.FeeType(r.Fee.Type) {
-	case models.FeeTypeRate:
-		if r.Fee.Rate == nil {
-			return nil, fmt.Errorf("%w: missing fee.rate", ErrDataInsufficient)
-		}
-	case models.FeeTypeFixed:
-		if r.Fee.Fixed == nil {
-			return nil, fmt.Errorf("%w: missing fee.fixed", ErrDataInsufficient)
-		}
+	if err := ValidateFee(r.Fee); err != nil {
+		return nil, err
 	}
 
 	rows, err := s.repository.Create(ctx, &repository.CreateFeesRequest{
@@ -95,15 +88,8 @@ func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, e
 	if r.Fee == nil {
 		return nil, fmt.Errorf("%w: missing fee", ErrDataInsufficient)
 	}
-	switch models.FeeType(r.Fee.Type) {
-	case models.FeeTypeRate:
-		if r.Fee.Rate == nil {
-			return nil, fmt.Errorf("%w: missing fee.rate", ErrDataInsufficient)
-		}
-	case models.FeeTypeFixed:
-		if r.Fee.Fixed == nil {
-			return nil, fmt.Errorf("%w: missing fee.fixed", ErrDataInsufficient)
-		}
+	if err := ValidateFee(r.Fee); err != nil {
+		return nil, err
 	}
 
 	row, err := s.repository.Update(ctx, &repository.UpdateFeeRequest{
@@ -130,20 +116,104 @@ func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, e
 	if r.FeePublicID == "" {
 		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
 	}
+	if r.Policy == repository.DeletePolicyReassign && r.ReplacementPublicID == "" {
+		return nil, fmt.Errorf("%w: missing replacement id", ErrDataInsufficient)
+	}
 
 	_, err := s.repository.Delete(ctx, &repository.DeleteFeesRequest{
-		FeePublicIDs: []string{r.FeePublicID},
-		UserID:       r.UserID,
+		FeePublicIDs:        []string{r.FeePublicID},
+		UserID:              r.UserID,
+		Policy:              r.Policy,
+		ReplacementPublicID: r.ReplacementPublicID,
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrDataNotFound) {
 			return nil, ErrFeeNotFound
 		}
+		if errors.Is(err, repository.ErrDataReferenced) {
+			return nil, fmt.Errorf("%w: %w", ErrFeeReferenced, err)
+		}
+		if errors.Is(err, repository.ErrReferenceNotFound) ||
+			errors.Is(err, repository.ErrInvalidReference) {
+			return nil, ErrInvalidReplacement
+		}
 		return nil, err
 	}
 	return &DeleteReply{}, nil
 }
 
+func (s *service) Evaluate(ctx context.Context, r *EvaluateRequest) (*EvaluateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.FeePublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListFeesRequest{
+		UserID:      r.UserID,
+		FeePublicID: &r.FeePublicID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrFeeNotFound
+		}
+		return nil, err
+	}
+
+	fee := reply.Fees[0].Charge(r.Amount).Round(6)
+	return &EvaluateReply{
+		Fee:   fee,
+		Total: r.Amount.Add(fee),
+	}, nil
+}
+
+// ValidateFee checks the required fields of the type of the fee. It is shared with the
+// importer of the ledger.
+func ValidateFee(v *BaseFee) error {
+	switch models.FeeType(v.Type) {
+	case models.FeeTypeRate:
+		if v.Rate == nil {
+			return fmt.Errorf("%w: missing fee.rate", ErrDataInsufficient)
+		}
+	case models.FeeTypeFixed:
+		if v.Fixed == nil {
+			return fmt.Errorf("%w: missing fee.fixed", ErrDataInsufficient)
+		}
+	case models.FeeTypeCombined:
+		if v.Rate == nil {
+			return fmt.Errorf("%w: missing fee.rate", ErrDataInsufficient)
+		}
+		if v.Fixed == nil {
+			return fmt.Errorf("%w: missing fee.fixed", ErrDataInsufficient)
+		}
+	case models.FeeTypeTiered:
+		if len(v.Tiers) == 0 {
+			return fmt.Errorf("%w: missing fee.tiers", ErrDataInsufficient)
+		}
+		for i, tier := range v.Tiers {
+			if tier == nil || (tier.Rate == nil && tier.Fixed == nil) {
+				return fmt.Errorf("%w: missing fee.tiers[%d].rate or fee.tiers[%d].fixed", ErrDataInsufficient, i, i)
+			}
+			if tier.UpTo == nil {
+				if i != len(v.Tiers)-1 {
+					return fmt.Errorf("%w: only the last tier can be unbounded", ErrInvalidFee)
+				}
+				continue
+			}
+			if i > 0 && !tier.UpTo.GreaterThan(*v.Tiers[i-1].UpTo) {
+				return fmt.Errorf("%w: tiers must be sorted by upTo in ascending order", ErrInvalidFee)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown type[%d]", ErrInvalidFee, v.Type)
+	}
+	if v.Min != nil && v.Max != nil && v.Min.GreaterThan(*v.Max) {
+		return fmt.Errorf("%w: min is greater than max", ErrInvalidFee)
+	}
+	return nil
+}
+
 func parseFee(v *repository.Fee) *Fee {
 	return &Fee{
 		ID:       v.ID,
@@ -81,6 +81,83 @@ func Test_service_Create(t *testing.T) {
 			},
 		}, reply)
 	})
+	t.Run("invalid fee", func(t *testing.T) {
+		tests := []struct {
+			name string
+			fee  *BaseFee
+			err  error
+		}{
+			{
+				name: "combined fee without fixed",
+				fee: &BaseFee{
+					Name: "A",
+					Type: repository.FeeTypeCombined,
+					Rate: lo.ToPtr(decimal.NewFromFloat(0.015)),
+				},
+				err: ErrDataInsufficient,
+			},
+			{
+				name: "unsorted tiers",
+				fee: &BaseFee{
+					Name: "A",
+					Type: repository.FeeTypeTiered,
+					Tiers: []*FeeTier{
+						{UpTo: lo.ToPtr(decimal.NewFromInt(1000)), Fixed: lo.ToPtr(decimal.NewFromInt(10))},
+						{UpTo: lo.ToPtr(decimal.NewFromInt(500)), Fixed: lo.ToPtr(decimal.NewFromInt(5))},
+					},
+				},
+				err: ErrInvalidFee,
+			},
+			{
+				name: "unbounded tier before the last one",
+				fee: &BaseFee{
+					Name: "A",
+					Type: repository.FeeTypeTiered,
+					Tiers: []*FeeTier{
+						{Fixed: lo.ToPtr(decimal.NewFromInt(10))},
+						{UpTo: lo.ToPtr(decimal.NewFromInt(500)), Fixed: lo.ToPtr(decimal.NewFromInt(5))},
+					},
+				},
+				err: ErrInvalidFee,
+			},
+			{
+				name: "min is greater than max",
+				fee: &BaseFee{
+					Name: "A",
+					Type: repository.FeeTypeRate,
+					Rate: lo.ToPtr(decimal.NewFromFloat(0.005)),
+					Min:  lo.ToPtr(decimal.NewFromInt(100)),
+					Max:  lo.ToPtr(decimal.NewFromInt(15)),
+				},
+				err: ErrInvalidFee,
+			},
+			{
+				name: "unknown type",
+				fee: &BaseFee{
+					Name: "A",
+					Type: 9,
+				},
+				err: ErrInvalidFee,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				controller := gomock.NewController(t)
+
+				s, err := NewService(repository.NewMockFeeRepository(controller))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Create(context.Background(), &CreateRequest{
+					UserID: "user-id",
+					Fee:    tt.fee,
+				})
+				assert.ErrorIs(t, err, tt.err)
+				assert.Nil(t, reply)
+			})
+		}
+	})
 }
 
 func Test_service_List(t *testing.T) {
@@ -342,4 +419,181 @@ func Test_service_Delete(t *testing.T) {
 		assert.ErrorIs(err, ErrFeeNotFound)
 		assert.Nil(reply)
 	})
+	t.Run("delete policies", func(t *testing.T) {
+		referenced := &repository.ReferencedError{References: []*repository.Reference{
+			{Type: repository.ReferenceTypeDailyItem, PublicID: "dailyItemID"},
+			{Type: repository.ReferenceTypeTransfer, PublicID: "transferID"},
+		}}
+		tests := []struct {
+			name        string
+			policy      repository.DeletePolicy
+			replacement string
+			// repoErr is returned by the repository, which is not called if the request is
+			// insufficient.
+			repoErr error
+			wantErr error
+		}{
+			{name: "referenced fee is restricted", policy: repository.DeletePolicyRestrict, repoErr: referenced, wantErr: ErrFeeReferenced},
+			{name: "missing replacement", policy: repository.DeletePolicyReassign, wantErr: ErrDataInsufficient},
+			{name: "invalid replacement", policy: repository.DeletePolicyReassign, replacement: "2", repoErr: repository.ErrReferenceNotFound, wantErr: ErrInvalidReplacement},
+			{name: "references are cascaded", policy: repository.DeletePolicyCascade},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				controller := gomock.NewController(t)
+				mockRepo := repository.NewMockFeeRepository(controller)
+				if tt.wantErr != ErrDataInsufficient {
+					mockRepo.EXPECT().
+						Delete(gomock.Any(), &repository.DeleteFeesRequest{
+							UserID:              "userID",
+							FeePublicIDs:        []string{"1"},
+							Policy:              tt.policy,
+							ReplacementPublicID: tt.replacement,
+						}).
+						Return(nil, tt.repoErr)
+				}
+
+				s, err := NewService(mockRepo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Delete(context.Background(), &DeleteRequest{
+					UserID:              "userID",
+					FeePublicID:         "1",
+					Policy:              tt.policy,
+					ReplacementPublicID: tt.replacement,
+				})
+				if tt.wantErr == nil {
+					assert.NoError(err)
+					assert.Equal(&DeleteReply{}, reply)
+					return
+				}
+				assert.ErrorIs(err, tt.wantErr)
+				if tt.repoErr == referenced {
+					// the references are kept for the response of the conflict.
+					assert.ErrorIs(err, referenced)
+				}
+				assert.Nil(reply)
+			})
+		}
+	})
+}
+
+func Test_service_Evaluate(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "publicID"
+	)
+
+	tests := []struct {
+		name   string
+		fee    *repository.BaseFee
+		amount decimal.Decimal
+		want   decimal.Decimal
+	}{
+		{
+			name: "rate fee raised to min",
+			fee: &repository.BaseFee{
+				Type: repository.FeeTypeRate,
+				Rate: lo.ToPtr(decimal.NewFromFloat(0.005)),
+				Min:  lo.ToPtr(decimal.NewFromInt(15)),
+				Max:  lo.ToPtr(decimal.NewFromInt(100)),
+			},
+			amount: decimal.NewFromInt(1000),
+			want:   decimal.NewFromInt(15),
+		},
+		{
+			name: "rate fee lowered to max",
+			fee: &repository.BaseFee{
+				Type: repository.FeeTypeRate,
+				Rate: lo.ToPtr(decimal.NewFromFloat(0.005)),
+				Min:  lo.ToPtr(decimal.NewFromInt(15)),
+				Max:  lo.ToPtr(decimal.NewFromInt(100)),
+			},
+			amount: decimal.NewFromInt(30000),
+			want:   decimal.NewFromInt(100),
+		},
+		{
+			name: "combined fee",
+			fee: &repository.BaseFee{
+				Type:  repository.FeeTypeCombined,
+				Rate:  lo.ToPtr(decimal.NewFromFloat(0.015)),
+				Fixed: lo.ToPtr(decimal.NewFromInt(10)),
+			},
+			amount: decimal.NewFromInt(200),
+			want:   decimal.NewFromInt(13),
+		},
+		{
+			name: "tiered fee",
+			fee: &repository.BaseFee{
+				Type: repository.FeeTypeTiered,
+				Tiers: []*repository.FeeTier{
+					{UpTo: lo.ToPtr(decimal.NewFromInt(1000)), Fixed: lo.ToPtr(decimal.NewFromInt(10))},
+					{UpTo: lo.ToPtr(decimal.NewFromInt(10000)), Fixed: lo.ToPtr(decimal.NewFromInt(30))},
+					{Rate: lo.ToPtr(decimal.NewFromFloat(0.001))},
+				},
+			},
+			amount: decimal.NewFromInt(1000),
+			want:   decimal.NewFromInt(30),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			controller := gomock.NewController(t)
+			mockRepo := repository.NewMockFeeRepository(controller)
+			gomock.InOrder(
+				mockRepo.EXPECT().
+					List(gomock.Any(), &repository.ListFeesRequest{
+						UserID:      userID,
+						FeePublicID: lo.ToPtr(publicID),
+					}).
+					Return(&repository.ListFeesReply{
+						Fees: []*repository.Fee{
+							{ID: 1, PublicID: publicID, BaseFee: tt.fee},
+						},
+					}, nil),
+			)
+
+			s, err := NewService(mockRepo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reply, err := s.Evaluate(context.Background(), &EvaluateRequest{
+				UserID:      userID,
+				FeePublicID: publicID,
+				Amount:      tt.amount,
+			})
+			assert.NoError(err)
+			assert.True(tt.want.Equal(reply.Fee), reply.Fee.String())
+			assert.True(tt.amount.Add(tt.want).Equal(reply.Total), reply.Total.String())
+		})
+	}
+	t.Run("fee not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockFeeRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Evaluate(context.Background(), &EvaluateRequest{
+			UserID:      userID,
+			FeePublicID: publicID,
+			Amount:      decimal.NewFromInt(100),
+		})
+		assert.ErrorIs(err, ErrFeeNotFound)
+		assert.Nil(reply)
+	})
 }
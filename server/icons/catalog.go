@@ -0,0 +1,72 @@
+package icons
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+//go:embed icons.toml
+var builtinCatalog []byte
+
+// Catalog defines the icons of each kind.
+type Catalog struct {
+	Account []*CatalogIcon `toml:"account"`
+	Expense []*CatalogIcon `toml:"expense"`
+	Income  []*CatalogIcon `toml:"income"`
+}
+
+type CatalogIcon struct {
+	// ID is unique in the same kind, and it is positive because 0 means no icon.
+	ID   int32  `toml:"id"`
+	Name string `toml:"name"`
+}
+
+// LoadCatalog returns the built-in catalog.
+func LoadCatalog() (*Catalog, error) {
+	return ParseCatalog(builtinCatalog)
+}
+
+// ReadCatalog returns the catalog in the TOML file.
+func ReadCatalog(name string) (*Catalog, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCatalog(data)
+}
+
+// ParseCatalog parses the catalog in TOML and validates it.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var c Catalog
+	if err := toml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid icon catalog: %v", err)
+	}
+
+	for kind, icons := range c.kinds() {
+		ids := map[int32]bool{}
+		for _, icon := range icons {
+			if icon.ID <= 0 {
+				return nil, fmt.Errorf("invalid icon catalog: id of %s icon must be positive", kind)
+			}
+			if ids[icon.ID] {
+				return nil, fmt.Errorf("invalid icon catalog: duplicated id[%d] of %s icon", icon.ID, kind)
+			}
+			if icon.Name == "" {
+				return nil, fmt.Errorf("invalid icon catalog: missing name of %s icon[%d]", kind, icon.ID)
+			}
+			ids[icon.ID] = true
+		}
+	}
+	return &c, nil
+}
+
+func (c *Catalog) kinds() map[Kind][]*CatalogIcon {
+	return map[Kind][]*CatalogIcon{
+		KindAccount: c.Account,
+		KindExpense: c.Expense,
+		KindIncome:  c.Income,
+	}
+}
@@ -0,0 +1,44 @@
+package icons
+
+import (
+	"errors"
+
+	"github.com/kataras/iris/v12"
+	"github.com/samber/lo"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Icon]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Icon]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				return &ListRequest{
+					Kind: Kind(c.URLParam("kind")),
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrInvalidKind):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.Icon, error) {
+				return lo.ToPtr(lo.Map(reply.Icons, func(item *Icon, _ int) *models.Icon {
+					return &models.Icon{
+						Kind: models.IconKind(item.Kind),
+						Id:   models.IconId(item.ID),
+						Name: item.Name,
+					}
+				})), nil
+			},
+		},
+	}
+}
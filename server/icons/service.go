@@ -0,0 +1,64 @@
+package icons
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrInvalidKind  = fmt.Errorf("invalid kind of icon")
+	ErrIconNotFound = fmt.Errorf("icon not found")
+)
+
+type Service interface {
+	// List returns the icons sorted by kind and id, and returns ErrInvalidKind if the
+	// kind is provided but not supported.
+	List(context.Context, *ListRequest) (*ListReply, error)
+	Validator
+}
+
+// Validator validates the icon ids which the accounts and the categories refer to.
+type Validator interface {
+	// Validate returns error:
+	//  - ErrInvalidKind if the kind is not supported,
+	//  - ErrIconNotFound if the icon is not in the catalog of the kind.
+	// The zero id means no icon, it is always valid.
+	Validate(context.Context, *ValidateRequest) error
+}
+
+// Kind is the kind of the icon, the icons of different kinds may have the same id.
+type Kind string
+
+const (
+	KindAccount Kind = "account"
+	KindExpense Kind = "expense"
+	KindIncome  Kind = "income"
+)
+
+// Kinds are the supported kinds.
+var Kinds = []Kind{KindAccount, KindExpense, KindIncome}
+
+func (k Kind) Valid() bool {
+	return slices.Contains(Kinds, k)
+}
+
+type Icon struct {
+	Kind Kind
+	ID   int32
+	Name string
+}
+
+type ListRequest struct {
+	// Kind is optional, all of the icons are returned if it is empty.
+	Kind Kind
+}
+
+type ListReply struct {
+	Icons []*Icon
+}
+
+type ValidateRequest struct {
+	Kind   Kind
+	IconID int32
+}
@@ -0,0 +1,70 @@
+package icons
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+type service struct {
+	// icons are sorted by id in each kind.
+	icons map[Kind][]*Icon
+}
+
+func NewService(catalog *Catalog) (Service, error) {
+	if catalog == nil {
+		return nil, fmt.Errorf("missing icon catalog")
+	}
+
+	icons := make(map[Kind][]*Icon, len(Kinds))
+	for kind, items := range catalog.kinds() {
+		icons[kind] = make([]*Icon, 0, len(items))
+		for _, item := range items {
+			icons[kind] = append(icons[kind], &Icon{
+				Kind: kind,
+				ID:   item.ID,
+				Name: item.Name,
+			})
+		}
+		slices.SortFunc(icons[kind], func(a, b *Icon) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+	}
+	return &service{
+		icons: icons,
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.Kind != "" && !r.Kind.Valid() {
+		return nil, fmt.Errorf("%w[%s]", ErrInvalidKind, r.Kind)
+	}
+
+	icons := []*Icon{}
+	for _, kind := range Kinds {
+		if r.Kind == "" || r.Kind == kind {
+			icons = append(icons, s.icons[kind]...)
+		}
+	}
+	return &ListReply{
+		Icons: icons,
+	}, nil
+}
+
+func (s *service) Validate(ctx context.Context, r *ValidateRequest) error {
+	if !r.Kind.Valid() {
+		return fmt.Errorf("%w[%s]", ErrInvalidKind, r.Kind)
+	}
+	if r.IconID == 0 {
+		return nil
+	}
+
+	_, found := slices.BinarySearchFunc(s.icons[r.Kind], r.IconID, func(icon *Icon, id int32) int {
+		return cmp.Compare(icon.ID, id)
+	})
+	if !found {
+		return fmt.Errorf("%w: %s icon[%d]", ErrIconNotFound, r.Kind, r.IconID)
+	}
+	return nil
+}
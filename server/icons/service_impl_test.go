@@ -0,0 +1,134 @@
+package icons
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_service_List(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(`
+[[account]]
+id = 2
+name = "bank"
+
+[[account]]
+id = 1
+name = "wallet"
+
+[[expense]]
+id = 1
+name = "food"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewService(catalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list all icons", func(t *testing.T) {
+		assert := assert.New(t)
+
+		reply, err := s.List(context.Background(), &ListRequest{})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Icons: []*Icon{
+				{Kind: KindAccount, ID: 1, Name: "wallet"},
+				{Kind: KindAccount, ID: 2, Name: "bank"},
+				{Kind: KindExpense, ID: 1, Name: "food"},
+			},
+		}, reply)
+	})
+	t.Run("list icons of the kind", func(t *testing.T) {
+		assert := assert.New(t)
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			Kind: KindIncome,
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Icons: []*Icon{},
+		}, reply)
+	})
+	t.Run("invalid kind", func(t *testing.T) {
+		assert := assert.New(t)
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			Kind: "unknown",
+		})
+		assert.ErrorIs(err, ErrInvalidKind)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Validate(t *testing.T) {
+	catalog, err := LoadCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewService(catalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		request *ValidateRequest
+		wantErr error
+	}{
+		{
+			name:    "icon of the kind",
+			request: &ValidateRequest{Kind: KindIncome, IconID: 1},
+		},
+		{
+			name:    "no icon",
+			request: &ValidateRequest{Kind: KindAccount, IconID: 0},
+		},
+		{
+			name:    "icon not found",
+			request: &ValidateRequest{Kind: KindExpense, IconID: 999},
+			wantErr: ErrIconNotFound,
+		},
+		{
+			name:    "invalid kind",
+			request: &ValidateRequest{Kind: "unknown", IconID: 1},
+			wantErr: ErrInvalidKind,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, s.Validate(context.Background(), tt.request), tt.wantErr)
+		})
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "non-positive id",
+			data: "[[account]]\nid = 0\nname = \"wallet\"",
+		},
+		{
+			name: "duplicated id",
+			data: "[[income]]\nid = 1\nname = \"salary\"\n[[income]]\nid = 1\nname = \"bonus\"",
+		},
+		{
+			name: "missing name",
+			data: "[[expense]]\nid = 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCatalog([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -18,6 +18,9 @@ func (s *Server) registerRoutes() {
 		user.Get("/accounts", s.controllers.Account.List)
 		user.Put("/accounts/{accountId}", s.controllers.Account.Update)
 		user.Delete("/accounts/{accountId}", s.controllers.Account.Delete)
+		user.Get("/accounts/{accountId}/statements", s.controllers.Statement.List.Get)
+		user.Put("/accounts/{accountId}/statements/{closingDate}/payment", s.controllers.Statement.Pay.Handle)
+		user.Delete("/accounts/{accountId}/statements/{closingDate}/payment", s.controllers.Statement.Unpay.Handle)
 	}
 	{ // user's categories
 		user.Post("/categories", s.controllers.Category.Create)
@@ -25,22 +28,79 @@ func (s *Server) registerRoutes() {
 		user.Put("/categories/{categoryId}", s.controllers.Category.Update)
 		user.Delete("/categories/{categoryId}", s.controllers.Category.Delete)
 	}
+	{ // icons of accounts and categories
+		user.Get("/icons", s.controllers.Icon.List)
+	}
 	{ // user's shops
 		user.Post("/shops", s.controllers.Shop.Create)
 		user.Get("/shops", s.controllers.Shop.List)
+		user.Get("/shops/nearest", s.controllers.Shop.Nearest.Get)
 		user.Put("/shops/{shopId}", s.controllers.Shop.Update)
 		user.Delete("/shops/{shopId}", s.controllers.Shop.Delete)
+		user.Get("/shops/{shopId}/prices", s.controllers.Shop.PriceHistory.Get)
+	}
+	{ // user's tags
+		user.Post("/tags", s.controllers.Tag.Create)
+		user.Get("/tags", s.controllers.Tag.List)
+		user.Put("/tags/{tagId}", s.controllers.Tag.Update)
+		user.Delete("/tags/{tagId}", s.controllers.Tag.Delete)
+	}
+	{ // user's unit conversions
+		user.Post("/unit-conversions", s.controllers.UnitConversion.Create)
+		user.Get("/unit-conversions", s.controllers.UnitConversion.List)
+		user.Put("/unit-conversions/{unitConversionId}", s.controllers.UnitConversion.Update)
+		user.Delete("/unit-conversions/{unitConversionId}", s.controllers.UnitConversion.Delete)
 	}
 	{ // user's fees
 		user.Post("/fees", s.controllers.Fee.Create)
 		user.Get("/fees", s.controllers.Fee.List)
 		user.Put("/fees/{feeId}", s.controllers.Fee.Update)
 		user.Delete("/fees/{feeId}", s.controllers.Fee.Delete)
+		user.Post("/fees/{feeId}/evaluate", s.controllers.Fee.Evaluate.Handle)
 	}
 	{ // user's daily items
-		user.Post("/daily-items")
-		user.Get("/daily-items")
-		user.Put("/daily-items/{dailyItemId}")
-		user.Delete("/daily-items/{dailyItemId}")
+		user.Post("/daily-items", s.controllers.DailyItem.Create)
+		user.Get("/daily-items", s.controllers.DailyItem.List)
+		user.Get("/daily-items/search", s.controllers.DailyItem.Search.Get)
+		user.Put("/daily-items/{dailyItemId}", s.controllers.DailyItem.Update)
+		user.Delete("/daily-items/{dailyItemId}", s.controllers.DailyItem.Delete)
+	}
+	{ // user's calendar
+		user.Get("/calendar/{year}/{month}", s.controllers.Calendar.Month.Get)
+	}
+	{ // user's repeating items
+		user.Post("/repeating-items", s.controllers.RepeatingItem.Create)
+		user.Get("/repeating-items", s.controllers.RepeatingItem.List)
+		user.Put("/repeating-items/{repeatingItemId}", s.controllers.RepeatingItem.Update)
+		user.Delete("/repeating-items/{repeatingItemId}", s.controllers.RepeatingItem.Delete)
+	}
+	{ // user's transfers
+		user.Post("/transfers", s.controllers.Transfer.Create)
+		user.Get("/transfers", s.controllers.Transfer.List)
+		user.Delete("/transfers/{transferId}", s.controllers.Transfer.Delete)
+	}
+	{ // user's budgets
+		user.Post("/budgets", s.controllers.Budget.Create)
+		user.Get("/budgets", s.controllers.Budget.List)
+		user.Get("/budgets/progress", s.controllers.Budget.Progress.Get)
+		user.Put("/budgets/{budgetId}", s.controllers.Budget.Update)
+		user.Delete("/budgets/{budgetId}", s.controllers.Budget.Delete)
+	}
+	{ // user's exchange rates
+		user.Post("/exchange-rates", s.controllers.ExchangeRate.Create)
+		user.Get("/exchange-rates", s.controllers.ExchangeRate.List)
+		user.Post("/exchange-rates/import", s.controllers.ExchangeRate.Import)
+		user.Get("/exchange-rates/convert", s.controllers.ExchangeRate.Convert.Get)
+		user.Delete("/exchange-rates/{exchangeRateId}", s.controllers.ExchangeRate.Delete)
+	}
+	{ // user's reports
+		user.Get("/reports/categories", s.controllers.Report.CategoryBreakdown.Get)
+		user.Get("/reports/shops", s.controllers.Report.ShopPriceRanking.Get)
+		user.Get("/reports/net-worth", s.controllers.Report.NetWorth.Get)
+		user.Get("/reports/tags", s.controllers.Report.TagTotals.Get)
+	}
+	{ // user's ledger
+		user.Get("/ledger/export", s.controllers.Ledger.Export)
+		user.Post("/ledger/import", s.controllers.Ledger.Import)
 	}
 }
@@ -9,13 +9,25 @@ import (
 	"github.com/kataras/iris/v12/middleware/requestid"
 
 	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/budgets"
+	"github.com/n101661/maney/server/calendar"
 	"github.com/n101661/maney/server/categories"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
 	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/icons"
 	"github.com/n101661/maney/server/impl/iris/config"
+	"github.com/n101661/maney/server/ledger"
 	"github.com/n101661/maney/server/middleware/errors"
 	"github.com/n101661/maney/server/middleware/logger"
 	"github.com/n101661/maney/server/middleware/recover"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/reports"
 	"github.com/n101661/maney/server/shops"
+	"github.com/n101661/maney/server/statements"
+	"github.com/n101661/maney/server/tags"
+	"github.com/n101661/maney/server/transfers"
+	"github.com/n101661/maney/server/unitconversions"
 	"github.com/n101661/maney/server/users"
 )
 
@@ -25,11 +37,23 @@ type Config struct {
 }
 
 type Controllers struct {
-	User     *users.IrisController
-	Account  *accounts.IrisController
-	Category *categories.IrisController
-	Shop     *shops.IrisController
-	Fee      *fees.IrisController
+	User           *users.IrisController
+	Account        *accounts.IrisController
+	Category       *categories.IrisController
+	Icon           *icons.IrisController
+	Shop           *shops.IrisController
+	Tag            *tags.IrisController
+	UnitConversion *unitconversions.IrisController
+	Fee            *fees.IrisController
+	DailyItem      *dailyitems.IrisController
+	Calendar       *calendar.IrisController
+	RepeatingItem  *repeatingitems.IrisController
+	Transfer       *transfers.IrisController
+	Statement      *statements.IrisController
+	Budget         *budgets.IrisController
+	ExchangeRate   *exchangerates.IrisController
+	Report         *reports.IrisController
+	Ledger         *ledger.IrisController
 }
 
 type Server struct {
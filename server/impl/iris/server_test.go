@@ -8,15 +8,29 @@ import (
 
 	"github.com/iris-contrib/httpexpect/v2"
 	"github.com/kataras/iris/v12/httptest"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 	"go.uber.org/mock/gomock"
 
 	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/budgets"
+	"github.com/n101661/maney/server/calendar"
 	"github.com/n101661/maney/server/categories"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
 	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/icons"
+	"github.com/n101661/maney/server/ledger"
 	"github.com/n101661/maney/server/models"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/reports"
+	"github.com/n101661/maney/server/repository"
 	"github.com/n101661/maney/server/shops"
+	"github.com/n101661/maney/server/statements"
+	"github.com/n101661/maney/server/tags"
+	"github.com/n101661/maney/server/transfers"
+	"github.com/n101661/maney/server/unitconversions"
 	"github.com/n101661/maney/server/users"
 )
 
@@ -108,13 +122,16 @@ func TestServer(t *testing.T) {
 		},
 	}, nil).AnyTimes()
 	categoryService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&categories.ListReply{
-		Categories: []*categories.Category{{
-			ID:       0,
-			PublicID: "PublicID",
-			BaseCategory: &categories.BaseCategory{
-				Name:   "",
-				IconID: 0,
+		Categories: []*categories.CategoryNode{{
+			Category: &categories.Category{
+				ID:       0,
+				PublicID: "PublicID",
+				BaseCategory: &categories.BaseCategory{
+					Name:   "",
+					IconID: 0,
+				},
 			},
+			Children: []*categories.CategoryNode{},
 		}},
 	}, nil).AnyTimes()
 	categoryService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&categories.UpdateReply{
@@ -127,6 +144,14 @@ func TestServer(t *testing.T) {
 			},
 		},
 	}, nil).AnyTimes()
+	categoryService.EXPECT().Delete(gomock.Any(), gomock.Cond(func(r *categories.DeleteRequest) bool {
+		return r.CategoryPublicID == "ReferencedID"
+	})).Return(nil, fmt.Errorf("%w: %w", categories.ErrCategoryReferenced, &repository.ReferencedError{
+		References: []*repository.Reference{{
+			Type:     repository.ReferenceTypeDailyItem,
+			PublicID: "DailyItemID",
+		}},
+	})).AnyTimes()
 	categoryService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&categories.DeleteReply{}, nil).AnyTimes()
 
 	shopService := shops.NewMockService(controller)
@@ -152,13 +177,42 @@ func TestServer(t *testing.T) {
 		},
 	}, nil).AnyTimes()
 	shopService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&shops.DeleteReply{}, nil).AnyTimes()
+	shopService.EXPECT().PriceHistory(gomock.Any(), gomock.Any()).Return(&shops.PriceHistoryReply{
+		Prices: []*shops.ItemPrice{},
+	}, nil).AnyTimes()
+	shopService.EXPECT().Nearest(gomock.Any(), gomock.Any()).Return(&shops.NearestReply{
+		Shops: []*shops.NearestShop{{
+			Shop: &shops.Shop{
+				ID:       0,
+				PublicID: "PublicID",
+				BaseShop: &shops.BaseShop{},
+			},
+			Location: &shops.ShopLocation{
+				Label:      "Main",
+				Coordinate: &shops.Coordinate{Latitude: 25.0478, Longitude: 121.517},
+			},
+			Distance: 200,
+		}},
+	}, nil).AnyTimes()
 
 	httpExpect := httptest.New(t, NewServer(&Config{}, &Controllers{
-		User:     users.NewIrisController(userService),
-		Account:  accounts.NewIrisController(accountService),
-		Category: categories.NewIrisController(categoryService),
-		Shop:     shops.NewIrisController(shopService),
-		Fee:      fees.NewIrisController(newFeeService(controller)),
+		User:           users.NewIrisController(userService),
+		Account:        accounts.NewIrisController(accountService),
+		Category:       categories.NewIrisController(categoryService),
+		Icon:           icons.NewIrisController(newIconService(controller)),
+		Shop:           shops.NewIrisController(shopService),
+		Tag:            tags.NewIrisController(newTagService(controller)),
+		UnitConversion: unitconversions.NewIrisController(newUnitConversionService(controller)),
+		Fee:            fees.NewIrisController(newFeeService(controller)),
+		DailyItem:      dailyitems.NewIrisController(newDailyItemService(controller)),
+		Calendar:       calendar.NewIrisController(newCalendarService(controller)),
+		RepeatingItem:  repeatingitems.NewIrisController(newRepeatingItemService(controller)),
+		Transfer:       transfers.NewIrisController(newTransferService(controller)),
+		Statement:      statements.NewIrisController(newStatementService(controller)),
+		Budget:         budgets.NewIrisController(newBudgetService(controller)),
+		ExchangeRate:   exchangerates.NewIrisController(newExchangeRateService(controller)),
+		Report:         reports.NewIrisController(newReportService(controller)),
+		Ledger:         ledger.NewIrisController(newLedgerService(controller)),
 	}).app)
 
 	loginResponse := httpExpect.POST("/login").WithJSON(models.LoginRequest{
@@ -199,6 +253,9 @@ func TestServer(t *testing.T) {
 	withAuthorization(httpExpect.GET("/accounts")).
 		Expect().Status(httptest.StatusOK)
 
+	withAuthorization(httpExpect.GET("/accounts")).WithQuery("type", "creditCard").
+		Expect().Status(httptest.StatusOK)
+
 	withAuthorization(httpExpect.PUT("/accounts/PublicID")).WithJSON(models.BasicAccount{
 		Name:           "A",
 		IconId:         0,
@@ -206,12 +263,26 @@ func TestServer(t *testing.T) {
 	}).Expect().Status(httptest.StatusOK)
 
 	withAuthorization(httpExpect.DELETE("/accounts/PublicID")).
+		WithQuery("policy", "reassign").WithQuery("replacementId", "BankID").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/accounts/PublicID/statements")).
+		WithQuery("from", "2025-01-01").WithQuery("to", "2025-01-31").
+		Expect().Status(httptest.StatusOK).
+		JSON().Object().HasValue("unpaid", "100")
+
+	withAuthorization(httpExpect.PUT("/accounts/PublicID/statements/2025-01-15/payment")).WithJSON(models.StatementPaymentRequest{
+		Date:          openapi_types.Date{Time: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		FromAccountId: lo.ToPtr("BankID"),
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/accounts/PublicID/statements/2025-01-15/payment")).
 		Expect().Status(httptest.StatusOK)
 
 	withAuthorization(httpExpect.POST("/categories")).WithJSON(models.CreatingCategory{
 		IconId: lo.ToPtr(models.IconId(0)),
 		Name:   "A",
-		Type:   models.Expense,
+		Type:   models.CategoryTypeExpense,
 	}).Expect().Status(httptest.StatusOK)
 
 	withAuthorization(httpExpect.GET("/categories")).
@@ -225,8 +296,24 @@ func TestServer(t *testing.T) {
 	withAuthorization(httpExpect.DELETE("/categories/PublicID")).
 		Expect().Status(httptest.StatusOK)
 
+	withAuthorization(httpExpect.DELETE("/categories/ReferencedID")).
+		Expect().Status(httptest.StatusConflict).
+		JSON().Object().Value("references").Array().Value(0).Object().
+		HasValue("type", "dailyItem").HasValue("id", "DailyItemID")
+
+	withAuthorization(httpExpect.DELETE("/categories/PublicID")).WithQuery("policy", "unknown").
+		Expect().Status(httptest.StatusBadRequest)
+
+	withAuthorization(httpExpect.GET("/icons")).WithQuery("kind", "expense").
+		Expect().Status(httptest.StatusOK)
+
 	withAuthorization(httpExpect.POST("/shops")).WithJSON(models.CreateShopJSONRequestBody{
 		Name: "A",
+		Locations: &[]models.ShopLocation{{
+			Label:      lo.ToPtr("Main"),
+			Coordinate: &models.Coordinate{Latitude: 25.0478, Longitude: 121.517},
+		}},
+		Memo: lo.ToPtr("memo"),
 	}).Expect().Status(httptest.StatusOK)
 
 	withAuthorization(httpExpect.GET("/shops")).
@@ -236,7 +323,47 @@ func TestServer(t *testing.T) {
 		Name: "A",
 	}).Expect().Status(httptest.StatusOK)
 
-	withAuthorization(httpExpect.DELETE("/shops/PublicID")).
+	withAuthorization(httpExpect.DELETE("/shops/PublicID")).WithQuery("policy", "cascade").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/shops/PublicID/prices")).WithQuery("name", "A").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/shops/nearest")).
+		WithQuery("latitude", 25.046).WithQuery("longitude", 121.517).
+		Expect().Status(httptest.StatusOK).
+		JSON().Array().Value(0).Object().HasValue("distance", 200)
+
+	withAuthorization(httpExpect.POST("/tags")).WithJSON(models.CreateTagJSONRequestBody{
+		Name: "A",
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/tags")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.PUT("/tags/PublicID")).WithJSON(models.BasicTag{
+		Name: "A",
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/tags/PublicID")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/unit-conversions")).WithJSON(models.CreateUnitConversionJSONRequestBody{
+		Unit:   models.Pack,
+		ToUnit: models.Pcs,
+		Factor: "6",
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/unit-conversions")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.PUT("/unit-conversions/PublicID")).WithJSON(models.BasicUnitConversion{
+		Unit:   models.Pack,
+		ToUnit: models.Pcs,
+		Factor: "10",
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/unit-conversions/PublicID")).
 		Expect().Status(httptest.StatusOK)
 
 	withAuthorization(httpExpect.POST("/fees")).WithJSON(models.CreateFeeJSONRequestBody{
@@ -256,6 +383,153 @@ func TestServer(t *testing.T) {
 
 	withAuthorization(httpExpect.DELETE("/fees/PublicID")).
 		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/fees/PublicID/evaluate")).WithJSON(models.EvaluateFeeJSONRequestBody{
+		Amount: "100",
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/daily-items")).WithJSON(newBasicDailyItem()).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/daily-items")).WithJSON(models.BasicDailyItem{
+		Date:        openapi_types.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Name:        "A",
+		CategoryIds: []models.Id{"CategoryID", "HouseholdID"},
+		Splits: &[]models.ItemSplit{
+			{CategoryId: "CategoryID", Amount: lo.ToPtr(models.Decimal("0.7"))},
+			{CategoryId: "HouseholdID", Percentage: lo.ToPtr(models.Decimal("30"))},
+		},
+		Price: "1",
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/daily-items")).WithQuery("date", "2025-01-01").
+		Expect().Status(httptest.StatusOK).
+		JSON().Array().Value(0).Object().Value("normalizedPrice").Object().
+		HasValue("price", "0.2").HasValue("quantity", "100").HasValue("unit", "g")
+
+	withAuthorization(httpExpect.GET("/daily-items")).WithQuery("tagId", "TagID").
+		Expect().Status(httptest.StatusOK).
+		JSON().Array().Value(0).Object().HasValue("tagIds", []string{"TagID"})
+
+	withAuthorization(httpExpect.GET("/daily-items/search")).
+		WithQuery("keyword", "A").
+		WithQuery("from", "2025-01-01").
+		WithQuery("minAmount", "1").
+		WithQuery("limit", 20).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/calendar/2025/1")).WithQuery("withItems", true).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.PUT("/daily-items/PublicID")).WithJSON(newBasicDailyItem()).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/daily-items/PublicID")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/repeating-items")).WithJSON(newBasicRepeatingItem()).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/repeating-items")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.PUT("/repeating-items/PublicID")).WithJSON(newBasicRepeatingItem()).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/repeating-items/PublicID")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/transfers")).WithJSON(models.BasicTransfer{
+		Date:          openapi_types.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		FromAccountId: "FromAccountID",
+		ToAccountId:   "ToAccountID",
+		Amount:        "1",
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/transfers")).WithQuery("accountId", "FromAccountID").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/transfers/PublicID")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/budgets")).WithJSON(newBasicBudget()).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/budgets")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/budgets/progress")).WithQuery("month", "2025-01").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.PUT("/budgets/PublicID")).WithJSON(newBasicBudget()).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/budgets/PublicID")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/exchange-rates")).WithJSON(models.BasicExchangeRate{
+		Date:          openapi_types.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		BaseCurrency:  "USD",
+		QuoteCurrency: "TWD",
+		Rate:          "32",
+	}).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/exchange-rates")).WithQuery("baseCurrency", "USD").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/exchange-rates/import")).
+		WithHeader("Content-Type", "text/csv").
+		WithText("date,baseCurrency,quoteCurrency,rate\n2025-01-01,USD,TWD,32\n").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/exchange-rates/convert")).
+		WithQuery("amount", "10").WithQuery("from", "USD").WithQuery("date", "2025-01-01").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.DELETE("/exchange-rates/PublicID")).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/reports/categories")).
+		WithQuery("type", "expense").WithQuery("from", "2025-01-01").WithQuery("to", "2025-01-31").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/reports/tags")).
+		WithQuery("type", "expense").WithQuery("from", "2025-01-01").WithQuery("to", "2025-01-31").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/reports/shops")).
+		WithQuery("name", "A").WithQuery("maxAgeDays", 30).
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/reports/net-worth")).
+		WithQuery("date", "2025-01-31").
+		Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.GET("/ledger/export")).
+		Expect().Status(httptest.StatusOK).
+		ContentType("application/json").
+		JSON().Object().HasValue("version", ledger.DocumentVersion)
+
+	withAuthorization(httpExpect.GET("/ledger/export")).WithHeader("Accept", "application/xml").
+		Expect().Status(httptest.StatusOK).
+		ContentType("application/xml")
+
+	withAuthorization(httpExpect.POST("/ledger/import")).WithJSON(&ledger.Document{
+		Version: ledger.DocumentVersion,
+	}).Expect().Status(httptest.StatusOK)
+
+	withAuthorization(httpExpect.POST("/ledger/import")).WithJSON(&ledger.Document{
+		Version:   ledger.DocumentVersion,
+		Transfers: []*ledger.Transfer{{ID: "TransferID"}},
+	}).Expect().Status(httptest.StatusBadRequest).
+		JSON().Object().Value("errors").Array().Value(0).Object().
+		HasValue("record", "transfers[0]").HasValue("id", "TransferID")
+
+	withAuthorization(httpExpect.POST("/ledger/import")).WithQuery("dryRun", true).
+		WithHeader("Content-Type", "application/xml").
+		WithText(`<ledger version="1"></ledger>`).
+		Expect().Status(httptest.StatusOK)
 }
 
 func newWithAuthorizationHandler(resp *httpexpect.Response) (func(*httpexpect.Request) *httpexpect.Request, error) {
@@ -271,6 +545,66 @@ func newWithAuthorizationHandler(resp *httpexpect.Response) (func(*httpexpect.Re
 	}, nil
 }
 
+func newIconService(controller *gomock.Controller) icons.Service {
+	iconService := icons.NewMockService(controller)
+	iconService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&icons.ListReply{
+		Icons: []*icons.Icon{{
+			Kind: icons.KindExpense,
+			ID:   1,
+			Name: "food",
+		}},
+	}, nil).AnyTimes()
+	return iconService
+}
+
+func newTagService(controller *gomock.Controller) tags.Service {
+	tag := &tags.Tag{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseTag: &tags.BaseTag{
+			Name: "A",
+		},
+	}
+
+	tagService := tags.NewMockService(controller)
+	tagService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&tags.CreateReply{
+		Tag: tag,
+	}, nil).AnyTimes()
+	tagService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&tags.ListReply{
+		Tags: []*tags.Tag{tag},
+	}, nil).AnyTimes()
+	tagService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&tags.UpdateReply{
+		Tag: tag,
+	}, nil).AnyTimes()
+	tagService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&tags.DeleteReply{}, nil).AnyTimes()
+	return tagService
+}
+
+func newUnitConversionService(controller *gomock.Controller) unitconversions.Service {
+	conversion := &unitconversions.UnitConversion{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseUnitConversion: &unitconversions.BaseUnitConversion{
+			Unit:   repository.UnitPack,
+			ToUnit: repository.UnitPiece,
+			Factor: decimal.NewFromInt(6),
+		},
+	}
+
+	unitConversionService := unitconversions.NewMockService(controller)
+	unitConversionService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&unitconversions.CreateReply{
+		Conversion: conversion,
+	}, nil).AnyTimes()
+	unitConversionService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&unitconversions.ListReply{
+		Conversions: []*unitconversions.UnitConversion{conversion},
+	}, nil).AnyTimes()
+	unitConversionService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&unitconversions.UpdateReply{
+		Conversion: conversion,
+	}, nil).AnyTimes()
+	unitConversionService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&unitconversions.DeleteReply{}, nil).AnyTimes()
+	return unitConversionService
+}
+
 func newFeeService(controller *gomock.Controller) fees.Service {
 	feeService := fees.NewMockService(controller)
 	feeService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&fees.CreateReply{
@@ -304,6 +638,10 @@ func newFeeService(controller *gomock.Controller) fees.Service {
 		},
 	}, nil).AnyTimes()
 	feeService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&fees.DeleteReply{}, nil).AnyTimes()
+	feeService.EXPECT().Evaluate(gomock.Any(), gomock.Any()).Return(&fees.EvaluateReply{
+		Fee:   decimal.NewFromInt(1),
+		Total: decimal.NewFromInt(101),
+	}, nil).AnyTimes()
 	return feeService
 }
 
@@ -317,3 +655,335 @@ func newFeeValue() (models.BasicFee_Value, error) {
 	}
 	return v, nil
 }
+
+func newDailyItemService(controller *gomock.Controller) dailyitems.Service {
+	item := &dailyitems.DailyItem{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseDailyItem: &dailyitems.BaseDailyItem{
+			Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			BaseItem: &dailyitems.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{"CategoryID"},
+				Quantity:          lo.ToPtr(decimal.NewFromInt(500)),
+				Unit:              repository.UnitGram,
+				Price:             decimal.NewFromInt(1),
+			},
+			TagPublicIDs: []string{"TagID"},
+		},
+		NormalizedPrice: &dailyitems.NormalizedPrice{
+			Price:    decimal.RequireFromString("0.2"),
+			Quantity: decimal.NewFromInt(100),
+			Unit:     repository.UnitGram,
+		},
+	}
+
+	dailyItemService := dailyitems.NewMockService(controller)
+	dailyItemService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&dailyitems.CreateReply{
+		Item: item,
+	}, nil).AnyTimes()
+	dailyItemService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&dailyitems.ListReply{
+		Items: []*dailyitems.DailyItem{item},
+	}, nil).AnyTimes()
+	dailyItemService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&dailyitems.UpdateReply{
+		Item: item,
+	}, nil).AnyTimes()
+	dailyItemService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&dailyitems.DeleteReply{}, nil).AnyTimes()
+	dailyItemService.EXPECT().Search(gomock.Any(), gomock.Any()).Return(&dailyitems.SearchReply{
+		Items:      []*dailyitems.DailyItem{item},
+		NextCursor: "cursor",
+	}, nil).AnyTimes()
+	return dailyItemService
+}
+
+func newBasicDailyItem() models.BasicDailyItem {
+	return models.BasicDailyItem{
+		Date:        openapi_types.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Name:        "A",
+		CategoryIds: []models.Id{"CategoryID"},
+		Price:       "1",
+	}
+}
+
+func newRepeatingItemService(controller *gomock.Controller) repeatingitems.Service {
+	item := &repeatingitems.RepeatingItem{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseRepeatingItem: &repeatingitems.BaseRepeatingItem{
+			Item: &repeatingitems.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{"CategoryID"},
+				Price:             decimal.NewFromInt(1),
+			},
+			StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency: &repeatingitems.RepeatingFrequency{
+				Days: 1,
+			},
+		},
+		NextDate: lo.ToPtr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	repeatingItemService := repeatingitems.NewMockService(controller)
+	repeatingItemService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&repeatingitems.CreateReply{
+		Item: item,
+	}, nil).AnyTimes()
+	repeatingItemService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repeatingitems.ListReply{
+		Items: []*repeatingitems.RepeatingItem{item},
+	}, nil).AnyTimes()
+	repeatingItemService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&repeatingitems.UpdateReply{
+		Item: item,
+	}, nil).AnyTimes()
+	repeatingItemService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&repeatingitems.DeleteReply{}, nil).AnyTimes()
+	return repeatingItemService
+}
+
+func newBasicRepeatingItem() models.BasicRepeatingItem {
+	return models.BasicRepeatingItem{
+		Item: models.BasicItem{
+			Name:        "A",
+			CategoryIds: []models.Id{"CategoryID"},
+			Price:       "1",
+		},
+		Valid: models.ValidPeriod{
+			Start: openapi_types.Date{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		Frequency: models.RepeatingFrequency{
+			Duration: lo.ToPtr(int32(1)),
+		},
+	}
+}
+
+func newTransferService(controller *gomock.Controller) transfers.Service {
+	transfer := &transfers.Transfer{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseTransfer: &transfers.BaseTransfer{
+			Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			FromAccountPublicID: "FromAccountID",
+			ToAccountPublicID:   "ToAccountID",
+			Amount:              decimal.NewFromInt(1),
+		},
+	}
+
+	transferService := transfers.NewMockService(controller)
+	transferService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&transfers.CreateReply{
+		Transfer: transfer,
+	}, nil).AnyTimes()
+	transferService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&transfers.ListReply{
+		Transfers: []*transfers.Transfer{transfer},
+	}, nil).AnyTimes()
+	transferService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&transfers.DeleteReply{}, nil).AnyTimes()
+	return transferService
+}
+
+func newStatementService(controller *gomock.Controller) statements.Service {
+	payment := &statements.Payment{
+		Date:             time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		Amount:           decimal.NewFromInt(100),
+		TransferPublicID: lo.ToPtr("TransferID"),
+	}
+
+	statementService := statements.NewMockService(controller)
+	statementService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&statements.ListReply{
+		Statements: []*statements.Statement{
+			{
+				StartDate:   time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC),
+				ClosingDate: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+				DueDate:     lo.ToPtr(time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)),
+				Balance:     decimal.NewFromInt(100),
+				Items:       []*statements.DailyItem{},
+			},
+		},
+		Unpaid: decimal.NewFromInt(100),
+	}, nil).AnyTimes()
+	statementService.EXPECT().Pay(gomock.Any(), gomock.Any()).Return(&statements.PayReply{
+		Payment: payment,
+	}, nil).AnyTimes()
+	statementService.EXPECT().Unpay(gomock.Any(), gomock.Any()).Return(&statements.UnpayReply{}, nil).AnyTimes()
+	return statementService
+}
+
+func newBudgetService(controller *gomock.Controller) budgets.Service {
+	budget := &budgets.Budget{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseBudget: &budgets.BaseBudget{
+			CategoryPublicID: "CategoryID",
+			Limit:            decimal.NewFromInt(100),
+			StartMonth:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	budgetService := budgets.NewMockService(controller)
+	budgetService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&budgets.CreateReply{
+		Budget: budget,
+	}, nil).AnyTimes()
+	budgetService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&budgets.ListReply{
+		Budgets: []*budgets.Budget{budget},
+	}, nil).AnyTimes()
+	budgetService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&budgets.UpdateReply{
+		Budget: budget,
+	}, nil).AnyTimes()
+	budgetService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&budgets.DeleteReply{}, nil).AnyTimes()
+	budgetService.EXPECT().Progress(gomock.Any(), gomock.Any()).Return(&budgets.ProgressReply{
+		Budgets: []*budgets.BudgetProgress{
+			{
+				Budget:      budget,
+				Carried:     decimal.Zero,
+				Available:   decimal.NewFromInt(100),
+				Spent:       decimal.NewFromInt(40),
+				Remaining:   decimal.NewFromInt(60),
+				PercentUsed: decimal.NewFromInt(40),
+			},
+		},
+	}, nil).AnyTimes()
+	return budgetService
+}
+
+func newExchangeRateService(controller *gomock.Controller) exchangerates.Service {
+	rate := &exchangerates.ExchangeRate{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseExchangeRate: &exchangerates.BaseExchangeRate{
+			Date:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			BaseCurrency:  "USD",
+			QuoteCurrency: "TWD",
+			Rate:          decimal.NewFromInt(32),
+		},
+	}
+
+	exchangeRateService := exchangerates.NewMockService(controller)
+	exchangeRateService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&exchangerates.CreateReply{
+		Rate: rate,
+	}, nil).AnyTimes()
+	exchangeRateService.EXPECT().List(gomock.Any(), gomock.Any()).Return(&exchangerates.ListReply{
+		Rates: []*exchangerates.ExchangeRate{rate},
+	}, nil).AnyTimes()
+	exchangeRateService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&exchangerates.DeleteReply{}, nil).AnyTimes()
+	exchangeRateService.EXPECT().Import(gomock.Any(), gomock.Any()).Return(&exchangerates.ImportReply{
+		ImportedCount: 1,
+	}, nil).AnyTimes()
+	exchangeRateService.EXPECT().Convert(gomock.Any(), gomock.Any()).Return(&exchangerates.ConvertReply{
+		Amount: decimal.NewFromInt(320),
+		Rate:   decimal.NewFromInt(32),
+	}, nil).AnyTimes()
+	return exchangeRateService
+}
+
+func newCalendarService(controller *gomock.Controller) calendar.Service {
+	item := &calendar.DailyItem{
+		ID:       0,
+		PublicID: "PublicID",
+		BaseDailyItem: &dailyitems.BaseDailyItem{
+			Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			BaseItem: &dailyitems.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{"CategoryID"},
+				Price:             decimal.NewFromInt(1),
+			},
+		},
+	}
+
+	calendarService := calendar.NewMockService(controller)
+	calendarService.EXPECT().Month(gomock.Any(), gomock.Any()).Return(&calendar.MonthReply{
+		Days: []*calendar.Day{
+			{
+				Date:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Expense: decimal.NewFromInt(1),
+				Net:     decimal.NewFromInt(-1),
+				Count:   1,
+				Items:   []*calendar.DailyItem{item},
+			},
+		},
+	}, nil).AnyTimes()
+	return calendarService
+}
+
+func newReportService(controller *gomock.Controller) reports.Service {
+	reportService := reports.NewMockService(controller)
+	reportService.EXPECT().CategoryBreakdown(gomock.Any(), gomock.Any()).Return(&reports.CategoryBreakdownReply{
+		Total: decimal.NewFromInt(100),
+		Categories: []*reports.CategoryShare{
+			{
+				CategoryPublicID: "CategoryID",
+				Amount:           decimal.NewFromInt(100),
+				Count:            2,
+				Percentage:       decimal.NewFromInt(100),
+			},
+		},
+	}, nil).AnyTimes()
+	reportService.EXPECT().TagTotals(gomock.Any(), gomock.Any()).Return(&reports.TagTotalsReply{
+		Tags: []*reports.TagTotal{
+			{
+				TagPublicID: "TagID",
+				Amount:      decimal.NewFromInt(100),
+				Count:       2,
+			},
+		},
+	}, nil).AnyTimes()
+	reportService.EXPECT().ShopPriceRanking(gomock.Any(), gomock.Any()).Return(&reports.ShopPriceRankingReply{
+		Shops: []*reports.ShopPrice{
+			{
+				ShopPublicID: "ShopID",
+				LatestPrice:  decimal.NewFromInt(10),
+				LatestDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				AveragePrice: decimal.NewFromInt(10),
+				Count:        1,
+			},
+		},
+	}, nil).AnyTimes()
+	reportService.EXPECT().NetWorth(gomock.Any(), gomock.Any()).Return(&reports.NetWorthReply{
+		Assets:      decimal.NewFromInt(100),
+		Liabilities: decimal.NewFromInt(30),
+		NetWorth:    decimal.NewFromInt(70),
+		AssetAccounts: []*reports.AccountBalance{
+			{AccountPublicID: "AccountID", Type: repository.AccountTypeCash, Balance: decimal.NewFromInt(100)},
+		},
+		LiabilityAccounts: []*reports.AccountBalance{
+			{AccountPublicID: "CardID", Type: repository.AccountTypeCreditCard, Balance: decimal.NewFromInt(-30)},
+		},
+	}, nil).AnyTimes()
+	return reportService
+}
+
+func newLedgerService(controller *gomock.Controller) ledger.Service {
+	ledgerService := ledger.NewMockService(controller)
+	ledgerService.EXPECT().Export(gomock.Any(), gomock.Any()).Return(&ledger.ExportReply{
+		Document: &ledger.Document{
+			Version: ledger.DocumentVersion,
+			Config:  &ledger.Config{},
+			Accounts: []*ledger.Account{
+				{
+					ID:             "AccountID",
+					Name:           "A",
+					InitialBalance: decimal.NewFromInt(100),
+				},
+			},
+			Categories:     []*ledger.Category{},
+			Shops:          []*ledger.Shop{},
+			Fees:           []*ledger.Fee{},
+			DailyItems:     []*ledger.DailyItem{},
+			RepeatingItems: []*ledger.RepeatingItem{},
+		},
+	}, nil).AnyTimes()
+	ledgerService.EXPECT().Import(gomock.Any(), gomock.Cond(func(r *ledger.ImportRequest) bool {
+		return len(r.Document.Transfers) > 0
+	})).Return(nil, fmt.Errorf("%w: %w", ledger.ErrCurrencyMismatch, &ledger.RecordError{
+		Record:  "transfers[0]",
+		ID:      "TransferID",
+		Message: "the currencies are mismatched",
+	})).AnyTimes()
+	ledgerService.EXPECT().Import(gomock.Any(), gomock.Any()).Return(&ledger.ImportReply{
+		Errors:    []*ledger.RecordError{},
+		PublicIDs: map[string]string{},
+	}, nil).AnyTimes()
+	return ledgerService
+}
+
+func newBasicBudget() models.BasicBudget {
+	return models.BasicBudget{
+		CategoryId: "CategoryID",
+		Limit:      "100",
+		StartMonth: "2025-01",
+	}
+}
@@ -0,0 +1,162 @@
+package ledger
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+
+	"github.com/kataras/iris/v12"
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/server/models"
+)
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeXML  = "application/xml"
+)
+
+type IrisController struct {
+	s Service
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		s: s,
+	}
+}
+
+// Export writes the Document in XML if the request accepts application/xml, or in JSON
+// otherwise.
+func (controller *IrisController) Export(c iris.Context) {
+	user := c.User()
+	if user == nil {
+		c.StopWithJSON(iris.StatusUnauthorized, &models.EmptyResponse{})
+		return
+	}
+
+	userID, err := user.GetID()
+	if err != nil {
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	reply, err := controller.s.Export(c.Request().Context(), &ExportRequest{
+		UserID: userID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			c.StopWithText(iris.StatusNotFound, err.Error())
+		case errors.Is(err, ErrDataInsufficient):
+			c.StopWithText(iris.StatusBadRequest, err.Error())
+		default:
+			c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		}
+		return
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), contentTypeXML) {
+		c.ContentType(contentTypeXML)
+		c.Header("Content-Disposition", `attachment; filename="maney.xml"`)
+		if _, err := c.WriteString(xml.Header); err != nil {
+			c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+			return
+		}
+		encoder := xml.NewEncoder(c)
+		encoder.Indent("", "  ")
+		if err := encoder.Encode(reply.Document); err != nil {
+			c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+			return
+		}
+		// ends with the new line like JSON.
+		_, _ = c.WriteString("\n")
+		return
+	}
+
+	c.ContentType(contentTypeJSON)
+	c.Header("Content-Disposition", `attachment; filename="maney.json"`)
+	encoder := json.NewEncoder(c)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reply.Document); err != nil {
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+	}
+}
+
+// Import reads the Document in XML if the content type of the request is XML, or in JSON
+// otherwise. It responds the invalid records with 400, including the record which the
+// repository rejects.
+func (controller *IrisController) Import(c iris.Context) {
+	user := c.User()
+	if user == nil {
+		c.StopWithJSON(iris.StatusUnauthorized, &models.EmptyResponse{})
+		return
+	}
+
+	userID, err := user.GetID()
+	if err != nil {
+		c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		return
+	}
+
+	doc := &Document{}
+	if strings.Contains(c.GetContentTypeRequested(), "xml") {
+		err = xml.NewDecoder(c.Request().Body).Decode(doc)
+	} else {
+		err = json.NewDecoder(c.Request().Body).Decode(doc)
+	}
+	if err != nil {
+		c.StopWithText(iris.StatusBadRequest, "invalid document: %v", err)
+		return
+	}
+
+	dryRun := c.URLParamBoolDefault("dryRun", false)
+	reply, err := controller.s.Import(c.Request().Context(), &ImportRequest{
+		UserID:   userID,
+		Document: doc,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrUnsupportedVersion):
+			c.StopWithText(iris.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrUserNotFound):
+			c.StopWithJSON(iris.StatusNotFound, &models.EmptyResponse{})
+		case errors.Is(err, ErrReferenceNotFound), errors.Is(err, ErrInvalidReference),
+			errors.Is(err, ErrCurrencyMismatch), errors.Is(err, ErrInvalidSplit):
+			record := &RecordError{Message: err.Error()}
+			if target := (*RecordError)(nil); errors.As(err, &target) {
+				record = target
+			}
+			c.StopWithJSON(iris.StatusBadRequest, &models.ImportLedgerResponse{
+				DryRun: dryRun,
+				Errors: toImportLedgerErrors([]*RecordError{record}),
+				Ids:    map[string]string{},
+			})
+		default:
+			c.StopWithPlainError(iris.StatusInternalServerError, iris.PrivateError(err))
+		}
+		return
+	}
+
+	status := iris.StatusOK
+	if len(reply.Errors) > 0 {
+		status = iris.StatusBadRequest
+	}
+	c.StopWithJSON(status, &models.ImportLedgerResponse{
+		DryRun: dryRun,
+		Errors: toImportLedgerErrors(reply.Errors),
+		Ids:    reply.PublicIDs,
+	})
+}
+
+func toImportLedgerErrors(errs []*RecordError) []models.ImportLedgerError {
+	return lo.Map(errs, func(item *RecordError, _ int) models.ImportLedgerError {
+		return models.ImportLedgerError{
+			Record:  item.Record,
+			Id:      lo.EmptyableToPtr(item.ID),
+			Message: item.Message,
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package ledger
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DocumentVersion is the version of the Document which is exported.
+const DocumentVersion = 1
+
+// Document is the ledger of a user which can be encoded in JSON or XML. The resources
+// are referenced by their public ids, and they are sorted in the order of creation
+// except the daily items, the transfers and the exchange rates which are sorted by date,
+// and the statement payments which are sorted by the closing date of each account. The
+// fee items of the transfers are in their transfers instead of the daily items.
+type Document struct {
+	XMLName           xml.Name            `json:"-" xml:"ledger"`
+	Version           int                 `json:"version" xml:"version,attr"`
+	Config            *Config             `json:"config" xml:"config"`
+	Accounts          []*Account          `json:"accounts" xml:"accounts>account"`
+	Categories        []*Category         `json:"categories" xml:"categories>category"`
+	Shops             []*Shop             `json:"shops" xml:"shops>shop"`
+	Tags              []*Tag              `json:"tags" xml:"tags>tag"`
+	Fees              []*Fee              `json:"fees" xml:"fees>fee"`
+	DailyItems        []*DailyItem        `json:"dailyItems" xml:"dailyItems>dailyItem"`
+	RepeatingItems    []*RepeatingItem    `json:"repeatingItems" xml:"repeatingItems>repeatingItem"`
+	Transfers         []*Transfer         `json:"transfers" xml:"transfers>transfer"`
+	StatementPayments []*StatementPayment `json:"statementPayments" xml:"statementPayments>statementPayment"`
+	Budgets           []*Budget           `json:"budgets" xml:"budgets>budget"`
+	ExchangeRates     []*ExchangeRate     `json:"exchangeRates" xml:"exchangeRates>exchangeRate"`
+	UnitConversions   []*UnitConversion   `json:"unitConversions" xml:"unitConversions>unitConversion"`
+}
+
+type Config struct {
+	CompareItemsInDifferentShop bool   `json:"compareItemsInDifferentShop" xml:"compareItemsInDifferentShop"`
+	CompareItemsInSameShop      bool   `json:"compareItemsInSameShop" xml:"compareItemsInSameShop"`
+	HomeCurrency                string `json:"homeCurrency,omitempty" xml:"homeCurrency,omitempty"`
+}
+
+type Account struct {
+	ID             string          `json:"id" xml:"id,attr"`
+	Name           string          `json:"name" xml:"name"`
+	IconID         int32           `json:"iconId" xml:"iconId"`
+	InitialBalance decimal.Decimal `json:"initialBalance" xml:"initialBalance"`
+	Currency       string          `json:"currency,omitempty" xml:"currency,omitempty"`
+	// Type is one of "cash", "bank", "creditCard", "eWallet" and "storedValueCard", it is
+	// "cash" if it is absent.
+	Type                string           `json:"type,omitempty" xml:"type,omitempty"`
+	BankName            string           `json:"bankName,omitempty" xml:"bankName,omitempty"`
+	LastDigits          string           `json:"lastDigits,omitempty" xml:"lastDigits,omitempty"`
+	CreditLimit         *decimal.Decimal `json:"creditLimit,omitempty" xml:"creditLimit,omitempty"`
+	StatementClosingDay int              `json:"statementClosingDay,omitempty" xml:"statementClosingDay,omitempty"`
+	PaymentDueDay       int              `json:"paymentDueDay,omitempty" xml:"paymentDueDay,omitempty"`
+}
+
+type Category struct {
+	ID string `json:"id" xml:"id,attr"`
+	// Type is either "expense" or "income".
+	Type   string `json:"type" xml:"type,attr"`
+	Name   string `json:"name" xml:"name"`
+	IconID int32  `json:"iconId" xml:"iconId"`
+	// ParentID is the id of the parent category of the same type, it is empty for the
+	// top-level category.
+	ParentID string `json:"parentId,omitempty" xml:"parentId,omitempty"`
+}
+
+type Shop struct {
+	ID        string          `json:"id" xml:"id,attr"`
+	Name      string          `json:"name" xml:"name"`
+	Address   string          `json:"address,omitempty" xml:"address,omitempty"`
+	Locations []*ShopLocation `json:"locations,omitempty" xml:"locations>location,omitempty"`
+	Memo      string          `json:"memo,omitempty" xml:"memo,omitempty"`
+}
+
+// ShopLocation is a branch of the shop, the latitude and the longitude are either both
+// present or both absent.
+type ShopLocation struct {
+	Label     string   `json:"label,omitempty" xml:"label,omitempty"`
+	Address   string   `json:"address,omitempty" xml:"address,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty" xml:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty" xml:"longitude,omitempty"`
+}
+
+type Tag struct {
+	ID   string `json:"id" xml:"id,attr"`
+	Name string `json:"name" xml:"name"`
+}
+
+type Fee struct {
+	ID   string `json:"id" xml:"id,attr"`
+	Name string `json:"name" xml:"name"`
+	// Type is 0 for rate fee, 1 for fixed fee, 2 for combined fee and 3 for tiered fee.
+	Type  int8             `json:"type" xml:"type"`
+	Rate  *decimal.Decimal `json:"rate,omitempty" xml:"rate,omitempty"`
+	Fixed *decimal.Decimal `json:"fixed,omitempty" xml:"fixed,omitempty"`
+	Tiers []*FeeTier       `json:"tiers,omitempty" xml:"tiers>tier,omitempty"`
+	Min   *decimal.Decimal `json:"min,omitempty" xml:"min,omitempty"`
+	Max   *decimal.Decimal `json:"max,omitempty" xml:"max,omitempty"`
+}
+
+type FeeTier struct {
+	UpTo  *decimal.Decimal `json:"upTo,omitempty" xml:"upTo,omitempty"`
+	Rate  *decimal.Decimal `json:"rate,omitempty" xml:"rate,omitempty"`
+	Fixed *decimal.Decimal `json:"fixed,omitempty" xml:"fixed,omitempty"`
+}
+
+type DailyItem struct {
+	ID   string `json:"id" xml:"id,attr"`
+	Date Date   `json:"date" xml:"date"`
+	// RepeatingItemID is the repeating item which the item is created from.
+	RepeatingItemID string `json:"repeatingItemId,omitempty" xml:"repeatingItemId,omitempty"`
+	// TagIDs are the tags of the item, the item may have no tag.
+	TagIDs []string `json:"tagIds,omitempty" xml:"tagIds>tagId,omitempty"`
+	*Item
+}
+
+type RepeatingItem struct {
+	ID           string `json:"id" xml:"id,attr"`
+	StartDate    Date   `json:"startDate" xml:"startDate"`
+	EndDate      *Date  `json:"endDate,omitempty" xml:"endDate,omitempty"`
+	EveryDays    int32  `json:"everyDays,omitempty" xml:"everyDays,omitempty"`
+	EveryWorkDay bool   `json:"everyWorkDay,omitempty" xml:"everyWorkDay,omitempty"`
+	// NextDate is the date of the next occurrence which is not created yet.
+	NextDate *Date `json:"nextDate,omitempty" xml:"nextDate,omitempty"`
+	Item     *Item `json:"item" xml:"item"`
+}
+
+type Item struct {
+	Name        string           `json:"name" xml:"name"`
+	CategoryIDs []string         `json:"categoryIds" xml:"categoryIds>categoryId"`
+	Splits      []*ItemSplit     `json:"splits,omitempty" xml:"splits>split,omitempty"`
+	ShopID      string           `json:"shopId,omitempty" xml:"shopId,omitempty"`
+	AccountID   string           `json:"accountId,omitempty" xml:"accountId,omitempty"`
+	Quantity    *decimal.Decimal `json:"quantity,omitempty" xml:"quantity,omitempty"`
+	// Unit is one of "g", "kg", "ml", "L", "pcs" and "pack", it is absent if the quantity
+	// has no unit.
+	Unit     string           `json:"unit,omitempty" xml:"unit,omitempty"`
+	FeeID    string           `json:"feeId,omitempty" xml:"feeId,omitempty"`
+	Fee      *decimal.Decimal `json:"fee,omitempty" xml:"fee,omitempty"`
+	Price    decimal.Decimal  `json:"price" xml:"price"`
+	Currency string           `json:"currency,omitempty" xml:"currency,omitempty"`
+	Memo     string           `json:"memo,omitempty" xml:"memo,omitempty"`
+}
+
+// ItemSplit is the part of the amount of the item which is attributed to the category.
+type ItemSplit struct {
+	CategoryID string           `json:"categoryId" xml:"categoryId,attr"`
+	Amount     *decimal.Decimal `json:"amount,omitempty" xml:"amount,omitempty"`
+	Percentage *decimal.Decimal `json:"percentage,omitempty" xml:"percentage,omitempty"`
+}
+
+type Transfer struct {
+	ID            string          `json:"id" xml:"id,attr"`
+	Date          Date            `json:"date" xml:"date"`
+	FromAccountID string          `json:"fromAccountId" xml:"fromAccountId"`
+	ToAccountID   string          `json:"toAccountId" xml:"toAccountId"`
+	Amount        decimal.Decimal `json:"amount" xml:"amount"`
+	FeeID         string          `json:"feeId,omitempty" xml:"feeId,omitempty"`
+	// Fee is the charged fee, it is absent if there is no fee.
+	Fee *decimal.Decimal `json:"fee,omitempty" xml:"fee,omitempty"`
+	// FeeItem is the expense daily item which records the fee, it is absent if the fee is
+	// absent or zero.
+	FeeItem *TransferFeeItem `json:"feeItem,omitempty" xml:"feeItem,omitempty"`
+	Memo    string           `json:"memo,omitempty" xml:"memo,omitempty"`
+}
+
+// TransferFeeItem is charged to the source account of the transfer on the date of the
+// transfer.
+type TransferFeeItem struct {
+	ID         string `json:"id" xml:"id,attr"`
+	CategoryID string `json:"categoryId" xml:"categoryId"`
+}
+
+// StatementPayment marks the statement of the credit card as paid.
+type StatementPayment struct {
+	AccountID string `json:"accountId" xml:"accountId,attr"`
+	// ClosingDate is the last date of the cycle of the statement.
+	ClosingDate Date            `json:"closingDate" xml:"closingDate"`
+	Date        Date            `json:"date" xml:"date"`
+	Amount      decimal.Decimal `json:"amount" xml:"amount"`
+	// TransferID is the transfer which pays the statement, it is absent if the statement
+	// is marked as paid without the transfer.
+	TransferID string `json:"transferId,omitempty" xml:"transferId,omitempty"`
+}
+
+type Budget struct {
+	ID string `json:"id" xml:"id,attr"`
+	// CategoryID is the id of the expense category.
+	CategoryID string          `json:"categoryId" xml:"categoryId"`
+	Limit      decimal.Decimal `json:"limit" xml:"limit"`
+	Rollover   bool            `json:"rollover,omitempty" xml:"rollover,omitempty"`
+	// StartMonth is the first day of the month which the budget starts from.
+	StartMonth Date `json:"startMonth" xml:"startMonth"`
+}
+
+type ExchangeRate struct {
+	ID            string          `json:"id" xml:"id,attr"`
+	Date          Date            `json:"date" xml:"date"`
+	BaseCurrency  string          `json:"baseCurrency" xml:"baseCurrency"`
+	QuoteCurrency string          `json:"quoteCurrency" xml:"quoteCurrency"`
+	Rate          decimal.Decimal `json:"rate" xml:"rate"`
+}
+
+type UnitConversion struct {
+	ID     string          `json:"id" xml:"id,attr"`
+	Unit   string          `json:"unit" xml:"unit"`
+	ToUnit string          `json:"toUnit" xml:"toUnit"`
+	Factor decimal.Decimal `json:"factor" xml:"factor"`
+}
+
+// Date is encoded as "2006-01-02".
+type Date time.Time
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(time.Time(d).Format(time.DateOnly)), nil
+}
+
+func (d *Date) UnmarshalText(b []byte) error {
+	t, err := time.Parse(time.DateOnly, string(b))
+	if err != nil {
+		return fmt.Errorf("invalid date[%s]", b)
+	}
+	*d = Date(t)
+	return nil
+}
@@ -0,0 +1,989 @@
+package ledger
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/currency"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/icons"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/repository"
+)
+
+// importer validates the records of a Document and converts them into the resources
+// with new public ids. The invalid records are collected in errors instead of failing
+// on the first one.
+type importer struct {
+	genPublicID   func(prefix string) string
+	iconValidator icons.Validator
+
+	errors []*RecordError
+	// publicIDs maps the ids in the Document to the new public ids.
+	publicIDs map[string]string
+	// records maps the keys of repository.ImportError to the records which they are
+	// imported from, the messages of the records are empty.
+	records map[string]*RecordError
+
+	// the followings are indexed by the ids in the Document.
+	accountCurrencies map[string]string
+	accountTypes      map[string]repository.AccountType
+	categoryTypes     map[string]repository.CategoryType
+	shops             map[string]struct{}
+	tags              map[string]struct{}
+	fees              map[string]*repository.BaseFee
+	repeatingItems    map[string]struct{}
+	transfers         map[string]*repository.ImportTransfer
+	// paidTransfers are the transfers which pay the statements.
+	paidTransfers    map[*repository.ImportTransfer]struct{}
+	budgetCategories map[string]struct{}
+}
+
+func newImporter(genPublicID func(prefix string) string, iconValidator icons.Validator) *importer {
+	return &importer{
+		genPublicID:       genPublicID,
+		iconValidator:     iconValidator,
+		errors:            []*RecordError{},
+		publicIDs:         map[string]string{},
+		records:           map[string]*RecordError{},
+		accountCurrencies: map[string]string{},
+		accountTypes:      map[string]repository.AccountType{},
+		categoryTypes:     map[string]repository.CategoryType{},
+		shops:             map[string]struct{}{},
+		tags:              map[string]struct{}{},
+		fees:              map[string]*repository.BaseFee{},
+		repeatingItems:    map[string]struct{}{},
+		transfers:         map[string]*repository.ImportTransfer{},
+		paidTransfers:     map[*repository.ImportTransfer]struct{}{},
+		budgetCategories:  map[string]struct{}{},
+	}
+}
+
+// Import converts the Document into the request of the user. The request is only valid
+// if there is no error after the call.
+func (im *importer) Import(ctx context.Context, userID string, doc *Document) *repository.ImportLedgerRequest {
+	r := &repository.ImportLedgerRequest{
+		UserID:            userID,
+		Accounts:          []*repository.BaseCreateAccount{},
+		ExpenseCategories: []*repository.BaseCreateCategory{},
+		IncomeCategories:  []*repository.BaseCreateCategory{},
+		Shops:             []*repository.BaseCreateShop{},
+		Tags:              []*repository.BaseCreateTag{},
+		Fees:              []*repository.BaseCreateFee{},
+		UnitConversions:   []*repository.BaseCreateUnitConversion{},
+		ExchangeRates:     []*repository.BaseCreateExchangeRate{},
+		RepeatingItems:    []*repository.BaseCreateRepeatingItem{},
+		DailyItems:        []*repository.BaseCreateDailyItem{},
+		Transfers:         []*repository.ImportTransfer{},
+		StatementPayments: []*repository.ImportStatementPayment{},
+		Budgets:           []*repository.ImportBudget{},
+	}
+
+	r.Config = im.importConfig(doc.Config)
+	// the referenced resources are imported before the items.
+	for i, v := range doc.Accounts {
+		if account := im.importAccount(ctx, fmt.Sprintf("accounts[%d]", i), v); account != nil {
+			r.Accounts = append(r.Accounts, account)
+		}
+	}
+	categories := []*importedCategory{}
+	for i, v := range doc.Categories {
+		record := fmt.Sprintf("categories[%d]", i)
+		if category := im.importCategory(ctx, record, v); category != nil {
+			categories = append(categories, category)
+		}
+	}
+	for _, category := range im.resolveParents(categories) {
+		if category.type_ == repository.CategoryTypeExpense {
+			r.ExpenseCategories = append(r.ExpenseCategories, category.category)
+		} else {
+			r.IncomeCategories = append(r.IncomeCategories, category.category)
+		}
+	}
+	for i, v := range doc.Shops {
+		if shop := im.importShop(fmt.Sprintf("shops[%d]", i), v); shop != nil {
+			r.Shops = append(r.Shops, shop)
+		}
+	}
+	tagNames := map[string]struct{}{}
+	for i, v := range doc.Tags {
+		if tag := im.importTag(fmt.Sprintf("tags[%d]", i), v, tagNames); tag != nil {
+			r.Tags = append(r.Tags, tag)
+		}
+	}
+	for i, v := range doc.Fees {
+		if fee := im.importFee(fmt.Sprintf("fees[%d]", i), v); fee != nil {
+			r.Fees = append(r.Fees, fee)
+		}
+	}
+	units := map[repository.Unit]repository.Unit{}
+	for i, v := range doc.UnitConversions {
+		if conversion := im.importUnitConversion(fmt.Sprintf("unitConversions[%d]", i), v, units); conversion != nil {
+			r.UnitConversions = append(r.UnitConversions, conversion)
+		}
+	}
+	rates := map[string]struct{}{}
+	for i, v := range doc.ExchangeRates {
+		if rate := im.importExchangeRate(fmt.Sprintf("exchangeRates[%d]", i), v, rates); rate != nil {
+			r.ExchangeRates = append(r.ExchangeRates, rate)
+		}
+	}
+	for i, v := range doc.RepeatingItems {
+		if item := im.importRepeatingItem(fmt.Sprintf("repeatingItems[%d]", i), v); item != nil {
+			r.RepeatingItems = append(r.RepeatingItems, item)
+		}
+	}
+	for i, v := range doc.DailyItems {
+		if item := im.importDailyItem(fmt.Sprintf("dailyItems[%d]", i), v); item != nil {
+			r.DailyItems = append(r.DailyItems, item)
+		}
+	}
+	transfers := []*repository.ImportTransfer{}
+	for i, v := range doc.Transfers {
+		if transfer := im.importTransfer(fmt.Sprintf("transfers[%d]", i), v); transfer != nil {
+			transfers = append(transfers, transfer)
+		}
+	}
+	payments := map[string]struct{}{}
+	for i, v := range doc.StatementPayments {
+		if payment := im.importStatementPayment(fmt.Sprintf("statementPayments[%d]", i), v, payments); payment != nil {
+			r.StatementPayments = append(r.StatementPayments, payment)
+		}
+	}
+	// the transfers which pay the statements are created with the payments.
+	r.Transfers = lo.Filter(transfers, func(item *repository.ImportTransfer, _ int) bool {
+		_, ok := im.paidTransfers[item]
+		return !ok
+	})
+	for i, v := range doc.Budgets {
+		if budget := im.importBudget(fmt.Sprintf("budgets[%d]", i), v); budget != nil {
+			r.Budgets = append(r.Budgets, budget)
+		}
+	}
+	return r
+}
+
+func (im *importer) addError(record, id string, format string, args ...any) {
+	im.errors = append(im.errors, &RecordError{
+		Record:  record,
+		ID:      id,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// register generates the new public id for the id of the record. It returns false if
+// the id is missing or duplicated.
+func (im *importer) register(record, id, prefix string) (string, bool) {
+	if id == "" {
+		im.addError(record, id, "missing id")
+		return "", false
+	}
+	if _, ok := im.publicIDs[id]; ok {
+		im.addError(record, id, "duplicated id[%s]", id)
+		return "", false
+	}
+	publicID := im.genPublicID(prefix)
+	im.publicIDs[id] = publicID
+	im.records[publicID] = &RecordError{Record: record, ID: id}
+	return publicID, true
+}
+
+func (im *importer) importConfig(v *Config) *repository.UserConfig {
+	if v == nil {
+		return nil
+	}
+	code, err := currency.Normalize(v.HomeCurrency)
+	if err != nil {
+		im.addError("config", "", "%v", err)
+		return nil
+	}
+	return &repository.UserConfig{
+		CompareItemsInDifferentShop: v.CompareItemsInDifferentShop,
+		CompareItemsInSameShop:      v.CompareItemsInSameShop,
+		HomeCurrency:                lo.EmptyableToPtr(code),
+	}
+}
+
+func (im *importer) importAccount(ctx context.Context, record string, v *Account) *repository.BaseCreateAccount {
+	if v == nil {
+		im.addError(record, "", "missing account")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "act")
+	if !ok {
+		return nil
+	}
+	code, err := currency.Normalize(v.Currency)
+	if err != nil {
+		im.addError(record, v.ID, "%v", err)
+		return nil
+	}
+	type_ := repository.AccountTypeCash
+	if v.Type != "" {
+		type_, err = repository.ToAccountType(v.Type)
+		if err != nil {
+			im.addError(record, v.ID, "%v", err)
+			return nil
+		}
+	}
+	if !im.validateIcon(ctx, record, v.ID, icons.KindAccount, v.IconID) {
+		return nil
+	}
+	im.accountCurrencies[v.ID] = code
+	im.accountTypes[v.ID] = type_
+	return &repository.BaseCreateAccount{
+		PublicID: publicID,
+		BaseAccount: &repository.BaseAccount{
+			Name:                v.Name,
+			IconID:              v.IconID,
+			InitialBalance:      v.InitialBalance,
+			Currency:            code,
+			Type:                type_,
+			BankName:            v.BankName,
+			LastDigits:          v.LastDigits,
+			CreditLimit:         v.CreditLimit,
+			StatementClosingDay: v.StatementClosingDay,
+			PaymentDueDay:       v.PaymentDueDay,
+		},
+	}
+}
+
+// validateIcon returns false if the icon is not in the catalog of the kind.
+func (im *importer) validateIcon(ctx context.Context, record, id string, kind icons.Kind, iconID int32) bool {
+	err := im.iconValidator.Validate(ctx, &icons.ValidateRequest{
+		Kind:   kind,
+		IconID: iconID,
+	})
+	if err != nil {
+		im.addError(record, id, "%v", err)
+		return false
+	}
+	return true
+}
+
+type importedCategory struct {
+	record   string
+	id       string
+	parentID string
+	type_    repository.CategoryType
+	category *repository.BaseCreateCategory
+}
+
+func (im *importer) importCategory(ctx context.Context, record string, v *Category) *importedCategory {
+	if v == nil {
+		im.addError(record, "", "missing category")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "cat")
+	if !ok {
+		return nil
+	}
+	type_, err := repository.ToCategoryType(v.Type)
+	if err != nil {
+		im.addError(record, v.ID, "%v", err)
+		return nil
+	}
+	kind := icons.KindExpense
+	if type_ == repository.CategoryTypeIncome {
+		kind = icons.KindIncome
+	}
+	if !im.validateIcon(ctx, record, v.ID, kind, v.IconID) {
+		return nil
+	}
+	im.categoryTypes[v.ID] = type_
+	return &importedCategory{
+		record:   record,
+		id:       v.ID,
+		parentID: v.ParentID,
+		type_:    type_,
+		category: &repository.BaseCreateCategory{
+			PublicID: publicID,
+			BaseCategory: &repository.BaseCategory{
+				Name:   v.Name,
+				IconID: v.IconID,
+			},
+		},
+	}
+}
+
+// resolveParents remaps the parents of the categories which may be anywhere in the
+// Document, and orders the categories so that the parents precede their children.
+func (im *importer) resolveParents(categories []*importedCategory) []*importedCategory {
+	parentIDs := lo.SliceToMap(categories, func(item *importedCategory) (string, string) {
+		return item.id, item.parentID
+	})
+
+	depths := map[string]int{}
+	resolved := lo.Filter(categories, func(item *importedCategory, _ int) bool {
+		if item.parentID == "" {
+			return true
+		}
+		type_, ok := im.categoryTypes[item.parentID]
+		if !ok {
+			im.addError(item.record, item.id, "parent category[%s] not found", item.parentID)
+			return false
+		}
+		if type_ != item.type_ {
+			im.addError(item.record, item.id, "parent category[%s] is not the same type", item.parentID)
+			return false
+		}
+
+		// the ancestors are walked no more than the number of the categories unless
+		// there is a cycle.
+		depth := 0
+		for id := item.parentID; id != ""; id = parentIDs[id] {
+			if id == item.id || depth == len(categories) {
+				im.addError(item.record, item.id, "parent category[%s] is circular", item.parentID)
+				return false
+			}
+			depth++
+		}
+		depths[item.id] = depth
+
+		item.category.ParentPublicID = lo.ToPtr(im.publicIDs[item.parentID])
+		return true
+	})
+
+	slices.SortStableFunc(resolved, func(a, b *importedCategory) int {
+		return cmp.Compare(depths[a.id], depths[b.id])
+	})
+	return resolved
+}
+
+func (im *importer) importShop(record string, v *Shop) *repository.BaseCreateShop {
+	if v == nil {
+		im.addError(record, "", "missing shop")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "shp")
+	if !ok {
+		return nil
+	}
+	var locations []*repository.ShopLocation
+	for _, location := range v.Locations {
+		var coordinate *repository.Coordinate
+		if location.Latitude != nil || location.Longitude != nil {
+			if location.Latitude == nil || location.Longitude == nil {
+				im.addError(record, v.ID, "missing the latitude or the longitude of the location")
+				return nil
+			}
+			coordinate = &repository.Coordinate{
+				Latitude:  *location.Latitude,
+				Longitude: *location.Longitude,
+			}
+			if !coordinate.Valid() {
+				im.addError(record, v.ID, "invalid coordinate of the location")
+				return nil
+			}
+		}
+		locations = append(locations, &repository.ShopLocation{
+			Label:      location.Label,
+			Address:    location.Address,
+			Coordinate: coordinate,
+		})
+	}
+
+	im.shops[v.ID] = struct{}{}
+	return &repository.BaseCreateShop{
+		PublicID: publicID,
+		BaseShop: &repository.BaseShop{
+			Name:      v.Name,
+			Address:   v.Address,
+			Locations: locations,
+			Memo:      v.Memo,
+		},
+	}
+}
+
+// importTag trims the spaces around the name, names are the names of the imported tags
+// since the names of the tags of the user are unique.
+func (im *importer) importTag(record string, v *Tag, names map[string]struct{}) *repository.BaseCreateTag {
+	if v == nil {
+		im.addError(record, "", "missing tag")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "tag")
+	if !ok {
+		return nil
+	}
+	name := strings.TrimSpace(v.Name)
+	if name == "" {
+		im.addError(record, v.ID, "missing name")
+		return nil
+	}
+	if _, ok := names[name]; ok {
+		im.addError(record, v.ID, "duplicated name[%s]", name)
+		return nil
+	}
+	names[name] = struct{}{}
+	im.tags[v.ID] = struct{}{}
+	return &repository.BaseCreateTag{
+		PublicID: publicID,
+		BaseTag:  &repository.BaseTag{Name: name},
+	}
+}
+
+func (im *importer) importFee(record string, v *Fee) *repository.BaseCreateFee {
+	if v == nil {
+		im.addError(record, "", "missing fee")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "fee")
+	if !ok {
+		return nil
+	}
+	fee := &repository.BaseFee{
+		Name:  v.Name,
+		Type:  v.Type,
+		Rate:  v.Rate,
+		Fixed: v.Fixed,
+		Min:   v.Min,
+		Max:   v.Max,
+	}
+	for _, tier := range v.Tiers {
+		if tier == nil {
+			fee.Tiers = append(fee.Tiers, nil)
+			continue
+		}
+		fee.Tiers = append(fee.Tiers, &repository.FeeTier{
+			UpTo:  tier.UpTo,
+			Rate:  tier.Rate,
+			Fixed: tier.Fixed,
+		})
+	}
+	if err := fees.ValidateFee((*fees.BaseFee)(fee)); err != nil {
+		im.addError(record, v.ID, "%v", err)
+		return nil
+	}
+	im.fees[v.ID] = fee
+	return &repository.BaseCreateFee{
+		PublicID: publicID,
+		BaseFee:  fee,
+	}
+}
+
+func (im *importer) importRepeatingItem(record string, v *RepeatingItem) *repository.BaseCreateRepeatingItem {
+	if v == nil {
+		im.addError(record, "", "missing repeating item")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "rpt")
+	if !ok {
+		return nil
+	}
+	item, ok := im.importItem(record, v.ID, v.Item)
+	if !ok {
+		return nil
+	}
+	repeatingItem := &repository.BaseRepeatingItem{
+		Item:      item,
+		StartDate: time.Time(v.StartDate),
+		EndDate:   fromOptionalDate(v.EndDate),
+		Frequency: &repository.RepeatingFrequency{
+			Days:         v.EveryDays,
+			EveryWorkDay: v.EveryWorkDay,
+		},
+	}
+	if err := repeatingitems.ValidateBaseRepeatingItem((*repeatingitems.BaseRepeatingItem)(repeatingItem)); err != nil {
+		im.addError(record, v.ID, "%v", err)
+		return nil
+	}
+	im.repeatingItems[v.ID] = struct{}{}
+	return &repository.BaseCreateRepeatingItem{
+		PublicID:          publicID,
+		BaseRepeatingItem: repeatingItem,
+		NextDate:          fromOptionalDate(v.NextDate),
+	}
+}
+
+func (im *importer) importDailyItem(record string, v *DailyItem) *repository.BaseCreateDailyItem {
+	if v == nil {
+		im.addError(record, "", "missing daily item")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "itm")
+	if !ok {
+		return nil
+	}
+	if v.RepeatingItemID != "" {
+		if _, ok := im.repeatingItems[v.RepeatingItemID]; !ok {
+			im.addError(record, v.ID, "repeating item[%s] not found", v.RepeatingItemID)
+			return nil
+		}
+	}
+	var tagPublicIDs []string
+	for _, tagID := range lo.Uniq(v.TagIDs) {
+		if _, ok := im.tags[tagID]; !ok {
+			im.addError(record, v.ID, "tag[%s] not found", tagID)
+			return nil
+		}
+		tagPublicIDs = append(tagPublicIDs, im.publicIDs[tagID])
+	}
+	item, ok := im.importItem(record, v.ID, v.Item)
+	if !ok {
+		return nil
+	}
+	dailyItem := &repository.BaseDailyItem{
+		Date: time.Time(v.Date),
+		RepeatingItemPublicID: lo.IfF(v.RepeatingItemID != "", func() *string {
+			return lo.ToPtr(im.publicIDs[v.RepeatingItemID])
+		}).Else(nil),
+		TagPublicIDs: tagPublicIDs,
+		BaseItem:     item,
+	}
+	if err := dailyitems.ValidateBaseDailyItem((*dailyitems.BaseDailyItem)(dailyItem)); err != nil {
+		im.addError(record, v.ID, "%v", err)
+		return nil
+	}
+	return &repository.BaseCreateDailyItem{
+		PublicID:      publicID,
+		BaseDailyItem: dailyItem,
+	}
+}
+
+// importItem remaps the references of the item. The categories must be the same type, the
+// currency must be the currency of the account if both of them are provided, and the
+// splits of the item which references a fee must be valid with the charged fee. The other
+// fields are validated by the services of the daily items and the repeating items.
+func (im *importer) importItem(record, id string, v *Item) (*repository.BaseItem, bool) {
+	if v == nil {
+		im.addError(record, id, "missing item")
+		return nil, false
+	}
+	categoryPublicIDs := make([]string, len(v.CategoryIDs))
+	for i, categoryID := range v.CategoryIDs {
+		type_, ok := im.categoryTypes[categoryID]
+		if !ok {
+			im.addError(record, id, "category[%s] not found", categoryID)
+			return nil, false
+		}
+		if type_ != im.categoryTypes[v.CategoryIDs[0]] {
+			im.addError(record, id, "categories are not the same type")
+			return nil, false
+		}
+		categoryPublicIDs[i] = im.publicIDs[categoryID]
+	}
+
+	if v.ShopID != "" {
+		if _, ok := im.shops[v.ShopID]; !ok {
+			im.addError(record, id, "shop[%s] not found", v.ShopID)
+			return nil, false
+		}
+	}
+
+	if v.FeeID != "" {
+		if _, ok := im.fees[v.FeeID]; !ok {
+			im.addError(record, id, "fee[%s] not found", v.FeeID)
+			return nil, false
+		}
+	}
+
+	code, err := currency.Normalize(v.Currency)
+	if err != nil {
+		im.addError(record, id, "%v", err)
+		return nil, false
+	}
+	if v.AccountID != "" {
+		accountCurrency, ok := im.accountCurrencies[v.AccountID]
+		if !ok {
+			im.addError(record, id, "account[%s] not found", v.AccountID)
+			return nil, false
+		}
+		if code != "" && code != accountCurrency {
+			im.addError(record, id, "currency[%s] is not the currency of the account", code)
+			return nil, false
+		}
+	}
+
+	var splits []*repository.ItemSplit
+	for _, split := range v.Splits {
+		if _, ok := im.categoryTypes[split.CategoryID]; !ok {
+			im.addError(record, id, "category[%s] of the split not found", split.CategoryID)
+			return nil, false
+		}
+		splits = append(splits, &repository.ItemSplit{
+			CategoryPublicID: im.publicIDs[split.CategoryID],
+			Amount:           split.Amount,
+			Percentage:       split.Percentage,
+		})
+	}
+
+	item := &repository.BaseItem{
+		Name:              v.Name,
+		CategoryPublicIDs: categoryPublicIDs,
+		Splits:            splits,
+		ShopPublicID: lo.IfF(v.ShopID != "", func() *string {
+			return lo.ToPtr(im.publicIDs[v.ShopID])
+		}).Else(nil),
+		AccountPublicID: lo.IfF(v.AccountID != "", func() *string {
+			return lo.ToPtr(im.publicIDs[v.AccountID])
+		}).Else(nil),
+		Quantity: v.Quantity,
+		Unit:     repository.Unit(v.Unit),
+		FeePublicID: lo.IfF(v.FeeID != "", func() *string {
+			return lo.ToPtr(im.publicIDs[v.FeeID])
+		}).Else(nil),
+		Fee:      v.Fee,
+		Price:    v.Price,
+		Currency: code,
+		Memo:     v.Memo,
+	}
+	// the services leave the splits of the item which references a fee to the repository,
+	// so they are resolved with the fee which the repository computes by the fee.
+	if v.FeeID != "" {
+		resolved := *item
+		resolved.Fee = lo.ToPtr(im.fees[v.FeeID].Charge(item.Subtotal()).Round(6))
+		if _, err := resolved.ResolveSplits(); err != nil {
+			im.addError(record, id, "%v", err)
+			return nil, false
+		}
+	}
+	return item, true
+}
+
+// importUnitConversion validates the conversion, units maps the units of the imported
+// conversions to the units which they are converted into.
+func (im *importer) importUnitConversion(record string, v *UnitConversion, units map[repository.Unit]repository.Unit) *repository.BaseCreateUnitConversion {
+	if v == nil {
+		im.addError(record, "", "missing unit conversion")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "unc")
+	if !ok {
+		return nil
+	}
+	unit, toUnit := repository.Unit(v.Unit), repository.Unit(v.ToUnit)
+	if !unit.Convertible() {
+		im.addError(record, v.ID, "unit[%s] is not convertible", v.Unit)
+		return nil
+	}
+	if !toUnit.Valid() || toUnit == unit {
+		im.addError(record, v.ID, "invalid unit[%s] to convert into", v.ToUnit)
+		return nil
+	}
+	if !v.Factor.IsPositive() {
+		im.addError(record, v.ID, "factor must be positive")
+		return nil
+	}
+	if _, ok := units[unit]; ok {
+		im.addError(record, v.ID, "duplicated conversion of unit[%s]", v.Unit)
+		return nil
+	}
+	for next, ok := units[toUnit]; ok; next, ok = units[next] {
+		if next == unit {
+			im.addError(record, v.ID, "conversion of unit[%s] is circular", v.Unit)
+			return nil
+		}
+	}
+	units[unit] = toUnit
+	return &repository.BaseCreateUnitConversion{
+		PublicID: publicID,
+		BaseUnitConversion: &repository.BaseUnitConversion{
+			Unit:   unit,
+			ToUnit: toUnit,
+			Factor: v.Factor,
+		},
+	}
+}
+
+// importExchangeRate validates the exchange rate, rates are the keys of the imported
+// rates so that no rate is upserted twice.
+func (im *importer) importExchangeRate(record string, v *ExchangeRate, rates map[string]struct{}) *repository.BaseCreateExchangeRate {
+	if v == nil {
+		im.addError(record, "", "missing exchange rate")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "xrt")
+	if !ok {
+		return nil
+	}
+	if time.Time(v.Date).IsZero() {
+		im.addError(record, v.ID, "missing date")
+		return nil
+	}
+	base, err := currency.Normalize(v.BaseCurrency)
+	if err != nil || base == "" {
+		im.addError(record, v.ID, "invalid baseCurrency[%s]", v.BaseCurrency)
+		return nil
+	}
+	quote, err := currency.Normalize(v.QuoteCurrency)
+	if err != nil || quote == "" {
+		im.addError(record, v.ID, "invalid quoteCurrency[%s]", v.QuoteCurrency)
+		return nil
+	}
+	if base == quote {
+		im.addError(record, v.ID, "baseCurrency and quoteCurrency are the same")
+		return nil
+	}
+	if !v.Rate.IsPositive() {
+		im.addError(record, v.ID, "rate must be positive")
+		return nil
+	}
+	key := fmt.Sprintf("%s/%s@%s", base, quote, time.Time(v.Date).Format(time.DateOnly))
+	if _, ok := rates[key]; ok {
+		im.addError(record, v.ID, "duplicated rate of %s", key)
+		return nil
+	}
+	rates[key] = struct{}{}
+	return &repository.BaseCreateExchangeRate{
+		PublicID: publicID,
+		BaseExchangeRate: &repository.BaseExchangeRate{
+			Date:          time.Time(v.Date),
+			BaseCurrency:  base,
+			QuoteCurrency: quote,
+			Rate:          v.Rate,
+		},
+	}
+}
+
+// importTransfer validates the accounts and the fee of the transfer, the fee item is
+// required if the fee is not zero.
+func (im *importer) importTransfer(record string, v *Transfer) *repository.ImportTransfer {
+	if v == nil {
+		im.addError(record, "", "missing transfer")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "trf")
+	if !ok {
+		return nil
+	}
+	if time.Time(v.Date).IsZero() {
+		im.addError(record, v.ID, "missing date")
+		return nil
+	}
+	for _, accountID := range []string{v.FromAccountID, v.ToAccountID} {
+		if _, ok := im.accountCurrencies[accountID]; !ok {
+			im.addError(record, v.ID, "account[%s] not found", accountID)
+			return nil
+		}
+	}
+	if v.FromAccountID == v.ToAccountID {
+		im.addError(record, v.ID, "fromAccountId and toAccountId are the same")
+		return nil
+	}
+	if im.accountCurrencies[v.FromAccountID] != im.accountCurrencies[v.ToAccountID] {
+		im.addError(record, v.ID, "accounts are in different currencies")
+		return nil
+	}
+	if !v.Amount.IsPositive() {
+		im.addError(record, v.ID, "amount must be positive")
+		return nil
+	}
+
+	transfer := &repository.ImportTransfer{
+		PublicID: publicID,
+		Transfer: &repository.BaseTransfer{
+			Date:                time.Time(v.Date),
+			FromAccountPublicID: im.publicIDs[v.FromAccountID],
+			ToAccountPublicID:   im.publicIDs[v.ToAccountID],
+			Amount:              v.Amount,
+			Memo:                v.Memo,
+		},
+	}
+	if v.FeeID == "" {
+		if v.Fee != nil || v.FeeItem != nil {
+			im.addError(record, v.ID, "missing feeId of the fee")
+			return nil
+		}
+	} else {
+		fee, ok := im.fees[v.FeeID]
+		if !ok {
+			im.addError(record, v.ID, "fee[%s] not found", v.FeeID)
+			return nil
+		}
+		charged := fee.Charge(v.Amount).Round(6)
+		if v.Fee != nil {
+			charged = *v.Fee
+		}
+		if charged.IsNegative() {
+			im.addError(record, v.ID, "fee must not be negative")
+			return nil
+		}
+		transfer.Transfer.FeePublicID = lo.ToPtr(im.publicIDs[v.FeeID])
+		transfer.Fee = v.Fee
+		if !charged.IsZero() {
+			feeItem, ok := im.importTransferFeeItem(record, v.ID, v.FeeItem)
+			if !ok {
+				return nil
+			}
+			transfer.FeeItem = feeItem
+		}
+	}
+
+	im.transfers[v.ID] = transfer
+	return transfer
+}
+
+func (im *importer) importTransferFeeItem(record, id string, v *TransferFeeItem) (*repository.TransferFeeItem, bool) {
+	if v == nil {
+		im.addError(record, id, "missing feeItem")
+		return nil, false
+	}
+	type_, ok := im.categoryTypes[v.CategoryID]
+	if !ok {
+		im.addError(record, id, "category[%s] of the fee item not found", v.CategoryID)
+		return nil, false
+	}
+	if type_ != repository.CategoryTypeExpense {
+		im.addError(record, id, "category[%s] of the fee item is not an expense category", v.CategoryID)
+		return nil, false
+	}
+	publicID, ok := im.register(record, v.ID, "itm")
+	if !ok {
+		return nil, false
+	}
+	return &repository.TransferFeeItem{
+		PublicID:         publicID,
+		CategoryPublicID: im.publicIDs[v.CategoryID],
+	}, true
+}
+
+// importStatementPayment validates the payment of the credit card, payments are the keys
+// of the imported payments so that each statement is paid once.
+func (im *importer) importStatementPayment(record string, v *StatementPayment, payments map[string]struct{}) *repository.ImportStatementPayment {
+	if v == nil {
+		im.addError(record, "", "missing statement payment")
+		return nil
+	}
+	type_, ok := im.accountTypes[v.AccountID]
+	if !ok {
+		im.addError(record, "", "account[%s] not found", v.AccountID)
+		return nil
+	}
+	if type_ != repository.AccountTypeCreditCard {
+		im.addError(record, "", "account[%s] is not a credit card", v.AccountID)
+		return nil
+	}
+	if time.Time(v.ClosingDate).IsZero() {
+		im.addError(record, "", "missing closingDate")
+		return nil
+	}
+	if time.Time(v.Date).IsZero() {
+		im.addError(record, "", "missing date")
+		return nil
+	}
+
+	payment := &repository.ImportStatementPayment{
+		AccountPublicID: im.publicIDs[v.AccountID],
+		Payment: &repository.BaseStatementPayment{
+			ClosingDate: time.Time(v.ClosingDate),
+			Date:        time.Time(v.Date),
+			Amount:      v.Amount,
+		},
+	}
+	if _, ok := payments[payment.Key()]; ok {
+		im.addError(record, "", "duplicated payment of the statement closed on %s", time.Time(v.ClosingDate).Format(time.DateOnly))
+		return nil
+	}
+	if v.TransferID != "" {
+		transfer, ok := im.transfers[v.TransferID]
+		if !ok {
+			im.addError(record, "", "transfer[%s] not found", v.TransferID)
+			return nil
+		}
+		if transfer.Transfer.ToAccountPublicID != payment.AccountPublicID {
+			im.addError(record, "", "transfer[%s] is not to the credit card", v.TransferID)
+			return nil
+		}
+		if _, ok := im.paidTransfers[transfer]; ok {
+			im.addError(record, "", "transfer[%s] pays another statement", v.TransferID)
+			return nil
+		}
+		im.paidTransfers[transfer] = struct{}{}
+		payment.Transfer = transfer
+	}
+
+	payments[payment.Key()] = struct{}{}
+	im.records[payment.Key()] = &RecordError{Record: record}
+	return payment
+}
+
+func (im *importer) importBudget(record string, v *Budget) *repository.ImportBudget {
+	if v == nil {
+		im.addError(record, "", "missing budget")
+		return nil
+	}
+	publicID, ok := im.register(record, v.ID, "bgt")
+	if !ok {
+		return nil
+	}
+	type_, ok := im.categoryTypes[v.CategoryID]
+	if !ok {
+		im.addError(record, v.ID, "category[%s] not found", v.CategoryID)
+		return nil
+	}
+	if type_ != repository.CategoryTypeExpense {
+		im.addError(record, v.ID, "category[%s] is not an expense category", v.CategoryID)
+		return nil
+	}
+	if _, ok := im.budgetCategories[v.CategoryID]; ok {
+		im.addError(record, v.ID, "category[%s] has had a budget", v.CategoryID)
+		return nil
+	}
+	if time.Time(v.StartMonth).IsZero() {
+		im.addError(record, v.ID, "missing startMonth")
+		return nil
+	}
+	if !v.Limit.IsPositive() {
+		im.addError(record, v.ID, "limit must be positive")
+		return nil
+	}
+	im.budgetCategories[v.CategoryID] = struct{}{}
+	startMonth := time.Time(v.StartMonth)
+	return &repository.ImportBudget{
+		PublicID: publicID,
+		Budget: &repository.BaseBudget{
+			CategoryPublicID: im.publicIDs[v.CategoryID],
+			Limit:            v.Limit,
+			Rollover:         v.Rollover,
+			StartMonth:       time.Date(startMonth.Year(), startMonth.Month(), 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+// parseRepositoryError maps the error of repository.LedgerRepository.Import to the error
+// of the service, which wraps the record of the resource if the resource is known.
+func (im *importer) parseRepositoryError(err error) error {
+	var target error
+	switch {
+	case errors.Is(err, repository.ErrDataNotFound):
+		return ErrUserNotFound
+	case errors.Is(err, repository.ErrReferenceNotFound):
+		target = ErrReferenceNotFound
+	case errors.Is(err, repository.ErrInvalidReference):
+		target = ErrInvalidReference
+	case errors.Is(err, repository.ErrCurrencyMismatch):
+		target = ErrCurrencyMismatch
+	case errors.Is(err, repository.ErrInvalidSplit):
+		target = ErrInvalidSplit
+	default:
+		return err
+	}
+
+	var importErr *repository.ImportError
+	if errors.As(err, &importErr) {
+		if record, ok := im.records[importErr.Key]; ok {
+			return fmt.Errorf("%w: %w", target, &RecordError{
+				Record:  record.Record,
+				ID:      record.ID,
+				Message: importErr.Err.Error(),
+			})
+		}
+	}
+	return target
+}
+
+func fromOptionalDate(v *Date) *time.Time {
+	if v == nil {
+		return nil
+	}
+	return lo.ToPtr(time.Time(*v))
+}
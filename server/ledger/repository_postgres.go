@@ -0,0 +1,194 @@
+package ledger
+
+import (
+	"context"
+
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/budgets"
+	"github.com/n101661/maney/server/categories"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/shops"
+	"github.com/n101661/maney/server/statements"
+	"github.com/n101661/maney/server/tags"
+	"github.com/n101661/maney/server/transfers"
+	"github.com/n101661/maney/server/unitconversions"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.LedgerRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Import(ctx context.Context, r *repository.ImportLedgerRequest) error {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
+	// the config is imported first since the items are created with it.
+	if r.Config != nil {
+		affected, err := session.Update(&postgres.UsersModel{
+			Config: &postgres.UserConfig{UserConfig: r.Config},
+		}, &postgres.UsersModel{
+			ID: r.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return repository.ErrDataNotFound
+		}
+	}
+
+	// the referenced resources are created before the items.
+	if len(r.Accounts) > 0 {
+		_, err := accounts.CreateWithSession(session, &repository.CreateAccountsRequest{
+			UserID:   r.UserID,
+			Accounts: r.Accounts,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(r.ExpenseCategories) > 0 {
+		_, err := categories.CreateWithSession(session, &repository.CreateCategoriesRequest{
+			UserID:     r.UserID,
+			Type:       repository.CategoryTypeExpense,
+			Categories: r.ExpenseCategories,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(r.IncomeCategories) > 0 {
+		_, err := categories.CreateWithSession(session, &repository.CreateCategoriesRequest{
+			UserID:     r.UserID,
+			Type:       repository.CategoryTypeIncome,
+			Categories: r.IncomeCategories,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(r.Shops) > 0 {
+		_, err := shops.CreateWithSession(session, &repository.CreateShopsRequest{
+			UserID: r.UserID,
+			Shops:  r.Shops,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(r.Tags) > 0 {
+		_, err := tags.CreateWithSession(session, &repository.CreateTagsRequest{
+			UserID: r.UserID,
+			Tags:   r.Tags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(r.Fees) > 0 {
+		_, err := fees.CreateWithSession(session, &repository.CreateFeesRequest{
+			UserID: r.UserID,
+			Fees:   r.Fees,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	// the conversions normalize the prices of the items.
+	if len(r.UnitConversions) > 0 {
+		_, err := unitconversions.CreateWithSession(session, &repository.CreateUnitConversionsRequest{
+			UserID:      r.UserID,
+			Conversions: r.UnitConversions,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(r.ExchangeRates) > 0 {
+		_, err := exchangerates.UpsertWithSession(session, &repository.UpsertExchangeRatesRequest{
+			UserID: r.UserID,
+			Rates:  r.ExchangeRates,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// the items are created one by one so that the failed one is known.
+	for _, item := range r.RepeatingItems {
+		_, err := repeatingitems.CreateWithSession(session, &repository.CreateRepeatingItemsRequest{
+			UserID: r.UserID,
+			Items:  []*repository.BaseCreateRepeatingItem{item},
+		})
+		if err != nil {
+			return &repository.ImportError{Key: item.PublicID, Err: err}
+		}
+	}
+	for _, item := range r.DailyItems {
+		_, err := dailyitems.CreateWithSession(session, &repository.CreateDailyItemsRequest{
+			UserID: r.UserID,
+			Items:  []*repository.BaseCreateDailyItem{item},
+		})
+		if err != nil {
+			return &repository.ImportError{Key: item.PublicID, Err: err}
+		}
+	}
+	for _, transfer := range r.Transfers {
+		_, err := transfers.CreateWithSession(session, toCreateTransferRequest(r.UserID, transfer))
+		if err != nil {
+			return &repository.ImportError{Key: transfer.PublicID, Err: err}
+		}
+	}
+	for _, payment := range r.StatementPayments {
+		req := &repository.CreateStatementPaymentRequest{
+			UserID:          r.UserID,
+			AccountPublicID: payment.AccountPublicID,
+			Payment:         payment.Payment,
+		}
+		if payment.Transfer != nil {
+			req.Transfer = toCreateTransferRequest(r.UserID, payment.Transfer)
+		}
+		if _, err := statements.CreatePaymentWithSession(session, req); err != nil {
+			return &repository.ImportError{Key: payment.Key(), Err: err}
+		}
+	}
+	for _, budget := range r.Budgets {
+		_, err := budgets.CreateWithSession(session, &repository.CreateBudgetRequest{
+			UserID:   r.UserID,
+			PublicID: budget.PublicID,
+			Budget:   budget.Budget,
+		})
+		if err != nil {
+			return &repository.ImportError{Key: budget.PublicID, Err: err}
+		}
+	}
+
+	return session.Commit()
+}
+
+func toCreateTransferRequest(userID string, v *repository.ImportTransfer) *repository.CreateTransferRequest {
+	return &repository.CreateTransferRequest{
+		UserID:   userID,
+		PublicID: v.PublicID,
+		Transfer: v.Transfer,
+		Fee:      v.Fee,
+		FeeItem:  v.FeeItem,
+	}
+}
@@ -0,0 +1,211 @@
+package ledger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/budgets"
+	"github.com/n101661/maney/server/categories"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/icons"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/repository/postgres/postgrestest"
+	"github.com/n101661/maney/server/shops"
+	"github.com/n101661/maney/server/statements"
+	"github.com/n101661/maney/server/tags"
+	"github.com/n101661/maney/server/transfers"
+	"github.com/n101661/maney/server/unitconversions"
+	"github.com/n101661/maney/server/users"
+)
+
+func Test_postgresRepository_Import(t *testing.T) {
+	t.Run("exported ledger is imported with the same balances", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine := postgrestest.NewEngine(t)
+		postgrestest.Insert(t, engine, newTestUser("user-id"), newTestUser("another-user-id"))
+		repos := newPostgresRepositories(t, engine)
+		iconService, err := icons.NewService(lo.Must(icons.LoadCatalog()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewService(repos, iconService)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jan1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID: "user-id",
+			Document: &Document{
+				Version: DocumentVersion,
+				Config:  &Config{HomeCurrency: "USD"},
+				Accounts: []*Account{
+					{ID: "bank", Name: "Bank", InitialBalance: decimal.NewFromInt(1000), Currency: "USD", Type: "bank"},
+					{ID: "card", Name: "Card", Currency: "USD", Type: "creditCard", StatementClosingDay: 25, PaymentDueDay: 10},
+				},
+				Categories: []*Category{
+					{ID: "food", Type: "expense", Name: "Food"},
+					{ID: "salary", Type: "income", Name: "Salary"},
+				},
+				Tags: []*Tag{
+					{ID: "japan", Name: "#japan-2026"},
+				},
+				Fees: []*Fee{
+					{ID: "fee", Name: "Wire", Type: repository.FeeTypeFixed, Fixed: lo.ToPtr(decimal.NewFromInt(2))},
+				},
+				DailyItems: []*DailyItem{
+					{
+						ID:     "lunch",
+						Date:   Date(jan1),
+						TagIDs: []string{"japan"},
+						Item:   &Item{Name: "Lunch", CategoryIDs: []string{"food"}, AccountID: "card", Price: decimal.NewFromInt(30)},
+					},
+					{
+						ID:   "pay",
+						Date: Date(jan1),
+						Item: &Item{Name: "Pay", CategoryIDs: []string{"salary"}, AccountID: "bank", Price: decimal.NewFromInt(500)},
+					},
+				},
+				Transfers: []*Transfer{
+					{
+						ID:            "transfer",
+						Date:          Date(jan1.AddDate(0, 1, 0)),
+						FromAccountID: "bank",
+						ToAccountID:   "card",
+						Amount:        decimal.NewFromInt(30),
+						FeeID:         "fee",
+						FeeItem:       &TransferFeeItem{ID: "feeItem", CategoryID: "food"},
+					},
+				},
+				StatementPayments: []*StatementPayment{
+					{
+						AccountID:   "card",
+						ClosingDate: Date(time.Date(2025, 1, 25, 0, 0, 0, 0, time.UTC)),
+						Date:        Date(jan1.AddDate(0, 1, 0)),
+						Amount:      decimal.NewFromInt(30),
+						TransferID:  "transfer",
+					},
+				},
+				Budgets: []*Budget{
+					{ID: "budget", CategoryID: "food", Limit: decimal.NewFromInt(100), StartMonth: Date(jan1)},
+				},
+				ExchangeRates: []*ExchangeRate{
+					{ID: "rate", Date: Date(jan1), BaseCurrency: "USD", QuoteCurrency: "TWD", Rate: decimal.NewFromInt(30)},
+				},
+			},
+		})
+		assert.NoError(err)
+		assert.Empty(reply.Errors)
+
+		exported, err := s.Export(context.Background(), &ExportRequest{UserID: "user-id"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		reply, err = s.Import(context.Background(), &ImportRequest{
+			UserID:   "another-user-id",
+			Document: exported.Document,
+		})
+		assert.NoError(err)
+		assert.Empty(reply.Errors)
+
+		// bank: 1000 + 500 - 30 - 2, card: -30 + 30.
+		expected := map[string]decimal.Decimal{
+			"Bank": decimal.NewFromInt(1468),
+			"Card": decimal.Zero,
+		}
+		for _, userID := range []string{"user-id", "another-user-id"} {
+			accounts, err := repos.Account.List(context.Background(), &repository.ListAccountsRequest{
+				UserID: userID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			balances := lo.SliceToMap(accounts.Accounts, func(item *repository.Account) (string, decimal.Decimal) {
+				return item.Name, item.Balance
+			})
+			for name, balance := range expected {
+				assert.True(balance.Equal(balances[name]), "balance of %s of %s: %s", name, userID, balances[name])
+			}
+		}
+
+		reimported, err := s.Export(context.Background(), &ExportRequest{UserID: "another-user-id"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(reimported.Document.Transfers, 1)
+		assert.Len(reimported.Document.StatementPayments, 1)
+		assert.Len(reimported.Document.Budgets, 1)
+		assert.Len(reimported.Document.ExchangeRates, 1)
+		assert.Equal(exported.Document.Config, reimported.Document.Config)
+		if assert.Len(reimported.Document.Tags, 1) {
+			tag := reimported.Document.Tags[0]
+			assert.Equal("#japan-2026", tag.Name)
+			lunch, _ := lo.Find(reimported.Document.DailyItems, func(item *DailyItem) bool {
+				return item.Name == "Lunch"
+			})
+			assert.Equal([]string{tag.ID}, lunch.TagIDs)
+		}
+	})
+}
+
+func newTestUser(id string) *postgres.UsersModel {
+	return &postgres.UsersModel{
+		ID:       id,
+		Password: []byte("password"),
+		Config:   &postgres.UserConfig{UserConfig: &repository.UserConfig{}},
+	}
+}
+
+func newPostgresRepositories(t *testing.T, engine *xorm.Engine) *Repositories {
+	t.Helper()
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	repos := &Repositories{}
+	var err error
+	repos.User, err = users.NewPostgresRepository(engine)
+	must(err)
+	repos.Account, err = accounts.NewPostgresRepository(engine)
+	must(err)
+	repos.Category, err = categories.NewPostgresRepository(engine)
+	must(err)
+	repos.Shop, err = shops.NewPostgresRepository(engine)
+	must(err)
+	repos.Tag, err = tags.NewPostgresRepository(engine)
+	must(err)
+	repos.Fee, err = fees.NewPostgresRepository(engine)
+	must(err)
+	repos.DailyItem, err = dailyitems.NewPostgresRepository(engine)
+	must(err)
+	repos.RepeatingItem, err = repeatingitems.NewPostgresRepository(engine)
+	must(err)
+	repos.Transfer, err = transfers.NewPostgresRepository(engine)
+	must(err)
+	repos.Statement, err = statements.NewPostgresRepository(engine)
+	must(err)
+	repos.Budget, err = budgets.NewPostgresRepository(engine)
+	must(err)
+	repos.ExchangeRate, err = exchangerates.NewPostgresRepository(engine)
+	must(err)
+	repos.UnitConversion, err = unitconversions.NewPostgresRepository(engine)
+	must(err)
+	repos.Ledger, err = NewPostgresRepository(engine)
+	must(err)
+	return repos
+}
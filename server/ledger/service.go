@@ -0,0 +1,72 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+)
+
+var (
+	ErrDataInsufficient = fmt.Errorf("data insufficient")
+	ErrUserNotFound     = fmt.Errorf("user not found")
+
+	ErrUnsupportedVersion = fmt.Errorf("unsupported version of document")
+
+	ErrReferenceNotFound = fmt.Errorf("referenced data not found")
+	ErrInvalidReference  = fmt.Errorf("invalid reference")
+	ErrCurrencyMismatch  = fmt.Errorf("currency mismatch")
+	ErrInvalidSplit      = fmt.Errorf("invalid split")
+)
+
+type Service interface {
+	// Export returns the ledger of the user as the Document of DocumentVersion, it returns
+	// error:
+	//  - ErrDataInsufficient if any of fields of ExportRequest is zero-value,
+	//  - ErrUserNotFound if the user does not exist.
+	// The same ledger is always exported as the same Document.
+	Export(context.Context, *ExportRequest) (*ExportReply, error)
+	// Import recreates the resources of the Document for the user with new public ids,
+	// the references between the resources are preserved. Nothing is created if there is
+	// any invalid record or ImportRequest.DryRun is set. It returns error:
+	//  - ErrDataInsufficient if any of fields of ImportRequest is zero-value,
+	//  - ErrUnsupportedVersion if the version of the Document is not DocumentVersion,
+	//  - ErrUserNotFound if the user does not exist,
+	//  - ErrReferenceNotFound, ErrInvalidReference, ErrCurrencyMismatch or ErrInvalidSplit
+	//    if any of the records is rejected while it is created, the error wraps the
+	//    *RecordError of the record if the record is known.
+	Import(context.Context, *ImportRequest) (*ImportReply, error)
+}
+
+type ExportRequest struct {
+	UserID string
+}
+
+type ExportReply struct {
+	Document *Document
+}
+
+type ImportRequest struct {
+	UserID   string
+	Document *Document
+	// DryRun validates the Document only.
+	DryRun bool
+}
+
+type ImportReply struct {
+	// Errors are the invalid records of the Document, it is empty if all of them are valid.
+	Errors []*RecordError
+	// PublicIDs maps the ids in the Document to the public ids of the resources which are
+	// created, it is empty if nothing is created.
+	PublicIDs map[string]string
+}
+
+type RecordError struct {
+	// Record is the location of the record in the Document, e.g. "accounts[0]".
+	Record string
+	// ID is the id of the record in the Document.
+	ID      string
+	Message string
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("%s[%s]: %s", e.Record, e.ID, e.Message)
+}
@@ -0,0 +1,513 @@
+package ledger
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/icons"
+	"github.com/n101661/maney/server/repository"
+)
+
+// Repositories are the repositories of the resources in the ledger.
+type Repositories struct {
+	User           repository.UserRepository
+	Account        repository.AccountRepository
+	Category       repository.CategoryRepository
+	Shop           repository.ShopRepository
+	Tag            repository.TagRepository
+	Fee            repository.FeeRepository
+	DailyItem      repository.DailyItemRepository
+	RepeatingItem  repository.RepeatingItemRepository
+	Transfer       repository.TransferRepository
+	Statement      repository.StatementRepository
+	Budget         repository.BudgetRepository
+	ExchangeRate   repository.ExchangeRateRepository
+	UnitConversion repository.UnitConversionRepository
+	Ledger         repository.LedgerRepository
+}
+
+type service struct {
+	repos         *Repositories
+	iconValidator icons.Validator
+
+	opts *LedgerServiceOptions
+}
+
+func NewService(
+	repos *Repositories,
+	iconValidator icons.Validator,
+	opts ...utils.Option[LedgerServiceOptions],
+) (Service, error) {
+	return &service{
+		repos:         repos,
+		iconValidator: iconValidator,
+		opts:          utils.ApplyOptions(defaultLedgerServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Export(ctx context.Context, r *ExportRequest) (*ExportReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+
+	user, err := s.repos.User.GetUser(ctx, r.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	accounts, err := orEmpty(s.repos.Account.List(ctx, &repository.ListAccountsRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(accounts.Accounts, func(a, b *repository.Account) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	categories := []*Category{}
+	for _, type_ := range []repository.CategoryType{repository.CategoryTypeExpense, repository.CategoryTypeIncome} {
+		reply, err := orEmpty(s.repos.Category.List(ctx, &repository.ListCategoriesRequest{
+			UserID: r.UserID,
+			Type:   type_,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(reply.Categories, func(a, b *repository.Category) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+		categories = append(categories, lo.Map(reply.Categories, func(item *repository.Category, _ int) *Category {
+			return toCategory(type_, item)
+		})...)
+	}
+
+	shops, err := orEmpty(s.repos.Shop.List(ctx, &repository.ListShopsRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(shops.Shops, func(a, b *repository.Shop) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	tags, err := orEmpty(s.repos.Tag.List(ctx, &repository.ListTagsRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(tags.Tags, func(a, b *repository.Tag) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	fees, err := orEmpty(s.repos.Fee.List(ctx, &repository.ListFeesRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(fees.Fees, func(a, b *repository.Fee) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	dailyItems, err := orEmpty(s.repos.DailyItem.List(ctx, &repository.ListDailyItemsRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(dailyItems.Items, func(a, b *repository.DailyItem) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+	// the fee items are exported with their transfers.
+	feeItems := map[string]*repository.DailyItem{}
+	dailyItems.Items = lo.Filter(dailyItems.Items, func(item *repository.DailyItem, _ int) bool {
+		if item.TransferPublicID != nil {
+			feeItems[*item.TransferPublicID] = item
+			return false
+		}
+		return true
+	})
+
+	repeatingItems, err := orEmpty(s.repos.RepeatingItem.List(ctx, &repository.ListRepeatingItemsRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(repeatingItems.Items, func(a, b *repository.RepeatingItem) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	transfers, err := orEmpty(s.repos.Transfer.List(ctx, &repository.ListTransfersRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(transfers.Transfers, func(a, b *repository.Transfer) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+
+	// only the credit cards have the statements.
+	payments := []*StatementPayment{}
+	for _, account := range accounts.Accounts {
+		if account.Type != repository.AccountTypeCreditCard {
+			continue
+		}
+		reply, err := orEmpty(s.repos.Statement.ListPayments(ctx, &repository.ListStatementPaymentsRequest{
+			UserID:          r.UserID,
+			AccountPublicID: account.PublicID,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(reply.Payments, func(a, b *repository.StatementPayment) int {
+			return a.ClosingDate.Compare(b.ClosingDate)
+		})
+		payments = append(payments, lo.Map(reply.Payments, func(item *repository.StatementPayment, _ int) *StatementPayment {
+			return toStatementPayment(item)
+		})...)
+	}
+
+	budgets, err := orEmpty(s.repos.Budget.List(ctx, &repository.ListBudgetsRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(budgets.Budgets, func(a, b *repository.Budget) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	rates, err := orEmpty(s.repos.ExchangeRate.List(ctx, &repository.ListExchangeRatesRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(rates.Rates, func(a, b *repository.ExchangeRate) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+
+	conversions, err := orEmpty(s.repos.UnitConversion.List(ctx, &repository.ListUnitConversionsRequest{
+		UserID: r.UserID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(conversions.Conversions, func(a, b *repository.UnitConversion) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return &ExportReply{
+		Document: &Document{
+			Version: DocumentVersion,
+			Config:  toConfig(user.Config),
+			Accounts: lo.Map(accounts.Accounts, func(item *repository.Account, _ int) *Account {
+				return toAccount(item)
+			}),
+			Categories: categories,
+			Shops: lo.Map(shops.Shops, func(item *repository.Shop, _ int) *Shop {
+				return toShop(item)
+			}),
+			Tags: lo.Map(tags.Tags, func(item *repository.Tag, _ int) *Tag {
+				return toTag(item)
+			}),
+			Fees: lo.Map(fees.Fees, func(item *repository.Fee, _ int) *Fee {
+				return toFee(item)
+			}),
+			DailyItems: lo.Map(dailyItems.Items, func(item *repository.DailyItem, _ int) *DailyItem {
+				return toDailyItem(item)
+			}),
+			RepeatingItems: lo.Map(repeatingItems.Items, func(item *repository.RepeatingItem, _ int) *RepeatingItem {
+				return toRepeatingItem(item)
+			}),
+			Transfers: lo.Map(transfers.Transfers, func(item *repository.Transfer, _ int) *Transfer {
+				return toTransfer(item, feeItems[item.PublicID])
+			}),
+			StatementPayments: payments,
+			Budgets: lo.Map(budgets.Budgets, func(item *repository.Budget, _ int) *Budget {
+				return toBudget(item)
+			}),
+			ExchangeRates: lo.Map(rates.Rates, func(item *repository.ExchangeRate, _ int) *ExchangeRate {
+				return toExchangeRate(item)
+			}),
+			UnitConversions: lo.Map(conversions.Conversions, func(item *repository.UnitConversion, _ int) *UnitConversion {
+				return toUnitConversion(item)
+			}),
+		},
+	}, nil
+}
+
+func (s *service) Import(ctx context.Context, r *ImportRequest) (*ImportReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Document == nil {
+		return nil, fmt.Errorf("%w: missing document", ErrDataInsufficient)
+	}
+	if r.Document.Version != DocumentVersion {
+		return nil, fmt.Errorf("%w[%d]", ErrUnsupportedVersion, r.Document.Version)
+	}
+
+	im := newImporter(s.opts.genPublicID, s.iconValidator)
+	req := im.Import(ctx, r.UserID, r.Document)
+	if len(im.errors) > 0 || r.DryRun {
+		return &ImportReply{
+			Errors:    im.errors,
+			PublicIDs: map[string]string{},
+		}, nil
+	}
+
+	if err := s.repos.Ledger.Import(ctx, req); err != nil {
+		return nil, im.parseRepositoryError(err)
+	}
+	return &ImportReply{
+		Errors:    im.errors,
+		PublicIDs: im.publicIDs,
+	}, nil
+}
+
+// orEmpty returns the empty reply if the error is repository.ErrDataNotFound.
+func orEmpty[Reply any](reply *Reply, err error) (*Reply, error) {
+	if errors.Is(err, repository.ErrDataNotFound) {
+		return new(Reply), nil
+	}
+	return reply, err
+}
+
+func toConfig(v *repository.UserConfig) *Config {
+	if v == nil {
+		return &Config{}
+	}
+	return &Config{
+		CompareItemsInDifferentShop: v.CompareItemsInDifferentShop,
+		CompareItemsInSameShop:      v.CompareItemsInSameShop,
+		HomeCurrency:                lo.FromPtr(v.HomeCurrency),
+	}
+}
+
+func toAccount(v *repository.Account) *Account {
+	return &Account{
+		ID:                  v.PublicID,
+		Name:                v.Name,
+		IconID:              v.IconID,
+		InitialBalance:      v.InitialBalance,
+		Currency:            v.Currency,
+		Type:                v.Type.String(),
+		BankName:            v.BankName,
+		LastDigits:          v.LastDigits,
+		CreditLimit:         v.CreditLimit,
+		StatementClosingDay: v.StatementClosingDay,
+		PaymentDueDay:       v.PaymentDueDay,
+	}
+}
+
+func toCategory(type_ repository.CategoryType, v *repository.Category) *Category {
+	return &Category{
+		ID:       v.PublicID,
+		Type:     type_.String(),
+		Name:     v.Name,
+		IconID:   v.IconID,
+		ParentID: lo.FromPtr(v.ParentPublicID),
+	}
+}
+
+func toTag(v *repository.Tag) *Tag {
+	return &Tag{
+		ID:   v.PublicID,
+		Name: v.Name,
+	}
+}
+
+func toShop(v *repository.Shop) *Shop {
+	return &Shop{
+		ID:      v.PublicID,
+		Name:    v.Name,
+		Address: v.Address,
+		Locations: lo.IfF(len(v.Locations) > 0, func() []*ShopLocation {
+			return lo.Map(v.Locations, func(location *repository.ShopLocation, _ int) *ShopLocation {
+				result := &ShopLocation{
+					Label:   location.Label,
+					Address: location.Address,
+				}
+				if location.Coordinate != nil {
+					result.Latitude = lo.ToPtr(location.Coordinate.Latitude)
+					result.Longitude = lo.ToPtr(location.Coordinate.Longitude)
+				}
+				return result
+			})
+		}).Else(nil),
+		Memo: v.Memo,
+	}
+}
+
+func toFee(v *repository.Fee) *Fee {
+	fee := &Fee{
+		ID:    v.PublicID,
+		Name:  v.Name,
+		Type:  v.Type,
+		Rate:  v.Rate,
+		Fixed: v.Fixed,
+		Min:   v.Min,
+		Max:   v.Max,
+	}
+	for _, tier := range v.Tiers {
+		fee.Tiers = append(fee.Tiers, &FeeTier{
+			UpTo:  tier.UpTo,
+			Rate:  tier.Rate,
+			Fixed: tier.Fixed,
+		})
+	}
+	return fee
+}
+
+func toDailyItem(v *repository.DailyItem) *DailyItem {
+	return &DailyItem{
+		ID:              v.PublicID,
+		Date:            Date(v.Date),
+		RepeatingItemID: lo.FromPtr(v.RepeatingItemPublicID),
+		TagIDs:          v.TagPublicIDs,
+		Item:            toItem(v.BaseItem),
+	}
+}
+
+func toRepeatingItem(v *repository.RepeatingItem) *RepeatingItem {
+	item := &RepeatingItem{
+		ID:        v.PublicID,
+		StartDate: Date(v.StartDate),
+		EndDate:   toOptionalDate(v.EndDate),
+		NextDate:  toOptionalDate(v.NextDate),
+		Item:      toItem(v.Item),
+	}
+	if v.Frequency != nil {
+		item.EveryDays = v.Frequency.Days
+		item.EveryWorkDay = v.Frequency.EveryWorkDay
+	}
+	return item
+}
+
+func toItem(v *repository.BaseItem) *Item {
+	return &Item{
+		Name:        v.Name,
+		CategoryIDs: v.CategoryPublicIDs,
+		Splits: lo.IfF(len(v.Splits) > 0, func() []*ItemSplit {
+			return lo.Map(v.Splits, func(split *repository.ItemSplit, _ int) *ItemSplit {
+				return &ItemSplit{
+					CategoryID: split.CategoryPublicID,
+					Amount:     split.Amount,
+					Percentage: split.Percentage,
+				}
+			})
+		}).Else(nil),
+		ShopID:    lo.FromPtr(v.ShopPublicID),
+		AccountID: lo.FromPtr(v.AccountPublicID),
+		Quantity:  v.Quantity,
+		Unit:      string(v.Unit),
+		FeeID:     lo.FromPtr(v.FeePublicID),
+		Fee:       v.Fee,
+		Price:     v.Price,
+		Currency:  v.Currency,
+		Memo:      v.Memo,
+	}
+}
+
+func toTransfer(v *repository.Transfer, feeItem *repository.DailyItem) *Transfer {
+	transfer := &Transfer{
+		ID:            v.PublicID,
+		Date:          Date(v.Date),
+		FromAccountID: v.FromAccountPublicID,
+		ToAccountID:   v.ToAccountPublicID,
+		Amount:        v.Amount,
+		FeeID:         lo.FromPtr(v.FeePublicID),
+		Fee:           v.Fee,
+		Memo:          v.Memo,
+	}
+	if feeItem != nil {
+		transfer.FeeItem = &TransferFeeItem{
+			ID:         feeItem.PublicID,
+			CategoryID: lo.FirstOrEmpty(feeItem.CategoryPublicIDs),
+		}
+	}
+	return transfer
+}
+
+func toStatementPayment(v *repository.StatementPayment) *StatementPayment {
+	return &StatementPayment{
+		AccountID:   v.AccountPublicID,
+		ClosingDate: Date(v.ClosingDate),
+		Date:        Date(v.Date),
+		Amount:      v.Amount,
+		TransferID:  lo.FromPtr(v.TransferPublicID),
+	}
+}
+
+func toBudget(v *repository.Budget) *Budget {
+	return &Budget{
+		ID:         v.PublicID,
+		CategoryID: v.CategoryPublicID,
+		Limit:      v.Limit,
+		Rollover:   v.Rollover,
+		StartMonth: Date(v.StartMonth),
+	}
+}
+
+func toExchangeRate(v *repository.ExchangeRate) *ExchangeRate {
+	return &ExchangeRate{
+		ID:            v.PublicID,
+		Date:          Date(v.Date),
+		BaseCurrency:  v.BaseCurrency,
+		QuoteCurrency: v.QuoteCurrency,
+		Rate:          v.Rate,
+	}
+}
+
+func toUnitConversion(v *repository.UnitConversion) *UnitConversion {
+	return &UnitConversion{
+		ID:     v.PublicID,
+		Unit:   string(v.Unit),
+		ToUnit: string(v.ToUnit),
+		Factor: v.Factor,
+	}
+}
+
+func toOptionalDate(v *time.Time) *Date {
+	if v == nil {
+		return nil
+	}
+	return lo.ToPtr(Date(*v))
+}
+
+type LedgerServiceOptions struct {
+	genPublicID func(prefix string) string
+}
+
+func defaultLedgerServiceOptions() *LedgerServiceOptions {
+	return &LedgerServiceOptions{
+		genPublicID: func(prefix string) string {
+			return slugid.New(prefix, 11)
+		},
+	}
+}
+
+// WithLedgerServiceGenPublicID sets the generator of the public ids of the imported
+// resources, the prefix is the one of the resource, e.g. "act" for the accounts.
+func WithLedgerServiceGenPublicID(f func(prefix string) string) utils.Option[LedgerServiceOptions] {
+	return func(o *LedgerServiceOptions) {
+		o.genPublicID = f
+	}
+}
@@ -0,0 +1,980 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/fees"
+	"github.com/n101661/maney/server/icons"
+	"github.com/n101661/maney/server/models"
+	"github.com/n101661/maney/server/repeatingitems"
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Export(t *testing.T) {
+	t.Run("export successful", func(t *testing.T) {
+		const userID = "user-id"
+		var (
+			jan1 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			jan2 = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+		gomock.InOrder(
+			repos.user.EXPECT().GetUser(gomock.Any(), userID).Return(&repository.UserModel{
+				ID: userID,
+				Config: &models.UserConfig{
+					CompareItemsInSameShop: true,
+					HomeCurrency:           lo.ToPtr("TWD"),
+				},
+			}, nil),
+			repos.account.EXPECT().
+				List(gomock.Any(), &repository.ListAccountsRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListAccountsReply{
+					Accounts: []*repository.Account{
+						{
+							ID:       2,
+							PublicID: "account2",
+							BaseAccount: &repository.BaseAccount{
+								Name:           "B",
+								InitialBalance: decimal.NewFromInt(10),
+								Currency:       "USD",
+								Type:           repository.AccountTypeCreditCard,
+							},
+							Balance: decimal.NewFromInt(5),
+						},
+						{
+							ID:       1,
+							PublicID: "account1",
+							BaseAccount: &repository.BaseAccount{
+								Name:           "A",
+								IconID:         1,
+								InitialBalance: decimal.NewFromInt(100),
+							},
+							Balance: decimal.NewFromInt(90),
+						},
+					},
+				}, nil),
+			repos.category.EXPECT().
+				List(gomock.Any(), &repository.ListCategoriesRequest{
+					UserID: userID,
+					Type:   repository.CategoryTypeExpense,
+				}).
+				Return(&repository.ListCategoriesReply{
+					Categories: []*repository.Category{
+						{ID: 1, PublicID: "food", BaseCategory: &repository.BaseCategory{Name: "Food"}},
+					},
+				}, nil),
+			repos.category.EXPECT().
+				List(gomock.Any(), &repository.ListCategoriesRequest{
+					UserID: userID,
+					Type:   repository.CategoryTypeIncome,
+				}).
+				Return(nil, repository.ErrDataNotFound),
+			repos.shop.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+			repos.tag.EXPECT().List(gomock.Any(), &repository.ListTagsRequest{
+				UserID: userID,
+			}).Return(&repository.ListTagsReply{
+				Tags: []*repository.Tag{
+					{ID: 2, PublicID: "wedding", BaseTag: &repository.BaseTag{Name: "#wedding"}},
+					{ID: 1, PublicID: "japan", BaseTag: &repository.BaseTag{Name: "#japan-2026"}},
+				},
+			}, nil),
+			repos.fee.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListFeesReply{
+				Fees: []*repository.Fee{
+					{
+						ID:       1,
+						PublicID: "fee",
+						BaseFee: &repository.BaseFee{
+							Name:  "F",
+							Type:  repository.FeeTypeFixed,
+							Fixed: lo.ToPtr(decimal.NewFromInt(15)),
+						},
+					},
+				},
+			}, nil),
+			repos.dailyItem.EXPECT().
+				List(gomock.Any(), &repository.ListDailyItemsRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListDailyItemsReply{
+					Items: []*repository.DailyItem{
+						{
+							ID:               4,
+							PublicID:         "item4",
+							TransferPublicID: lo.ToPtr("transfer"),
+							BaseDailyItem: &repository.BaseDailyItem{
+								Date: jan2,
+								BaseItem: &repository.BaseItem{
+									Name:              "F",
+									CategoryPublicIDs: []string{"food"},
+									AccountPublicID:   lo.ToPtr("account1"),
+									Price:             decimal.NewFromInt(15),
+								},
+							},
+						},
+						{
+							ID:       3,
+							PublicID: "item3",
+							BaseDailyItem: &repository.BaseDailyItem{
+								Date:         jan2,
+								TagPublicIDs: []string{"japan", "wedding"},
+								BaseItem: &repository.BaseItem{
+									Name:              "C",
+									CategoryPublicIDs: []string{"food"},
+									Price:             decimal.NewFromInt(3),
+								},
+							},
+						},
+						{
+							ID:       2,
+							PublicID: "item2",
+							BaseDailyItem: &repository.BaseDailyItem{
+								Date:                  jan1,
+								RepeatingItemPublicID: lo.ToPtr("repeating"),
+								BaseItem: &repository.BaseItem{
+									Name:              "B",
+									CategoryPublicIDs: []string{"food"},
+									AccountPublicID:   lo.ToPtr("account1"),
+									Price:             decimal.NewFromInt(10),
+								},
+							},
+						},
+					},
+				}, nil),
+			repos.repeatingItem.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListRepeatingItemsReply{
+				Items: []*repository.RepeatingItem{
+					{
+						ID:       1,
+						PublicID: "repeating",
+						BaseRepeatingItem: &repository.BaseRepeatingItem{
+							Item: &repository.BaseItem{
+								Name:              "B",
+								CategoryPublicIDs: []string{"food"},
+								AccountPublicID:   lo.ToPtr("account1"),
+								Price:             decimal.NewFromInt(10),
+							},
+							StartDate: jan1,
+							Frequency: &repository.RepeatingFrequency{Days: 7},
+						},
+						NextDate: lo.ToPtr(jan1.AddDate(0, 0, 7)),
+					},
+				},
+			}, nil),
+			repos.transfer.EXPECT().
+				List(gomock.Any(), &repository.ListTransfersRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListTransfersReply{
+					Transfers: []*repository.Transfer{
+						{
+							ID:       1,
+							PublicID: "transfer",
+							BaseTransfer: &repository.BaseTransfer{
+								Date:                jan2,
+								FromAccountPublicID: "account1",
+								ToAccountPublicID:   "account2",
+								Amount:              decimal.NewFromInt(20),
+								FeePublicID:         lo.ToPtr("fee"),
+							},
+							Fee: lo.ToPtr(decimal.NewFromInt(15)),
+						},
+					},
+				}, nil),
+			repos.statement.EXPECT().
+				ListPayments(gomock.Any(), &repository.ListStatementPaymentsRequest{
+					UserID:          userID,
+					AccountPublicID: "account2",
+				}).
+				Return(&repository.ListStatementPaymentsReply{
+					Payments: []*repository.StatementPayment{
+						{
+							ID:              1,
+							AccountPublicID: "account2",
+							BaseStatementPayment: &repository.BaseStatementPayment{
+								ClosingDate: jan1,
+								Date:        jan2,
+								Amount:      decimal.NewFromInt(20),
+							},
+							TransferPublicID: lo.ToPtr("transfer"),
+						},
+					},
+				}, nil),
+			repos.budget.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListBudgetsReply{
+				Budgets: []*repository.Budget{
+					{
+						ID:       1,
+						PublicID: "budget",
+						BaseBudget: &repository.BaseBudget{
+							CategoryPublicID: "food",
+							Limit:            decimal.NewFromInt(100),
+							Rollover:         true,
+							StartMonth:       jan1,
+						},
+					},
+				},
+			}, nil),
+			repos.exchangeRate.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListExchangeRatesReply{
+				Rates: []*repository.ExchangeRate{
+					{
+						ID:       1,
+						PublicID: "rate",
+						BaseExchangeRate: &repository.BaseExchangeRate{
+							Date:          jan1,
+							BaseCurrency:  "USD",
+							QuoteCurrency: "TWD",
+							Rate:          decimal.NewFromInt(30),
+						},
+					},
+				},
+			}, nil),
+			repos.unitConversion.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(repos.Repositories, icons.NewMockValidator(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Export(context.Background(), &ExportRequest{
+			UserID: userID,
+		})
+		assert.NoError(err)
+		assert.Equal(&ExportReply{
+			Document: &Document{
+				Version: DocumentVersion,
+				Config: &Config{
+					CompareItemsInSameShop: true,
+					HomeCurrency:           "TWD",
+				},
+				Accounts: []*Account{
+					{ID: "account1", Name: "A", IconID: 1, InitialBalance: decimal.NewFromInt(100), Type: "cash"},
+					{ID: "account2", Name: "B", InitialBalance: decimal.NewFromInt(10), Currency: "USD", Type: "creditCard"},
+				},
+				Categories: []*Category{
+					{ID: "food", Type: "expense", Name: "Food"},
+				},
+				Shops: []*Shop{},
+				Tags: []*Tag{
+					{ID: "japan", Name: "#japan-2026"},
+					{ID: "wedding", Name: "#wedding"},
+				},
+				Fees: []*Fee{
+					{ID: "fee", Name: "F", Type: repository.FeeTypeFixed, Fixed: lo.ToPtr(decimal.NewFromInt(15))},
+				},
+				DailyItems: []*DailyItem{
+					{
+						ID:              "item2",
+						Date:            Date(jan1),
+						RepeatingItemID: "repeating",
+						Item: &Item{
+							Name:        "B",
+							CategoryIDs: []string{"food"},
+							AccountID:   "account1",
+							Price:       decimal.NewFromInt(10),
+						},
+					},
+					{
+						ID:     "item3",
+						Date:   Date(jan2),
+						TagIDs: []string{"japan", "wedding"},
+						Item: &Item{
+							Name:        "C",
+							CategoryIDs: []string{"food"},
+							Price:       decimal.NewFromInt(3),
+						},
+					},
+				},
+				RepeatingItems: []*RepeatingItem{
+					{
+						ID:        "repeating",
+						StartDate: Date(jan1),
+						EveryDays: 7,
+						NextDate:  lo.ToPtr(Date(jan1.AddDate(0, 0, 7))),
+						Item: &Item{
+							Name:        "B",
+							CategoryIDs: []string{"food"},
+							AccountID:   "account1",
+							Price:       decimal.NewFromInt(10),
+						},
+					},
+				},
+				Transfers: []*Transfer{
+					{
+						ID:            "transfer",
+						Date:          Date(jan2),
+						FromAccountID: "account1",
+						ToAccountID:   "account2",
+						Amount:        decimal.NewFromInt(20),
+						FeeID:         "fee",
+						Fee:           lo.ToPtr(decimal.NewFromInt(15)),
+						FeeItem:       &TransferFeeItem{ID: "item4", CategoryID: "food"},
+					},
+				},
+				StatementPayments: []*StatementPayment{
+					{
+						AccountID:   "account2",
+						ClosingDate: Date(jan1),
+						Date:        Date(jan2),
+						Amount:      decimal.NewFromInt(20),
+						TransferID:  "transfer",
+					},
+				},
+				Budgets: []*Budget{
+					{ID: "budget", CategoryID: "food", Limit: decimal.NewFromInt(100), Rollover: true, StartMonth: Date(jan1)},
+				},
+				ExchangeRates: []*ExchangeRate{
+					{ID: "rate", Date: Date(jan1), BaseCurrency: "USD", QuoteCurrency: "TWD", Rate: decimal.NewFromInt(30)},
+				},
+				UnitConversions: []*UnitConversion{},
+			},
+		}, reply)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+		gomock.InOrder(
+			repos.user.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(repos.Repositories, icons.NewMockValidator(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Export(context.Background(), &ExportRequest{
+			UserID: "user-id",
+		})
+		assert.ErrorIs(err, ErrUserNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Import(t *testing.T) {
+	const userID = "user-id"
+	jan1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newDocument := func() *Document {
+		return &Document{
+			Version: DocumentVersion,
+			Config:  &Config{CompareItemsInSameShop: true, HomeCurrency: "usd"},
+			Accounts: []*Account{
+				{ID: "account", Name: "A", InitialBalance: decimal.NewFromInt(100), Currency: "usd"},
+				{ID: "card", Name: "C", Currency: "USD", Type: "creditCard", StatementClosingDay: 25, PaymentDueDay: 10},
+			},
+			Categories: []*Category{
+				{ID: "food", Type: "expense", Name: "Food"},
+				{ID: "salary", Type: "income", Name: "Salary"},
+			},
+			Shops: []*Shop{
+				{ID: "shop", Name: "S"},
+			},
+			Tags: []*Tag{
+				{ID: "japan", Name: " #japan-2026 "},
+			},
+			Fees: []*Fee{
+				{ID: "fee", Name: "F", Type: repository.FeeTypeRate, Rate: lo.ToPtr(decimal.NewFromFloat(0.1))},
+			},
+			RepeatingItems: []*RepeatingItem{
+				{
+					ID:        "repeating",
+					StartDate: Date(jan1),
+					EveryDays: 7,
+					NextDate:  lo.ToPtr(Date(jan1.AddDate(0, 0, 7))),
+					Item: &Item{
+						Name:        "B",
+						CategoryIDs: []string{"food"},
+						AccountID:   "account",
+						Price:       decimal.NewFromInt(10),
+					},
+				},
+			},
+			DailyItems: []*DailyItem{
+				{
+					ID:              "item",
+					Date:            Date(jan1),
+					RepeatingItemID: "repeating",
+					TagIDs:          []string{"japan", "japan"},
+					Item: &Item{
+						Name:        "B",
+						CategoryIDs: []string{"food"},
+						ShopID:      "shop",
+						AccountID:   "account",
+						FeeID:       "fee",
+						Price:       decimal.NewFromInt(10),
+						Currency:    "USD",
+					},
+				},
+			},
+			Transfers: []*Transfer{
+				{
+					ID:            "transfer",
+					Date:          Date(jan1),
+					FromAccountID: "account",
+					ToAccountID:   "card",
+					Amount:        decimal.NewFromInt(50),
+					FeeID:         "fee",
+					Fee:           lo.ToPtr(decimal.NewFromInt(1)),
+					FeeItem:       &TransferFeeItem{ID: "feeItem", CategoryID: "food"},
+				},
+				{
+					ID:            "transfer2",
+					Date:          Date(jan1),
+					FromAccountID: "card",
+					ToAccountID:   "account",
+					Amount:        decimal.NewFromInt(20),
+				},
+			},
+			StatementPayments: []*StatementPayment{
+				{
+					AccountID:   "card",
+					ClosingDate: Date(jan1),
+					Date:        Date(jan1),
+					Amount:      decimal.NewFromInt(50),
+					TransferID:  "transfer",
+				},
+			},
+			Budgets: []*Budget{
+				{ID: "budget", CategoryID: "food", Limit: decimal.NewFromInt(100), StartMonth: Date(jan1)},
+			},
+			ExchangeRates: []*ExchangeRate{
+				{ID: "rate", Date: Date(jan1), BaseCurrency: "usd", QuoteCurrency: "twd", Rate: decimal.NewFromInt(30)},
+			},
+			UnitConversions: []*UnitConversion{
+				{ID: "conversion", Unit: "pack", ToUnit: "pcs", Factor: decimal.NewFromInt(6)},
+			},
+		}
+	}
+	genPublicID := func(prefix string) string {
+		return prefix + "-new"
+	}
+
+	t.Run("import successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+		gomock.InOrder(
+			repos.ledger.EXPECT().Import(gomock.Any(), &repository.ImportLedgerRequest{
+				UserID: userID,
+				Config: &repository.UserConfig{
+					CompareItemsInSameShop: true,
+					HomeCurrency:           lo.ToPtr("USD"),
+				},
+				Accounts: []*repository.BaseCreateAccount{
+					{
+						PublicID: "act-new",
+						BaseAccount: &repository.BaseAccount{
+							Name:           "A",
+							InitialBalance: decimal.NewFromInt(100),
+							Currency:       "USD",
+						},
+					},
+					{
+						PublicID: "act-new",
+						BaseAccount: &repository.BaseAccount{
+							Name:                "C",
+							Currency:            "USD",
+							Type:                repository.AccountTypeCreditCard,
+							StatementClosingDay: 25,
+							PaymentDueDay:       10,
+						},
+					},
+				},
+				ExpenseCategories: []*repository.BaseCreateCategory{
+					{PublicID: "cat-new", BaseCategory: &repository.BaseCategory{Name: "Food"}},
+				},
+				IncomeCategories: []*repository.BaseCreateCategory{
+					{PublicID: "cat-new", BaseCategory: &repository.BaseCategory{Name: "Salary"}},
+				},
+				Shops: []*repository.BaseCreateShop{
+					{PublicID: "shp-new", BaseShop: &repository.BaseShop{Name: "S"}},
+				},
+				Tags: []*repository.BaseCreateTag{
+					{PublicID: "tag-new", BaseTag: &repository.BaseTag{Name: "#japan-2026"}},
+				},
+				Fees: []*repository.BaseCreateFee{
+					{
+						PublicID: "fee-new",
+						BaseFee: &repository.BaseFee{
+							Name: "F",
+							Type: repository.FeeTypeRate,
+							Rate: lo.ToPtr(decimal.NewFromFloat(0.1)),
+						},
+					},
+				},
+				UnitConversions: []*repository.BaseCreateUnitConversion{
+					{
+						PublicID: "unc-new",
+						BaseUnitConversion: &repository.BaseUnitConversion{
+							Unit:   repository.UnitPack,
+							ToUnit: repository.UnitPiece,
+							Factor: decimal.NewFromInt(6),
+						},
+					},
+				},
+				ExchangeRates: []*repository.BaseCreateExchangeRate{
+					{
+						PublicID: "xrt-new",
+						BaseExchangeRate: &repository.BaseExchangeRate{
+							Date:          jan1,
+							BaseCurrency:  "USD",
+							QuoteCurrency: "TWD",
+							Rate:          decimal.NewFromInt(30),
+						},
+					},
+				},
+				RepeatingItems: []*repository.BaseCreateRepeatingItem{
+					{
+						PublicID: "rpt-new",
+						BaseRepeatingItem: &repository.BaseRepeatingItem{
+							Item: &repository.BaseItem{
+								Name:              "B",
+								CategoryPublicIDs: []string{"cat-new"},
+								AccountPublicID:   lo.ToPtr("act-new"),
+								Price:             decimal.NewFromInt(10),
+							},
+							StartDate: jan1,
+							Frequency: &repository.RepeatingFrequency{Days: 7},
+						},
+						NextDate: lo.ToPtr(jan1.AddDate(0, 0, 7)),
+					},
+				},
+				DailyItems: []*repository.BaseCreateDailyItem{
+					{
+						PublicID: "itm-new",
+						BaseDailyItem: &repository.BaseDailyItem{
+							Date:                  jan1,
+							RepeatingItemPublicID: lo.ToPtr("rpt-new"),
+							TagPublicIDs:          []string{"tag-new"},
+							BaseItem: &repository.BaseItem{
+								Name:              "B",
+								CategoryPublicIDs: []string{"cat-new"},
+								ShopPublicID:      lo.ToPtr("shp-new"),
+								AccountPublicID:   lo.ToPtr("act-new"),
+								FeePublicID:       lo.ToPtr("fee-new"),
+								Price:             decimal.NewFromInt(10),
+								Currency:          "USD",
+							},
+						},
+					},
+				},
+				Transfers: []*repository.ImportTransfer{
+					{
+						PublicID: "trf-new",
+						Transfer: &repository.BaseTransfer{
+							Date:                jan1,
+							FromAccountPublicID: "act-new",
+							ToAccountPublicID:   "act-new",
+							Amount:              decimal.NewFromInt(20),
+						},
+					},
+				},
+				StatementPayments: []*repository.ImportStatementPayment{
+					{
+						AccountPublicID: "act-new",
+						Payment: &repository.BaseStatementPayment{
+							ClosingDate: jan1,
+							Date:        jan1,
+							Amount:      decimal.NewFromInt(50),
+						},
+						Transfer: &repository.ImportTransfer{
+							PublicID: "trf-new",
+							Transfer: &repository.BaseTransfer{
+								Date:                jan1,
+								FromAccountPublicID: "act-new",
+								ToAccountPublicID:   "act-new",
+								Amount:              decimal.NewFromInt(50),
+								FeePublicID:         lo.ToPtr("fee-new"),
+							},
+							Fee:     lo.ToPtr(decimal.NewFromInt(1)),
+							FeeItem: &repository.TransferFeeItem{PublicID: "itm-new", CategoryPublicID: "cat-new"},
+						},
+					},
+				},
+				Budgets: []*repository.ImportBudget{
+					{
+						PublicID: "bgt-new",
+						Budget: &repository.BaseBudget{
+							CategoryPublicID: "cat-new",
+							Limit:            decimal.NewFromInt(100),
+							StartMonth:       jan1,
+						},
+					},
+				},
+			}).Return(nil),
+		)
+
+		s, err := NewService(repos.Repositories, newMockIconValidator(controller), WithLedgerServiceGenPublicID(genPublicID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID:   userID,
+			Document: newDocument(),
+		})
+		assert.NoError(err)
+		assert.Equal(&ImportReply{
+			Errors: []*RecordError{},
+			PublicIDs: map[string]string{
+				"account":    "act-new",
+				"food":       "cat-new",
+				"salary":     "cat-new",
+				"shop":       "shp-new",
+				"japan":      "tag-new",
+				"fee":        "fee-new",
+				"card":       "act-new",
+				"repeating":  "rpt-new",
+				"item":       "itm-new",
+				"transfer":   "trf-new",
+				"transfer2":  "trf-new",
+				"feeItem":    "itm-new",
+				"budget":     "bgt-new",
+				"rate":       "xrt-new",
+				"conversion": "unc-new",
+			},
+		}, reply)
+	})
+	t.Run("dry run", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+
+		s, err := NewService(repos.Repositories, newMockIconValidator(controller), WithLedgerServiceGenPublicID(genPublicID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID:   userID,
+			Document: newDocument(),
+			DryRun:   true,
+		})
+		assert.NoError(err)
+		assert.Equal(&ImportReply{
+			Errors:    []*RecordError{},
+			PublicIDs: map[string]string{},
+		}, reply)
+	})
+	t.Run("invalid records", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+
+		doc := newDocument()
+		doc.Categories = append(doc.Categories,
+			&Category{ID: "lunch", Type: "expense", Name: "Lunch", ParentID: "salary"},
+			&Category{ID: "a", Type: "expense", Name: "A", ParentID: "b"},
+			&Category{ID: "b", Type: "expense", Name: "B", ParentID: "a"},
+		)
+		doc.Shops = append(doc.Shops, &Shop{ID: "food", Name: "Duplicated"})
+		doc.Tags = append(doc.Tags, &Tag{ID: "japan2", Name: "#japan-2026"})
+		doc.Fees[0].Rate = nil
+		doc.Fees = append(doc.Fees, &Fee{
+			ID:   "tiered",
+			Name: "T",
+			Type: repository.FeeTypeTiered,
+			Tiers: []*FeeTier{
+				{Fixed: lo.ToPtr(decimal.NewFromInt(1))},
+				{UpTo: lo.ToPtr(decimal.NewFromInt(10)), Fixed: lo.ToPtr(decimal.NewFromInt(2))},
+			},
+		})
+		doc.RepeatingItems[0].EveryWorkDay = true
+		doc.DailyItems[0].RepeatingItemID = ""
+		doc.DailyItems[0].CategoryIDs = []string{"food", "salary"}
+		doc.DailyItems = append(doc.DailyItems, &DailyItem{
+			ID:   "item2",
+			Date: Date(jan1),
+			Item: &Item{
+				CategoryIDs: []string{"food"},
+				AccountID:   "account",
+				Currency:    "TWD",
+			},
+		}, &DailyItem{
+			ID:   "item3",
+			Date: Date(jan1),
+			Item: &Item{
+				CategoryIDs: []string{"food"},
+				FeeID:       "fee",
+			},
+		}, &DailyItem{
+			ID:     "item4",
+			Date:   Date(jan1),
+			TagIDs: []string{"japan2"},
+			Item: &Item{
+				CategoryIDs: []string{"food"},
+			},
+		})
+		doc.Accounts = append(doc.Accounts,
+			&Account{ID: "twd", Name: "T", Currency: "TWD"},
+			&Account{ID: "icon", Name: "I", IconID: unknownIconID},
+		)
+		doc.Transfers = append(doc.Transfers, &Transfer{
+			ID:            "transfer3",
+			Date:          Date(jan1),
+			FromAccountID: "account",
+			ToAccountID:   "twd",
+			Amount:        decimal.NewFromInt(10),
+		})
+		doc.StatementPayments = append(doc.StatementPayments, &StatementPayment{
+			AccountID:   "account",
+			ClosingDate: Date(jan1),
+			Date:        Date(jan1),
+		}, &StatementPayment{
+			AccountID:   "card",
+			ClosingDate: Date(jan1.AddDate(0, 1, 0)),
+			Date:        Date(jan1.AddDate(0, 1, 0)),
+			TransferID:  "transfer",
+		})
+		doc.Budgets = append(doc.Budgets, &Budget{
+			ID:         "budget2",
+			CategoryID: "salary",
+			Limit:      decimal.NewFromInt(100),
+			StartMonth: Date(jan1),
+		})
+		doc.ExchangeRates[0].QuoteCurrency = "USD"
+		doc.UnitConversions = append(doc.UnitConversions, &UnitConversion{
+			ID:     "conversion2",
+			Unit:   "pcs",
+			ToUnit: "pack",
+			Factor: decimal.NewFromInt(6),
+		})
+
+		s, err := NewService(repos.Repositories, newMockIconValidator(controller), WithLedgerServiceGenPublicID(genPublicID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID:   userID,
+			Document: doc,
+		})
+		assert.NoError(err)
+		assert.Equal(&ImportReply{
+			Errors: []*RecordError{
+				{Record: "accounts[3]", ID: "icon", Message: icons.ErrIconNotFound.Error()},
+				{Record: "categories[2]", ID: "lunch", Message: "parent category[salary] is not the same type"},
+				{Record: "categories[3]", ID: "a", Message: "parent category[b] is circular"},
+				{Record: "categories[4]", ID: "b", Message: "parent category[a] is circular"},
+				{Record: "shops[1]", ID: "food", Message: "duplicated id[food]"},
+				{Record: "tags[1]", ID: "japan2", Message: "duplicated name[#japan-2026]"},
+				{Record: "fees[0]", ID: "fee", Message: fmt.Errorf("%w: missing fee.rate", fees.ErrDataInsufficient).Error()},
+				{Record: "fees[1]", ID: "tiered", Message: fmt.Errorf("%w: only the last tier can be unbounded", fees.ErrInvalidFee).Error()},
+				{Record: "unitConversions[1]", ID: "conversion2", Message: "conversion of unit[pcs] is circular"},
+				{Record: "exchangeRates[0]", ID: "rate", Message: "baseCurrency and quoteCurrency are the same"},
+				{Record: "repeatingItems[0]", ID: "repeating", Message: repeatingitems.ErrInvalidFrequency.Error()},
+				{Record: "dailyItems[0]", ID: "item", Message: "categories are not the same type"},
+				{Record: "dailyItems[1]", ID: "item2", Message: "currency[TWD] is not the currency of the account"},
+				{Record: "dailyItems[2]", ID: "item3", Message: "fee[fee] not found"},
+				{Record: "dailyItems[3]", ID: "item4", Message: "tag[japan2] not found"},
+				{Record: "transfers[0]", ID: "transfer", Message: "fee[fee] not found"},
+				{Record: "transfers[2]", ID: "transfer3", Message: "accounts are in different currencies"},
+				{Record: "statementPayments[0]", Message: "transfer[transfer] not found"},
+				{Record: "statementPayments[1]", Message: "account[account] is not a credit card"},
+				{Record: "statementPayments[2]", Message: "transfer[transfer] not found"},
+				{Record: "budgets[1]", ID: "budget2", Message: "category[salary] is not an expense category"},
+			},
+			PublicIDs: map[string]string{},
+		}, reply)
+	})
+	t.Run("rejected by repository", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+		gomock.InOrder(
+			repos.ledger.EXPECT().Import(gomock.Any(), gomock.Any()).Return(&repository.ImportError{
+				Key: "bgt-new",
+				Err: repository.ErrInvalidReference,
+			}),
+		)
+
+		s, err := NewService(repos.Repositories, newMockIconValidator(controller), WithLedgerServiceGenPublicID(genPublicID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID:   userID,
+			Document: newDocument(),
+		})
+		assert.ErrorIs(err, ErrInvalidReference)
+		var record *RecordError
+		if assert.ErrorAs(err, &record) {
+			assert.Equal(&RecordError{
+				Record:  "budgets[0]",
+				ID:      "budget",
+				Message: repository.ErrInvalidReference.Error(),
+			}, record)
+		}
+		assert.Nil(reply)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+		gomock.InOrder(
+			repos.ledger.EXPECT().Import(gomock.Any(), gomock.Any()).Return(repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(repos.Repositories, newMockIconValidator(controller), WithLedgerServiceGenPublicID(genPublicID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID:   userID,
+			Document: newDocument(),
+		})
+		assert.ErrorIs(err, ErrUserNotFound)
+		assert.Nil(reply)
+	})
+	t.Run("parents precede children", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+
+		var categories []*repository.BaseCreateCategory
+		gomock.InOrder(
+			repos.ledger.EXPECT().Import(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, r *repository.ImportLedgerRequest) error {
+					categories = r.ExpenseCategories
+					return nil
+				},
+			),
+		)
+
+		s, err := NewService(repos.Repositories, newMockIconValidator(controller), WithLedgerServiceGenPublicID(genPublicID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc := newDocument()
+		doc.Categories = []*Category{
+			{ID: "lunch", Type: "expense", Name: "Lunch", ParentID: "meal"},
+			{ID: "meal", Type: "expense", Name: "Meal", ParentID: "food"},
+			{ID: "food", Type: "expense", Name: "Food"},
+			{ID: "salary", Type: "income", Name: "Salary"},
+		}
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID:   userID,
+			Document: doc,
+		})
+		assert.NoError(err)
+		assert.Empty(reply.Errors)
+		assert.Equal([]string{"Food", "Meal", "Lunch"}, lo.Map(categories, func(item *repository.BaseCreateCategory, _ int) string {
+			return item.Name
+		}))
+		assert.Nil(categories[0].ParentPublicID)
+		assert.Equal(lo.ToPtr("cat-new"), categories[1].ParentPublicID)
+		assert.Equal(lo.ToPtr("cat-new"), categories[2].ParentPublicID)
+	})
+	t.Run("unsupported version", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		repos := newMockRepositories(controller)
+
+		s, err := NewService(repos.Repositories, icons.NewMockValidator(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		doc := newDocument()
+		doc.Version = DocumentVersion + 1
+		reply, err := s.Import(context.Background(), &ImportRequest{
+			UserID:   userID,
+			Document: doc,
+		})
+		assert.ErrorIs(err, ErrUnsupportedVersion)
+		assert.Nil(reply)
+	})
+}
+
+type mockRepositories struct {
+	*Repositories
+
+	user           *repository.MockUserRepository
+	account        *repository.MockAccountRepository
+	category       *repository.MockCategoryRepository
+	shop           *repository.MockShopRepository
+	tag            *repository.MockTagRepository
+	fee            *repository.MockFeeRepository
+	dailyItem      *repository.MockDailyItemRepository
+	repeatingItem  *repository.MockRepeatingItemRepository
+	transfer       *repository.MockTransferRepository
+	statement      *repository.MockStatementRepository
+	budget         *repository.MockBudgetRepository
+	exchangeRate   *repository.MockExchangeRateRepository
+	unitConversion *repository.MockUnitConversionRepository
+	ledger         *repository.MockLedgerRepository
+}
+
+// unknownIconID is the icon which is not in the catalog of newMockIconValidator.
+const unknownIconID = 99
+
+func newMockIconValidator(controller *gomock.Controller) *icons.MockValidator {
+	validator := icons.NewMockValidator(controller)
+	validator.EXPECT().
+		Validate(gomock.Any(), gomock.Cond(func(r *icons.ValidateRequest) bool {
+			return r.IconID == unknownIconID
+		})).
+		Return(icons.ErrIconNotFound).
+		AnyTimes()
+	validator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return validator
+}
+
+func newMockRepositories(controller *gomock.Controller) *mockRepositories {
+	repos := &mockRepositories{
+		user:           repository.NewMockUserRepository(controller),
+		account:        repository.NewMockAccountRepository(controller),
+		category:       repository.NewMockCategoryRepository(controller),
+		shop:           repository.NewMockShopRepository(controller),
+		tag:            repository.NewMockTagRepository(controller),
+		fee:            repository.NewMockFeeRepository(controller),
+		dailyItem:      repository.NewMockDailyItemRepository(controller),
+		repeatingItem:  repository.NewMockRepeatingItemRepository(controller),
+		transfer:       repository.NewMockTransferRepository(controller),
+		statement:      repository.NewMockStatementRepository(controller),
+		budget:         repository.NewMockBudgetRepository(controller),
+		exchangeRate:   repository.NewMockExchangeRateRepository(controller),
+		unitConversion: repository.NewMockUnitConversionRepository(controller),
+		ledger:         repository.NewMockLedgerRepository(controller),
+	}
+	repos.Repositories = &Repositories{
+		User:           repos.user,
+		Account:        repos.account,
+		Category:       repos.category,
+		Shop:           repos.shop,
+		Tag:            repos.tag,
+		Fee:            repos.fee,
+		DailyItem:      repos.dailyItem,
+		RepeatingItem:  repos.repeatingItem,
+		Transfer:       repos.transfer,
+		Statement:      repos.statement,
+		Budget:         repos.budget,
+		ExchangeRate:   repos.exchangeRate,
+		UnitConversion: repos.unitConversion,
+		Ledger:         repos.ledger,
+	}
+	return repos
+}
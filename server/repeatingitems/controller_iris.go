@@ -0,0 +1,164 @@
+package repeatingitems
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	*irisController.SimpleCreateTemplate[models.BasicRepeatingItem, CreateRequest, CreateReply, models.ObjectId]
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.RepeatingItem]
+	*irisController.SimpleUpdateTemplate[models.BasicRepeatingItem, UpdateRequest, UpdateReply]
+	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicRepeatingItem, CreateRequest, CreateReply, models.ObjectId]{
+			Service: s,
+			ParseServiceRequest: func(userID string, r *models.BasicRepeatingItem) (*CreateRequest, error) {
+				item, err := toServiceBaseRepeatingItem(r)
+				if err != nil {
+					return nil, err
+				}
+				return &CreateRequest{
+					UserID: userID,
+					Item:   item,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidFrequency),
+					errors.Is(err, ErrInvalidPeriod),
+					errors.Is(err, ErrInvalidSplit),
+					errors.Is(err, ErrInvalidUnit):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *CreateReply) (*models.ObjectId, error) {
+				return &models.ObjectId{
+					Id: lo.ToPtr(models.Id(reply.Item.PublicID)),
+				}, nil
+			},
+		},
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.RepeatingItem]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				return &ListRequest{
+					UserID: userID,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.RepeatingItem, error) {
+				return lo.ToPtr(lo.Map(reply.Items, func(item *RepeatingItem, _ int) *models.RepeatingItem {
+					return toAPIRepeatingItem(item)
+				})), nil
+			},
+		},
+		SimpleUpdateTemplate: &irisController.SimpleUpdateTemplate[models.BasicRepeatingItem, UpdateRequest, UpdateReply]{
+			Placeholder: "repeatingItemId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string, r *models.BasicRepeatingItem) (*UpdateRequest, error) {
+				item, err := toServiceBaseRepeatingItem(r)
+				if err != nil {
+					return nil, err
+				}
+				return &UpdateRequest{
+					UserID:                userID,
+					RepeatingItemPublicID: publicID,
+					Item:                  item,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrRepeatingItemNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidFrequency),
+					errors.Is(err, ErrInvalidPeriod),
+					errors.Is(err, ErrInvalidSplit),
+					errors.Is(err, ErrInvalidUnit):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+			Placeholder: "repeatingItemId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+				return &DeleteRequest{
+					UserID:                userID,
+					RepeatingItemPublicID: publicID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrRepeatingItemNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+	}
+}
+
+func toServiceBaseRepeatingItem(r *models.BasicRepeatingItem) (*BaseRepeatingItem, error) {
+	item, err := dailyitems.ParseBasicItem(&r.Item)
+	if err != nil {
+		return nil, err
+	}
+	return &BaseRepeatingItem{
+		Item:      item,
+		StartDate: r.Valid.Start.Time,
+		EndDate: lo.IfF(r.Valid.End != nil, func() *time.Time {
+			return lo.ToPtr(r.Valid.End.Time)
+		}).Else(nil),
+		Frequency: &RepeatingFrequency{
+			Days:         lo.FromPtr(r.Frequency.Duration),
+			EveryWorkDay: lo.FromPtr(r.Frequency.EveryWorkDay),
+		},
+	}, nil
+}
+
+func toAPIRepeatingItem(v *RepeatingItem) *models.RepeatingItem {
+	return &models.RepeatingItem{
+		Id:   lo.ToPtr(models.Id(v.PublicID)),
+		Item: *dailyitems.ToBasicItem(v.Item),
+		Valid: models.ValidPeriod{
+			Start: openapi_types.Date{Time: v.StartDate},
+			End: lo.IfF(v.EndDate != nil, func() *openapi_types.Date {
+				return &openapi_types.Date{Time: *v.EndDate}
+			}).Else(nil),
+		},
+		Frequency: models.RepeatingFrequency{
+			Duration: lo.IfF(v.Frequency.Days > 0, func() *int32 {
+				return lo.ToPtr(v.Frequency.Days)
+			}).Else(nil),
+			EveryWorkDay: lo.IfF(v.Frequency.EveryWorkDay, func() *bool {
+				return lo.ToPtr(true)
+			}).Else(nil),
+		},
+		NextDate: lo.IfF(v.NextDate != nil, func() *openapi_types.Date {
+			return &openapi_types.Date{Time: *v.NextDate}
+		}).Else(nil),
+	}
+}
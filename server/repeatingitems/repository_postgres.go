@@ -0,0 +1,224 @@
+package repeatingitems
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.RepeatingItemRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Create(ctx context.Context, r *repository.CreateRepeatingItemsRequest) ([]*repository.RepeatingItem, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	return CreateWithSession(session, r)
+}
+
+// CreateWithSession is the same as Create, but it creates the repeating items with the session
+// so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateRepeatingItemsRequest) ([]*repository.RepeatingItem, error) {
+	rows := lo.Map(r.Items, func(item *repository.BaseCreateRepeatingItem, _ int) *postgres.RepeatingItemsModel {
+		return &postgres.RepeatingItemsModel{
+			PublicID: item.PublicID,
+			UserID:   r.UserID,
+			Data: &postgres.BaseRepeatingItem{
+				BaseRepeatingItem: item.BaseRepeatingItem,
+			},
+			NextDate: postgres.ToNullTime(item.NextDate),
+		}
+	})
+	_, err := session.Insert(rows)
+	if err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	return lo.Map(rows, func(item *postgres.RepeatingItemsModel, _ int) *repository.RepeatingItem {
+		return toRepeatingItem(item)
+	}), nil
+}
+
+func (repo *postgresRepository) List(ctx context.Context, r *repository.ListRepeatingItemsRequest) (*repository.ListRepeatingItemsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var rows []*postgres.RepeatingItemsModel
+	err := session.Asc("id").Find(&rows, &postgres.RepeatingItemsModel{
+		PublicID: lo.FromPtr(r.RepeatingItemPublicID),
+		UserID:   r.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+	return &repository.ListRepeatingItemsReply{
+		Items: lo.Map(rows, func(item *postgres.RepeatingItemsModel, _ int) *repository.RepeatingItem {
+			return toRepeatingItem(item)
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) ListDue(ctx context.Context, r *repository.ListDueRepeatingItemsRequest) (*repository.ListDueRepeatingItemsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var rows []*postgres.RepeatingItemsModel
+	err := session.
+		Where("next_date IS NOT NULL").
+		And("next_date <= ?", r.Date.Format(time.DateOnly)).
+		Asc("next_date", "id").
+		Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+	return &repository.ListDueRepeatingItemsReply{
+		Items: lo.Map(rows, func(item *postgres.RepeatingItemsModel, _ int) *repository.DueRepeatingItem {
+			return &repository.DueRepeatingItem{
+				UserID:        item.UserID,
+				RepeatingItem: toRepeatingItem(item),
+			}
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) Update(ctx context.Context, r *repository.UpdateRepeatingItemRequest) (*repository.RepeatingItem, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	row := postgres.RepeatingItemsModel{
+		PublicID: r.RepeatingItemPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&row)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrDataNotFound
+	}
+
+	row.Data = &postgres.BaseRepeatingItem{
+		BaseRepeatingItem: r.Item,
+	}
+	row.NextDate = postgres.ToNullTime(r.NextDate)
+
+	affected, err := session.Cols("data", "next_date").Update(&row, &postgres.RepeatingItemsModel{
+		ID: row.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	return toRepeatingItem(&row), nil
+}
+
+func (repo *postgresRepository) UpdateNextDate(ctx context.Context, r *repository.UpdateRepeatingItemNextDateRequest) error {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	affected, err := session.Cols("next_date").Update(&postgres.RepeatingItemsModel{
+		NextDate: postgres.ToNullTime(r.NextDate),
+	}, &postgres.RepeatingItemsModel{
+		PublicID: r.RepeatingItemPublicID,
+		UserID:   r.UserID,
+	})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrDataNotFound
+	}
+	return nil
+}
+
+func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteRepeatingItemsRequest) ([]*repository.RepeatingItem, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	items, err := DeleteWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// DeleteWithSession is the same as Delete, but it deletes the repeating items with the
+// session so that they can be deleted in the transaction of the session.
+func DeleteWithSession(session *xorm.Session, r *repository.DeleteRepeatingItemsRequest) ([]*repository.RepeatingItem, error) {
+	session.Where("user_id = ?", r.UserID)
+	if len(r.RepeatingItemPublicIDs) > 0 {
+		session.In("public_id", r.RepeatingItemPublicIDs)
+	}
+
+	var rows []*postgres.RepeatingItemsModel
+	err := session.Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.RepeatingItemPublicIDs) > 0 && len(rows) != len(r.RepeatingItemPublicIDs) {
+		return nil, repository.ErrDataNotFound
+	}
+	if len(r.RepeatingItemPublicIDs) == 0 && len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	ids := lo.Map(rows, func(item *postgres.RepeatingItemsModel, _ int) any {
+		return item.ID
+	})
+	// keeps the created daily items.
+	_, err = session.
+		In("repeating_item_id", ids).
+		Cols("repeating_item_id").
+		Update(&postgres.DailyItemsModel{})
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.In("id", ids).Delete(&postgres.RepeatingItemsModel{})
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(rows, func(item *postgres.RepeatingItemsModel, _ int) *repository.RepeatingItem {
+		return toRepeatingItem(item)
+	}), nil
+}
+
+func toRepeatingItem(item *postgres.RepeatingItemsModel) *repository.RepeatingItem {
+	return &repository.RepeatingItem{
+		ID:                item.ID,
+		PublicID:          item.PublicID,
+		BaseRepeatingItem: item.Data.BaseRepeatingItem,
+		NextDate:          postgres.FromNullTime(item.NextDate),
+	}
+}
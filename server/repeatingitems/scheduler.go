@@ -0,0 +1,89 @@
+package repeatingitems
+
+import (
+	"context"
+	"time"
+
+	"github.com/kataras/golog"
+
+	"github.com/n101661/maney/pkg/utils"
+)
+
+// Scheduler materializes the due repeating items periodically.
+type Scheduler struct {
+	service Service
+
+	opts *schedulerOptions
+}
+
+func NewScheduler(service Service, opts ...utils.Option[schedulerOptions]) *Scheduler {
+	return &Scheduler{
+		service: service,
+		opts:    utils.ApplyOptions(defaultSchedulerOptions(), opts),
+	}
+}
+
+// Run materializes the due repeating items immediately and then at every interval
+// until the context is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.interval)
+	defer ticker.Stop()
+
+	for {
+		s.materialize(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) materialize(ctx context.Context) {
+	reply, err := s.service.Materialize(ctx, &MaterializeRequest{
+		Date: s.opts.now(),
+	})
+	if s.opts.logger == nil {
+		return
+	}
+	if err != nil {
+		s.opts.logger.Errorf("failed to materialize repeating items: %v", err)
+		return
+	}
+	for _, skipped := range reply.Skipped {
+		s.opts.logger.Warnf("skip the occurrence of repeating item[%s] on %s: %v",
+			skipped.RepeatingItemPublicID, skipped.Date.Format(time.DateOnly), skipped.Err)
+	}
+	if reply.CreatedCount > 0 {
+		s.opts.logger.Infof("%d daily items are created from repeating items", reply.CreatedCount)
+	}
+}
+
+type schedulerOptions struct {
+	interval time.Duration
+	now      func() time.Time
+	logger   *golog.Logger
+}
+
+func defaultSchedulerOptions() *schedulerOptions {
+	return &schedulerOptions{
+		interval: time.Hour,
+		now:      time.Now,
+	}
+}
+
+// WithSchedulerInterval sets the interval of materializing, it is ignored if d is not positive.
+func WithSchedulerInterval(d time.Duration) utils.Option[schedulerOptions] {
+	return func(o *schedulerOptions) {
+		if d > 0 {
+			o.interval = d
+		}
+	}
+}
+
+func WithSchedulerLogger(logger *golog.Logger) utils.Option[schedulerOptions] {
+	return func(o *schedulerOptions) {
+		o.logger = logger
+	}
+}
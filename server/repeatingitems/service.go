@@ -0,0 +1,120 @@
+package repeatingitems
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+var (
+	ErrDataInsufficient      = fmt.Errorf("data insufficient")
+	ErrInvalidFrequency      = fmt.Errorf("frequency must be either positive days or every work day")
+	ErrInvalidPeriod         = fmt.Errorf("end date must not be before start date")
+	ErrRepeatingItemNotFound = fmt.Errorf("repeating item not found")
+	ErrInvalidSplit          = fmt.Errorf("splits must cover each category of the item once and sum to the item total")
+	ErrInvalidUnit           = fmt.Errorf("invalid unit of the quantity")
+)
+
+type Service interface {
+	// Create returns error:
+	//  - ErrDataInsufficient if any of required fields of CreateRequest is zero-value,
+	//  - ErrInvalidFrequency if the frequency is neither positive days nor every work day,
+	//  - ErrInvalidPeriod if the end date is before the start date,
+	//  - ErrInvalidSplit if the splits of the item are invalid, see repository.BaseItem.ResolveSplits,
+	//  - ErrInvalidUnit if the unit is not supported or it is provided without the quantity.
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	// List returns ErrDataInsufficient if any of fields of ListRequest is zero-value,
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Update returns error:
+	//  - ErrDataInsufficient if any of required fields of UpdateRequest is zero-value,
+	//  - ErrInvalidFrequency if the frequency is neither positive days nor every work day,
+	//  - ErrInvalidPeriod if the end date is before the start date,
+	//  - ErrInvalidSplit if the splits of the item are invalid, see repository.BaseItem.ResolveSplits,
+	//  - ErrInvalidUnit if the unit is not supported or it is provided without the quantity,
+	//  - ErrRepeatingItemNotFound if the repeating item does not exist.
+	// The occurrences before today are not created after the update.
+	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
+	// Delete returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
+	//  - ErrRepeatingItemNotFound if the repeating item does not exist.
+	// The daily items created from the repeating item are kept.
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+	// Materialize creates the daily items of all occurrences which are due on or before
+	// the date of MaterializeRequest. Each occurrence is created once even if it is called
+	// repeatedly, so it is safe to be retried.
+	Materialize(context.Context, *MaterializeRequest) (*MaterializeReply, error)
+}
+
+type BaseRepeatingItem struct {
+	Item      *BaseItem
+	StartDate time.Time
+	EndDate   *time.Time
+	Frequency *RepeatingFrequency
+}
+
+type BaseItem = repository.BaseItem
+
+type RepeatingFrequency = repository.RepeatingFrequency
+
+type RepeatingItem struct {
+	ID       int32
+	PublicID string
+	*BaseRepeatingItem
+	// NextDate is the date of the next occurrence which is not created yet.
+	// It is nil if there is no more occurrence.
+	NextDate *time.Time
+}
+
+type CreateRequest struct {
+	UserID string
+	Item   *BaseRepeatingItem
+}
+
+type CreateReply struct {
+	Item *RepeatingItem
+}
+
+type ListRequest struct {
+	UserID string
+}
+
+type ListReply struct {
+	Items []*RepeatingItem
+}
+
+type UpdateRequest struct {
+	UserID                string
+	RepeatingItemPublicID string
+	Item                  *BaseRepeatingItem
+}
+
+type UpdateReply struct {
+	Item *RepeatingItem
+}
+
+type DeleteRequest struct {
+	UserID                string
+	RepeatingItemPublicID string
+}
+
+type DeleteReply struct{}
+
+type MaterializeRequest struct {
+	Date time.Time
+}
+
+type MaterializeReply struct {
+	// CreatedCount is the number of the created daily items.
+	CreatedCount int
+	// Skipped are the occurrences which can never be created, such as the referenced
+	// category has been deleted, they are not retried.
+	Skipped []*SkippedOccurrence
+}
+
+type SkippedOccurrence struct {
+	RepeatingItemPublicID string
+	Date                  time.Time
+	Err                   error
+}
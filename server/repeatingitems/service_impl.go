@@ -0,0 +1,364 @@
+package repeatingitems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	repository          repository.RepeatingItemRepository
+	dailyItemRepository repository.DailyItemRepository
+
+	opts *RepeatingItemServiceOptions
+}
+
+func NewService(
+	repository repository.RepeatingItemRepository,
+	dailyItemRepository repository.DailyItemRepository,
+	opts ...utils.Option[RepeatingItemServiceOptions],
+) (Service, error) {
+	return &service{
+		repository:          repository,
+		dailyItemRepository: dailyItemRepository,
+		opts:                utils.ApplyOptions(defaultRepeatingItemServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if err := ValidateBaseRepeatingItem(r.Item); err != nil {
+		return nil, err
+	}
+
+	item := parseBaseRepeatingItem(r.Item)
+	rows, err := s.repository.Create(ctx, &repository.CreateRepeatingItemsRequest{
+		UserID: r.UserID,
+		Items: []*repository.BaseCreateRepeatingItem{
+			{
+				PublicID:          s.opts.genPublicID(),
+				BaseRepeatingItem: item,
+				NextDate:          nextOccurrence(item, item.StartDate),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateReply{
+		Item: parseRepeatingItem(rows[0]),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListRepeatingItemsRequest{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ListReply{
+				Items: []*RepeatingItem{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &ListReply{
+		Items: lo.Map(reply.Items, func(item *repository.RepeatingItem, _ int) *RepeatingItem {
+			return parseRepeatingItem(item)
+		}),
+	}, nil
+}
+
+func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.RepeatingItemPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+	if err := ValidateBaseRepeatingItem(r.Item); err != nil {
+		return nil, err
+	}
+
+	item := parseBaseRepeatingItem(r.Item)
+	row, err := s.repository.Update(ctx, &repository.UpdateRepeatingItemRequest{
+		UserID:                r.UserID,
+		RepeatingItemPublicID: r.RepeatingItemPublicID,
+		Item:                  item,
+		NextDate:              nextOccurrence(item, maxDate(item.StartDate, toDate(s.opts.now()))),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrRepeatingItemNotFound
+		}
+		return nil, err
+	}
+
+	return &UpdateReply{
+		Item: parseRepeatingItem(row),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.RepeatingItemPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.Delete(ctx, &repository.DeleteRepeatingItemsRequest{
+		UserID:                 r.UserID,
+		RepeatingItemPublicIDs: []string{r.RepeatingItemPublicID},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrRepeatingItemNotFound
+		}
+		return nil, err
+	}
+	return &DeleteReply{}, nil
+}
+
+func (s *service) Materialize(ctx context.Context, r *MaterializeRequest) (*MaterializeReply, error) {
+	if r.Date.IsZero() {
+		return nil, fmt.Errorf("%w: missing date", ErrDataInsufficient)
+	}
+	date := toDate(r.Date)
+
+	reply, err := s.repository.ListDue(ctx, &repository.ListDueRepeatingItemsRequest{
+		Date: date,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &MaterializeReply{}, nil
+		}
+		return nil, err
+	}
+
+	var (
+		result = &MaterializeReply{}
+		errs   []error
+	)
+	for _, item := range reply.Items {
+		if err := s.materialize(ctx, item, date, result); err != nil {
+			errs = append(errs, fmt.Errorf("failed to materialize repeating item[%s]: %w", item.PublicID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// materialize creates the daily items of the occurrences from the next date of the item
+// to the date, then moves the next date forward. An occurrence which has been created
+// is skipped, so the next date can lag behind the created daily items. An occurrence
+// which can never be created is skipped and added to the reply, the other errors stop
+// the item so that it is retried from the occurrence.
+func (s *service) materialize(ctx context.Context, item *repository.DueRepeatingItem, date time.Time, reply *MaterializeReply) error {
+	var err error
+	next := item.NextDate
+	for next != nil && !next.After(date) {
+		_, err = s.dailyItemRepository.Create(ctx, &repository.CreateDailyItemsRequest{
+			UserID: item.UserID,
+			Items: []*repository.BaseCreateDailyItem{
+				{
+					PublicID: s.opts.genDailyItemPublicID(),
+					BaseDailyItem: &repository.BaseDailyItem{
+						Date:                  *next,
+						RepeatingItemPublicID: lo.ToPtr(item.PublicID),
+						BaseItem:              item.Item,
+					},
+				},
+			},
+		})
+		if err != nil && !errors.Is(err, repository.ErrDataExists) {
+			if !unrecoverable(err) {
+				break
+			}
+			reply.Skipped = append(reply.Skipped, &SkippedOccurrence{
+				RepeatingItemPublicID: item.PublicID,
+				Date:                  *next,
+				Err:                   err,
+			})
+		}
+		if err == nil {
+			reply.CreatedCount++
+		}
+		err = nil
+
+		next = nextOccurrence(item.BaseRepeatingItem, next.AddDate(0, 0, 1))
+	}
+
+	if next != item.NextDate {
+		if e := s.repository.UpdateNextDate(ctx, &repository.UpdateRepeatingItemNextDateRequest{
+			UserID:                item.UserID,
+			RepeatingItemPublicID: item.PublicID,
+			NextDate:              next,
+		}); e != nil {
+			err = errors.Join(err, e)
+		}
+	}
+	return err
+}
+
+// unrecoverable reports whether the daily item fails to be created by its data, so that it
+// fails every time it is retried.
+func unrecoverable(err error) bool {
+	return errors.Is(err, repository.ErrReferenceNotFound) ||
+		errors.Is(err, repository.ErrInvalidReference) ||
+		errors.Is(err, repository.ErrCurrencyMismatch) ||
+		errors.Is(err, repository.ErrInvalidSplit)
+}
+
+// nextOccurrence returns the first occurrence of the item on or after the date, it returns
+// nil if there is no more occurrence.
+func nextOccurrence(v *repository.BaseRepeatingItem, date time.Time) *time.Time {
+	start := toDate(v.StartDate)
+	date = maxDate(start, toDate(date))
+
+	switch {
+	case v.Frequency.EveryWorkDay:
+		for date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			date = date.AddDate(0, 0, 1)
+		}
+	case v.Frequency.Days > 0:
+		days := int(date.Sub(start).Hours() / 24)
+		if remain := days % int(v.Frequency.Days); remain != 0 {
+			date = date.AddDate(0, 0, int(v.Frequency.Days)-remain)
+		}
+	default:
+		return nil
+	}
+
+	if v.EndDate != nil && date.After(toDate(*v.EndDate)) {
+		return nil
+	}
+	return &date
+}
+
+// toDate truncates the time to the date in UTC.
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func maxDate(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// ValidateBaseRepeatingItem checks the required fields, the frequency, the period, the unit
+// and the splits of the item. It is shared with the importer of the ledger.
+func ValidateBaseRepeatingItem(v *BaseRepeatingItem) error {
+	if v == nil {
+		return fmt.Errorf("%w: missing item", ErrDataInsufficient)
+	}
+	if v.Item == nil {
+		return fmt.Errorf("%w: missing item.item", ErrDataInsufficient)
+	}
+	if len(v.Item.CategoryPublicIDs) == 0 {
+		return fmt.Errorf("%w: missing item.item.categoryIds", ErrDataInsufficient)
+	}
+	if v.StartDate.IsZero() {
+		return fmt.Errorf("%w: missing item.valid.start", ErrDataInsufficient)
+	}
+	if v.Frequency == nil {
+		return fmt.Errorf("%w: missing item.frequency", ErrDataInsufficient)
+	}
+	if v.Frequency.EveryWorkDay == (v.Frequency.Days > 0) || v.Frequency.Days < 0 {
+		return ErrInvalidFrequency
+	}
+	if v.EndDate != nil && toDate(*v.EndDate).Before(toDate(v.StartDate)) {
+		return ErrInvalidPeriod
+	}
+	if v.Item.Unit != repository.UnitNone && (!v.Item.Unit.Valid() || v.Item.Quantity == nil) {
+		return ErrInvalidUnit
+	}
+	// the splits of the item which references a fee are validated while the occurrences
+	// are created since the fee is computed by the repository.
+	if v.Item.FeePublicID == nil {
+		if _, err := v.Item.ResolveSplits(); err != nil {
+			if errors.Is(err, repository.ErrInvalidSplit) {
+				return ErrInvalidSplit
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func parseRepeatingItem(v *repository.RepeatingItem) *RepeatingItem {
+	return &RepeatingItem{
+		ID:                v.ID,
+		PublicID:          v.PublicID,
+		BaseRepeatingItem: lo.ToPtr(BaseRepeatingItem(*v.BaseRepeatingItem)),
+		NextDate:          v.NextDate,
+	}
+}
+
+func parseBaseRepeatingItem(v *BaseRepeatingItem) *repository.BaseRepeatingItem {
+	return &repository.BaseRepeatingItem{
+		Item:      v.Item,
+		StartDate: toDate(v.StartDate),
+		EndDate: lo.IfF(v.EndDate != nil, func() *time.Time {
+			return lo.ToPtr(toDate(*v.EndDate))
+		}).Else(nil),
+		Frequency: v.Frequency,
+	}
+}
+
+type RepeatingItemServiceOptions struct {
+	genPublicID          func() string
+	genDailyItemPublicID func() string
+	now                  func() time.Time
+}
+
+func defaultRepeatingItemServiceOptions() *RepeatingItemServiceOptions {
+	return &RepeatingItemServiceOptions{
+		genPublicID: func() string {
+			return slugid.New("rpt", 11)
+		},
+		genDailyItemPublicID: func() string {
+			return slugid.New("itm", 11)
+		},
+		now: time.Now,
+	}
+}
+
+func WithRepeatingItemServiceGenPublicID(f func() string) utils.Option[RepeatingItemServiceOptions] {
+	return func(o *RepeatingItemServiceOptions) {
+		o.genPublicID = f
+	}
+}
+
+// WithRepeatingItemServiceGenDailyItemPublicID sets the public id generator of the
+// daily items created from the repeating items.
+func WithRepeatingItemServiceGenDailyItemPublicID(f func() string) utils.Option[RepeatingItemServiceOptions] {
+	return func(o *RepeatingItemServiceOptions) {
+		o.genDailyItemPublicID = f
+	}
+}
+
+func WithRepeatingItemServiceNow(f func() time.Time) utils.Option[RepeatingItemServiceOptions] {
+	return func(o *RepeatingItemServiceOptions) {
+		o.now = f
+	}
+}
@@ -0,0 +1,686 @@
+package repeatingitems
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Create(t *testing.T) {
+	t.Run("create successful", func(t *testing.T) {
+		const (
+			userID     = "user-id"
+			publicID   = "publicID"
+			categoryID = "categoryID"
+
+			returnedItemID = 9
+		)
+		var (
+			// Saturday.
+			startDate = time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)
+			item      = &repository.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{categoryID},
+				Price:             decimal.NewFromInt(50),
+			}
+			nextDate = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Create(gomock.Any(), &repository.CreateRepeatingItemsRequest{
+					UserID: userID,
+					Items: []*repository.BaseCreateRepeatingItem{
+						{
+							PublicID: publicID,
+							BaseRepeatingItem: &repository.BaseRepeatingItem{
+								Item:      item,
+								StartDate: startDate,
+								Frequency: &repository.RepeatingFrequency{
+									EveryWorkDay: true,
+								},
+							},
+							NextDate: lo.ToPtr(nextDate),
+						},
+					},
+				}).
+				Return([]*repository.RepeatingItem{
+					{
+						ID:       returnedItemID,
+						PublicID: publicID,
+						BaseRepeatingItem: &repository.BaseRepeatingItem{
+							Item:      item,
+							StartDate: startDate,
+							Frequency: &repository.RepeatingFrequency{
+								EveryWorkDay: true,
+							},
+						},
+						NextDate: lo.ToPtr(nextDate),
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, WithRepeatingItemServiceGenPublicID(func() string {
+			return publicID
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Item: &BaseRepeatingItem{
+				Item:      item,
+				StartDate: startDate,
+				Frequency: &RepeatingFrequency{
+					EveryWorkDay: true,
+				},
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&CreateReply{
+			Item: &RepeatingItem{
+				ID:       returnedItemID,
+				PublicID: publicID,
+				BaseRepeatingItem: &BaseRepeatingItem{
+					Item:      item,
+					StartDate: startDate,
+					Frequency: &RepeatingFrequency{
+						EveryWorkDay: true,
+					},
+				},
+				NextDate: lo.ToPtr(nextDate),
+			},
+		}, reply)
+	})
+	t.Run("invalid frequency", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseRepeatingItem{
+				Item: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					Price:             decimal.NewFromInt(1),
+				},
+				StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency: &RepeatingFrequency{
+					Days:         1,
+					EveryWorkDay: true,
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidFrequency)
+		assert.Nil(reply)
+	})
+	t.Run("end date is before start date", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseRepeatingItem{
+				Item: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					Price:             decimal.NewFromInt(1),
+				},
+				StartDate: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+				EndDate:   lo.ToPtr(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+				Frequency: &RepeatingFrequency{
+					Days: 1,
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidPeriod)
+		assert.Nil(reply)
+	})
+	t.Run("splits do not sum to the total", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Item: &BaseRepeatingItem{
+				Item: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"rentID", "utilitiesID"},
+					Splits: []*repository.ItemSplit{
+						{CategoryPublicID: "rentID", Percentage: lo.ToPtr(decimal.NewFromInt(80))},
+						{CategoryPublicID: "utilitiesID", Percentage: lo.ToPtr(decimal.NewFromInt(10))},
+					},
+					Price: decimal.NewFromInt(1000),
+				},
+				StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency: &RepeatingFrequency{
+					Days: 30,
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidSplit)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_List(t *testing.T) {
+	t.Run("no repeating item", func(t *testing.T) {
+		const userID = "user-id"
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListRepeatingItemsRequest{
+					UserID: userID,
+				}).
+				Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: userID,
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Items: []*RepeatingItem{},
+		}, reply)
+	})
+}
+
+func Test_service_Update(t *testing.T) {
+	t.Run("update successful", func(t *testing.T) {
+		const (
+			userID   = "user-id"
+			publicID = "publicID"
+		)
+		var (
+			startDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			now       = time.Date(2025, 1, 5, 10, 0, 0, 0, time.Local)
+			item      = &repository.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{"categoryID"},
+				Price:             decimal.NewFromInt(50),
+			}
+			nextDate = time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Update(gomock.Any(), &repository.UpdateRepeatingItemRequest{
+					UserID:                userID,
+					RepeatingItemPublicID: publicID,
+					Item: &repository.BaseRepeatingItem{
+						Item:      item,
+						StartDate: startDate,
+						Frequency: &repository.RepeatingFrequency{
+							Days: 3,
+						},
+					},
+					NextDate: lo.ToPtr(nextDate),
+				}).
+				Return(&repository.RepeatingItem{
+					ID:       1,
+					PublicID: publicID,
+					BaseRepeatingItem: &repository.BaseRepeatingItem{
+						Item:      item,
+						StartDate: startDate,
+						Frequency: &repository.RepeatingFrequency{
+							Days: 3,
+						},
+					},
+					NextDate: lo.ToPtr(nextDate),
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, WithRepeatingItemServiceNow(func() time.Time {
+			return now
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:                userID,
+			RepeatingItemPublicID: publicID,
+			Item: &BaseRepeatingItem{
+				Item:      item,
+				StartDate: startDate,
+				Frequency: &RepeatingFrequency{
+					Days: 3,
+				},
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&UpdateReply{
+			Item: &RepeatingItem{
+				ID:       1,
+				PublicID: publicID,
+				BaseRepeatingItem: &BaseRepeatingItem{
+					Item:      item,
+					StartDate: startDate,
+					Frequency: &RepeatingFrequency{
+						Days: 3,
+					},
+				},
+				NextDate: lo.ToPtr(nextDate),
+			},
+		}, reply)
+	})
+	t.Run("repeating item not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:                "user-id",
+			RepeatingItemPublicID: "publicID",
+			Item: &BaseRepeatingItem{
+				Item: &BaseItem{
+					Name:              "A",
+					CategoryPublicIDs: []string{"categoryID"},
+					Price:             decimal.NewFromInt(1),
+				},
+				StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency: &RepeatingFrequency{
+					Days: 1,
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrRepeatingItemNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Delete(t *testing.T) {
+	t.Run("repeating item not found", func(t *testing.T) {
+		const (
+			userID   = "user-id"
+			publicID = "publicID"
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Delete(gomock.Any(), &repository.DeleteRepeatingItemsRequest{
+					UserID:                 userID,
+					RepeatingItemPublicIDs: []string{publicID},
+				}).
+				Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:                userID,
+			RepeatingItemPublicID: publicID,
+		})
+		assert.ErrorIs(err, ErrRepeatingItemNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Materialize(t *testing.T) {
+	t.Run("create due occurrences and skip created ones", func(t *testing.T) {
+		const (
+			userID             = "user-id"
+			publicID           = "publicID"
+			dailyItemPublicID  = "dailyItemPublicID"
+			repeatingFrequency = 2
+		)
+		var (
+			startDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			date      = time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+			item      = &repository.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{"categoryID"},
+				Price:             decimal.NewFromInt(50),
+			}
+		)
+		newCreateRequest := func(date time.Time) *repository.CreateDailyItemsRequest {
+			return &repository.CreateDailyItemsRequest{
+				UserID: userID,
+				Items: []*repository.BaseCreateDailyItem{
+					{
+						PublicID: dailyItemPublicID,
+						BaseDailyItem: &repository.BaseDailyItem{
+							Date:                  date,
+							RepeatingItemPublicID: lo.ToPtr(publicID),
+							BaseItem:              item,
+						},
+					},
+				},
+			}
+		}
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				ListDue(gomock.Any(), &repository.ListDueRepeatingItemsRequest{
+					Date: date,
+				}).
+				Return(&repository.ListDueRepeatingItemsReply{
+					Items: []*repository.DueRepeatingItem{
+						{
+							UserID: userID,
+							RepeatingItem: &repository.RepeatingItem{
+								ID:       1,
+								PublicID: publicID,
+								BaseRepeatingItem: &repository.BaseRepeatingItem{
+									Item:      item,
+									StartDate: startDate,
+									Frequency: &repository.RepeatingFrequency{
+										Days: repeatingFrequency,
+									},
+								},
+								NextDate: lo.ToPtr(startDate),
+							},
+						},
+					},
+				}, nil),
+			mockDailyItemRepo.EXPECT().
+				Create(gomock.Any(), newCreateRequest(startDate)).
+				Return(nil, repository.ErrDataExists),
+			mockDailyItemRepo.EXPECT().
+				Create(gomock.Any(), newCreateRequest(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC))).
+				Return([]*repository.DailyItem{{}}, nil),
+			mockDailyItemRepo.EXPECT().
+				Create(gomock.Any(), newCreateRequest(date)).
+				Return([]*repository.DailyItem{{}}, nil),
+			mockRepo.EXPECT().
+				UpdateNextDate(gomock.Any(), &repository.UpdateRepeatingItemNextDateRequest{
+					UserID:                userID,
+					RepeatingItemPublicID: publicID,
+					NextDate:              lo.ToPtr(time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)),
+				}).
+				Return(nil),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, WithRepeatingItemServiceGenDailyItemPublicID(func() string {
+			return dailyItemPublicID
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Materialize(context.Background(), &MaterializeRequest{
+			Date: date,
+		})
+		assert.NoError(err)
+		assert.Equal(&MaterializeReply{
+			CreatedCount: 2,
+		}, reply)
+	})
+	t.Run("no more occurrence after end date", func(t *testing.T) {
+		const (
+			userID            = "user-id"
+			publicID          = "publicID"
+			dailyItemPublicID = "dailyItemPublicID"
+		)
+		var (
+			// Friday.
+			endDate = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+			date    = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+			item    = &repository.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{"categoryID"},
+				Price:             decimal.NewFromInt(50),
+			}
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockRepeatingItemRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				ListDue(gomock.Any(), gomock.Any()).
+				Return(&repository.ListDueRepeatingItemsReply{
+					Items: []*repository.DueRepeatingItem{
+						{
+							UserID: userID,
+							RepeatingItem: &repository.RepeatingItem{
+								ID:       1,
+								PublicID: publicID,
+								BaseRepeatingItem: &repository.BaseRepeatingItem{
+									Item:      item,
+									StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+									EndDate:   lo.ToPtr(endDate),
+									Frequency: &repository.RepeatingFrequency{
+										EveryWorkDay: true,
+									},
+								},
+								NextDate: lo.ToPtr(endDate),
+							},
+						},
+					},
+				}, nil),
+			mockDailyItemRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				Return([]*repository.DailyItem{{}}, nil),
+			mockRepo.EXPECT().
+				UpdateNextDate(gomock.Any(), &repository.UpdateRepeatingItemNextDateRequest{
+					UserID:                userID,
+					RepeatingItemPublicID: publicID,
+					NextDate:              nil,
+				}).
+				Return(nil),
+		)
+
+		s, err := NewService(mockRepo, mockDailyItemRepo, WithRepeatingItemServiceGenDailyItemPublicID(func() string {
+			return dailyItemPublicID
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Materialize(context.Background(), &MaterializeRequest{
+			Date: date,
+		})
+		assert.NoError(err)
+		assert.Equal(&MaterializeReply{
+			CreatedCount: 1,
+		}, reply)
+	})
+	t.Run("skip occurrences which can never be created", func(t *testing.T) {
+		const (
+			userID            = "user-id"
+			publicID          = "publicID"
+			dailyItemPublicID = "dailyItemPublicID"
+		)
+		var (
+			jan1 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			jan2 = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+			jan3 = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+			item = &repository.BaseItem{
+				Name:              "A",
+				CategoryPublicIDs: []string{"categoryID"},
+				Price:             decimal.NewFromInt(50),
+			}
+		)
+		newDueItem := func() *repository.DueRepeatingItem {
+			return &repository.DueRepeatingItem{
+				UserID: userID,
+				RepeatingItem: &repository.RepeatingItem{
+					ID:       1,
+					PublicID: publicID,
+					BaseRepeatingItem: &repository.BaseRepeatingItem{
+						Item:      item,
+						StartDate: jan1,
+						Frequency: &repository.RepeatingFrequency{
+							Days: 1,
+						},
+					},
+					NextDate: lo.ToPtr(jan1),
+				},
+			}
+		}
+		newCreateRequest := func(date time.Time) *repository.CreateDailyItemsRequest {
+			return &repository.CreateDailyItemsRequest{
+				UserID: userID,
+				Items: []*repository.BaseCreateDailyItem{
+					{
+						PublicID: dailyItemPublicID,
+						BaseDailyItem: &repository.BaseDailyItem{
+							Date:                  date,
+							RepeatingItemPublicID: lo.ToPtr(publicID),
+							BaseItem:              item,
+						},
+					},
+				},
+			}
+		}
+
+		t.Run("unrecoverable error", func(t *testing.T) {
+			assert := assert.New(t)
+
+			controller := gomock.NewController(t)
+			mockRepo := repository.NewMockRepeatingItemRepository(controller)
+			mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+			gomock.InOrder(
+				mockRepo.EXPECT().
+					ListDue(gomock.Any(), gomock.Any()).
+					Return(&repository.ListDueRepeatingItemsReply{
+						Items: []*repository.DueRepeatingItem{newDueItem()},
+					}, nil),
+				mockDailyItemRepo.EXPECT().
+					Create(gomock.Any(), newCreateRequest(jan1)).
+					Return(nil, repository.ErrReferenceNotFound),
+				mockDailyItemRepo.EXPECT().
+					Create(gomock.Any(), newCreateRequest(jan2)).
+					Return([]*repository.DailyItem{{}}, nil),
+				mockRepo.EXPECT().
+					UpdateNextDate(gomock.Any(), &repository.UpdateRepeatingItemNextDateRequest{
+						UserID:                userID,
+						RepeatingItemPublicID: publicID,
+						NextDate:              lo.ToPtr(jan3),
+					}).
+					Return(nil),
+			)
+
+			s, err := NewService(mockRepo, mockDailyItemRepo, WithRepeatingItemServiceGenDailyItemPublicID(func() string {
+				return dailyItemPublicID
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reply, err := s.Materialize(context.Background(), &MaterializeRequest{
+				Date: jan2,
+			})
+			assert.NoError(err)
+			assert.Equal(&MaterializeReply{
+				CreatedCount: 1,
+				Skipped: []*SkippedOccurrence{
+					{
+						RepeatingItemPublicID: publicID,
+						Date:                  jan1,
+						Err:                   repository.ErrReferenceNotFound,
+					},
+				},
+			}, reply)
+		})
+		t.Run("other errors are retried", func(t *testing.T) {
+			assert := assert.New(t)
+
+			controller := gomock.NewController(t)
+			mockRepo := repository.NewMockRepeatingItemRepository(controller)
+			mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+			gomock.InOrder(
+				mockRepo.EXPECT().
+					ListDue(gomock.Any(), gomock.Any()).
+					Return(&repository.ListDueRepeatingItemsReply{
+						Items: []*repository.DueRepeatingItem{newDueItem()},
+					}, nil),
+				mockDailyItemRepo.EXPECT().
+					Create(gomock.Any(), newCreateRequest(jan1)).
+					Return(nil, errors.New("connection refused")),
+			)
+
+			s, err := NewService(mockRepo, mockDailyItemRepo, WithRepeatingItemServiceGenDailyItemPublicID(func() string {
+				return dailyItemPublicID
+			}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reply, err := s.Materialize(context.Background(), &MaterializeRequest{
+				Date: jan2,
+			})
+			assert.Error(err)
+			assert.Nil(reply)
+		})
+	})
+}
@@ -0,0 +1,209 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/models"
+	"github.com/n101661/maney/server/repository"
+)
+
+type IrisController struct {
+	CategoryBreakdown *irisController.SimpleGetTemplate[CategoryBreakdownRequest, CategoryBreakdownReply, models.CategoryBreakdown]
+	TagTotals         *irisController.SimpleGetTemplate[TagTotalsRequest, TagTotalsReply, models.TagTotals]
+	ShopPriceRanking  *irisController.SimpleGetTemplate[ShopPriceRankingRequest, ShopPriceRankingReply, models.ShopPriceRanking]
+	NetWorth          *irisController.SimpleGetTemplate[NetWorthRequest, NetWorthReply, models.NetWorth]
+}
+
+// defaultMaxPriceAgeDays is the age of the stale prices if it is not provided.
+const defaultMaxPriceAgeDays = 90
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		CategoryBreakdown: &irisController.SimpleGetTemplate[CategoryBreakdownRequest, CategoryBreakdownReply, models.CategoryBreakdown]{
+			Handle: s.CategoryBreakdown,
+			ParseServiceRequest: func(c iris.Context, userID string) (*CategoryBreakdownRequest, error) {
+				type_, err := repository.ToCategoryType(c.URLParamDefault("type", repository.CategoryTypeExpense.String()))
+				if err != nil {
+					return nil, err
+				}
+				from, err := parseDate(c.URLParam("from"))
+				if err != nil {
+					return nil, err
+				}
+				to, err := parseDate(c.URLParam("to"))
+				if err != nil {
+					return nil, err
+				}
+				return &CategoryBreakdownRequest{
+					UserID: userID,
+					Type:   type_,
+					From:   from,
+					To:     to,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidPeriod):
+					return iris.StatusBadRequest, true
+				}
+				return exchangerates.BadConversion(err)
+			},
+			ParseAPIResponse: func(reply *CategoryBreakdownReply) (*models.CategoryBreakdown, error) {
+				return &models.CategoryBreakdown{
+					Total:    reply.Total.String(),
+					TotalFee: reply.TotalFee.String(),
+					Categories: lo.Map(reply.Categories, func(item *CategoryShare, _ int) models.CategoryShare {
+						return models.CategoryShare{
+							CategoryId: item.CategoryPublicID,
+							ParentId:   item.ParentPublicID,
+							Amount:     item.Amount.String(),
+							Fee:        item.Fee.String(),
+							Base:       item.Base.String(),
+							Count:      item.Count,
+							Percentage: item.Percentage.String(),
+						}
+					}),
+				}, nil
+			},
+		},
+		TagTotals: &irisController.SimpleGetTemplate[TagTotalsRequest, TagTotalsReply, models.TagTotals]{
+			Handle: s.TagTotals,
+			ParseServiceRequest: func(c iris.Context, userID string) (*TagTotalsRequest, error) {
+				type_, err := repository.ToCategoryType(c.URLParamDefault("type", repository.CategoryTypeExpense.String()))
+				if err != nil {
+					return nil, err
+				}
+				from, err := parseDate(c.URLParam("from"))
+				if err != nil {
+					return nil, err
+				}
+				to, err := parseDate(c.URLParam("to"))
+				if err != nil {
+					return nil, err
+				}
+				return &TagTotalsRequest{
+					UserID: userID,
+					Type:   type_,
+					From:   from,
+					To:     to,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidPeriod):
+					return iris.StatusBadRequest, true
+				}
+				return exchangerates.BadConversion(err)
+			},
+			ParseAPIResponse: func(reply *TagTotalsReply) (*models.TagTotals, error) {
+				return &models.TagTotals{
+					Tags: lo.Map(reply.Tags, func(item *TagTotal, _ int) models.TagTotal {
+						return models.TagTotal{
+							TagId:  item.TagPublicID,
+							Amount: item.Amount.String(),
+							Count:  item.Count,
+						}
+					}),
+				}, nil
+			},
+		},
+		ShopPriceRanking: &irisController.SimpleGetTemplate[ShopPriceRankingRequest, ShopPriceRankingReply, models.ShopPriceRanking]{
+			Handle: s.ShopPriceRanking,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ShopPriceRankingRequest, error) {
+				date, err := parseDate(c.URLParamDefault("date", time.Now().Format(time.DateOnly)))
+				if err != nil {
+					return nil, err
+				}
+				maxAgeDays, err := c.URLParamInt("maxAgeDays")
+				if err != nil {
+					if c.URLParamExists("maxAgeDays") {
+						return nil, fmt.Errorf("invalid maxAgeDays[%s]", c.URLParam("maxAgeDays"))
+					}
+					maxAgeDays = defaultMaxPriceAgeDays
+				}
+				return &ShopPriceRankingRequest{
+					UserID:     userID,
+					ItemName:   c.URLParam("name"),
+					Date:       date,
+					MaxAgeDays: maxAgeDays,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return exchangerates.BadConversion(err)
+			},
+			ParseAPIResponse: func(reply *ShopPriceRankingReply) (*models.ShopPriceRanking, error) {
+				return &models.ShopPriceRanking{
+					Unit: lo.EmptyableToPtr(models.Unit(reply.Unit)),
+					Shops: lo.Map(reply.Shops, func(item *ShopPrice, _ int) models.ShopPrice {
+						return models.ShopPrice{
+							ShopId:       item.ShopPublicID,
+							LatestPrice:  item.LatestPrice.String(),
+							LatestDate:   openapi_types.Date{Time: item.LatestDate},
+							AveragePrice: item.AveragePrice.String(),
+							Count:        item.Count,
+						}
+					}),
+				}, nil
+			},
+		},
+		NetWorth: &irisController.SimpleGetTemplate[NetWorthRequest, NetWorthReply, models.NetWorth]{
+			Handle: s.NetWorth,
+			ParseServiceRequest: func(c iris.Context, userID string) (*NetWorthRequest, error) {
+				date, err := parseDate(c.URLParamDefault("date", time.Now().Format(time.DateOnly)))
+				if err != nil {
+					return nil, err
+				}
+				return &NetWorthRequest{
+					UserID: userID,
+					Date:   date,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return exchangerates.BadConversion(err)
+			},
+			ParseAPIResponse: func(reply *NetWorthReply) (*models.NetWorth, error) {
+				return &models.NetWorth{
+					Assets:            reply.Assets.String(),
+					Liabilities:       reply.Liabilities.String(),
+					NetWorth:          reply.NetWorth.String(),
+					AssetAccounts:     lo.Map(reply.AssetAccounts, toAPIAccountBalance),
+					LiabilityAccounts: lo.Map(reply.LiabilityAccounts, toAPIAccountBalance),
+				}, nil
+			},
+		},
+	}
+}
+
+func toAPIAccountBalance(item *AccountBalance, _ int) models.AccountBalance {
+	return models.AccountBalance{
+		AccountId: item.AccountPublicID,
+		Type:      models.AccountType(item.Type.String()),
+		Balance:   item.Balance.String(),
+	}
+}
+
+func parseDate(s string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date[%s]", s)
+	}
+	return date, nil
+}
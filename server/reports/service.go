@@ -0,0 +1,164 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+var (
+	ErrDataInsufficient = fmt.Errorf("data insufficient")
+	ErrInvalidPeriod    = fmt.Errorf("the end date is before the start date")
+)
+
+type Service interface {
+	// CategoryBreakdown returns the total amount, the number and the share of the daily items
+	// of each category of the type in the period. The amount of the item which has multiple
+	// categories is attributed by its splits, or split equally to each of them if it has no
+	// split, and the item is counted in each of them.
+	// The amounts and the numbers of the categories are rolled up into their ancestors, so
+	// the total is not the sum of the amounts of all categories if there are sub-categories.
+	// The fees and the taxes of the items are separated from their base prices.
+	// The amounts in foreign currencies are converted into the home currency with the
+	// exchange rates on the end date. It returns error:
+	//  - ErrDataInsufficient if any of required fields of CategoryBreakdownRequest is zero-value,
+	//  - ErrInvalidPeriod if the end date is before the start date,
+	//  - any error of exchangerates.Converter if the amounts fail to be converted.
+	CategoryBreakdown(context.Context, *CategoryBreakdownRequest) (*CategoryBreakdownReply, error)
+	// TagTotals returns the total amount and the number of the daily items of each tag of
+	// the type in the period. The full amount of the item which has multiple tags is counted
+	// in each of them, so the amounts of the tags may overlap. The amounts in foreign
+	// currencies are converted into the home currency with the exchange rates on the end
+	// date. It returns error:
+	//  - ErrDataInsufficient if any of required fields of TagTotalsRequest is zero-value,
+	//  - ErrInvalidPeriod if the end date is before the start date,
+	//  - any error of exchangerates.Converter if the amounts fail to be converted.
+	TagTotals(context.Context, *TagTotalsRequest) (*TagTotalsReply, error)
+	// ShopPriceRanking ranks the shops by the latest and the average unit prices of the
+	// item which are recorded while the user compares the items in different shops. The
+	// prices older than MaxAgeDays days before the date are considered stale and ignored.
+	// The prices in foreign currencies are converted into the home currency with the
+	// exchange rates on their dates. The normalized prices are ranked instead of the unit
+	// prices if all the prices have them in the same unit, so that the different sizes of
+	// the item are comparable. It returns error:
+	//  - ErrDataInsufficient if any of fields of ShopPriceRankingRequest is zero-value,
+	//  - any error of exchangerates.Converter if the prices fail to be converted.
+	ShopPriceRanking(context.Context, *ShopPriceRankingRequest) (*ShopPriceRankingReply, error)
+	// NetWorth sums the balances of the accounts at the end of the date into the assets
+	// and the liabilities, the credit cards are the liabilities. The balances in foreign
+	// currencies are converted into the home currency with the exchange rates on the date.
+	// It returns error:
+	//  - ErrDataInsufficient if any of fields of NetWorthRequest is zero-value,
+	//  - any error of exchangerates.Converter if the balances fail to be converted.
+	NetWorth(context.Context, *NetWorthRequest) (*NetWorthReply, error)
+}
+
+type CategoryType = repository.CategoryType
+
+type CategoryBreakdownRequest struct {
+	UserID string
+	Type   CategoryType
+	// From is the first date of the period, inclusive.
+	From time.Time
+	// To is the last date of the period, inclusive.
+	To time.Time
+}
+
+type CategoryBreakdownReply struct {
+	Total decimal.Decimal
+	// TotalFee is the part of the total which is the fees and the taxes.
+	TotalFee decimal.Decimal
+	// Categories are sorted by the amount in descending order.
+	Categories []*CategoryShare
+}
+
+type CategoryShare struct {
+	CategoryPublicID string
+	ParentPublicID   *string
+	// Amount includes the amounts of the descendants of the category.
+	Amount decimal.Decimal
+	// Fee is the part of the amount which is the fees and the taxes.
+	Fee decimal.Decimal
+	// Base is the amount without the fees and the taxes.
+	Base decimal.Decimal
+	// Count is the number of the daily items of the category and its descendants.
+	Count int64
+	// Percentage is the share of the amount in the total, rounded to 2 decimal places.
+	Percentage decimal.Decimal
+}
+
+type TagTotalsRequest struct {
+	UserID string
+	Type   CategoryType
+	// From is the first date of the period, inclusive.
+	From time.Time
+	// To is the last date of the period, inclusive.
+	To time.Time
+}
+
+type TagTotalsReply struct {
+	// Tags are sorted by the amount in descending order.
+	Tags []*TagTotal
+}
+
+type TagTotal struct {
+	TagPublicID string
+	Amount      decimal.Decimal
+	// Count is the number of the daily items of the tag.
+	Count int64
+}
+
+type ShopPriceRankingRequest struct {
+	UserID   string
+	ItemName string
+	// Date is the last date of the prices, inclusive.
+	Date       time.Time
+	MaxAgeDays int
+}
+
+type ShopPriceRankingReply struct {
+	// Unit is the unit of the normalized prices which the shops are ranked by, it is
+	// empty if the shops are ranked by the unit prices.
+	Unit repository.Unit
+	// Shops are sorted by the latest price, then the average price in ascending order.
+	Shops []*ShopPrice
+}
+
+type ShopPrice struct {
+	ShopPublicID string
+	LatestPrice  decimal.Decimal
+	LatestDate   time.Time
+	// AveragePrice is rounded to 6 decimal places.
+	AveragePrice decimal.Decimal
+	// Count is the number of the prices of the shop.
+	Count int64
+}
+
+type NetWorthRequest struct {
+	UserID string
+	// Date is the date of the balances and the exchange rates.
+	Date time.Time
+}
+
+type NetWorthReply struct {
+	Assets decimal.Decimal
+	// Liabilities is the amount owed, which is the negated sum of the balances of the
+	// liability accounts.
+	Liabilities decimal.Decimal
+	// NetWorth is the assets minus the liabilities.
+	NetWorth decimal.Decimal
+	// AssetAccounts and LiabilityAccounts are sorted by the balance in descending order.
+	AssetAccounts     []*AccountBalance
+	LiabilityAccounts []*AccountBalance
+}
+
+type AccountBalance struct {
+	AccountPublicID string
+	Type            repository.AccountType
+	// Balance is in the home currency.
+	Balance decimal.Decimal
+}
@@ -0,0 +1,404 @@
+package reports
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	dailyItemRepository repository.DailyItemRepository
+	categoryRepository  repository.CategoryRepository
+	accountRepository   repository.AccountRepository
+	converter           exchangerates.Converter
+}
+
+func NewService(
+	dailyItemRepository repository.DailyItemRepository,
+	categoryRepository repository.CategoryRepository,
+	accountRepository repository.AccountRepository,
+	converter exchangerates.Converter,
+) (Service, error) {
+	return &service{
+		dailyItemRepository: dailyItemRepository,
+		categoryRepository:  categoryRepository,
+		accountRepository:   accountRepository,
+		converter:           converter,
+	}, nil
+}
+
+func (s *service) CategoryBreakdown(ctx context.Context, r *CategoryBreakdownRequest) (*CategoryBreakdownReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Type == repository.CategoryTypeNone {
+		return nil, fmt.Errorf("%w: missing type", ErrDataInsufficient)
+	}
+	if r.From.IsZero() {
+		return nil, fmt.Errorf("%w: missing from", ErrDataInsufficient)
+	}
+	if r.To.IsZero() {
+		return nil, fmt.Errorf("%w: missing to", ErrDataInsufficient)
+	}
+	from, to := toDate(r.From), toDate(r.To)
+	if to.Before(from) {
+		return nil, ErrInvalidPeriod
+	}
+
+	reply, err := s.dailyItemRepository.SumByCategory(ctx, &repository.SumDailyItemsByCategoryRequest{
+		UserID: r.UserID,
+		Type:   r.Type,
+		From:   from,
+		To:     to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		total      = decimal.Zero
+		totalFee   = decimal.Zero
+		categories []*CategoryShare
+		indexes    = map[string]int{}
+	)
+	for _, category := range reply.Categories {
+		amount, fee := category.Amount, category.Fee
+		if category.Currency != "" {
+			converted, err := s.converter.Convert(ctx, &exchangerates.ConvertRequest{
+				UserID:       r.UserID,
+				Amount:       amount,
+				FromCurrency: category.Currency,
+				Date:         to,
+			})
+			if err != nil {
+				return nil, err
+			}
+			amount = converted.Amount
+			fee = fee.Mul(converted.Rate).Round(6)
+		}
+		total = total.Add(amount)
+		totalFee = totalFee.Add(fee)
+
+		i, ok := indexes[category.CategoryPublicID]
+		if !ok {
+			i = len(categories)
+			indexes[category.CategoryPublicID] = i
+			categories = append(categories, &CategoryShare{
+				CategoryPublicID: category.CategoryPublicID,
+			})
+		}
+		categories[i].Amount = categories[i].Amount.Add(amount)
+		categories[i].Fee = categories[i].Fee.Add(fee)
+		categories[i].Count += category.Count
+	}
+
+	categories, err = s.rollUp(ctx, r.UserID, r.Type, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, category := range categories {
+		category.Base = category.Amount.Sub(category.Fee)
+		if total.IsZero() {
+			category.Percentage = decimal.Zero
+			continue
+		}
+		category.Percentage = category.Amount.Div(total).Mul(decimal.NewFromInt(100)).Round(2)
+	}
+	slices.SortStableFunc(categories, func(a, b *CategoryShare) int {
+		return b.Amount.Cmp(a.Amount)
+	})
+
+	return &CategoryBreakdownReply{
+		Total:      total,
+		TotalFee:   totalFee,
+		Categories: categories,
+	}, nil
+}
+
+// rollUp adds the amounts and the numbers of the categories into all of their ancestors,
+// the ancestors without any daily item are appended.
+func (s *service) rollUp(ctx context.Context, userID string, type_ CategoryType, shares []*CategoryShare) ([]*CategoryShare, error) {
+	reply, err := s.categoryRepository.List(ctx, &repository.ListCategoriesRequest{
+		UserID: userID,
+		Type:   type_,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return shares, nil
+		}
+		return nil, err
+	}
+	parents := lo.SliceToMap(reply.Categories, func(item *repository.Category) (string, *string) {
+		return item.PublicID, item.ParentPublicID
+	})
+
+	indexes := map[string]int{}
+	for i, share := range shares {
+		indexes[share.CategoryPublicID] = i
+		share.ParentPublicID = parents[share.CategoryPublicID]
+	}
+	// only the own amounts of the categories are added.
+	owns := lo.Map(shares, func(item *CategoryShare, _ int) CategoryShare {
+		return *item
+	})
+	for _, own := range owns {
+		for parent := own.ParentPublicID; parent != nil; parent = parents[*parent] {
+			i, ok := indexes[*parent]
+			if !ok {
+				i = len(shares)
+				indexes[*parent] = i
+				shares = append(shares, &CategoryShare{
+					CategoryPublicID: *parent,
+					ParentPublicID:   parents[*parent],
+				})
+			}
+			shares[i].Amount = shares[i].Amount.Add(own.Amount)
+			shares[i].Fee = shares[i].Fee.Add(own.Fee)
+			shares[i].Count += own.Count
+		}
+	}
+	return shares, nil
+}
+
+func (s *service) TagTotals(ctx context.Context, r *TagTotalsRequest) (*TagTotalsReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Type == repository.CategoryTypeNone {
+		return nil, fmt.Errorf("%w: missing type", ErrDataInsufficient)
+	}
+	if r.From.IsZero() {
+		return nil, fmt.Errorf("%w: missing from", ErrDataInsufficient)
+	}
+	if r.To.IsZero() {
+		return nil, fmt.Errorf("%w: missing to", ErrDataInsufficient)
+	}
+	from, to := toDate(r.From), toDate(r.To)
+	if to.Before(from) {
+		return nil, ErrInvalidPeriod
+	}
+
+	reply, err := s.dailyItemRepository.SumByTag(ctx, &repository.SumDailyItemsByTagRequest{
+		UserID: r.UserID,
+		Type:   r.Type,
+		From:   from,
+		To:     to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tags    = []*TagTotal{}
+		indexes = map[string]int{}
+	)
+	for _, tag := range reply.Tags {
+		amount := tag.Amount
+		if tag.Currency != "" {
+			converted, err := s.converter.Convert(ctx, &exchangerates.ConvertRequest{
+				UserID:       r.UserID,
+				Amount:       amount,
+				FromCurrency: tag.Currency,
+				Date:         to,
+			})
+			if err != nil {
+				return nil, err
+			}
+			amount = converted.Amount
+		}
+
+		i, ok := indexes[tag.TagPublicID]
+		if !ok {
+			i = len(tags)
+			indexes[tag.TagPublicID] = i
+			tags = append(tags, &TagTotal{
+				TagPublicID: tag.TagPublicID,
+			})
+		}
+		tags[i].Amount = tags[i].Amount.Add(amount)
+		tags[i].Count += tag.Count
+	}
+	slices.SortStableFunc(tags, func(a, b *TagTotal) int {
+		return b.Amount.Cmp(a.Amount)
+	})
+
+	return &TagTotalsReply{
+		Tags: tags,
+	}, nil
+}
+
+func (s *service) ShopPriceRanking(ctx context.Context, r *ShopPriceRankingRequest) (*ShopPriceRankingReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.ItemName == "" {
+		return nil, fmt.Errorf("%w: missing item name", ErrDataInsufficient)
+	}
+	if r.Date.IsZero() {
+		return nil, fmt.Errorf("%w: missing date", ErrDataInsufficient)
+	}
+	if r.MaxAgeDays <= 0 {
+		return nil, fmt.Errorf("%w: missing max age", ErrDataInsufficient)
+	}
+	date := toDate(r.Date)
+
+	reply, err := s.dailyItemRepository.ListShopPrices(ctx, &repository.ListItemShopPricesRequest{
+		UserID:   r.UserID,
+		ItemName: r.ItemName,
+		From:     date.AddDate(0, 0, -r.MaxAgeDays),
+		To:       date.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ShopPriceRankingReply{
+				Shops: []*ShopPrice{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	unit := normalizedUnit(reply.Prices)
+
+	var (
+		shops   []*ShopPrice
+		sums    []decimal.Decimal
+		indexes = map[string]int{}
+	)
+	// the prices are sorted by date, so the last one of each shop is the latest.
+	for _, price := range reply.Prices {
+		unitPrice := price.UnitPrice
+		if unit != repository.UnitNone {
+			unitPrice = price.NormalizedPrice.Price
+		}
+		if price.Currency != "" {
+			converted, err := s.converter.Convert(ctx, &exchangerates.ConvertRequest{
+				UserID:       r.UserID,
+				Amount:       unitPrice,
+				FromCurrency: price.Currency,
+				Date:         price.Date,
+			})
+			if err != nil {
+				return nil, err
+			}
+			unitPrice = converted.Amount
+		}
+
+		i, ok := indexes[price.ShopPublicID]
+		if !ok {
+			i = len(shops)
+			indexes[price.ShopPublicID] = i
+			shops = append(shops, &ShopPrice{
+				ShopPublicID: price.ShopPublicID,
+			})
+			sums = append(sums, decimal.Zero)
+		}
+		shops[i].LatestPrice = unitPrice
+		shops[i].LatestDate = price.Date
+		shops[i].Count++
+		sums[i] = sums[i].Add(unitPrice)
+	}
+
+	for i, shop := range shops {
+		shop.AveragePrice = sums[i].Div(decimal.NewFromInt(shop.Count)).Round(6)
+	}
+	slices.SortStableFunc(shops, func(a, b *ShopPrice) int {
+		return cmp.Or(a.LatestPrice.Cmp(b.LatestPrice), a.AveragePrice.Cmp(b.AveragePrice))
+	})
+
+	return &ShopPriceRankingReply{
+		Unit:  unit,
+		Shops: shops,
+	}, nil
+}
+
+// normalizedUnit returns the unit of the normalized prices if all the prices have them
+// in the same unit, or it returns UnitNone.
+func normalizedUnit(prices []*repository.ItemShopPrice) repository.Unit {
+	if len(prices) == 0 || prices[0].NormalizedPrice == nil {
+		return repository.UnitNone
+	}
+	unit := prices[0].NormalizedPrice.Unit
+	for _, price := range prices[1:] {
+		if price.NormalizedPrice == nil || price.NormalizedPrice.Unit != unit {
+			return repository.UnitNone
+		}
+	}
+	return unit
+}
+
+func (s *service) NetWorth(ctx context.Context, r *NetWorthRequest) (*NetWorthReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Date.IsZero() {
+		return nil, fmt.Errorf("%w: missing date", ErrDataInsufficient)
+	}
+
+	reply := &NetWorthReply{
+		AssetAccounts:     []*AccountBalance{},
+		LiabilityAccounts: []*AccountBalance{},
+	}
+	date := toDate(r.Date)
+	accounts, err := s.accountRepository.List(ctx, &repository.ListAccountsRequest{
+		UserID:      r.UserID,
+		BalanceDate: &date,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return reply, nil
+		}
+		return nil, err
+	}
+
+	for _, account := range accounts.Accounts {
+		balance := account.Balance
+		if account.Currency != "" {
+			converted, err := s.converter.Convert(ctx, &exchangerates.ConvertRequest{
+				UserID:       r.UserID,
+				Amount:       balance,
+				FromCurrency: account.Currency,
+				Date:         date,
+			})
+			if err != nil {
+				return nil, err
+			}
+			balance = converted.Amount
+		}
+
+		item := &AccountBalance{
+			AccountPublicID: account.PublicID,
+			Type:            account.Type,
+			Balance:         balance,
+		}
+		if account.Type.IsLiability() {
+			reply.Liabilities = reply.Liabilities.Sub(balance)
+			reply.LiabilityAccounts = append(reply.LiabilityAccounts, item)
+		} else {
+			reply.Assets = reply.Assets.Add(balance)
+			reply.AssetAccounts = append(reply.AssetAccounts, item)
+		}
+	}
+	reply.NetWorth = reply.Assets.Sub(reply.Liabilities)
+
+	byBalance := func(a, b *AccountBalance) int {
+		return b.Balance.Cmp(a.Balance)
+	}
+	slices.SortStableFunc(reply.AssetAccounts, byBalance)
+	slices.SortStableFunc(reply.LiabilityAccounts, byBalance)
+	return reply, nil
+}
+
+// toDate truncates the time to the date in UTC.
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
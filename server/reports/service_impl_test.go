@@ -0,0 +1,568 @@
+package reports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/exchangerates"
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_CategoryBreakdown(t *testing.T) {
+	t.Run("breakdown successful", func(t *testing.T) {
+		const (
+			userID      = "user-id"
+			foodID      = "foodID"
+			transportID = "transportID"
+		)
+		var (
+			from = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			to   = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockCategoryRepo := repository.NewMockCategoryRepository(controller)
+		mockConverter := exchangerates.NewMockConverter(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().
+				SumByCategory(gomock.Any(), &repository.SumDailyItemsByCategoryRequest{
+					UserID: userID,
+					Type:   repository.CategoryTypeExpense,
+					From:   from,
+					To:     time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				}).
+				Return(&repository.SumDailyItemsByCategoryReply{
+					Categories: []*repository.CategorySum{
+						{CategoryPublicID: foodID, Amount: decimal.NewFromInt(100), Fee: decimal.NewFromInt(10), Count: 3},
+						{CategoryPublicID: transportID, Amount: decimal.NewFromInt(50), Count: 1},
+						{CategoryPublicID: transportID, Currency: "USD", Amount: decimal.NewFromInt(5), Fee: decimal.NewFromInt(1), Count: 1},
+					},
+				}, nil),
+			mockConverter.EXPECT().
+				Convert(gomock.Any(), &exchangerates.ConvertRequest{
+					UserID:       userID,
+					Amount:       decimal.NewFromInt(5),
+					FromCurrency: "USD",
+					Date:         to,
+				}).
+				Return(&exchangerates.ConvertReply{
+					Amount: decimal.NewFromInt(250),
+					Rate:   decimal.NewFromInt(50),
+				}, nil),
+			mockCategoryRepo.EXPECT().
+				List(gomock.Any(), &repository.ListCategoriesRequest{
+					UserID: userID,
+					Type:   repository.CategoryTypeExpense,
+				}).
+				Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockDailyItemRepo, mockCategoryRepo, repository.NewMockAccountRepository(controller), mockConverter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.CategoryBreakdown(context.Background(), &CategoryBreakdownRequest{
+			UserID: userID,
+			Type:   repository.CategoryTypeExpense,
+			From:   from,
+			To:     to,
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(400).Equal(reply.Total))
+		assert.True(decimal.NewFromInt(60).Equal(reply.TotalFee))
+		assert.Len(reply.Categories, 2)
+		assert.Equal(transportID, reply.Categories[0].CategoryPublicID)
+		assert.True(decimal.NewFromInt(300).Equal(reply.Categories[0].Amount))
+		assert.True(decimal.NewFromInt(50).Equal(reply.Categories[0].Fee))
+		assert.True(decimal.NewFromInt(250).Equal(reply.Categories[0].Base))
+		assert.EqualValues(2, reply.Categories[0].Count)
+		assert.True(decimal.NewFromInt(75).Equal(reply.Categories[0].Percentage))
+		assert.Equal(foodID, reply.Categories[1].CategoryPublicID)
+		assert.True(decimal.NewFromInt(100).Equal(reply.Categories[1].Amount))
+		assert.True(decimal.NewFromInt(10).Equal(reply.Categories[1].Fee))
+		assert.True(decimal.NewFromInt(90).Equal(reply.Categories[1].Base))
+		assert.EqualValues(3, reply.Categories[1].Count)
+		assert.True(decimal.NewFromInt(25).Equal(reply.Categories[1].Percentage))
+	})
+	t.Run("no daily item", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockCategoryRepo := repository.NewMockCategoryRepository(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().SumByCategory(gomock.Any(), gomock.Any()).Return(&repository.SumDailyItemsByCategoryReply{
+				Categories: []*repository.CategorySum{},
+			}, nil),
+			mockCategoryRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockDailyItemRepo, mockCategoryRepo, repository.NewMockAccountRepository(controller), exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.CategoryBreakdown(context.Background(), &CategoryBreakdownRequest{
+			UserID: "user-id",
+			Type:   repository.CategoryTypeIncome,
+			From:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.True(decimal.Zero.Equal(reply.Total))
+		assert.Empty(reply.Categories)
+	})
+	t.Run("roll up sub-categories", func(t *testing.T) {
+		const (
+			userID     = "user-id"
+			foodID     = "foodID"
+			mealID     = "mealID"
+			lunchID    = "lunchID"
+			transferID = "transferID"
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockCategoryRepo := repository.NewMockCategoryRepository(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().SumByCategory(gomock.Any(), gomock.Any()).Return(&repository.SumDailyItemsByCategoryReply{
+				Categories: []*repository.CategorySum{
+					{CategoryPublicID: lunchID, Amount: decimal.NewFromInt(60), Count: 2},
+					{CategoryPublicID: mealID, Amount: decimal.NewFromInt(20), Count: 1},
+					{CategoryPublicID: transferID, Amount: decimal.NewFromInt(20), Count: 1},
+				},
+			}, nil),
+			mockCategoryRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListCategoriesReply{
+				Categories: []*repository.Category{
+					{PublicID: foodID, BaseCategory: &repository.BaseCategory{}},
+					{PublicID: mealID, BaseCategory: &repository.BaseCategory{ParentPublicID: lo.ToPtr(foodID)}},
+					{PublicID: lunchID, BaseCategory: &repository.BaseCategory{ParentPublicID: lo.ToPtr(mealID)}},
+					{PublicID: transferID, BaseCategory: &repository.BaseCategory{}},
+				},
+			}, nil),
+		)
+
+		s, err := NewService(mockDailyItemRepo, mockCategoryRepo, repository.NewMockAccountRepository(controller), exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.CategoryBreakdown(context.Background(), &CategoryBreakdownRequest{
+			UserID: userID,
+			Type:   repository.CategoryTypeExpense,
+			From:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(100).Equal(reply.Total))
+		assert.Len(reply.Categories, 4)
+
+		shares := lo.SliceToMap(reply.Categories, func(item *CategoryShare) (string, *CategoryShare) {
+			return item.CategoryPublicID, item
+		})
+		assert.Nil(shares[foodID].ParentPublicID)
+		assert.True(decimal.NewFromInt(80).Equal(shares[foodID].Amount))
+		assert.EqualValues(3, shares[foodID].Count)
+		assert.True(decimal.NewFromInt(80).Equal(shares[foodID].Percentage))
+		assert.Equal(lo.ToPtr(foodID), shares[mealID].ParentPublicID)
+		assert.True(decimal.NewFromInt(80).Equal(shares[mealID].Amount))
+		assert.EqualValues(3, shares[mealID].Count)
+		assert.Equal(lo.ToPtr(mealID), shares[lunchID].ParentPublicID)
+		assert.True(decimal.NewFromInt(60).Equal(shares[lunchID].Amount))
+		assert.EqualValues(2, shares[lunchID].Count)
+		assert.True(decimal.NewFromInt(20).Equal(shares[transferID].Amount))
+	})
+	t.Run("invalid period", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+
+		s, err := NewService(
+			repository.NewMockDailyItemRepository(controller),
+			repository.NewMockCategoryRepository(controller),
+			repository.NewMockAccountRepository(controller),
+			exchangerates.NewMockConverter(controller),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.CategoryBreakdown(context.Background(), &CategoryBreakdownRequest{
+			UserID: "user-id",
+			Type:   repository.CategoryTypeExpense,
+			From:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.ErrorIs(err, ErrInvalidPeriod)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_TagTotals(t *testing.T) {
+	t.Run("sum successful", func(t *testing.T) {
+		const (
+			userID    = "user-id"
+			japanID   = "japanID"
+			weddingID = "weddingID"
+		)
+		var (
+			from = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			to   = time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockConverter := exchangerates.NewMockConverter(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().
+				SumByTag(gomock.Any(), &repository.SumDailyItemsByTagRequest{
+					UserID: userID,
+					Type:   repository.CategoryTypeExpense,
+					From:   from,
+					To:     time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				}).
+				Return(&repository.SumDailyItemsByTagReply{
+					Tags: []*repository.TagSum{
+						{TagPublicID: japanID, Amount: decimal.NewFromInt(100), Count: 2},
+						{TagPublicID: japanID, Currency: "JPY", Amount: decimal.NewFromInt(1000), Count: 1},
+						{TagPublicID: weddingID, Amount: decimal.NewFromInt(200), Count: 1},
+					},
+				}, nil),
+			mockConverter.EXPECT().
+				Convert(gomock.Any(), &exchangerates.ConvertRequest{
+					UserID:       userID,
+					Amount:       decimal.NewFromInt(1000),
+					FromCurrency: "JPY",
+					Date:         to,
+				}).
+				Return(&exchangerates.ConvertReply{
+					Amount: decimal.NewFromInt(210),
+					Rate:   decimal.RequireFromString("0.21"),
+				}, nil),
+		)
+
+		s, err := NewService(mockDailyItemRepo, repository.NewMockCategoryRepository(controller), repository.NewMockAccountRepository(controller), mockConverter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.TagTotals(context.Background(), &TagTotalsRequest{
+			UserID: userID,
+			Type:   repository.CategoryTypeExpense,
+			From:   from,
+			To:     to,
+		})
+		assert.NoError(err)
+		assert.Len(reply.Tags, 2)
+		assert.Equal(japanID, reply.Tags[0].TagPublicID)
+		assert.True(decimal.NewFromInt(310).Equal(reply.Tags[0].Amount))
+		assert.EqualValues(3, reply.Tags[0].Count)
+		assert.Equal(weddingID, reply.Tags[1].TagPublicID)
+		assert.True(decimal.NewFromInt(200).Equal(reply.Tags[1].Amount))
+		assert.EqualValues(1, reply.Tags[1].Count)
+	})
+	t.Run("no tagged daily item", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().SumByTag(gomock.Any(), gomock.Any()).Return(&repository.SumDailyItemsByTagReply{
+				Tags: []*repository.TagSum{},
+			}, nil),
+		)
+
+		s, err := NewService(mockDailyItemRepo, repository.NewMockCategoryRepository(controller), repository.NewMockAccountRepository(controller), exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.TagTotals(context.Background(), &TagTotalsRequest{
+			UserID: "user-id",
+			Type:   repository.CategoryTypeExpense,
+			From:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.Equal(&TagTotalsReply{
+			Tags: []*TagTotal{},
+		}, reply)
+	})
+	t.Run("invalid period", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		s, err := NewService(repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), repository.NewMockAccountRepository(controller), exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.TagTotals(context.Background(), &TagTotalsRequest{
+			UserID: "user-id",
+			Type:   repository.CategoryTypeExpense,
+			From:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.ErrorIs(err, ErrInvalidPeriod)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_ShopPriceRanking(t *testing.T) {
+	const (
+		userID   = "user-id"
+		itemName = "milk"
+	)
+	var (
+		jan1  = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		jan2  = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		jan3  = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+		jan31 = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("rank successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		mockConverter := exchangerates.NewMockConverter(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().
+				ListShopPrices(gomock.Any(), &repository.ListItemShopPricesRequest{
+					UserID:   userID,
+					ItemName: itemName,
+					From:     jan1,
+					To:       time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				}).
+				Return(&repository.ListItemShopPricesReply{
+					Prices: []*repository.ItemShopPrice{
+						{ShopPublicID: "shopA", Date: jan1, UnitPrice: decimal.NewFromInt(40)},
+						{ShopPublicID: "shopB", Date: jan1, UnitPrice: decimal.NewFromInt(50)},
+						{ShopPublicID: "shopA", Date: jan2, UnitPrice: decimal.NewFromInt(46)},
+						{ShopPublicID: "shopC", Date: jan3, UnitPrice: decimal.NewFromInt(1), Currency: "USD"},
+					},
+				}, nil),
+			mockConverter.EXPECT().
+				Convert(gomock.Any(), &exchangerates.ConvertRequest{
+					UserID:       userID,
+					Amount:       decimal.NewFromInt(1),
+					FromCurrency: "USD",
+					Date:         jan3,
+				}).
+				Return(&exchangerates.ConvertReply{
+					Amount: decimal.NewFromInt(30),
+					Rate:   decimal.NewFromInt(30),
+				}, nil),
+		)
+
+		s, err := NewService(mockDailyItemRepo, repository.NewMockCategoryRepository(controller), repository.NewMockAccountRepository(controller), mockConverter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.ShopPriceRanking(context.Background(), &ShopPriceRankingRequest{
+			UserID:     userID,
+			ItemName:   itemName,
+			Date:       jan31,
+			MaxAgeDays: 30,
+		})
+		assert.NoError(err)
+		assert.Len(reply.Shops, 3)
+		assert.Equal("shopC", reply.Shops[0].ShopPublicID)
+		assert.True(decimal.NewFromInt(30).Equal(reply.Shops[0].LatestPrice))
+		assert.Equal("shopA", reply.Shops[1].ShopPublicID)
+		assert.True(decimal.NewFromInt(46).Equal(reply.Shops[1].LatestPrice))
+		assert.Equal(jan2, reply.Shops[1].LatestDate)
+		assert.True(decimal.NewFromInt(43).Equal(reply.Shops[1].AveragePrice))
+		assert.EqualValues(2, reply.Shops[1].Count)
+		assert.Equal("shopB", reply.Shops[2].ShopPublicID)
+		assert.True(decimal.NewFromInt(50).Equal(reply.Shops[2].LatestPrice))
+	})
+	t.Run("rank by the normalized prices", func(t *testing.T) {
+		assert := assert.New(t)
+
+		normalizedPrice := func(price int64) *repository.NormalizedPrice {
+			return &repository.NormalizedPrice{
+				Price:    decimal.NewFromInt(price),
+				Quantity: decimal.NewFromInt(100),
+				Unit:     repository.UnitGram,
+			}
+		}
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().ListShopPrices(gomock.Any(), gomock.Any()).Return(&repository.ListItemShopPricesReply{
+				Prices: []*repository.ItemShopPrice{
+					{ShopPublicID: "shopA", Date: jan1, UnitPrice: decimal.NewFromInt(40), NormalizedPrice: normalizedPrice(8)},
+					{ShopPublicID: "shopB", Date: jan1, UnitPrice: decimal.NewFromInt(60), NormalizedPrice: normalizedPrice(6)},
+				},
+			}, nil),
+		)
+
+		s, err := NewService(mockDailyItemRepo, repository.NewMockCategoryRepository(controller), repository.NewMockAccountRepository(controller), exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.ShopPriceRanking(context.Background(), &ShopPriceRankingRequest{
+			UserID:     userID,
+			ItemName:   itemName,
+			Date:       jan31,
+			MaxAgeDays: 30,
+		})
+		assert.NoError(err)
+		assert.Equal(repository.UnitGram, reply.Unit)
+		assert.Len(reply.Shops, 2)
+		assert.Equal("shopB", reply.Shops[0].ShopPublicID)
+		assert.True(decimal.NewFromInt(6).Equal(reply.Shops[0].LatestPrice))
+		assert.Equal("shopA", reply.Shops[1].ShopPublicID)
+		assert.True(decimal.NewFromInt(8).Equal(reply.Shops[1].LatestPrice))
+	})
+	t.Run("no recorded price", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockDailyItemRepo.EXPECT().ListShopPrices(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockDailyItemRepo, repository.NewMockCategoryRepository(controller), repository.NewMockAccountRepository(controller), exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.ShopPriceRanking(context.Background(), &ShopPriceRankingRequest{
+			UserID:     userID,
+			ItemName:   itemName,
+			Date:       jan31,
+			MaxAgeDays: 30,
+		})
+		assert.NoError(err)
+		assert.Equal(&ShopPriceRankingReply{
+			Shops: []*ShopPrice{},
+		}, reply)
+	})
+	t.Run("missing max age", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		s, err := NewService(repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), repository.NewMockAccountRepository(controller), exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.ShopPriceRanking(context.Background(), &ShopPriceRankingRequest{
+			UserID:   userID,
+			ItemName: itemName,
+			Date:     jan31,
+		})
+		assert.ErrorIs(err, ErrDataInsufficient)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_NetWorth(t *testing.T) {
+	const userID = "user-id"
+	date := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("net worth successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockConverter := exchangerates.NewMockConverter(controller)
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().
+				List(gomock.Any(), &repository.ListAccountsRequest{UserID: userID, BalanceDate: &date}).
+				Return(&repository.ListAccountsReply{
+					Accounts: []*repository.Account{
+						{
+							PublicID:    "cash",
+							BaseAccount: &repository.BaseAccount{Type: repository.AccountTypeCash},
+							Balance:     decimal.NewFromInt(100),
+						},
+						{
+							PublicID:    "card",
+							BaseAccount: &repository.BaseAccount{Type: repository.AccountTypeCreditCard},
+							Balance:     decimal.NewFromInt(-30),
+						},
+						{
+							PublicID:    "bank",
+							BaseAccount: &repository.BaseAccount{Type: repository.AccountTypeBank, Currency: "USD"},
+							Balance:     decimal.NewFromInt(10),
+						},
+					},
+				}, nil),
+			mockConverter.EXPECT().
+				Convert(gomock.Any(), &exchangerates.ConvertRequest{
+					UserID:       userID,
+					Amount:       decimal.NewFromInt(10),
+					FromCurrency: "USD",
+					Date:         date,
+				}).
+				Return(&exchangerates.ConvertReply{
+					Amount: decimal.NewFromInt(300),
+					Rate:   decimal.NewFromInt(30),
+				}, nil),
+		)
+
+		s, err := NewService(repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), mockAccountRepo, mockConverter)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.NetWorth(context.Background(), &NetWorthRequest{
+			UserID: userID,
+			Date:   date,
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(400).Equal(reply.Assets))
+		assert.True(decimal.NewFromInt(30).Equal(reply.Liabilities))
+		assert.True(decimal.NewFromInt(370).Equal(reply.NetWorth))
+		assert.Len(reply.AssetAccounts, 2)
+		assert.Equal("bank", reply.AssetAccounts[0].AccountPublicID)
+		assert.True(decimal.NewFromInt(300).Equal(reply.AssetAccounts[0].Balance))
+		assert.Equal("cash", reply.AssetAccounts[1].AccountPublicID)
+		assert.Len(reply.LiabilityAccounts, 1)
+		assert.Equal("card", reply.LiabilityAccounts[0].AccountPublicID)
+		assert.Equal(repository.AccountTypeCreditCard, reply.LiabilityAccounts[0].Type)
+	})
+	t.Run("no account", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound)
+
+		s, err := NewService(repository.NewMockDailyItemRepository(controller), repository.NewMockCategoryRepository(controller), mockAccountRepo, exchangerates.NewMockConverter(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.NetWorth(context.Background(), &NetWorthRequest{
+			UserID: userID,
+			Date:   date,
+		})
+		assert.NoError(err)
+		assert.Equal(&NetWorthReply{
+			AssetAccounts:     []*AccountBalance{},
+			LiabilityAccounts: []*AccountBalance{},
+		}, reply)
+	})
+}
@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/shopspring/decimal"
 )
 
@@ -16,9 +20,21 @@ type AccountRepository interface {
 	List(context.Context, *ListAccountsRequest) (*ListAccountsReply, error)
 	// Update updates non-zero value fields on specific account of the user, it returns error:
 	//  - ErrDataNotFound if the account does not exist.
+	//  - ErrDataReferenced if the currency is changed while the account has daily items,
+	//    transfers or statement payments, or the type of the credit card is changed while it
+	//    has statement payments.
 	Update(context.Context, *UpdateAccountRequest) (*Account, error)
-	// Delete returns error:
-	//  - ErrDataNotFound if the account does not exist.
+	// Delete deletes the accounts, the daily items, the repeating items, the transfers and
+	// the statement payments of the accounts are handled by the policy. It returns error:
+	//  - ErrDataNotFound if the account does not exist,
+	//  - ErrDataReferenced as *ReferencedError if the accounts are referenced and the
+	//    policy is DeletePolicyRestrict,
+	//  - ErrReferenceNotFound if the replacement account does not exist,
+	//  - ErrInvalidReference if the replacement account is any of the deleted accounts,
+	//    or any of the transfers is between the replacement and the deleted accounts, or
+	//    the statement payments are moved to an account which is not a credit card or has
+	//    paid the statements of the same closing dates,
+	//  - ErrCurrencyMismatch if the currency of the replacement account is different.
 	Delete(context.Context, *DeleteAccountsRequest) ([]*Account, error)
 }
 
@@ -43,11 +59,30 @@ type BaseAccount struct {
 	Name           string
 	IconID         int32
 	InitialBalance decimal.Decimal
+	// Currency is the ISO-4217 code of the balance, it is empty for the home currency
+	// of the user.
+	Currency string
+
+	Type AccountType
+	// BankName and LastDigits are only for bank accounts and credit cards.
+	BankName   string
+	LastDigits string
+	// CreditLimit, StatementClosingDay and PaymentDueDay are only for credit cards. The
+	// days are the days of month, they are zero if they are not provided.
+	CreditLimit         *decimal.Decimal
+	StatementClosingDay int
+	PaymentDueDay       int
 }
 
 type ListAccountsRequest struct {
 	UserID          string
 	AccountPublicID *string
+	// Type lists the accounts of the type only if it is provided.
+	Type *AccountType
+	// BalanceDate returns the balances at the end of the date instead of the current
+	// balances if it is provided, which are the initial balances with the daily items and
+	// the transfers until the date.
+	BalanceDate *time.Time
 }
 
 type ListAccountsReply struct {
@@ -65,4 +100,48 @@ type UpdateAccountRequest struct {
 type DeleteAccountsRequest struct {
 	AccountPublicIDs []string
 	UserID           string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the account which the references are moved to, it is only
+	// for DeletePolicyReassign.
+	ReplacementPublicID string
+}
+
+const (
+	AccountTypeCash AccountType = iota
+	AccountTypeBank
+	AccountTypeCreditCard
+	AccountTypeEWallet
+	AccountTypeStoredValueCard
+)
+
+type AccountType uint8
+
+func (t AccountType) String() string {
+	if s, ok := accountTypeDescriptions[t]; ok {
+		return s
+	}
+	return strconv.Itoa(int(t))
+}
+
+// IsLiability reports whether the balance of the account is owed, such as credit cards.
+func (t AccountType) IsLiability() bool {
+	return t == AccountTypeCreditCard
+}
+
+var accountTypeDescriptions = map[AccountType]string{
+	AccountTypeCash:            "cash",
+	AccountTypeBank:            "bank",
+	AccountTypeCreditCard:      "creditCard",
+	AccountTypeEWallet:         "eWallet",
+	AccountTypeStoredValueCard: "storedValueCard",
+}
+
+var descriptionsToAccountType = lo.Invert(accountTypeDescriptions)
+
+func ToAccountType(s string) (AccountType, error) {
+	if t, ok := descriptionsToAccountType[s]; ok {
+		return t, nil
+	}
+	return 0, fmt.Errorf("unknown type of account[%s]", s)
 }
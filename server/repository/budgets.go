@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type BudgetRepository interface {
+	// Create creates a budget of specific user and return error:
+	//  - ErrDataExists if the data exists or the category has had a budget
+	//  - ErrReferenceNotFound if the referenced category does not exist
+	//  - ErrInvalidReference if the referenced category is not an expense category
+	// or returns Budget model with id.
+	Create(context.Context, *CreateBudgetRequest) (*Budget, error)
+	// List returns budgets, it returns error:
+	//  - ErrDataNotFound if there is no budget satisfied filter conditions.
+	List(context.Context, *ListBudgetsRequest) (*ListBudgetsReply, error)
+	// Update updates specific budget of the user, it returns error:
+	//  - ErrDataNotFound if the budget does not exist.
+	//  - ErrDataExists if the category has had another budget.
+	//  - ErrReferenceNotFound if the referenced category does not exist.
+	//  - ErrInvalidReference if the referenced category is not an expense category.
+	Update(context.Context, *UpdateBudgetRequest) (*Budget, error)
+	// Delete returns error:
+	//  - ErrDataNotFound if the budget does not exist.
+	Delete(context.Context, *DeleteBudgetsRequest) ([]*Budget, error)
+}
+
+type CreateBudgetRequest struct {
+	UserID   string
+	PublicID string
+	Budget   *BaseBudget
+}
+
+type Budget struct {
+	ID       int32
+	PublicID string
+	*BaseBudget
+}
+
+type BaseBudget struct {
+	CategoryPublicID string
+	// Limit is the monthly limit of the expense of the category.
+	Limit decimal.Decimal
+	// Rollover carries the unused amount of the previous months over to the next month.
+	Rollover bool
+	// StartMonth is the first day of the month which the budget starts from.
+	StartMonth time.Time
+}
+
+type ListBudgetsRequest struct {
+	UserID         string
+	BudgetPublicID *string
+}
+
+type ListBudgetsReply struct {
+	Budgets []*Budget
+}
+
+type UpdateBudgetRequest struct {
+	UserID         string
+	BudgetPublicID string
+
+	Budget *BaseBudget
+}
+
+type DeleteBudgetsRequest struct {
+	UserID          string
+	BudgetPublicIDs []string
+}
@@ -11,16 +11,30 @@ import (
 type CategoryRepository interface {
 	// Create creates categories of specific user and return error:
 	//  - ErrDataExists if the data exists
-	// or returns Category model with id.
+	//  - ErrReferenceNotFound if the parent category does not exist
+	//  - ErrInvalidReference if the parent category is not the same type
+	// or returns Category model with id. The parent category may be created in the same
+	// request before its children.
 	Create(context.Context, *CreateCategoriesRequest) ([]*Category, error)
 	// List returns categories, it returns error:
 	//  - ErrDataNotFound if there is no account satisfied filter conditions.
 	List(context.Context, *ListCategoriesRequest) (*ListCategoriesReply, error)
 	// Update updates non-zero value fields on specific account of the user, it returns error:
 	//  - ErrDataNotFound if the account does not exist.
+	//  - ErrReferenceNotFound if the parent category does not exist.
+	//  - ErrInvalidReference if the parent category is not the same type.
+	//  - ErrCircularReference if the parent category is the category or any of its descendants.
 	Update(context.Context, *UpdateCategoryRequest) (*Category, error)
-	// Delete returns error:
-	//  - ErrDataNotFound if the account does not exist.
+	// Delete deletes the categories, the daily items, the repeating items and the budgets
+	// of the categories are handled by the policy. The budgets are merged into the budget
+	// of the replacement category if it has one. It returns error:
+	//  - ErrDataNotFound if the account does not exist,
+	//  - ErrDataReferenced as *ReferencedError if the categories are referenced and the
+	//    policy is DeletePolicyRestrict,
+	//  - ErrReferenceNotFound if the replacement category does not exist,
+	//  - ErrInvalidReference if the replacement category is any of the deleted categories
+	//    or it is not the same type.
+	// The children of the deleted categories become the top-level categories.
 	Delete(context.Context, *DeleteCategoriesRequest) ([]*Category, error)
 }
 
@@ -54,6 +68,12 @@ type UpdateCategoryRequest struct {
 type DeleteCategoriesRequest struct {
 	UserID            string
 	CategoryPublicIDs []string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the category which the references are moved to, it is only
+	// for DeletePolicyReassign. The splits of the item are merged if the item is in both
+	// of the deleted and the replacement categories.
+	ReplacementPublicID string
 }
 
 const (
@@ -94,4 +114,7 @@ type Category struct {
 type BaseCategory struct {
 	Name   string
 	IconID int32
+	// ParentPublicID is the parent category of the same type, it is nil for the top-level
+	// category.
+	ParentPublicID *string
 }
@@ -0,0 +1,414 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+)
+
+type DailyItemRepository interface {
+	// Create creates daily items of specific user and return error:
+	//  - ErrDataExists if the data exists
+	//  - ErrReferenceNotFound if any of referenced categories, tags, shop, account or fee does not exist
+	//  - ErrInvalidReference if the referenced categories are not the same type
+	//  - ErrCurrencyMismatch if the currency is not the same as the currency of the account
+	//  - ErrInvalidSplit if the splits are invalid, see BaseItem.ResolveSplits
+	// or returns DailyItem model with id and the fee computed by the referenced fee.
+	// The balance of the referenced account is adjusted in the same transaction, and the
+	// unit price of the expense item bought at a shop is recorded if the user compares the
+	// items in the same shop or in different shops.
+	Create(context.Context, *CreateDailyItemsRequest) ([]*DailyItem, error)
+	// List returns daily items, it returns error:
+	//  - ErrDataNotFound if there is no daily item satisfied filter conditions.
+	List(context.Context, *ListDailyItemsRequest) (*ListDailyItemsReply, error)
+	// Update updates specific daily item of the user, it returns error:
+	//  - ErrDataNotFound if the daily item does not exist.
+	//  - ErrReferenceNotFound if any of referenced categories, tags, shop, account or fee does not exist.
+	//  - ErrInvalidReference if the referenced categories are not the same type.
+	//  - ErrCurrencyMismatch if the currency is not the same as the currency of the account.
+	//  - ErrInvalidSplit if the splits are invalid, see BaseItem.ResolveSplits.
+	//  - ErrDataManaged if the daily item is the fee item of a transfer.
+	// The fee is recomputed if the item references a fee. The original amount is reverted
+	// from the original account and the new amount is applied to the referenced account in
+	// the same transaction. The recorded unit price is updated as well.
+	Update(context.Context, *UpdateDailyItemRequest) (*DailyItem, error)
+	// Delete returns error:
+	//  - ErrDataNotFound if the daily item does not exist.
+	//  - ErrDataManaged if any of the daily items is the fee item of a transfer.
+	// The amount of the item is reverted from its account and the recorded unit price is
+	// deleted in the same transaction.
+	Delete(context.Context, *DeleteDailyItemsRequest) ([]*DailyItem, error)
+	// SumByCategory returns the total amount, the fee and the number of the daily items of
+	// each category and currency in the period. The amount of the item which has multiple
+	// categories is attributed by its splits, or split equally to each of them if it has no
+	// split. The sums of each month are returned separately if Monthly is true. It returns
+	// empty categories if there is no daily item.
+	SumByCategory(context.Context, *SumDailyItemsByCategoryRequest) (*SumDailyItemsByCategoryReply, error)
+	// ListShopPrices returns the unit prices of the item recorded while the user compares
+	// the items in different shops, the prices are sorted by date. It returns error:
+	//  - ErrDataNotFound if there is no recorded price in the period.
+	ListShopPrices(context.Context, *ListItemShopPricesRequest) (*ListItemShopPricesReply, error)
+	// Search returns at most Limit daily items after the cursor which satisfy all of the
+	// filter conditions, the items are sorted by date and id in descending order. The
+	// category filter includes the sub-categories of the category. It returns error:
+	//  - ErrDataNotFound if there is no daily item satisfied filter conditions.
+	Search(context.Context, *SearchDailyItemsRequest) (*SearchDailyItemsReply, error)
+	// SumByTag returns the total amount and the number of the daily items of each tag and
+	// currency in the period. The full amount of the item which has multiple tags is counted
+	// in each of them. It returns empty tags if there is no tagged daily item.
+	SumByTag(context.Context, *SumDailyItemsByTagRequest) (*SumDailyItemsByTagReply, error)
+	// SumByDate returns the total amount and the number of the daily items of each date,
+	// type and currency in the period. It returns empty days if there is no daily item.
+	SumByDate(context.Context, *SumDailyItemsByDateRequest) (*SumDailyItemsByDateReply, error)
+}
+
+type CreateDailyItemsRequest struct {
+	UserID string
+	Items  []*BaseCreateDailyItem
+}
+
+type BaseCreateDailyItem struct {
+	PublicID string
+	*BaseDailyItem
+}
+
+type DailyItem struct {
+	ID       int32
+	PublicID string
+	// Type is the type of the categories of the item.
+	Type CategoryType
+	// NormalizedPrice is computed with the unit conversions of the user when the item is
+	// created or updated, it is nil if the item has no unit, see BaseItem.NormalizedPrice.
+	NormalizedPrice *NormalizedPrice
+	// TransferPublicID is the transfer which the item charges the fee for, it is nil if the
+	// item is not the fee of a transfer.
+	TransferPublicID *string
+	*BaseDailyItem
+}
+
+type BaseDailyItem struct {
+	Date time.Time
+	// RepeatingItemPublicID is the repeating item which the item is created from.
+	RepeatingItemPublicID *string
+	// TagPublicIDs are the tags of the item, the item may have no tag.
+	TagPublicIDs []string
+	*BaseItem
+}
+
+type BaseItem struct {
+	Name              string
+	CategoryPublicIDs []string
+	// Splits attribute the amount of the item to its categories, each category has one
+	// split. The amount is split equally to the categories if there is no split.
+	Splits          []*ItemSplit
+	ShopPublicID    *string
+	AccountPublicID *string
+	Quantity        *decimal.Decimal
+	// Unit is the unit of Quantity, it is empty if the quantity has no unit.
+	Unit Unit
+	// FeePublicID is the fee which Fee is computed by, Fee is ignored and replaced with
+	// the computed one if it is provided.
+	FeePublicID *string
+	Fee         *decimal.Decimal
+	Price       decimal.Decimal
+	// Currency is the ISO-4217 code of the price and the fee. It is the currency of the
+	// account if the account is provided, or it is empty for the home currency of the user.
+	Currency string
+	Memo     string
+}
+
+// ItemSplit is the part of the amount of the item which is attributed to the category.
+type ItemSplit struct {
+	CategoryPublicID string
+	// Amount is the part of the amount, it is computed by Percentage if Percentage is
+	// provided.
+	Amount *decimal.Decimal
+	// Percentage is the part of the amount in percent, such as 40 for 40%.
+	Percentage *decimal.Decimal
+}
+
+type ListDailyItemsRequest struct {
+	UserID            string
+	DailyItemPublicID *string
+	Date              *time.Time
+	// From is the first date of the period, inclusive.
+	From *time.Time
+	// To is the end date of the period, exclusive.
+	To              *time.Time
+	AccountPublicID *string
+	TagPublicID     *string
+}
+
+type ListDailyItemsReply struct {
+	Items []*DailyItem
+}
+
+type UpdateDailyItemRequest struct {
+	UserID            string
+	DailyItemPublicID string
+
+	Item *BaseDailyItem
+}
+
+type DeleteDailyItemsRequest struct {
+	UserID             string
+	DailyItemPublicIDs []string
+}
+
+// Subtotal returns the amount of the item without the fee, which is price * quantity.
+// The quantity is 1 if it is not provided.
+func (v *BaseItem) Subtotal() decimal.Decimal {
+	if v.Quantity != nil {
+		return v.Price.Mul(*v.Quantity)
+	}
+	return v.Price
+}
+
+// Amount returns the total amount of the item, which is price * quantity + fee.
+// The quantity is 1 if it is not provided.
+func (v *BaseItem) Amount() decimal.Decimal {
+	amount := v.Subtotal()
+	if v.Fee != nil {
+		amount = amount.Add(*v.Fee)
+	}
+	return amount
+}
+
+// ResolveSplits returns the splits whose amounts are computed by their percentages, the
+// rounding difference of the percentages is given to the last split by percentage. It
+// returns ErrInvalidSplit if:
+//   - the categories of the splits are not the categories of the item,
+//   - any split has neither the amount nor the percentage, or either of them is negative,
+//   - the amounts of the splits do not sum to Amount.
+//
+// It returns nil if the item has no split.
+func (v *BaseItem) ResolveSplits() ([]*ItemSplit, error) {
+	if len(v.Splits) == 0 {
+		return nil, nil
+	}
+
+	categories := lo.Uniq(v.CategoryPublicIDs)
+	splitCategories := lo.Uniq(lo.Map(v.Splits, func(item *ItemSplit, _ int) string {
+		return item.CategoryPublicID
+	}))
+	if len(splitCategories) != len(v.Splits) || len(splitCategories) != len(categories) ||
+		len(lo.Intersect(categories, splitCategories)) != len(categories) {
+		return nil, ErrInvalidSplit
+	}
+
+	total := v.Amount()
+	result := make([]*ItemSplit, len(v.Splits))
+	sum, rounded := decimal.Zero, decimal.Zero
+	last := -1
+	for i, split := range v.Splits {
+		var amount decimal.Decimal
+		switch {
+		case split.Percentage != nil:
+			if split.Percentage.IsNegative() {
+				return nil, ErrInvalidSplit
+			}
+			amount = total.Mul(*split.Percentage).Div(decimal.NewFromInt(100))
+			rounded = rounded.Add(amount.Round(6))
+			last = i
+		case split.Amount != nil:
+			if split.Amount.IsNegative() {
+				return nil, ErrInvalidSplit
+			}
+			amount = *split.Amount
+			rounded = rounded.Add(amount)
+		default:
+			return nil, ErrInvalidSplit
+		}
+		sum = sum.Add(amount)
+		result[i] = &ItemSplit{
+			CategoryPublicID: split.CategoryPublicID,
+			Amount:           lo.ToPtr(amount.Round(6)),
+			Percentage:       split.Percentage,
+		}
+	}
+	if !sum.Equal(total) {
+		return nil, ErrInvalidSplit
+	}
+	if last >= 0 {
+		result[last].Amount = lo.ToPtr(result[last].Amount.Add(total.Sub(rounded)))
+	}
+	return result, nil
+}
+
+// ReassignCategories replaces the categories with the replacement category, the splits of
+// the replaced categories are merged into the split of the replacement category. The
+// merged split is by percentage if all of the merged splits are by percentage, or it is
+// by the sum of their amounts.
+func (v *BaseItem) ReassignCategories(publicIDs []string, replacementPublicID string) {
+	replaced := func(publicID string) bool {
+		return publicID == replacementPublicID || slices.Contains(publicIDs, publicID)
+	}
+
+	v.CategoryPublicIDs = lo.Uniq(lo.Map(v.CategoryPublicIDs, func(item string, _ int) string {
+		if replaced(item) {
+			return replacementPublicID
+		}
+		return item
+	}))
+
+	var merged *ItemSplit
+	splits := make([]*ItemSplit, 0, len(v.Splits))
+	for _, split := range v.Splits {
+		if !replaced(split.CategoryPublicID) {
+			splits = append(splits, split)
+			continue
+		}
+		if merged == nil {
+			merged = &ItemSplit{
+				CategoryPublicID: replacementPublicID,
+				Amount:           split.Amount,
+				Percentage:       split.Percentage,
+			}
+			splits = append(splits, merged)
+			continue
+		}
+		if merged.Percentage != nil && split.Percentage != nil {
+			merged.Percentage = lo.ToPtr(merged.Percentage.Add(*split.Percentage))
+			continue
+		}
+		merged.Amount = lo.ToPtr(v.splitAmount(merged).Add(v.splitAmount(split)))
+		merged.Percentage = nil
+	}
+	v.Splits = splits
+}
+
+func (v *BaseItem) splitAmount(split *ItemSplit) decimal.Decimal {
+	if split.Percentage != nil {
+		return v.Amount().Mul(*split.Percentage).Div(decimal.NewFromInt(100)).Round(6)
+	}
+	return lo.FromPtr(split.Amount)
+}
+
+type SumDailyItemsByCategoryRequest struct {
+	UserID string
+	Type   CategoryType
+	// From is the first date of the period, inclusive.
+	From time.Time
+	// To is the end date of the period, exclusive.
+	To time.Time
+	// Monthly sums the daily items of each month in the period separately.
+	Monthly bool
+}
+
+type SumDailyItemsByCategoryReply struct {
+	Categories []*CategorySum
+}
+
+type CategorySum struct {
+	// Month is the first day of the month of the sum, it is zero if the sums are not monthly.
+	Month            time.Time
+	CategoryPublicID string
+	// Currency is the currency of the amount, it is empty for the home currency of the user.
+	Currency string
+	Amount   decimal.Decimal
+	// Fee is the part of the amount which is the fees and the taxes.
+	Fee decimal.Decimal
+	// Count is the number of the daily items of the category.
+	Count int64
+}
+
+type SumDailyItemsByTagRequest struct {
+	UserID string
+	Type   CategoryType
+	// From is the first date of the period, inclusive.
+	From time.Time
+	// To is the end date of the period, exclusive.
+	To time.Time
+}
+
+type SumDailyItemsByTagReply struct {
+	Tags []*TagSum
+}
+
+type TagSum struct {
+	TagPublicID string
+	// Currency is the currency of the amount, it is empty for the home currency of the user.
+	Currency string
+	Amount   decimal.Decimal
+	// Count is the number of the daily items of the tag.
+	Count int64
+}
+
+type ListItemShopPricesRequest struct {
+	UserID   string
+	ItemName string
+	// From is the first date of the period, inclusive.
+	From time.Time
+	// To is the last date of the period, exclusive.
+	To time.Time
+}
+
+type ListItemShopPricesReply struct {
+	Prices []*ItemShopPrice
+}
+
+type ItemShopPrice struct {
+	ShopPublicID string
+	Date         time.Time
+	UnitPrice    decimal.Decimal
+	Currency     string
+	// NormalizedPrice is nil if the item has no unit, see DailyItem.NormalizedPrice.
+	NormalizedPrice *NormalizedPrice
+}
+
+type SearchDailyItemsRequest struct {
+	UserID string
+	// Keyword matches the name or the memo case-insensitively, it matches all items if
+	// it is empty.
+	Keyword string
+	// From is the first date of the period, inclusive.
+	From *time.Time
+	// To is the end date of the period, exclusive.
+	To               *time.Time
+	CategoryPublicID *string
+	ShopPublicID     *string
+	AccountPublicID  *string
+	TagPublicID      *string
+	// MinAmount and MaxAmount are the inclusive range of the amount, see BaseItem.Amount.
+	MinAmount *decimal.Decimal
+	MaxAmount *decimal.Decimal
+	// After is the last item of the previous page, it is nil for the first page.
+	After *DailyItemCursor
+	Limit int
+}
+
+// DailyItemCursor is the position of the daily item in the search results.
+type DailyItemCursor struct {
+	Date time.Time
+	ID   int32
+}
+
+type SearchDailyItemsReply struct {
+	Items []*DailyItem
+}
+
+type SumDailyItemsByDateRequest struct {
+	UserID string
+	// From is the first date of the period, inclusive.
+	From time.Time
+	// To is the end date of the period, exclusive.
+	To time.Time
+}
+
+type SumDailyItemsByDateReply struct {
+	// Days are sorted by date.
+	Days []*DateSum
+}
+
+type DateSum struct {
+	Date time.Time
+	Type CategoryType
+	// Currency is the currency of the amount, it is empty for the home currency of the user.
+	Currency string
+	Amount   decimal.Decimal
+	// Count is the number of the daily items.
+	Count int64
+}
@@ -6,6 +6,13 @@ import (
 
 // Repository errors.
 var (
-	ErrDataExists   = errors.New("the data exists")
-	ErrDataNotFound = errors.New("the data is not found")
+	ErrDataExists        = errors.New("the data exists")
+	ErrDataNotFound      = errors.New("the data is not found")
+	ErrReferenceNotFound = errors.New("the referenced data is not found")
+	ErrInvalidReference  = errors.New("the referenced data is invalid")
+	ErrCurrencyMismatch  = errors.New("the currencies are mismatched")
+	ErrCircularReference = errors.New("the reference is circular")
+	ErrInvalidSplit      = errors.New("the splits are invalid")
+	ErrDataReferenced    = errors.New("the data is referenced")
+	ErrDataManaged       = errors.New("the data is managed by the other data")
 )
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ExchangeRateRepository interface {
+	// Upsert creates the exchange rates of specific user, the rate of the existing
+	// exchange rate of the same currencies and date is replaced and its public id is
+	// kept. All of the exchange rates are upserted in the same transaction.
+	// It returns ExchangeRate models with id.
+	Upsert(context.Context, *UpsertExchangeRatesRequest) ([]*ExchangeRate, error)
+	// List returns exchange rates ordered by date, it returns error:
+	//  - ErrDataNotFound if there is no exchange rate satisfied filter conditions.
+	List(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesReply, error)
+	// GetLatest returns the latest exchange rate of the currencies on or before the date,
+	// it returns error:
+	//  - ErrDataNotFound if there is no such exchange rate.
+	GetLatest(context.Context, *GetLatestExchangeRateRequest) (*ExchangeRate, error)
+	// Delete returns error:
+	//  - ErrDataNotFound if the exchange rate does not exist.
+	Delete(context.Context, *DeleteExchangeRatesRequest) ([]*ExchangeRate, error)
+}
+
+type UpsertExchangeRatesRequest struct {
+	UserID string
+	Rates  []*BaseCreateExchangeRate
+}
+
+type BaseCreateExchangeRate struct {
+	PublicID string
+	*BaseExchangeRate
+}
+
+type ExchangeRate struct {
+	ID       int32
+	PublicID string
+	*BaseExchangeRate
+}
+
+// BaseExchangeRate means 1 unit of BaseCurrency is exchanged for Rate units of
+// QuoteCurrency on the Date.
+type BaseExchangeRate struct {
+	Date          time.Time
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          decimal.Decimal
+}
+
+type ListExchangeRatesRequest struct {
+	UserID               string
+	ExchangeRatePublicID *string
+	BaseCurrency         *string
+	QuoteCurrency        *string
+}
+
+type ListExchangeRatesReply struct {
+	Rates []*ExchangeRate
+}
+
+type GetLatestExchangeRateRequest struct {
+	UserID        string
+	BaseCurrency  string
+	QuoteCurrency string
+	Date          time.Time
+}
+
+type DeleteExchangeRatesRequest struct {
+	UserID                string
+	ExchangeRatePublicIDs []string
+}
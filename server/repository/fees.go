@@ -17,8 +17,14 @@ type FeeRepository interface {
 	// Update updates non-zero value fields on specific fee of the user, it returns error:
 	//  - ErrDataNotFound if the fee does not exist.
 	Update(context.Context, *UpdateFeeRequest) (*Fee, error)
-	// Delete returns error:
-	//  - ErrDataNotFound if the fee does not exist.
+	// Delete deletes the fees, the daily items, the repeating items and the transfers which
+	// are charged by the fees are handled by the policy. It returns error:
+	//  - ErrDataNotFound if the fee does not exist,
+	//  - ErrDataReferenced as *ReferencedError if the fees are referenced and the policy
+	//    is DeletePolicyRestrict,
+	//  - ErrReferenceNotFound if the replacement fee does not exist,
+	//  - ErrInvalidReference if the replacement fee is any of the deleted fees.
+	// The charged fees of the references are not recomputed by the replacement fee.
 	Delete(context.Context, *DeleteFeesRequest) ([]*Fee, error)
 }
 
@@ -38,16 +44,41 @@ type Fee struct {
 	*BaseFee
 }
 
+// Fee types.
+const (
+	FeeTypeRate int8 = iota
+	FeeTypeFixed
+	// FeeTypeCombined charges the fixed value plus amount * rate.
+	FeeTypeCombined
+	// FeeTypeTiered charges by the tier which the amount falls in.
+	FeeTypeTiered
+)
+
 type BaseFee struct {
 	Name string
 
 	Type  int8
 	Rate  *decimal.Decimal
 	Fixed *decimal.Decimal
+	// Tiers are sorted by UpTo in ascending order, they are only used by tiered fee.
+	Tiers []*FeeTier
+	// Min and Max bound the charged fee of any type if they are provided.
+	Min *decimal.Decimal
+	Max *decimal.Decimal
+}
+
+// FeeTier charges the fixed value plus amount * rate for the amounts less than UpTo.
+type FeeTier struct {
+	// UpTo is the exclusive upper bound of the amounts, the last tier may have no bound.
+	UpTo  *decimal.Decimal
+	Rate  *decimal.Decimal
+	Fixed *decimal.Decimal
 }
 
 type ListFeesRequest struct {
 	UserID string
+	// FeePublicID lists the fee only if it is provided.
+	FeePublicID *string
 }
 
 type ListFeesReply struct {
@@ -64,4 +95,50 @@ type UpdateFeeRequest struct {
 type DeleteFeesRequest struct {
 	FeePublicIDs []string
 	UserID       string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the fee which the references are moved to, it is only for
+	// DeletePolicyReassign.
+	ReplacementPublicID string
+}
+
+// Charge returns the fee of the amount, which is amount * rate for rate fee,
+// the fixed value for fixed fee, the fixed value plus amount * rate for combined fee
+// or the charge of the first tier whose UpTo is greater than the amount for tiered fee.
+// The fee is raised to Min and lowered to Max if they are provided.
+func (v *BaseFee) Charge(amount decimal.Decimal) decimal.Decimal {
+	fee := decimal.Zero
+	switch v.Type {
+	case FeeTypeRate:
+		fee = charge(amount, v.Rate, nil)
+	case FeeTypeFixed:
+		fee = charge(amount, nil, v.Fixed)
+	case FeeTypeCombined:
+		fee = charge(amount, v.Rate, v.Fixed)
+	case FeeTypeTiered:
+		for _, tier := range v.Tiers {
+			if tier.UpTo == nil || amount.LessThan(*tier.UpTo) {
+				fee = charge(amount, tier.Rate, tier.Fixed)
+				break
+			}
+		}
+	}
+	if v.Min != nil && fee.LessThan(*v.Min) {
+		fee = *v.Min
+	}
+	if v.Max != nil && fee.GreaterThan(*v.Max) {
+		fee = *v.Max
+	}
+	return fee
+}
+
+func charge(amount decimal.Decimal, rate, fixed *decimal.Decimal) decimal.Decimal {
+	fee := decimal.Zero
+	if rate != nil {
+		fee = amount.Mul(*rate)
+	}
+	if fixed != nil {
+		fee = fee.Add(*fixed)
+	}
+	return fee
 }
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type LedgerRepository interface {
+	// Import creates all the resources of the user in a transaction, none of them is
+	// created if any of them fails. The resources may reference the resources which are
+	// created in the same request. It returns error:
+	//  - ErrDataExists if any of the resources exists,
+	//  - ErrDataNotFound if the user does not exist,
+	//  - ErrReferenceNotFound if any of the referenced resources does not exist,
+	//  - ErrInvalidReference if the categories of any of the daily items are not the same
+	//    type, or the category of any of the budgets or the fee items is not an expense
+	//    category,
+	//  - ErrCurrencyMismatch if the currency of any of the daily items is not the currency
+	//    of its account, or the accounts of any of the transfers are in different currencies,
+	//  - ErrInvalidSplit if the splits of any of the daily items are invalid.
+	// The errors of the daily items, the repeating items, the transfers, the statement
+	// payments and the budgets are returned as *ImportError.
+	Import(context.Context, *ImportLedgerRequest) error
+}
+
+type ImportLedgerRequest struct {
+	UserID string
+	// Config replaces the config of the user if it is provided.
+	Config            *UserConfig
+	Accounts          []*BaseCreateAccount
+	ExpenseCategories []*BaseCreateCategory
+	IncomeCategories  []*BaseCreateCategory
+	Shops             []*BaseCreateShop
+	Tags              []*BaseCreateTag
+	Fees              []*BaseCreateFee
+	UnitConversions   []*BaseCreateUnitConversion
+	ExchangeRates     []*BaseCreateExchangeRate
+	RepeatingItems    []*BaseCreateRepeatingItem
+	DailyItems        []*BaseCreateDailyItem
+	// Transfers exclude the transfers which pay the statements, which are created with
+	// their StatementPayments.
+	Transfers         []*ImportTransfer
+	StatementPayments []*ImportStatementPayment
+	Budgets           []*ImportBudget
+}
+
+// ImportTransfer is CreateTransferRequest of the user of ImportLedgerRequest.
+type ImportTransfer struct {
+	PublicID string
+	Transfer *BaseTransfer
+	Fee      *decimal.Decimal
+	FeeItem  *TransferFeeItem
+}
+
+// ImportStatementPayment is CreateStatementPaymentRequest of the user of
+// ImportLedgerRequest.
+type ImportStatementPayment struct {
+	AccountPublicID string
+	Payment         *BaseStatementPayment
+	Transfer        *ImportTransfer
+}
+
+// Key identifies the payment in ImportError, it is the public id of the credit card with
+// the closing date of the statement.
+func (v *ImportStatementPayment) Key() string {
+	return v.AccountPublicID + "@" + v.Payment.ClosingDate.Format(time.DateOnly)
+}
+
+// ImportBudget is CreateBudgetRequest of the user of ImportLedgerRequest.
+type ImportBudget struct {
+	PublicID string
+	Budget   *BaseBudget
+}
+
+// ImportError is the error of the resource which fails to be imported.
+type ImportError struct {
+	// Key is the public id of the resource, or ImportStatementPayment.Key of the statement
+	// payment.
+	Key string
+	Err error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("failed to import [%s]: %v", e.Key, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
@@ -10,3 +10,10 @@ func UniqueViolationError(err error) bool {
 	}
 	return false
 }
+
+func ForeignKeyViolationError(err error) bool {
+	if e, ok := err.(*pq.Error); ok {
+		return e.Code == "23503"
+	}
+	return false
+}
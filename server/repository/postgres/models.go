@@ -75,6 +75,7 @@ type CategoriesModel struct {
 	UserID   string                  `xorm:"index not null"`
 	Type     repository.CategoryType `xorm:"smallint not null"`
 	Data     *BaseCategory           `xorm:"json not null"`
+	ParentID sql.NullInt32           `xorm:"integer index null"`
 }
 
 func (*CategoriesModel) TableName() string {
@@ -85,8 +86,15 @@ type BaseCategory struct {
 	*repository.BaseCategory
 }
 
+// MarshalJSON omits the parent which is stored in CategoriesModel.ParentID.
 func (v *BaseCategory) MarshalJSON() ([]byte, error) {
-	return json.Marshal(v.BaseCategory)
+	return json.Marshal(struct {
+		Name   string
+		IconID int32
+	}{
+		Name:   v.Name,
+		IconID: v.IconID,
+	})
 }
 
 func (v *BaseCategory) UnmarshalJSON(data []byte) error {
@@ -99,12 +107,27 @@ type ShopsModel struct {
 	UserID   string `xorm:"index not null"`
 	Name     string `xorm:"text not null"`
 	Address  string `xorm:"text not null"`
+	Memo     string `xorm:"text not null default ''"`
 }
 
 func (*ShopsModel) TableName() string {
 	return "shops"
 }
 
+type ShopLocationsModel struct {
+	ID      int32  `xorm:"serial pk"`
+	ShopID  int32  `xorm:"index not null"`
+	Label   string `xorm:"text not null"`
+	Address string `xorm:"text not null"`
+	// Latitude and Longitude are null if the location is not on the map.
+	Latitude  sql.NullFloat64 `xorm:"double null"`
+	Longitude sql.NullFloat64 `xorm:"double null"`
+}
+
+func (*ShopLocationsModel) TableName() string {
+	return "shop_locations"
+}
+
 type FeesModel struct {
 	ID       int32    `xorm:"serial pk"`
 	PublicID string   `xorm:"unique not null"`
@@ -121,6 +144,15 @@ type BaseFee struct {
 	Type  int8
 	Rate  *decimal.Decimal `json:"rate,omitempty"`
 	Fixed *decimal.Decimal `json:"fixed,omitempty"`
+	Tiers []*FeeTier       `json:"tiers,omitempty"`
+	Min   *decimal.Decimal `json:"min,omitempty"`
+	Max   *decimal.Decimal `json:"max,omitempty"`
+}
+
+type FeeTier struct {
+	UpTo  *decimal.Decimal `json:"upTo,omitempty"`
+	Rate  *decimal.Decimal `json:"rate,omitempty"`
+	Fixed *decimal.Decimal `json:"fixed,omitempty"`
 }
 
 func (v *BaseFee) MarshalJSON() ([]byte, error) {
@@ -138,3 +170,219 @@ func (v *BaseFee) UnmarshalJSON(data []byte) error {
 }
 
 type tempBaseFee BaseFee
+
+type DailyItemsModel struct {
+	ID        int32                   `xorm:"serial pk"`
+	PublicID  string                  `xorm:"unique not null"`
+	UserID    string                  `xorm:"index(idx_daily_items_user_date) not null"`
+	Date      time.Time               `xorm:"date index(idx_daily_items_user_date) unique(uq_daily_items_repeating_item_date) not null"`
+	Name      string                  `xorm:"text not null"`
+	Type      repository.CategoryType `xorm:"smallint not null"`
+	ShopID    sql.NullInt32           `xorm:"integer null"`
+	AccountID sql.NullInt32           `xorm:"integer index null"`
+	Quantity  decimal.NullDecimal     `xorm:"numeric(15,6) null"`
+	Unit      repository.Unit         `xorm:"varchar(8) not null default ''"`
+	// FeeID is the fee which Fee is computed by.
+	FeeID    sql.NullInt32       `xorm:"integer index null"`
+	Fee      decimal.NullDecimal `xorm:"numeric(15,6) null"`
+	Price    decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+	Currency string              `xorm:"varchar(3) not null default ''"`
+	Memo     string              `xorm:"text not null"`
+	// Total is price * quantity + fee.
+	Total decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+	// RepeatingItemID is the repeating item which the item is created from, each occurrence
+	// of the repeating item is created once at most.
+	RepeatingItemID sql.NullInt32 `xorm:"integer unique(uq_daily_items_repeating_item_date) null"`
+	// TransferID is the transfer which the item charges the fee for.
+	TransferID sql.NullInt32 `xorm:"integer index null"`
+	// NormalizedPrice is the subtotal per the normalized quantity of NormalizedUnit, it is
+	// null if the item has no unit.
+	NormalizedPrice decimal.NullDecimal `xorm:"numeric(15,6) null"`
+	NormalizedUnit  repository.Unit     `xorm:"varchar(8) not null default ''"`
+}
+
+func (*DailyItemsModel) TableName() string {
+	return "daily_items"
+}
+
+// DailyItemAmountExpr returns the SQL expression of the amount of the daily item, see
+// BaseItem.Amount.
+func DailyItemAmountExpr(alias string) string {
+	return alias + ".total"
+}
+
+type DailyItemCategoriesModel struct {
+	DailyItemID int32 `xorm:"pk not null"`
+	CategoryID  int32 `xorm:"pk index not null"`
+	// Amount is the part of the amount of the item which is attributed to the category,
+	// it is null if the item has no split.
+	Amount decimal.NullDecimal `xorm:"numeric(15,6) null"`
+	// Percentage is the percentage which Amount is computed by, it is null if the split
+	// is given by the amount.
+	Percentage decimal.NullDecimal `xorm:"numeric(9,6) null"`
+}
+
+func (*DailyItemCategoriesModel) TableName() string {
+	return "daily_item_categories"
+}
+
+type TagsModel struct {
+	ID       int32  `xorm:"serial pk"`
+	PublicID string `xorm:"unique not null"`
+	UserID   string `xorm:"unique(uq_tags_user_name) not null"`
+	Name     string `xorm:"text unique(uq_tags_user_name) not null"`
+}
+
+func (*TagsModel) TableName() string {
+	return "tags"
+}
+
+type UnitConversionsModel struct {
+	ID       int32           `xorm:"serial pk"`
+	PublicID string          `xorm:"unique not null"`
+	UserID   string          `xorm:"unique(uq_unit_conversions_user_unit) not null"`
+	Unit     repository.Unit `xorm:"varchar(8) unique(uq_unit_conversions_user_unit) not null"`
+	ToUnit   repository.Unit `xorm:"varchar(8) not null"`
+	// Factor is the quantity of ToUnit which 1 Unit is.
+	Factor decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+}
+
+func (*UnitConversionsModel) TableName() string {
+	return "unit_conversions"
+}
+
+type DailyItemTagsModel struct {
+	DailyItemID int32 `xorm:"pk not null"`
+	TagID       int32 `xorm:"pk index not null"`
+}
+
+func (*DailyItemTagsModel) TableName() string {
+	return "daily_item_tags"
+}
+
+// ShopItemsModel is the unit price of the expense item bought at the shop, it is recorded
+// while the user compares the items in the same shop.
+type ShopItemsModel struct {
+	DailyItemID int32               `xorm:"pk not null"`
+	UserID      string              `xorm:"index(idx_shop_items_user_shop_name) not null"`
+	ShopID      int32               `xorm:"integer index(idx_shop_items_user_shop_name) not null"`
+	Name        string              `xorm:"text index(idx_shop_items_user_shop_name) not null"`
+	Date        time.Time           `xorm:"date not null"`
+	UnitPrice   decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+	Currency    string              `xorm:"varchar(3) not null default ''"`
+	// NormalizedPrice and NormalizedUnit are copied from the daily item.
+	NormalizedPrice decimal.NullDecimal `xorm:"numeric(15,6) null"`
+	NormalizedUnit  repository.Unit     `xorm:"varchar(8) not null default ''"`
+}
+
+func (*ShopItemsModel) TableName() string {
+	return "shop_items"
+}
+
+// AdvanceItemsModel is the unit price of the expense item bought at any shop, it is recorded
+// while the user compares the items in different shops.
+type AdvanceItemsModel struct {
+	DailyItemID int32               `xorm:"pk not null"`
+	UserID      string              `xorm:"index(idx_advance_items_user_name_date) not null"`
+	Name        string              `xorm:"text index(idx_advance_items_user_name_date) not null"`
+	Date        time.Time           `xorm:"date index(idx_advance_items_user_name_date) not null"`
+	ShopID      int32               `xorm:"integer index not null"`
+	UnitPrice   decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+	Currency    string              `xorm:"varchar(3) not null default ''"`
+	// NormalizedPrice and NormalizedUnit are copied from the daily item.
+	NormalizedPrice decimal.NullDecimal `xorm:"numeric(15,6) null"`
+	NormalizedUnit  repository.Unit     `xorm:"varchar(8) not null default ''"`
+}
+
+func (*AdvanceItemsModel) TableName() string {
+	return "advance_items"
+}
+
+type RepeatingItemsModel struct {
+	ID       int32              `xorm:"serial pk"`
+	PublicID string             `xorm:"unique not null"`
+	UserID   string             `xorm:"index not null"`
+	Data     *BaseRepeatingItem `xorm:"json not null"`
+	// NextDate is the date of the next occurrence which is not created yet. It is null
+	// if there is no more occurrence.
+	NextDate sql.NullTime `xorm:"date index null"`
+}
+
+func (*RepeatingItemsModel) TableName() string {
+	return "repeating_items"
+}
+
+type BaseRepeatingItem struct {
+	*repository.BaseRepeatingItem
+}
+
+func (v *BaseRepeatingItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.BaseRepeatingItem)
+}
+
+func (v *BaseRepeatingItem) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &v.BaseRepeatingItem)
+}
+
+type TransfersModel struct {
+	ID            int32               `xorm:"serial pk"`
+	PublicID      string              `xorm:"unique not null"`
+	UserID        string              `xorm:"index(idx_transfers_user_date) not null"`
+	Date          time.Time           `xorm:"date index(idx_transfers_user_date) not null"`
+	FromAccountID int32               `xorm:"integer index not null"`
+	ToAccountID   int32               `xorm:"integer index not null"`
+	Amount        decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+	FeeID         sql.NullInt32       `xorm:"integer null"`
+	Fee           decimal.NullDecimal `xorm:"numeric(15,6) null"`
+	Memo          string              `xorm:"text not null"`
+}
+
+func (*TransfersModel) TableName() string {
+	return "transfers"
+}
+
+// StatementPaymentsModel is the payment of the statement of the credit card, the statement
+// is paid if the payment exists.
+type StatementPaymentsModel struct {
+	ID          int32               `xorm:"serial pk"`
+	UserID      string              `xorm:"index not null"`
+	AccountID   int32               `xorm:"integer unique(uq_statement_payments_account_closing_date) not null"`
+	ClosingDate time.Time           `xorm:"date unique(uq_statement_payments_account_closing_date) not null"`
+	Date        time.Time           `xorm:"date not null"`
+	Amount      decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+	// TransferID is the transfer which pays the statement, it is null if the statement is
+	// marked as paid without the transfer.
+	TransferID sql.NullInt32 `xorm:"integer index null"`
+}
+
+func (*StatementPaymentsModel) TableName() string {
+	return "statement_payments"
+}
+
+type BudgetsModel struct {
+	ID         int32               `xorm:"serial pk"`
+	PublicID   string              `xorm:"unique not null"`
+	UserID     string              `xorm:"unique(uq_budgets_user_category) not null"`
+	CategoryID int32               `xorm:"integer unique(uq_budgets_user_category) not null"`
+	Amount     decimal.NullDecimal `xorm:"numeric(15,6) not null"`
+	Rollover   bool                `xorm:"not null"`
+	StartMonth time.Time           `xorm:"date not null"`
+}
+
+func (*BudgetsModel) TableName() string {
+	return "budgets"
+}
+
+type ExchangeRatesModel struct {
+	ID            int32               `xorm:"serial pk"`
+	PublicID      string              `xorm:"unique not null"`
+	UserID        string              `xorm:"unique(uq_exchange_rates_user_currencies_date) not null"`
+	BaseCurrency  string              `xorm:"varchar(3) unique(uq_exchange_rates_user_currencies_date) not null"`
+	QuoteCurrency string              `xorm:"varchar(3) unique(uq_exchange_rates_user_currencies_date) not null"`
+	Date          time.Time           `xorm:"date unique(uq_exchange_rates_user_currencies_date) not null"`
+	Rate          decimal.NullDecimal `xorm:"numeric(20,10) not null"`
+}
+
+func (*ExchangeRatesModel) TableName() string {
+	return "exchange_rates"
+}
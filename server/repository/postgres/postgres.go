@@ -1,7 +1,10 @@
 package postgres
 
 import (
+	"fmt"
 	"time"
+
+	"xorm.io/xorm"
 )
 
 type Config struct {
@@ -17,3 +20,48 @@ type Config struct {
 	MaxIdleConns    int           `toml:"max-idle-conns" comment:"The value <= 0 means no idle connections are retained."`
 	MaxOpenConns    int           `toml:"max-open-conns" comment:"The value <= 0 means there is no limit on the number of open connections."`
 }
+
+// SyncTables creates or alters the tables of the models.
+func SyncTables(engine *xorm.Engine) error {
+	return engine.Sync(
+		UsersModel{},
+		TokensModel{},
+		AccountsModel{},
+		CategoriesModel{},
+		ShopsModel{},
+		ShopLocationsModel{},
+		TagsModel{},
+		UnitConversionsModel{},
+		FeesModel{},
+		DailyItemsModel{},
+		DailyItemCategoriesModel{},
+		DailyItemTagsModel{},
+		ShopItemsModel{},
+		AdvanceItemsModel{},
+		RepeatingItemsModel{},
+		TransfersModel{},
+		StatementPaymentsModel{},
+		BudgetsModel{},
+		ExchangeRatesModel{},
+	)
+}
+
+// CreateSearchIndexes creates the trigram indexes which xorm does not support, so that the
+// daily items can be searched by the name and the memo with ILIKE efficiently.
+func CreateSearchIndexes(engine *xorm.Engine) error {
+	if _, err := engine.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm"); err != nil {
+		return fmt.Errorf("failed to create pg_trgm extension: %w", err)
+	}
+
+	table := engine.TableName(&DailyItemsModel{}, true)
+	for _, column := range []string{"name", "memo"} {
+		_, err := engine.Exec(fmt.Sprintf(
+			"CREATE INDEX IF NOT EXISTS idx_daily_items_%[1]s_trgm ON %[2]s USING gin (%[1]s gin_trgm_ops)",
+			column, table,
+		))
+		if err != nil {
+			return fmt.Errorf("failed to create trigram index of %s: %w", column, err)
+		}
+	}
+	return nil
+}
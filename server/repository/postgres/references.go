@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+// foreignKeys are the references to the ids of the categories, the shops, the fees and
+// the accounts.
+var foreignKeys = []struct {
+	table      any
+	column     string
+	referenced any
+}{
+	{&CategoriesModel{}, "parent_id", &CategoriesModel{}},
+	{&DailyItemCategoriesModel{}, "category_id", &CategoriesModel{}},
+	{&BudgetsModel{}, "category_id", &CategoriesModel{}},
+	{&DailyItemsModel{}, "shop_id", &ShopsModel{}},
+	{&ShopLocationsModel{}, "shop_id", &ShopsModel{}},
+	{&ShopItemsModel{}, "shop_id", &ShopsModel{}},
+	{&AdvanceItemsModel{}, "shop_id", &ShopsModel{}},
+	{&DailyItemsModel{}, "fee_id", &FeesModel{}},
+	{&TransfersModel{}, "fee_id", &FeesModel{}},
+	{&DailyItemsModel{}, "account_id", &AccountsModel{}},
+	{&TransfersModel{}, "from_account_id", &AccountsModel{}},
+	{&TransfersModel{}, "to_account_id", &AccountsModel{}},
+	{&StatementPaymentsModel{}, "account_id", &AccountsModel{}},
+}
+
+// CreateForeignKeys creates the foreign keys which xorm does not support, so that none of
+// the categories, the shops, the fees and the accounts is deleted while it is referenced.
+// The repositories handle the references by the delete policy before the deletion because
+// the balances of the accounts are updated with them. The existing rows are not validated,
+// so that the references orphaned before the foreign keys do not fail the startup.
+func CreateForeignKeys(engine *xorm.Engine) error {
+	for _, key := range foreignKeys {
+		table := engine.TableName(key.table, true)
+		name := fmt.Sprintf("fk_%s_%s", engine.TableName(key.table), key.column)
+
+		exists, err := engine.
+			SQL("SELECT 1 FROM pg_constraint WHERE conname = ? AND conrelid = ?::regclass", name, table).
+			Exist()
+		if err != nil {
+			return fmt.Errorf("failed to check foreign key %s: %w", name, err)
+		}
+		if exists {
+			continue
+		}
+
+		_, err = engine.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (id) NOT VALID",
+			table, name, key.column, engine.TableName(key.referenced, true),
+		))
+		if err != nil {
+			return fmt.Errorf("failed to create foreign key %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ListReferringRepeatingItems returns the repeating items of the user whose item refers
+// to the deleted resources.
+func ListReferringRepeatingItems(session *xorm.Session, userID string, refers func(*repository.BaseItem) bool) ([]*RepeatingItemsModel, error) {
+	var rows []*RepeatingItemsModel
+	err := session.Where("user_id = ?", userID).Asc("id").Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Filter(rows, func(item *RepeatingItemsModel, _ int) bool {
+		return refers(item.Data.Item)
+	}), nil
+}
+
+// UpdateRepeatingItemsData updates the data of the repeating items whose references are
+// reassigned.
+func UpdateRepeatingItemsData(session *xorm.Session, rows []*RepeatingItemsModel) error {
+	for _, row := range rows {
+		_, err := session.Cols("data").Update(row, &RepeatingItemsModel{
+			ID: row.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// References are the data which refer to the deleted data.
+type References struct {
+	DailyItems     []*DailyItemsModel
+	RepeatingItems []*RepeatingItemsModel
+	Transfers      []*TransfersModel
+	Budgets        []*BudgetsModel
+	// StatementPayments are the payments of the statements of the deleted credit cards.
+	StatementPayments []*StatementPaymentsModel
+}
+
+// NewReferencedError returns nil if there is no reference.
+func NewReferencedError(v *References) error {
+	var references []*repository.Reference
+	for _, item := range v.DailyItems {
+		references = append(references, &repository.Reference{
+			Type:     repository.ReferenceTypeDailyItem,
+			PublicID: item.PublicID,
+		})
+	}
+	for _, item := range v.RepeatingItems {
+		references = append(references, &repository.Reference{
+			Type:     repository.ReferenceTypeRepeatingItem,
+			PublicID: item.PublicID,
+		})
+	}
+	for _, item := range v.Transfers {
+		references = append(references, &repository.Reference{
+			Type:     repository.ReferenceTypeTransfer,
+			PublicID: item.PublicID,
+		})
+	}
+	for _, item := range v.Budgets {
+		references = append(references, &repository.Reference{
+			Type:     repository.ReferenceTypeBudget,
+			PublicID: item.PublicID,
+		})
+	}
+	for _, item := range v.StatementPayments {
+		references = append(references, &repository.Reference{
+			Type:     repository.ReferenceTypeStatementPayment,
+			PublicID: item.ClosingDate.Format(time.DateOnly),
+		})
+	}
+	if len(references) == 0 {
+		return nil
+	}
+	return &repository.ReferencedError{
+		References: references,
+	}
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+func ToNullDecimal(v *decimal.Decimal) decimal.NullDecimal {
+	if v == nil {
+		return decimal.NullDecimal{}
+	}
+	return decimal.NewNullDecimal(*v)
+}
+
+func FromNullDecimal(v decimal.NullDecimal) *decimal.Decimal {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Decimal
+}
+
+func ToNullInt32(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
+
+func ToNullTime(v *time.Time) sql.NullTime {
+	if v == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *v, Valid: true}
+}
+
+func FromNullTime(v sql.NullTime) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Time
+}
+
+// ToBaseFee converts the fee of repository into the data of the row.
+func ToBaseFee(v *repository.BaseFee) *BaseFee {
+	result := &BaseFee{
+		Type:  v.Type,
+		Rate:  v.Rate,
+		Fixed: v.Fixed,
+		Min:   v.Min,
+		Max:   v.Max,
+	}
+	for _, tier := range v.Tiers {
+		result.Tiers = append(result.Tiers, &FeeTier{
+			UpTo:  tier.UpTo,
+			Rate:  tier.Rate,
+			Fixed: tier.Fixed,
+		})
+	}
+	return result
+}
+
+// FromBaseFee converts the data of the row into the fee of repository.
+func FromBaseFee(name string, v *BaseFee) *repository.BaseFee {
+	result := &repository.BaseFee{
+		Name:  name,
+		Type:  v.Type,
+		Rate:  v.Rate,
+		Fixed: v.Fixed,
+		Min:   v.Min,
+		Max:   v.Max,
+	}
+	for _, tier := range v.Tiers {
+		result.Tiers = append(result.Tiers, &repository.FeeTier{
+			UpTo:  tier.UpTo,
+			Rate:  tier.Rate,
+			Fixed: tier.Fixed,
+		})
+	}
+	return result
+}
+
+// FromNormalizedPrice converts the normalized price into the columns of the row, the
+// price is null if it is nil.
+func FromNormalizedPrice(v *repository.NormalizedPrice) (decimal.NullDecimal, repository.Unit) {
+	if v == nil {
+		return decimal.NullDecimal{}, repository.UnitNone
+	}
+	return decimal.NewNullDecimal(v.Price), v.Unit
+}
+
+// ToNormalizedPrice converts the columns of the row into the normalized price, it returns
+// nil if the price is null.
+func ToNormalizedPrice(price decimal.NullDecimal, unit repository.Unit) *repository.NormalizedPrice {
+	if !price.Valid {
+		return nil
+	}
+	return &repository.NormalizedPrice{
+		Price:    price.Decimal,
+		Quantity: repository.NormalizedQuantity(unit),
+		Unit:     unit,
+	}
+}
+
+// FromCoordinate converts the coordinate into the columns of the row, the latitude and
+// the longitude are null if it is nil.
+func FromCoordinate(v *repository.Coordinate) (latitude, longitude sql.NullFloat64) {
+	if v == nil {
+		return sql.NullFloat64{}, sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: v.Latitude, Valid: true}, sql.NullFloat64{Float64: v.Longitude, Valid: true}
+}
+
+// ToCoordinate converts the columns of the row into the coordinate, it returns nil if
+// either of them is null.
+func ToCoordinate(latitude, longitude sql.NullFloat64) *repository.Coordinate {
+	if !latitude.Valid || !longitude.Valid {
+		return nil
+	}
+	return &repository.Coordinate{
+		Latitude:  latitude.Float64,
+		Longitude: longitude.Float64,
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// DeletePolicy decides how the references to the deleted resources are handled. The
+// references are the daily items, the repeating items and the transfers which refer to
+// the resources.
+type DeletePolicy uint8
+
+const (
+	// DeletePolicyRestrict refuses to delete the resources which are referenced.
+	DeletePolicyRestrict DeletePolicy = iota
+	// DeletePolicyReassign makes the references refer to the replacement resource.
+	DeletePolicyReassign
+	// DeletePolicyCascade deletes the references with the resources.
+	DeletePolicyCascade
+)
+
+var deletePolicyDescriptions = map[DeletePolicy]string{
+	DeletePolicyRestrict: "restrict",
+	DeletePolicyReassign: "reassign",
+	DeletePolicyCascade:  "cascade",
+}
+
+var descriptionsToDeletePolicy = lo.Invert(deletePolicyDescriptions)
+
+func (p DeletePolicy) String() string {
+	if s, ok := deletePolicyDescriptions[p]; ok {
+		return s
+	}
+	return strconv.Itoa(int(p))
+}
+
+func ToDeletePolicy(s string) (DeletePolicy, error) {
+	if p, ok := descriptionsToDeletePolicy[s]; ok {
+		return p, nil
+	}
+	return 0, fmt.Errorf("unknown delete policy[%s]", s)
+}
+
+// ReferenceType is the type of the resource which refers to the deleted resource.
+type ReferenceType string
+
+const (
+	ReferenceTypeDailyItem     ReferenceType = "dailyItem"
+	ReferenceTypeRepeatingItem ReferenceType = "repeatingItem"
+	ReferenceTypeTransfer      ReferenceType = "transfer"
+	ReferenceTypeBudget        ReferenceType = "budget"
+	// ReferenceTypeStatementPayment is the payment of the statement of the credit card, its
+	// public id is the closing date of the statement.
+	ReferenceTypeStatementPayment ReferenceType = "statementPayment"
+)
+
+type Reference struct {
+	Type     ReferenceType
+	PublicID string
+}
+
+// ReferencedError is ErrDataReferenced with the references which refuse the deletion.
+type ReferencedError struct {
+	References []*Reference
+}
+
+func (e *ReferencedError) Error() string {
+	return fmt.Sprintf("%v by %s", ErrDataReferenced, strings.Join(lo.Map(e.References, func(item *Reference, _ int) string {
+		return fmt.Sprintf("%s[%s]", item.Type, item.PublicID)
+	}), ", "))
+}
+
+func (e *ReferencedError) Is(target error) bool {
+	return target == ErrDataReferenced
+}
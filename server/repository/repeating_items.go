@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type RepeatingItemRepository interface {
+	// Create creates repeating items of specific user and return error:
+	//  - ErrDataExists if the data exists
+	// or returns RepeatingItem model with id.
+	Create(context.Context, *CreateRepeatingItemsRequest) ([]*RepeatingItem, error)
+	// List returns repeating items, it returns error:
+	//  - ErrDataNotFound if there is no repeating item satisfied filter conditions.
+	List(context.Context, *ListRepeatingItemsRequest) (*ListRepeatingItemsReply, error)
+	// ListDue returns repeating items of all users whose next date is not after
+	// the specific date, it returns error:
+	//  - ErrDataNotFound if there is no due repeating item.
+	ListDue(context.Context, *ListDueRepeatingItemsRequest) (*ListDueRepeatingItemsReply, error)
+	// Update updates specific repeating item of the user, it returns error:
+	//  - ErrDataNotFound if the repeating item does not exist.
+	Update(context.Context, *UpdateRepeatingItemRequest) (*RepeatingItem, error)
+	// UpdateNextDate updates the next date of specific repeating item of the user,
+	// it returns error:
+	//  - ErrDataNotFound if the repeating item does not exist.
+	UpdateNextDate(context.Context, *UpdateRepeatingItemNextDateRequest) error
+	// Delete returns error:
+	//  - ErrDataNotFound if the repeating item does not exist.
+	// The daily items created from the repeating items are kept.
+	Delete(context.Context, *DeleteRepeatingItemsRequest) ([]*RepeatingItem, error)
+}
+
+type CreateRepeatingItemsRequest struct {
+	UserID string
+	Items  []*BaseCreateRepeatingItem
+}
+
+type BaseCreateRepeatingItem struct {
+	PublicID string
+	*BaseRepeatingItem
+	NextDate *time.Time
+}
+
+type RepeatingItem struct {
+	ID       int32
+	PublicID string
+	*BaseRepeatingItem
+	// NextDate is the date of the next occurrence which is not created yet.
+	// It is nil if there is no more occurrence.
+	NextDate *time.Time
+}
+
+type BaseRepeatingItem struct {
+	Item      *BaseItem
+	StartDate time.Time
+	EndDate   *time.Time
+	Frequency *RepeatingFrequency
+}
+
+// RepeatingFrequency is either every Days days or every work day.
+type RepeatingFrequency struct {
+	Days         int32 `json:"days,omitempty"`
+	EveryWorkDay bool  `json:"everyWorkDay,omitempty"`
+}
+
+type ListRepeatingItemsRequest struct {
+	UserID                string
+	RepeatingItemPublicID *string
+}
+
+type ListRepeatingItemsReply struct {
+	Items []*RepeatingItem
+}
+
+type ListDueRepeatingItemsRequest struct {
+	Date time.Time
+}
+
+type ListDueRepeatingItemsReply struct {
+	Items []*DueRepeatingItem
+}
+
+type DueRepeatingItem struct {
+	UserID string
+	*RepeatingItem
+}
+
+type UpdateRepeatingItemRequest struct {
+	UserID                string
+	RepeatingItemPublicID string
+
+	Item     *BaseRepeatingItem
+	NextDate *time.Time
+}
+
+type UpdateRepeatingItemNextDateRequest struct {
+	UserID                string
+	RepeatingItemPublicID string
+	NextDate              *time.Time
+}
+
+type DeleteRepeatingItemsRequest struct {
+	UserID                 string
+	RepeatingItemPublicIDs []string
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type ShopRepository interface {
@@ -9,15 +12,27 @@ type ShopRepository interface {
 	//  - ErrDataExists if the data exists
 	// or returns Shop model with id.
 	Create(context.Context, *CreateShopsRequest) ([]*Shop, error)
-	// List returns shops, it returns error:
+	// List returns shops with their locations, it returns error:
 	//  - ErrDataNotFound if there is no shop satisfied filter conditions.
 	List(context.Context, *ListShopsRequest) (*ListShopsReply, error)
-	// Update updates non-zero value fields on specific shop of the user, it returns error:
+	// Update updates non-zero value fields on specific shop of the user and replaces the
+	// locations of the shop, it returns error:
 	//  - ErrDataNotFound if the shop does not exist.
 	Update(context.Context, *UpdateShopRequest) (*Shop, error)
-	// Delete returns error:
-	//  - ErrDataNotFound if the shop does not exist.
+	// Delete deletes the shops and their locations, the daily items and the repeating items
+	// of the shops are handled by the policy. It returns error:
+	//  - ErrDataNotFound if the shop does not exist,
+	//  - ErrDataReferenced as *ReferencedError if the shops are referenced and the policy
+	//    is DeletePolicyRestrict,
+	//  - ErrReferenceNotFound if the replacement shop does not exist,
+	//  - ErrInvalidReference if the replacement shop is any of the deleted shops.
+	// The recorded prices are moved to the replacement shop with the daily items.
 	Delete(context.Context, *DeleteShopsRequest) ([]*Shop, error)
+	// ListItemPrices returns the recorded unit prices of the item at the shop in the order
+	// of date, it returns error:
+	//  - ErrReferenceNotFound if the shop does not exist,
+	//  - ErrDataNotFound if there is no recorded price.
+	ListItemPrices(context.Context, *ListShopItemPricesRequest) (*ListShopItemPricesReply, error)
 }
 
 type CreateShopsRequest struct {
@@ -39,6 +54,29 @@ type Shop struct {
 type BaseShop struct {
 	Name    string
 	Address string
+	// Locations are the branches of the shop in the order of creation.
+	Locations []*ShopLocation
+	Memo      string
+}
+
+// ShopLocation is a branch of the shop.
+type ShopLocation struct {
+	// Label names the branch, such as "Taipei Main Station".
+	Label   string
+	Address string
+	// Coordinate is nil if the location is not on the map.
+	Coordinate *Coordinate
+}
+
+// Coordinate is the position on the map in degrees.
+type Coordinate struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Valid reports whether the latitude is in [-90, 90] and the longitude is in [-180, 180].
+func (c *Coordinate) Valid() bool {
+	return c.Latitude >= -90 && c.Latitude <= 90 && c.Longitude >= -180 && c.Longitude <= 180
 }
 
 type ListShopsRequest struct {
@@ -59,4 +97,28 @@ type UpdateShopRequest struct {
 type DeleteShopsRequest struct {
 	ShopPublicIDs []string
 	UserID        string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the shop which the references are moved to, it is only for
+	// DeletePolicyReassign.
+	ReplacementPublicID string
+}
+
+type ListShopItemPricesRequest struct {
+	UserID       string
+	ShopPublicID string
+	ItemName     string
+}
+
+type ListShopItemPricesReply struct {
+	Prices []*ShopItemPrice
+}
+
+type ShopItemPrice struct {
+	DailyItemPublicID string
+	Date              time.Time
+	UnitPrice         decimal.Decimal
+	Currency          string
+	// NormalizedPrice is nil if the item has no unit, see DailyItem.NormalizedPrice.
+	NormalizedPrice *NormalizedPrice
 }
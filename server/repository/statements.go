@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type StatementRepository interface {
+	// CreatePayment records the payment of the statement of the credit card and return error:
+	//  - ErrDataExists if the statement has been paid
+	//  - ErrReferenceNotFound if the credit card or any of referenced accounts of the transfer does not exist
+	//  - ErrCurrencyMismatch if the currencies of the accounts of the transfer are different
+	// or returns StatementPayment model with id.
+	// The transfer of the payment is created in the same transaction if it is provided.
+	CreatePayment(context.Context, *CreateStatementPaymentRequest) (*StatementPayment, error)
+	// ListPayments returns the payments of the statements of the credit card, the payments
+	// are sorted by the closing date. It returns error:
+	//  - ErrDataNotFound if there is no payment satisfied filter conditions.
+	ListPayments(context.Context, *ListStatementPaymentsRequest) (*ListStatementPaymentsReply, error)
+	// DeletePayment returns error:
+	//  - ErrDataNotFound if the statement is not paid.
+	// The transfer of the payment is deleted in the same transaction.
+	DeletePayment(context.Context, *DeleteStatementPaymentRequest) (*StatementPayment, error)
+}
+
+type CreateStatementPaymentRequest struct {
+	UserID          string
+	AccountPublicID string
+	Payment         *BaseStatementPayment
+
+	// Transfer moves the amount of the payment to the credit card, it is nil if the
+	// statement is marked as paid without the transfer.
+	Transfer *CreateTransferRequest
+}
+
+type StatementPayment struct {
+	ID              int32
+	AccountPublicID string
+	*BaseStatementPayment
+	// TransferPublicID is the transfer which pays the statement, it is nil if the statement
+	// is marked as paid without the transfer.
+	TransferPublicID *string
+}
+
+type BaseStatementPayment struct {
+	// ClosingDate is the last date of the cycle of the statement.
+	ClosingDate time.Time
+	// Date is the date of the payment.
+	Date   time.Time
+	Amount decimal.Decimal
+}
+
+type ListStatementPaymentsRequest struct {
+	UserID          string
+	AccountPublicID string
+	// From is the first closing date of the period, inclusive.
+	From *time.Time
+	// To is the end closing date of the period, exclusive.
+	To *time.Time
+}
+
+type ListStatementPaymentsReply struct {
+	Payments []*StatementPayment
+}
+
+type DeleteStatementPaymentRequest struct {
+	UserID          string
+	AccountPublicID string
+	ClosingDate     time.Time
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+)
+
+type TagRepository interface {
+	// Create creates tags of specific user and return error:
+	//  - ErrDataExists if the data exists or the user has the tag of the same name
+	// or returns Tag model with id.
+	Create(context.Context, *CreateTagsRequest) ([]*Tag, error)
+	// List returns tags sorted by name, it returns error:
+	//  - ErrDataNotFound if there is no tag satisfied filter conditions.
+	List(context.Context, *ListTagsRequest) (*ListTagsReply, error)
+	// Update updates non-zero value fields on specific tag of the user, it returns error:
+	//  - ErrDataNotFound if the tag does not exist,
+	//  - ErrDataExists if the user has another tag of the same name.
+	Update(context.Context, *UpdateTagRequest) (*Tag, error)
+	// Delete returns error:
+	//  - ErrDataNotFound if the tag does not exist.
+	// The tag is removed from the daily items in the same transaction.
+	Delete(context.Context, *DeleteTagsRequest) ([]*Tag, error)
+}
+
+type CreateTagsRequest struct {
+	UserID string
+	Tags   []*BaseCreateTag
+}
+
+type BaseCreateTag struct {
+	PublicID string
+	*BaseTag
+}
+
+type Tag struct {
+	ID       int32
+	PublicID string
+	*BaseTag
+}
+
+type BaseTag struct {
+	Name string
+}
+
+type ListTagsRequest struct {
+	UserID string
+}
+
+type ListTagsReply struct {
+	Tags []*Tag
+}
+
+type UpdateTagRequest struct {
+	UserID      string
+	TagPublicID string
+
+	Tag *BaseTag
+}
+
+type DeleteTagsRequest struct {
+	UserID       string
+	TagPublicIDs []string
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type TransferRepository interface {
+	// Create creates a transfer of specific user and return error:
+	//  - ErrDataExists if the data exists
+	//  - ErrReferenceNotFound if any of referenced accounts, fee or category does not exist
+	//  - ErrInvalidReference if the referenced category is not an expense category
+	//  - ErrCurrencyMismatch if the currencies of the source and the destination accounts are different
+	// or returns Transfer model with id.
+	// The amount is moved from the source account to the destination account, and the
+	// fee is charged to the source account as an expense daily item in the same transaction.
+	Create(context.Context, *CreateTransferRequest) (*Transfer, error)
+	// List returns transfers, it returns error:
+	//  - ErrDataNotFound if there is no transfer satisfied filter conditions.
+	List(context.Context, *ListTransfersRequest) (*ListTransfersReply, error)
+	// Delete returns error:
+	//  - ErrDataNotFound if the transfer does not exist.
+	// The amount is moved back, the fee daily items and the statement payments made by the
+	// transfer are deleted in the same transaction.
+	Delete(context.Context, *DeleteTransfersRequest) ([]*Transfer, error)
+}
+
+type CreateTransferRequest struct {
+	UserID   string
+	PublicID string
+	Transfer *BaseTransfer
+
+	// Fee overrides the fee charged by the fee of the transfer if both of them are
+	// provided, it keeps the charged fees of the imported transfers.
+	Fee *decimal.Decimal
+	// FeeItem is the daily item which records the fee, it is required if the fee charged
+	// by the FeePublicID of the transfer is not zero.
+	FeeItem *TransferFeeItem
+}
+
+type TransferFeeItem struct {
+	PublicID         string
+	CategoryPublicID string
+}
+
+type Transfer struct {
+	ID       int32
+	PublicID string
+	*BaseTransfer
+	// Fee is the charged fee, it is nil if there is no fee.
+	Fee *decimal.Decimal
+}
+
+type BaseTransfer struct {
+	Date                time.Time
+	FromAccountPublicID string
+	ToAccountPublicID   string
+	Amount              decimal.Decimal
+	FeePublicID         *string
+	Memo                string
+}
+
+type ListTransfersRequest struct {
+	UserID           string
+	TransferPublicID *string
+	// AccountPublicID filters the transfers from or to the account.
+	AccountPublicID *string
+}
+
+type ListTransfersReply struct {
+	Transfers []*Transfer
+}
+
+type DeleteTransfersRequest struct {
+	UserID            string
+	TransferPublicIDs []string
+}
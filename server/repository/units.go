@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+type UnitConversionRepository interface {
+	// Create creates unit conversions of specific user and return error:
+	//  - ErrDataExists if the data exists or the user has the conversion of the same unit
+	// or returns UnitConversion model with id.
+	Create(context.Context, *CreateUnitConversionsRequest) ([]*UnitConversion, error)
+	// List returns unit conversions sorted by unit, it returns error:
+	//  - ErrDataNotFound if there is no unit conversion satisfied filter conditions.
+	List(context.Context, *ListUnitConversionsRequest) (*ListUnitConversionsReply, error)
+	// Update updates specific unit conversion of the user, it returns error:
+	//  - ErrDataNotFound if the unit conversion does not exist,
+	//  - ErrDataExists if the user has another conversion of the same unit.
+	Update(context.Context, *UpdateUnitConversionRequest) (*UnitConversion, error)
+	// Delete returns error:
+	//  - ErrDataNotFound if the unit conversion does not exist.
+	Delete(context.Context, *DeleteUnitConversionsRequest) ([]*UnitConversion, error)
+}
+
+// Unit is the unit of the quantity of the item.
+type Unit string
+
+const (
+	UnitNone       Unit = ""
+	UnitGram       Unit = "g"
+	UnitKilogram   Unit = "kg"
+	UnitMilliliter Unit = "ml"
+	UnitLiter      Unit = "L"
+	UnitPiece      Unit = "pcs"
+	UnitPack       Unit = "pack"
+)
+
+// Units are the supported units.
+var Units = []Unit{UnitGram, UnitKilogram, UnitMilliliter, UnitLiter, UnitPiece, UnitPack}
+
+func (u Unit) Valid() bool {
+	return slices.Contains(Units, u)
+}
+
+// Convertible reports whether the user can define the conversion of the unit. The units
+// which have built-in conversions or are the base units of them are not convertible.
+func (u Unit) Convertible() bool {
+	return u == UnitPiece || u == UnitPack
+}
+
+// builtinUnitConversions are the conversions which are the same for all users.
+var builtinUnitConversions = map[Unit]*BaseUnitConversion{
+	UnitKilogram: {Unit: UnitKilogram, ToUnit: UnitGram, Factor: decimal.NewFromInt(1000)},
+	UnitLiter:    {Unit: UnitLiter, ToUnit: UnitMilliliter, Factor: decimal.NewFromInt(1000)},
+}
+
+// NormalizeQuantity converts the quantity in the unit into its base unit by the built-in
+// conversions and the conversions of the user, such as 1 kg into 1000 g, or 2 pack into
+// 12 pcs if 1 pack is 6 pcs. The unit is its own base unit if it has no conversion, and
+// the conversion is stopped at the unit which is converted before.
+func NormalizeQuantity(quantity decimal.Decimal, unit Unit, conversions []*BaseUnitConversion) (decimal.Decimal, Unit) {
+	userConversions := make(map[Unit]*BaseUnitConversion, len(conversions))
+	for _, conversion := range conversions {
+		userConversions[conversion.Unit] = conversion
+	}
+
+	visited := map[Unit]bool{}
+	for !visited[unit] {
+		visited[unit] = true
+		conversion, ok := builtinUnitConversions[unit]
+		if !ok {
+			conversion, ok = userConversions[unit]
+		}
+		if !ok {
+			break
+		}
+		quantity, unit = quantity.Mul(conversion.Factor), conversion.ToUnit
+	}
+	return quantity, unit
+}
+
+// NormalizedPrice is the price of the item per Quantity Unit, such as NT$ per 100 g.
+type NormalizedPrice struct {
+	Price decimal.Decimal
+	// Quantity is 100 for g and ml, or 1 for the other units.
+	Quantity decimal.Decimal
+	Unit     Unit
+}
+
+// NormalizedPrice returns the subtotal of the item per the quantity of its base unit, the
+// price is rounded to 6 decimal places. It returns nil if the item has no unit or its
+// quantity is not positive.
+func (v *BaseItem) NormalizedPrice(conversions []*BaseUnitConversion) *NormalizedPrice {
+	if v.Unit == UnitNone || v.Quantity == nil || !v.Quantity.IsPositive() {
+		return nil
+	}
+
+	quantity, unit := NormalizeQuantity(*v.Quantity, v.Unit, conversions)
+	per := NormalizedQuantity(unit)
+	return &NormalizedPrice{
+		Price:    v.Subtotal().Mul(per).Div(quantity).Round(6),
+		Quantity: per,
+		Unit:     unit,
+	}
+}
+
+// NormalizedQuantity returns the quantity of the unit which the normalized prices are per.
+func NormalizedQuantity(unit Unit) decimal.Decimal {
+	if unit == UnitGram || unit == UnitMilliliter {
+		return decimal.NewFromInt(100)
+	}
+	return decimal.NewFromInt(1)
+}
+
+type CreateUnitConversionsRequest struct {
+	UserID      string
+	Conversions []*BaseCreateUnitConversion
+}
+
+type BaseCreateUnitConversion struct {
+	PublicID string
+	*BaseUnitConversion
+}
+
+type UnitConversion struct {
+	ID       int32
+	PublicID string
+	*BaseUnitConversion
+}
+
+// BaseUnitConversion converts the unit into the other unit, 1 Unit is Factor ToUnit.
+type BaseUnitConversion struct {
+	Unit   Unit
+	ToUnit Unit
+	Factor decimal.Decimal
+}
+
+type ListUnitConversionsRequest struct {
+	UserID string
+}
+
+type ListUnitConversionsReply struct {
+	Conversions []*UnitConversion
+}
+
+type UpdateUnitConversionRequest struct {
+	UserID                 string
+	UnitConversionPublicID string
+
+	Conversion *BaseUnitConversion
+}
+
+type DeleteUnitConversionsRequest struct {
+	UserID                  string
+	UnitConversionPublicIDs []string
+}
@@ -8,8 +8,9 @@ import (
 )
 
 type UserRepository interface {
-	// Create creates the given user. It returns ErrDataExists if the user already exists.
-	CreateUser(ctx context.Context, user *UserModel) error
+	// CreateUser creates the given user with its default categories and accounts in the same
+	// transaction. It returns ErrDataExists if the user already exists.
+	CreateUser(ctx context.Context, r *CreateUserRequest) error
 	// GetUser returns the specified user. It returns ErrDataNotFound if the user does not exist.
 	GetUser(ctx context.Context, userID string) (*UserModel, error)
 	// UpdateUser updates non-zero-value fields for specific user.
@@ -32,6 +33,16 @@ type UserModel struct {
 
 type UserConfig = models.UserConfig
 
+type CreateUserRequest struct {
+	User *UserModel
+	// ExpenseCategories and IncomeCategories are the default categories of the user, the
+	// parent categories must be before their children.
+	ExpenseCategories []*BaseCreateCategory
+	IncomeCategories  []*BaseCreateCategory
+	// Accounts are the default accounts of the user.
+	Accounts []*BaseCreateAccount
+}
+
 type TokenModel struct {
 	ID         string
 	Claim      *TokenClaims
@@ -2,11 +2,15 @@ package shops
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 
 	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/dailyitems"
 	"github.com/n101661/maney/server/models"
 )
 
@@ -14,9 +18,15 @@ type IrisController struct {
 	*irisController.SimpleCreateTemplate[models.BasicShop, CreateRequest, CreateReply, models.ObjectId]
 	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Shop]
 	*irisController.SimpleUpdateTemplate[models.BasicShop, UpdateRequest, UpdateReply]
-	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+	*irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]
+
+	PriceHistory *irisController.SimpleGetTemplate[PriceHistoryRequest, PriceHistoryReply, models.ShopItemPriceHistory]
+	Nearest      *irisController.SimpleGetTemplate[NearestRequest, NearestReply, []models.NearestShop]
 }
 
+// defaultNearestLimit is the maximum number of the nearest shops if it is not provided.
+const defaultNearestLimit = 10
+
 func NewIrisController(s Service) *IrisController {
 	return &IrisController{
 		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicShop, CreateRequest, CreateReply, models.ObjectId]{
@@ -24,15 +34,13 @@ func NewIrisController(s Service) *IrisController {
 			ParseServiceRequest: func(userID string, r *models.BasicShop) (*CreateRequest, error) {
 				return &CreateRequest{
 					UserID: userID,
-					Shop: &BaseShop{
-						Name:    r.Name,
-						Address: lo.FromPtr(r.Address),
-					},
+					Shop:   toServiceBaseShop(r),
 				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidCoordinate):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
@@ -59,11 +67,7 @@ func NewIrisController(s Service) *IrisController {
 			},
 			ParseAPIResponse: func(reply *ListReply) (*[]*models.Shop, error) {
 				return lo.ToPtr(lo.Map(reply.Shops, func(item *Shop, _ int) *models.Shop {
-					return &models.Shop{
-						Id:      lo.ToPtr(models.Id(item.PublicID)),
-						Name:    item.Name,
-						Address: lo.ToPtr(item.Address),
-					}
+					return toAPIShop(item)
 				})), nil
 			},
 		},
@@ -74,31 +78,50 @@ func NewIrisController(s Service) *IrisController {
 				return &UpdateRequest{
 					UserID:       userID,
 					ShopPublicID: publicID,
-					Shop: &BaseShop{
-						Name:    r.Name,
-						Address: lo.FromPtr(r.Address),
-					},
+					Shop:         toServiceBaseShop(r),
 				}, nil
 			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
 				case errors.Is(err, ErrShopNotFound):
 					return iris.StatusNotFound, true
-				case errors.Is(err, ErrDataInsufficient):
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidCoordinate):
 					return iris.StatusBadRequest, true
 				}
 				return 0, false
 			},
 		},
-		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+		PolicyDeleteTemplate: &irisController.PolicyDeleteTemplate[DeleteRequest, DeleteReply]{
 			Placeholder: "shopId",
 			Service:     s,
-			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+			ParseServiceRequest: func(userID string, publicID string, policy DeletePolicy, replacementID string) *DeleteRequest {
 				return &DeleteRequest{
-					UserID:       userID,
-					ShopPublicID: publicID,
+					UserID:              userID,
+					ShopPublicID:        publicID,
+					Policy:              policy,
+					ReplacementPublicID: replacementID,
 				}
 			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrShopNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient), errors.Is(err, ErrInvalidReplacement):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		PriceHistory: &irisController.SimpleGetTemplate[PriceHistoryRequest, PriceHistoryReply, models.ShopItemPriceHistory]{
+			Handle: s.PriceHistory,
+			ParseServiceRequest: func(c iris.Context, userID string) (*PriceHistoryRequest, error) {
+				return &PriceHistoryRequest{
+					UserID:       userID,
+					ShopPublicID: c.Params().GetString("shopId"),
+					ItemName:     c.URLParam("name"),
+				}, nil
+			},
 			BadRequest: func(err error) (httpCode int, yes bool) {
 				switch {
 				case errors.Is(err, ErrShopNotFound):
@@ -108,6 +131,124 @@ func NewIrisController(s Service) *IrisController {
 				}
 				return 0, false
 			},
+			ParseAPIResponse: func(reply *PriceHistoryReply) (*models.ShopItemPriceHistory, error) {
+				return &models.ShopItemPriceHistory{
+					Prices: lo.Map(reply.Prices, func(item *ItemPrice, _ int) models.ShopItemPrice {
+						return models.ShopItemPrice{
+							DailyItemId: models.Id(item.DailyItemPublicID),
+							Date:        openapi_types.Date{Time: item.Date},
+							UnitPrice:   item.UnitPrice.String(),
+							Currency:    lo.EmptyableToPtr(item.Currency),
+							NormalizedPrice: lo.IfF(item.NormalizedPrice != nil, func() *models.NormalizedPrice {
+								return dailyitems.ToNormalizedPrice(item.NormalizedPrice)
+							}).Else(nil),
+							Change: toAPIChange(item.Change),
+						}
+					}),
+					Change: toAPIChange(reply.Change),
+				}, nil
+			},
+		},
+		Nearest: &irisController.SimpleGetTemplate[NearestRequest, NearestReply, []models.NearestShop]{
+			Handle: s.Nearest,
+			ParseServiceRequest: func(c iris.Context, userID string) (*NearestRequest, error) {
+				latitude, err := c.URLParamFloat64("latitude")
+				if err != nil {
+					return nil, fmt.Errorf("invalid latitude[%s]", c.URLParam("latitude"))
+				}
+				longitude, err := c.URLParamFloat64("longitude")
+				if err != nil {
+					return nil, fmt.Errorf("invalid longitude[%s]", c.URLParam("longitude"))
+				}
+				limit, err := c.URLParamInt("limit")
+				if err != nil {
+					if c.URLParamExists("limit") {
+						return nil, fmt.Errorf("invalid limit[%s]", c.URLParam("limit"))
+					}
+					limit = defaultNearestLimit
+				}
+				return &NearestRequest{
+					UserID: userID,
+					Coordinate: &Coordinate{
+						Latitude:  latitude,
+						Longitude: longitude,
+					},
+					Limit: limit,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidCoordinate):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *NearestReply) (*[]models.NearestShop, error) {
+				return lo.ToPtr(lo.Map(reply.Shops, func(item *NearestShop, _ int) models.NearestShop {
+					return models.NearestShop{
+						Shop:     *toAPIShop(item.Shop),
+						Location: toAPIShopLocation(item.Location),
+						Distance: item.Distance,
+					}
+				})), nil
+			},
 		},
 	}
 }
+
+func toServiceBaseShop(r *models.BasicShop) *BaseShop {
+	return &BaseShop{
+		Name:    r.Name,
+		Address: lo.FromPtr(r.Address),
+		Locations: lo.IfF(r.Locations != nil, func() []*ShopLocation {
+			return lo.Map(*r.Locations, func(item models.ShopLocation, _ int) *ShopLocation {
+				return &ShopLocation{
+					Label:   lo.FromPtr(item.Label),
+					Address: lo.FromPtr(item.Address),
+					Coordinate: lo.IfF(item.Coordinate != nil, func() *Coordinate {
+						return &Coordinate{
+							Latitude:  item.Coordinate.Latitude,
+							Longitude: item.Coordinate.Longitude,
+						}
+					}).Else(nil),
+				}
+			})
+		}).Else(nil),
+		Memo: lo.FromPtr(r.Memo),
+	}
+}
+
+func toAPIShop(v *Shop) *models.Shop {
+	return &models.Shop{
+		Id:      lo.ToPtr(models.Id(v.PublicID)),
+		Name:    v.Name,
+		Address: lo.ToPtr(v.Address),
+		Locations: lo.IfF(len(v.Locations) > 0, func() *[]models.ShopLocation {
+			return lo.ToPtr(lo.Map(v.Locations, func(item *ShopLocation, _ int) models.ShopLocation {
+				return toAPIShopLocation(item)
+			}))
+		}).Else(nil),
+		Memo: lo.ToPtr(v.Memo),
+	}
+}
+
+func toAPIShopLocation(v *ShopLocation) models.ShopLocation {
+	return models.ShopLocation{
+		Label:   lo.EmptyableToPtr(v.Label),
+		Address: lo.EmptyableToPtr(v.Address),
+		Coordinate: lo.IfF(v.Coordinate != nil, func() *models.Coordinate {
+			return &models.Coordinate{
+				Latitude:  v.Coordinate.Latitude,
+				Longitude: v.Coordinate.Longitude,
+			}
+		}).Else(nil),
+	}
+}
+
+func toAPIChange(v *decimal.Decimal) *models.Decimal {
+	if v == nil {
+		return nil
+	}
+	return lo.ToPtr(v.String())
+}
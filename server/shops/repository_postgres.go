@@ -2,7 +2,10 @@ package shops
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/repeatingitems"
 	"github.com/n101661/maney/server/repository"
 	"github.com/n101661/maney/server/repository/postgres"
 	"github.com/samber/lo"
@@ -23,12 +26,31 @@ func (repo *postgresRepository) Create(ctx context.Context, r *repository.Create
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	result, err := CreateWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWithSession is the same as Create, but it creates the shops with the session
+// so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateShopsRequest) ([]*repository.Shop, error) {
 	rows := lo.Map(r.Shops, func(item *repository.BaseCreateShop, _ int) *postgres.ShopsModel {
 		return &postgres.ShopsModel{
 			PublicID: item.PublicID,
 			UserID:   r.UserID,
 			Name:     item.Name,
 			Address:  item.Address,
+			Memo:     item.Memo,
 		}
 	})
 	_, err := session.Insert(rows)
@@ -38,9 +60,16 @@ func (repo *postgresRepository) Create(ctx context.Context, r *repository.Create
 		}
 		return nil, err
 	}
-	return lo.Map(rows, func(item *postgres.ShopsModel, _ int) *repository.Shop {
-		return toShop(item)
-	}), nil
+
+	result := make([]*repository.Shop, len(rows))
+	for i, row := range rows {
+		locations, err := insertLocations(session, row.ID, r.Shops[i].Locations)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = toShop(row, locations)
+	}
+	return result, nil
 }
 
 func (repo *postgresRepository) List(ctx context.Context, r *repository.ListShopsRequest) (*repository.ListShopsReply, error) {
@@ -57,9 +86,21 @@ func (repo *postgresRepository) List(ctx context.Context, r *repository.ListShop
 	if len(rows) == 0 {
 		return nil, repository.ErrDataNotFound
 	}
+
+	var locations []*postgres.ShopLocationsModel
+	err = session.In("shop_id", lo.Map(rows, func(item *postgres.ShopsModel, _ int) int32 {
+		return item.ID
+	})).Asc("id").Find(&locations)
+	if err != nil {
+		return nil, err
+	}
+	shopLocations := lo.GroupBy(locations, func(item *postgres.ShopLocationsModel) int32 {
+		return item.ShopID
+	})
+
 	return &repository.ListShopsReply{
 		Shops: lo.Map(rows, func(item *postgres.ShopsModel, _ int) *repository.Shop {
-			return toShop(item)
+			return toShop(item, shopLocations[item.ID])
 		}),
 	}, nil
 }
@@ -68,6 +109,10 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
 	row := postgres.ShopsModel{
 		PublicID: r.ShopPublicID,
 		UserID:   r.UserID,
@@ -83,13 +128,16 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 	cols := []string{}
 	bean := postgres.ShopsModel{}
 	if r.Shop != nil {
-		cols = append(cols, "name", "address")
+		cols = append(cols, "name", "address", "memo")
 
 		row.Name = r.Shop.Name
 		bean.Name = row.Name
 
 		row.Address = r.Shop.Address
 		bean.Address = row.Address
+
+		row.Memo = r.Shop.Memo
+		bean.Memo = row.Memo
 	}
 
 	affected, err := session.Cols(cols...).Update(&bean, &postgres.ShopsModel{
@@ -102,13 +150,37 @@ func (repo *postgresRepository) Update(ctx context.Context, r *repository.Update
 		return nil, repository.ErrDataNotFound
 	}
 
-	return toShop(&row), nil
+	var locations []*postgres.ShopLocationsModel
+	if r.Shop != nil {
+		_, err = session.Where("shop_id = ?", row.ID).Delete(&postgres.ShopLocationsModel{})
+		if err != nil {
+			return nil, err
+		}
+		locations, err = insertLocations(session, row.ID, r.Shop.Locations)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err = session.Where("shop_id = ?", row.ID).Asc("id").Find(&locations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return toShop(&row, locations), nil
 }
 
 func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteShopsRequest) ([]*repository.Shop, error) {
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
 	session.Where("user_id = ?", r.UserID)
 	if len(r.ShopPublicIDs) > 0 {
 		session.In("public_id", r.ShopPublicIDs)
@@ -127,25 +199,218 @@ func (repo *postgresRepository) Delete(ctx context.Context, r *repository.Delete
 		return nil, repository.ErrDataNotFound
 	}
 
-	_, err = session.In("id", lo.Map(rows, func(item *postgres.ShopsModel, _ int) any {
+	if err := handleReferences(session, r, rows); err != nil {
+		return nil, err
+	}
+
+	ids := lo.Map(rows, func(item *postgres.ShopsModel, _ int) any {
 		return item.ID
-	})).Table(&postgres.ShopsModel{}).Delete()
+	})
+	_, err = session.In("shop_id", ids).Delete(&postgres.ShopItemsModel{})
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.In("shop_id", ids).Delete(&postgres.AdvanceItemsModel{})
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.In("shop_id", ids).Delete(&postgres.ShopLocationsModel{})
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.In("id", ids).Table(&postgres.ShopsModel{}).Delete()
 	if err != nil {
+		if postgres.ForeignKeyViolationError(err) {
+			return nil, repository.ErrDataReferenced
+		}
 		return nil, err
 	}
 
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
 	return lo.Map(rows, func(item *postgres.ShopsModel, _ int) *repository.Shop {
-		return toShop(item)
+		return toShop(item, nil)
 	}), nil
 }
 
-func toShop(item *postgres.ShopsModel) *repository.Shop {
+// handleReferences handles the daily items and the repeating items of the deleted shops
+// by the policy.
+func handleReferences(session *xorm.Session, r *repository.DeleteShopsRequest, rows []*postgres.ShopsModel) error {
+	ids := lo.Map(rows, func(item *postgres.ShopsModel, _ int) any {
+		return item.ID
+	})
+	publicIDs := lo.Map(rows, func(item *postgres.ShopsModel, _ int) string {
+		return item.PublicID
+	})
+
+	var dailyItems []*postgres.DailyItemsModel
+	if err := session.In("shop_id", ids).Asc("id").Find(&dailyItems); err != nil {
+		return err
+	}
+	repeatingItems, err := postgres.ListReferringRepeatingItems(session, r.UserID, func(item *repository.BaseItem) bool {
+		return item.ShopPublicID != nil && lo.Contains(publicIDs, *item.ShopPublicID)
+	})
+	if err != nil {
+		return err
+	}
+
+	switch r.Policy {
+	case repository.DeletePolicyRestrict:
+		return postgres.NewReferencedError(&postgres.References{
+			DailyItems:     dailyItems,
+			RepeatingItems: repeatingItems,
+		})
+	case repository.DeletePolicyReassign:
+		replacement := postgres.ShopsModel{
+			PublicID: r.ReplacementPublicID,
+			UserID:   r.UserID,
+		}
+		has, err := session.Get(&replacement)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return repository.ErrReferenceNotFound
+		}
+		if lo.Contains(publicIDs, replacement.PublicID) {
+			return repository.ErrInvalidReference
+		}
+
+		// the recorded prices are moved with the daily items.
+		for _, bean := range []any{&postgres.DailyItemsModel{}, &postgres.ShopItemsModel{}, &postgres.AdvanceItemsModel{}} {
+			_, err := session.
+				Table(bean).
+				In("shop_id", ids).
+				Update(map[string]any{"shop_id": replacement.ID})
+			if err != nil {
+				return err
+			}
+		}
+		for _, row := range repeatingItems {
+			row.Data.Item.ShopPublicID = &replacement.PublicID
+		}
+		return postgres.UpdateRepeatingItemsData(session, repeatingItems)
+	case repository.DeletePolicyCascade:
+		if len(dailyItems) > 0 {
+			_, err := dailyitems.DeleteWithSession(session, &repository.DeleteDailyItemsRequest{
+				UserID: r.UserID,
+				DailyItemPublicIDs: lo.Map(dailyItems, func(item *postgres.DailyItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(repeatingItems) > 0 {
+			_, err := repeatingitems.DeleteWithSession(session, &repository.DeleteRepeatingItemsRequest{
+				UserID: r.UserID,
+				RepeatingItemPublicIDs: lo.Map(repeatingItems, func(item *postgres.RepeatingItemsModel, _ int) string {
+					return item.PublicID
+				}),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown delete policy[%v]", r.Policy)
+}
+
+func (repo *postgresRepository) ListItemPrices(ctx context.Context, r *repository.ListShopItemPricesRequest) (*repository.ListShopItemPricesReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	shop := postgres.ShopsModel{
+		PublicID: r.ShopPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&shop)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrReferenceNotFound
+	}
+
+	var rows []*postgres.ShopItemsModel
+	err = session.
+		Where("user_id = ? AND shop_id = ? AND name = ?", r.UserID, shop.ID, r.ItemName).
+		Asc("date", "daily_item_id").
+		Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	var items []*postgres.DailyItemsModel
+	err = session.Cols("id", "public_id").In("id", lo.Map(rows, func(item *postgres.ShopItemsModel, _ int) int32 {
+		return item.DailyItemID
+	})).Find(&items)
+	if err != nil {
+		return nil, err
+	}
+	itemPublicIDs := lo.SliceToMap(items, func(item *postgres.DailyItemsModel) (int32, string) {
+		return item.ID, item.PublicID
+	})
+
+	return &repository.ListShopItemPricesReply{
+		Prices: lo.Map(rows, func(item *postgres.ShopItemsModel, _ int) *repository.ShopItemPrice {
+			return &repository.ShopItemPrice{
+				DailyItemPublicID: itemPublicIDs[item.DailyItemID],
+				Date:              item.Date,
+				UnitPrice:         item.UnitPrice.Decimal,
+				Currency:          item.Currency,
+				NormalizedPrice:   postgres.ToNormalizedPrice(item.NormalizedPrice, item.NormalizedUnit),
+			}
+		}),
+	}, nil
+}
+
+// insertLocations inserts the locations of the shop in order.
+func insertLocations(session *xorm.Session, shopID int32, locations []*repository.ShopLocation) ([]*postgres.ShopLocationsModel, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	rows := lo.Map(locations, func(item *repository.ShopLocation, _ int) *postgres.ShopLocationsModel {
+		latitude, longitude := postgres.FromCoordinate(item.Coordinate)
+		return &postgres.ShopLocationsModel{
+			ShopID:    shopID,
+			Label:     item.Label,
+			Address:   item.Address,
+			Latitude:  latitude,
+			Longitude: longitude,
+		}
+	})
+	_, err := session.Insert(rows)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func toShop(item *postgres.ShopsModel, locations []*postgres.ShopLocationsModel) *repository.Shop {
 	return &repository.Shop{
 		ID:       item.ID,
 		PublicID: item.PublicID,
 		BaseShop: &repository.BaseShop{
 			Name:    item.Name,
 			Address: item.Address,
+			Locations: lo.IfF(len(locations) > 0, func() []*repository.ShopLocation {
+				return lo.Map(locations, func(location *postgres.ShopLocationsModel, _ int) *repository.ShopLocation {
+					return &repository.ShopLocation{
+						Label:      location.Label,
+						Address:    location.Address,
+						Coordinate: postgres.ToCoordinate(location.Latitude, location.Longitude),
+					}
+				})
+			}).Else(nil),
+			Memo: item.Memo,
 		},
 	}
 }
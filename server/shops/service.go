@@ -3,33 +3,68 @@ package shops
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/repository"
 )
 
 var (
-	ErrDataInsufficient = fmt.Errorf("data insufficient")
-	ErrShopNotFound     = fmt.Errorf("shop not found")
+	ErrDataInsufficient   = fmt.Errorf("data insufficient")
+	ErrShopNotFound       = fmt.Errorf("shop not found")
+	ErrInvalidCoordinate  = fmt.Errorf("invalid coordinate")
+	ErrShopReferenced     = fmt.Errorf("shop is referenced")
+	ErrInvalidReplacement = fmt.Errorf("invalid replacement")
 )
 
 type Service interface {
-	// Create returns ErrDataInsufficient if any of fields of CreateRequest is zero-value.
+	// Create returns error:
+	//  - ErrDataInsufficient if any of fields of CreateRequest is zero-value,
+	//  - ErrInvalidCoordinate if the coordinate of any location is out of range.
 	Create(context.Context, *CreateRequest) (*CreateReply, error)
 	// List returns ErrDataInsufficient if any of fields of ListRequest is zero-value,
 	List(context.Context, *ListRequest) (*ListReply, error)
-	// Update returns error:
+	// Update replaces the locations of the shop, it returns error:
 	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
+	//  - ErrInvalidCoordinate if the coordinate of any location is out of range,
 	//  - ErrShopNotFound if the shop does not exist.
 	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
-	// Delete returns error:
-	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
-	//  - ErrShopNotFound if the shop does not exist.
+	// Delete deletes the shop and handles its references by the policy, it returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value, or the
+	//    replacement is missing while the policy is DeletePolicyReassign,
+	//  - ErrShopNotFound if the shop does not exist,
+	//  - ErrShopReferenced wrapping *repository.ReferencedError if the shop is referenced
+	//    while the policy is DeletePolicyRestrict,
+	//  - ErrInvalidReplacement if the replacement does not exist or is not another shop.
+	// The locations of the shop are deleted and its recorded prices are moved to the
+	// replacement.
 	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+	// PriceHistory returns the unit prices of the item at the shop in the order of date,
+	// the prices are recorded only while the user compares the items in the same shop.
+	// It returns error:
+	//  - ErrDataInsufficient if any of fields of PriceHistoryRequest is zero-value,
+	//  - ErrShopNotFound if the shop does not exist.
+	PriceHistory(context.Context, *PriceHistoryRequest) (*PriceHistoryReply, error)
+	// Nearest returns the shops which have the locations on the map, sorted by the
+	// haversine distance from their nearest locations to the coordinate. It returns error:
+	//  - ErrDataInsufficient if any of fields of NearestRequest is zero-value,
+	//  - ErrInvalidCoordinate if the coordinate is out of range.
+	Nearest(context.Context, *NearestRequest) (*NearestReply, error)
 }
 
 type BaseShop struct {
 	Name    string
 	Address string
+	// Locations are the branches of the shop.
+	Locations []*ShopLocation
+	Memo      string
 }
 
+type ShopLocation = repository.ShopLocation
+
+type Coordinate = repository.Coordinate
+
 type Shop struct {
 	ID       int32
 	PublicID string
@@ -66,6 +101,61 @@ type UpdateReply struct {
 type DeleteRequest struct {
 	UserID       string
 	ShopPublicID string
+
+	Policy DeletePolicy
+	// ReplacementPublicID is the shop which the references are reassigned to, it is
+	// required while the policy is DeletePolicyReassign.
+	ReplacementPublicID string
 }
 
+type DeletePolicy = repository.DeletePolicy
+
 type DeleteReply struct{}
+
+type PriceHistoryRequest struct {
+	UserID       string
+	ShopPublicID string
+	ItemName     string
+}
+
+type PriceHistoryReply struct {
+	Prices []*ItemPrice
+	// Change is the percentage change from the first price to the latest one. It is nil
+	// if there is no comparable prices, see ItemPrice.Change.
+	Change *decimal.Decimal
+}
+
+type ItemPrice struct {
+	DailyItemPublicID string
+	Date              time.Time
+	UnitPrice         decimal.Decimal
+	Currency          string
+	// NormalizedPrice is nil if the quantity of the item has no unit.
+	NormalizedPrice *NormalizedPrice
+	// Change is the percentage change from the previous price. It is nil for the first
+	// price, or the previous price is zero or in different currency. The normalized prices
+	// are compared instead of the unit prices if both prices have them in the same unit.
+	Change *decimal.Decimal
+}
+
+type NormalizedPrice = repository.NormalizedPrice
+
+type NearestRequest struct {
+	UserID     string
+	Coordinate *Coordinate
+	// Limit is the maximum number of the shops.
+	Limit int
+}
+
+type NearestReply struct {
+	// Shops are sorted by the distance in ascending order.
+	Shops []*NearestShop
+}
+
+type NearestShop struct {
+	Shop *Shop
+	// Location is the nearest location of the shop.
+	Location *ShopLocation
+	// Distance is in meters.
+	Distance float64
+}
@@ -1,14 +1,18 @@
 package shops
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 
 	"github.com/n101661/maney/pkg/utils"
 	"github.com/n101661/maney/pkg/utils/slugid"
 	"github.com/n101661/maney/server/repository"
 	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 )
 
 type service struct {
@@ -34,6 +38,9 @@ func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, e
 	if r.Shop == nil {
 		return nil, fmt.Errorf("%w: missing shop", ErrDataInsufficient)
 	}
+	if err := validateLocations(r.Shop.Locations); err != nil {
+		return nil, err
+	}
 
 	rows, err := s.repository.Create(ctx, &repository.CreateShopsRequest{
 		UserID: r.UserID,
@@ -84,6 +91,9 @@ func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, e
 	if r.Shop == nil {
 		return nil, fmt.Errorf("%w: nothing to update", ErrDataInsufficient)
 	}
+	if err := validateLocations(r.Shop.Locations); err != nil {
+		return nil, err
+	}
 
 	row, err := s.repository.Update(ctx, &repository.UpdateShopRequest{
 		UserID:       r.UserID,
@@ -109,27 +119,192 @@ func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, e
 	if r.ShopPublicID == "" {
 		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
 	}
+	if r.Policy == repository.DeletePolicyReassign && r.ReplacementPublicID == "" {
+		return nil, fmt.Errorf("%w: missing replacement id", ErrDataInsufficient)
+	}
 
 	_, err := s.repository.Delete(ctx, &repository.DeleteShopsRequest{
-		ShopPublicIDs: []string{r.ShopPublicID},
-		UserID:        r.UserID,
+		ShopPublicIDs:       []string{r.ShopPublicID},
+		UserID:              r.UserID,
+		Policy:              r.Policy,
+		ReplacementPublicID: r.ReplacementPublicID,
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrDataNotFound) {
 			return nil, ErrShopNotFound
 		}
+		if errors.Is(err, repository.ErrDataReferenced) {
+			return nil, fmt.Errorf("%w: %w", ErrShopReferenced, err)
+		}
+		if errors.Is(err, repository.ErrReferenceNotFound) ||
+			errors.Is(err, repository.ErrInvalidReference) {
+			return nil, ErrInvalidReplacement
+		}
 		return nil, err
 	}
 	return &DeleteReply{}, nil
 }
 
+func (s *service) PriceHistory(ctx context.Context, r *PriceHistoryRequest) (*PriceHistoryReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.ShopPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+	if r.ItemName == "" {
+		return nil, fmt.Errorf("%w: missing item name", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.ListItemPrices(ctx, &repository.ListShopItemPricesRequest{
+		UserID:       r.UserID,
+		ShopPublicID: r.ShopPublicID,
+		ItemName:     r.ItemName,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrReferenceNotFound) {
+			return nil, ErrShopNotFound
+		}
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &PriceHistoryReply{
+				Prices: []*ItemPrice{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	prices := make([]*ItemPrice, len(reply.Prices))
+	for i, v := range reply.Prices {
+		prices[i] = &ItemPrice{
+			DailyItemPublicID: v.DailyItemPublicID,
+			Date:              v.Date,
+			UnitPrice:         v.UnitPrice,
+			Currency:          v.Currency,
+			NormalizedPrice:   v.NormalizedPrice,
+		}
+		if i > 0 {
+			prices[i].Change = percentChange(prices[i-1], prices[i])
+		}
+	}
+
+	var change *decimal.Decimal
+	if len(prices) > 1 {
+		change = percentChange(prices[0], prices[len(prices)-1])
+	}
+	return &PriceHistoryReply{
+		Prices: prices,
+		Change: change,
+	}, nil
+}
+
+// percentChange returns nil if the prices are not comparable. It compares the normalized
+// prices if both prices have them in the same unit, so that the prices of the different
+// sizes are comparable.
+func percentChange(from, to *ItemPrice) *decimal.Decimal {
+	if from.Currency != to.Currency {
+		return nil
+	}
+
+	fromPrice, toPrice := from.UnitPrice, to.UnitPrice
+	if from.NormalizedPrice != nil && to.NormalizedPrice != nil &&
+		from.NormalizedPrice.Unit == to.NormalizedPrice.Unit {
+		fromPrice, toPrice = from.NormalizedPrice.Price, to.NormalizedPrice.Price
+	}
+	if fromPrice.IsZero() {
+		return nil
+	}
+	return lo.ToPtr(toPrice.Sub(fromPrice).Div(fromPrice).Mul(decimal.NewFromInt(100)).Round(2))
+}
+
+func (s *service) Nearest(ctx context.Context, r *NearestRequest) (*NearestReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.Coordinate == nil {
+		return nil, fmt.Errorf("%w: missing coordinate", ErrDataInsufficient)
+	}
+	if r.Limit <= 0 {
+		return nil, fmt.Errorf("%w: missing limit", ErrDataInsufficient)
+	}
+	if !r.Coordinate.Valid() {
+		return nil, ErrInvalidCoordinate
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListShopsRequest{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &NearestReply{
+				Shops: []*NearestShop{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	shops := []*NearestShop{}
+	for _, shop := range reply.Shops {
+		var nearest *NearestShop
+		for _, location := range shop.Locations {
+			if location.Coordinate == nil {
+				continue
+			}
+			distance := haversineDistance(r.Coordinate, location.Coordinate)
+			if nearest == nil || distance < nearest.Distance {
+				nearest = &NearestShop{
+					Location: location,
+					Distance: distance,
+				}
+			}
+		}
+		if nearest != nil {
+			nearest.Shop = parseShop(shop)
+			shops = append(shops, nearest)
+		}
+	}
+	slices.SortStableFunc(shops, func(a, b *NearestShop) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+
+	return &NearestReply{
+		Shops: shops[:min(len(shops), r.Limit)],
+	}, nil
+}
+
+// earthRadius is the mean radius of the earth in meters.
+const earthRadius = 6371008.8
+
+// haversineDistance returns the great-circle distance between the coordinates in meters.
+func haversineDistance(a, b *Coordinate) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func validateLocations(locations []*ShopLocation) error {
+	for i, location := range locations {
+		if location == nil {
+			return fmt.Errorf("%w: missing locations[%d]", ErrDataInsufficient, i)
+		}
+		if location.Coordinate != nil && !location.Coordinate.Valid() {
+			return fmt.Errorf("%w: locations[%d]", ErrInvalidCoordinate, i)
+		}
+	}
+	return nil
+}
+
 func parseShop(v *repository.Shop) *Shop {
 	return &Shop{
 		ID:       v.ID,
 		PublicID: v.PublicID,
 		BaseShop: &BaseShop{
-			Name:    v.Name,
-			Address: v.Address,
+			Name:      v.Name,
+			Address:   v.Address,
+			Locations: v.Locations,
+			Memo:      v.Memo,
 		},
 	}
 }
@@ -139,8 +314,10 @@ func parseBaseShop(v *BaseShop) *repository.BaseShop {
 		return nil
 	}
 	return &repository.BaseShop{
-		Name:    v.Name,
-		Address: v.Address,
+		Name:      v.Name,
+		Address:   v.Address,
+		Locations: v.Locations,
+		Memo:      v.Memo,
 	}
 }
 
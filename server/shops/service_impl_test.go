@@ -3,7 +3,10 @@ package shops
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
@@ -77,6 +80,29 @@ func Test_service_Create(t *testing.T) {
 			},
 		}, reply)
 	})
+	t.Run("invalid coordinate", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Shop: &BaseShop{
+				Name: "A",
+				Locations: []*ShopLocation{
+					{Label: "Main", Coordinate: &Coordinate{Latitude: 91, Longitude: 121}},
+				},
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidCoordinate)
+		assert.Nil(reply)
+	})
 }
 
 func Test_service_List(t *testing.T) {
@@ -332,4 +358,346 @@ func Test_service_Delete(t *testing.T) {
 		assert.ErrorIs(err, ErrShopNotFound)
 		assert.Nil(reply)
 	})
+	t.Run("delete policies", func(t *testing.T) {
+		referenced := &repository.ReferencedError{References: []*repository.Reference{
+			{Type: repository.ReferenceTypeDailyItem, PublicID: "dailyItemID"},
+			{Type: repository.ReferenceTypeRepeatingItem, PublicID: "repeatingItemID"},
+		}}
+		tests := []struct {
+			name        string
+			policy      repository.DeletePolicy
+			replacement string
+			// repoErr is returned by the repository, which is not called if the request is
+			// insufficient.
+			repoErr error
+			wantErr error
+		}{
+			{name: "referenced shop is restricted", policy: repository.DeletePolicyRestrict, repoErr: referenced, wantErr: ErrShopReferenced},
+			{name: "missing replacement", policy: repository.DeletePolicyReassign, wantErr: ErrDataInsufficient},
+			{name: "invalid replacement", policy: repository.DeletePolicyReassign, replacement: "2", repoErr: repository.ErrReferenceNotFound, wantErr: ErrInvalidReplacement},
+			{name: "references are cascaded", policy: repository.DeletePolicyCascade},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				controller := gomock.NewController(t)
+				mockRepo := repository.NewMockShopRepository(controller)
+				if tt.wantErr != ErrDataInsufficient {
+					mockRepo.EXPECT().
+						Delete(gomock.Any(), &repository.DeleteShopsRequest{
+							UserID:              "userID",
+							ShopPublicIDs:       []string{"1"},
+							Policy:              tt.policy,
+							ReplacementPublicID: tt.replacement,
+						}).
+						Return(nil, tt.repoErr)
+				}
+
+				s, err := NewService(mockRepo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Delete(context.Background(), &DeleteRequest{
+					UserID:              "userID",
+					ShopPublicID:        "1",
+					Policy:              tt.policy,
+					ReplacementPublicID: tt.replacement,
+				})
+				if tt.wantErr == nil {
+					assert.NoError(err)
+					assert.Equal(&DeleteReply{}, reply)
+					return
+				}
+				assert.ErrorIs(err, tt.wantErr)
+				if tt.repoErr == referenced {
+					// the references are kept for the response of the conflict.
+					assert.ErrorIs(err, referenced)
+				}
+				assert.Nil(reply)
+			})
+		}
+	})
+}
+
+func Test_service_PriceHistory(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "publicID"
+		itemName = "milk"
+	)
+	var (
+		jan1 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		jan2 = time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		jan3 = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("list successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				ListItemPrices(gomock.Any(), &repository.ListShopItemPricesRequest{
+					UserID:       userID,
+					ShopPublicID: publicID,
+					ItemName:     itemName,
+				}).
+				Return(&repository.ListShopItemPricesReply{
+					Prices: []*repository.ShopItemPrice{
+						{DailyItemPublicID: "item1", Date: jan1, UnitPrice: decimal.NewFromInt(40)},
+						{DailyItemPublicID: "item2", Date: jan2, UnitPrice: decimal.NewFromInt(45)},
+						{DailyItemPublicID: "item3", Date: jan3, UnitPrice: decimal.NewFromInt(3), Currency: "USD"},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.PriceHistory(context.Background(), &PriceHistoryRequest{
+			UserID:       userID,
+			ShopPublicID: publicID,
+			ItemName:     itemName,
+		})
+		assert.NoError(err)
+		assert.Equal(&PriceHistoryReply{
+			Prices: []*ItemPrice{
+				{DailyItemPublicID: "item1", Date: jan1, UnitPrice: decimal.NewFromInt(40)},
+				{
+					DailyItemPublicID: "item2",
+					Date:              jan2,
+					UnitPrice:         decimal.NewFromInt(45),
+					Change:            lo.ToPtr(decimal.RequireFromString("12.50")),
+				},
+				{DailyItemPublicID: "item3", Date: jan3, UnitPrice: decimal.NewFromInt(3), Currency: "USD"},
+			},
+		}, reply)
+	})
+	t.Run("percentage change from the first price", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().ListItemPrices(gomock.Any(), gomock.Any()).Return(&repository.ListShopItemPricesReply{
+				Prices: []*repository.ShopItemPrice{
+					{DailyItemPublicID: "item1", Date: jan1, UnitPrice: decimal.NewFromInt(40)},
+					{DailyItemPublicID: "item2", Date: jan2, UnitPrice: decimal.NewFromInt(30)},
+				},
+			}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.PriceHistory(context.Background(), &PriceHistoryRequest{
+			UserID:       userID,
+			ShopPublicID: publicID,
+			ItemName:     itemName,
+		})
+		assert.NoError(err)
+		assert.Equal(lo.ToPtr(decimal.RequireFromString("-25.00")), reply.Change)
+	})
+	t.Run("compare the normalized prices of different sizes", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().ListItemPrices(gomock.Any(), gomock.Any()).Return(&repository.ListShopItemPricesReply{
+				Prices: []*repository.ShopItemPrice{
+					{
+						DailyItemPublicID: "item1",
+						Date:              jan1,
+						UnitPrice:         decimal.NewFromInt(40),
+						NormalizedPrice: &repository.NormalizedPrice{
+							Price:    decimal.NewFromInt(4),
+							Quantity: decimal.NewFromInt(100),
+							Unit:     repository.UnitMilliliter,
+						},
+					},
+					{
+						DailyItemPublicID: "item2",
+						Date:              jan2,
+						UnitPrice:         decimal.NewFromInt(90),
+						NormalizedPrice: &repository.NormalizedPrice{
+							Price:    decimal.NewFromInt(3),
+							Quantity: decimal.NewFromInt(100),
+							Unit:     repository.UnitMilliliter,
+						},
+					},
+				},
+			}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.PriceHistory(context.Background(), &PriceHistoryRequest{
+			UserID:       userID,
+			ShopPublicID: publicID,
+			ItemName:     itemName,
+		})
+		assert.NoError(err)
+		assert.Equal(lo.ToPtr(decimal.RequireFromString("-25.00")), reply.Change)
+	})
+	t.Run("no recorded price", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().ListItemPrices(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.PriceHistory(context.Background(), &PriceHistoryRequest{
+			UserID:       userID,
+			ShopPublicID: publicID,
+			ItemName:     itemName,
+		})
+		assert.NoError(err)
+		assert.Equal(&PriceHistoryReply{
+			Prices: []*ItemPrice{},
+		}, reply)
+	})
+	t.Run("shop not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().ListItemPrices(gomock.Any(), gomock.Any()).Return(nil, repository.ErrReferenceNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.PriceHistory(context.Background(), &PriceHistoryRequest{
+			UserID:       userID,
+			ShopPublicID: publicID,
+			ItemName:     itemName,
+		})
+		assert.ErrorIs(err, ErrShopNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Nearest(t *testing.T) {
+	const userID = "user-id"
+
+	var (
+		taipeiMainStation = &ShopLocation{
+			Label:      "Taipei Main Station",
+			Coordinate: &Coordinate{Latitude: 25.0478, Longitude: 121.5170},
+		}
+		taipei101 = &ShopLocation{
+			Label:      "Taipei 101",
+			Coordinate: &Coordinate{Latitude: 25.0340, Longitude: 121.5645},
+		}
+		kaohsiung = &ShopLocation{
+			Label:      "Kaohsiung",
+			Coordinate: &Coordinate{Latitude: 22.6273, Longitude: 120.3014},
+		}
+		online = &ShopLocation{Label: "Online"}
+	)
+
+	t.Run("list nearest shops", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListShopsRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListShopsReply{
+					Shops: []*repository.Shop{
+						{ID: 1, PublicID: "shopA", BaseShop: &repository.BaseShop{Name: "A", Locations: []*ShopLocation{kaohsiung, taipei101}}},
+						{ID: 2, PublicID: "shopB", BaseShop: &repository.BaseShop{Name: "B", Locations: []*ShopLocation{taipeiMainStation}}},
+						{ID: 3, PublicID: "shopC", BaseShop: &repository.BaseShop{Name: "C", Locations: []*ShopLocation{online}}},
+						{ID: 4, PublicID: "shopD", BaseShop: &repository.BaseShop{Name: "D", Locations: []*ShopLocation{kaohsiung}}},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// near Taipei Main Station.
+		reply, err := s.Nearest(context.Background(), &NearestRequest{
+			UserID:     userID,
+			Coordinate: &Coordinate{Latitude: 25.0460, Longitude: 121.5170},
+			Limit:      2,
+		})
+		assert.NoError(err)
+		assert.Len(reply.Shops, 2)
+		assert.Equal("shopB", reply.Shops[0].Shop.PublicID)
+		assert.Equal(taipeiMainStation, reply.Shops[0].Location)
+		assert.InDelta(200, reply.Shops[0].Distance, 1)
+		assert.Equal("shopA", reply.Shops[1].Shop.PublicID)
+		assert.Equal(taipei101, reply.Shops[1].Location)
+		assert.InDelta(4960, reply.Shops[1].Distance, 50)
+	})
+	t.Run("no shop", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Nearest(context.Background(), &NearestRequest{
+			UserID:     userID,
+			Coordinate: &Coordinate{Latitude: 25.0460, Longitude: 121.5170},
+			Limit:      10,
+		})
+		assert.NoError(err)
+		assert.Equal(&NearestReply{
+			Shops: []*NearestShop{},
+		}, reply)
+	})
+	t.Run("invalid coordinate", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockShopRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Nearest(context.Background(), &NearestRequest{
+			UserID:     userID,
+			Coordinate: &Coordinate{Latitude: 25.0460, Longitude: 181},
+			Limit:      10,
+		})
+		assert.ErrorIs(err, ErrInvalidCoordinate)
+		assert.Nil(reply)
+	})
 }
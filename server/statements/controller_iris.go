@@ -0,0 +1,173 @@
+package statements
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	List  *irisController.SimpleGetTemplate[ListRequest, ListReply, models.StatementList]
+	Pay   *irisController.SimpleHandleTemplate[PayRequest, PayReply, models.StatementPayment]
+	Unpay *irisController.SimpleHandleTemplate[UnpayRequest, UnpayReply, models.EmptyResponse]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		List: &irisController.SimpleGetTemplate[ListRequest, ListReply, models.StatementList]{
+			Handle: s.List,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				from, err := parseDate(c.URLParam("from"))
+				if err != nil {
+					return nil, err
+				}
+				to, err := parseDate(c.URLParam("to"))
+				if err != nil {
+					return nil, err
+				}
+				return &ListRequest{
+					UserID:          userID,
+					AccountPublicID: c.Params().GetString("accountId"),
+					From:            from,
+					To:              to,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrAccountNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidPeriod),
+					errors.Is(err, ErrNotCreditCard):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*models.StatementList, error) {
+				return &models.StatementList{
+					Statements: lo.Map(reply.Statements, func(item *Statement, _ int) models.Statement {
+						return toAPIStatement(item)
+					}),
+					Unpaid: reply.Unpaid.String(),
+				}, nil
+			},
+		},
+		Pay: &irisController.SimpleHandleTemplate[PayRequest, PayReply, models.StatementPayment]{
+			Service: s.Pay,
+			ParseServiceRequest: func(c iris.Context, userID string) (*PayRequest, error) {
+				closingDate, err := parseDate(c.Params().GetString("closingDate"))
+				if err != nil {
+					return nil, err
+				}
+				var r models.StatementPaymentRequest
+				if err := c.ReadJSON(&r); err != nil {
+					return nil, fmt.Errorf("invalid request: %v", err)
+				}
+				var amount *decimal.Decimal
+				if r.Amount != nil {
+					v, err := decimal.NewFromString(*r.Amount)
+					if err != nil {
+						return nil, fmt.Errorf("invalid decimal[%s]", *r.Amount)
+					}
+					amount = &v
+				}
+				return &PayRequest{
+					UserID:              userID,
+					AccountPublicID:     c.Params().GetString("accountId"),
+					ClosingDate:         closingDate,
+					Date:                r.Date.Time,
+					Amount:              amount,
+					FromAccountPublicID: r.FromAccountId,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrAccountNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrStatementPaid):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrNotCreditCard),
+					errors.Is(err, ErrInvalidClosingDate),
+					errors.Is(err, ErrSameAccount),
+					errors.Is(err, ErrInvalidAmount),
+					errors.Is(err, ErrReferenceNotFound),
+					errors.Is(err, ErrCurrencyMismatch):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *PayReply) (*models.StatementPayment, error) {
+				return toAPIStatementPayment(reply.Payment), nil
+			},
+		},
+		Unpay: &irisController.SimpleHandleTemplate[UnpayRequest, UnpayReply, models.EmptyResponse]{
+			Service: s.Unpay,
+			ParseServiceRequest: func(c iris.Context, userID string) (*UnpayRequest, error) {
+				closingDate, err := parseDate(c.Params().GetString("closingDate"))
+				if err != nil {
+					return nil, err
+				}
+				return &UnpayRequest{
+					UserID:          userID,
+					AccountPublicID: c.Params().GetString("accountId"),
+					ClosingDate:     closingDate,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrStatementNotPaid):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *UnpayReply) (*models.EmptyResponse, error) {
+				return &models.EmptyResponse{}, nil
+			},
+		},
+	}
+}
+
+func toAPIStatement(v *Statement) models.Statement {
+	return models.Statement{
+		StartDate:   openapi_types.Date{Time: v.StartDate},
+		ClosingDate: openapi_types.Date{Time: v.ClosingDate},
+		DueDate: lo.IfF(v.DueDate != nil, func() *openapi_types.Date {
+			return &openapi_types.Date{Time: *v.DueDate}
+		}).Else(nil),
+		Balance: v.Balance.String(),
+		Items: lo.Map(v.Items, func(item *DailyItem, _ int) models.DailyItem {
+			return *dailyitems.ToDailyItem(item)
+		}),
+		Payment: lo.IfF(v.Payment != nil, func() *models.StatementPayment {
+			return toAPIStatementPayment(v.Payment)
+		}).Else(nil),
+	}
+}
+
+func toAPIStatementPayment(v *Payment) *models.StatementPayment {
+	return &models.StatementPayment{
+		Date:       openapi_types.Date{Time: v.Date},
+		Amount:     v.Amount.String(),
+		TransferId: v.TransferPublicID,
+	}
+}
+
+func parseDate(s string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date[%s]", s)
+	}
+	return date, nil
+}
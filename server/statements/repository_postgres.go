@@ -0,0 +1,220 @@
+package statements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/transfers"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.StatementRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) CreatePayment(ctx context.Context, r *repository.CreateStatementPaymentRequest) (*repository.StatementPayment, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	result, err := CreatePaymentWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreatePaymentWithSession is the same as CreatePayment, but it creates the payment with
+// the session so that it can be created in the transaction of the session.
+func CreatePaymentWithSession(session *xorm.Session, r *repository.CreateStatementPaymentRequest) (*repository.StatementPayment, error) {
+	accountID, err := resolveAccount(session, r.UserID, r.AccountPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &postgres.StatementPaymentsModel{
+		UserID:      r.UserID,
+		AccountID:   accountID,
+		ClosingDate: r.Payment.ClosingDate,
+		Date:        r.Payment.Date,
+		Amount:      decimal.NewNullDecimal(r.Payment.Amount),
+	}
+	var transferPublicID *string
+	if r.Transfer != nil {
+		transfer, err := transfers.CreateWithSession(session, r.Transfer)
+		if err != nil {
+			return nil, err
+		}
+		row.TransferID = postgres.ToNullInt32(&transfer.ID)
+		transferPublicID = &transfer.PublicID
+	}
+
+	if _, err := session.Insert(row); err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	return &repository.StatementPayment{
+		ID:                   row.ID,
+		AccountPublicID:      r.AccountPublicID,
+		BaseStatementPayment: r.Payment,
+		TransferPublicID:     transferPublicID,
+	}, nil
+}
+
+func (repo *postgresRepository) ListPayments(ctx context.Context, r *repository.ListStatementPaymentsRequest) (*repository.ListStatementPaymentsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	accountID, err := resolveAccount(session, r.UserID, r.AccountPublicID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReferenceNotFound) {
+			return nil, repository.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	session.Where("user_id = ? AND account_id = ?", r.UserID, accountID)
+	if r.From != nil {
+		session.And("closing_date >= ?", r.From.Format(time.DateOnly))
+	}
+	if r.To != nil {
+		session.And("closing_date < ?", r.To.Format(time.DateOnly))
+	}
+
+	var rows []*postgres.StatementPaymentsModel
+	err = session.Asc("closing_date").Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	payments, err := loadPayments(session, r.AccountPublicID, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.ListStatementPaymentsReply{
+		Payments: payments,
+	}, nil
+}
+
+func (repo *postgresRepository) DeletePayment(ctx context.Context, r *repository.DeleteStatementPaymentRequest) (*repository.StatementPayment, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	accountID, err := resolveAccount(session, r.UserID, r.AccountPublicID)
+	if err != nil {
+		if errors.Is(err, repository.ErrReferenceNotFound) {
+			return nil, repository.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	var row postgres.StatementPaymentsModel
+	has, err := session.
+		Where("user_id = ? AND account_id = ? AND closing_date = ?", r.UserID, accountID, r.ClosingDate.Format(time.DateOnly)).
+		Get(&row)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrDataNotFound
+	}
+
+	payments, err := loadPayments(session, r.AccountPublicID, []*postgres.StatementPaymentsModel{&row})
+	if err != nil {
+		return nil, err
+	}
+
+	if payment := payments[0]; payment.TransferPublicID != nil {
+		// the payment is deleted with its transfer.
+		_, err = transfers.DeleteWithSession(session, &repository.DeleteTransfersRequest{
+			UserID:            r.UserID,
+			TransferPublicIDs: []string{*payment.TransferPublicID},
+		})
+	} else {
+		_, err = session.Delete(&postgres.StatementPaymentsModel{
+			ID: row.ID,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return payments[0], nil
+}
+
+// resolveAccount returns the id of the account of the user, it returns ErrReferenceNotFound
+// if the account does not exist.
+func resolveAccount(session *xorm.Session, userID, publicID string) (int32, error) {
+	account := postgres.AccountsModel{PublicID: publicID, UserID: userID}
+	has, err := session.Cols("id").Get(&account)
+	if err != nil {
+		return 0, err
+	}
+	if !has {
+		return 0, repository.ErrReferenceNotFound
+	}
+	return account.ID, nil
+}
+
+// loadPayments fills the referenced public ids of the rows.
+func loadPayments(session *xorm.Session, accountPublicID string, rows []*postgres.StatementPaymentsModel) ([]*repository.StatementPayment, error) {
+	transferPublicIDs := map[int32]string{}
+	if transferIDs := lo.Uniq(lo.FilterMap(rows, func(item *postgres.StatementPaymentsModel, _ int) (int32, bool) {
+		return item.TransferID.Int32, item.TransferID.Valid
+	})); len(transferIDs) > 0 {
+		var transferRows []*postgres.TransfersModel
+		err := session.In("id", transferIDs).Find(&transferRows)
+		if err != nil {
+			return nil, err
+		}
+		for _, transfer := range transferRows {
+			transferPublicIDs[transfer.ID] = transfer.PublicID
+		}
+	}
+
+	return lo.Map(rows, func(item *postgres.StatementPaymentsModel, _ int) *repository.StatementPayment {
+		return &repository.StatementPayment{
+			ID:              item.ID,
+			AccountPublicID: accountPublicID,
+			BaseStatementPayment: &repository.BaseStatementPayment{
+				ClosingDate: item.ClosingDate,
+				Date:        item.Date,
+				Amount:      item.Amount.Decimal,
+			},
+			TransferPublicID: lo.IfF(item.TransferID.Valid, func() *string {
+				return lo.ToPtr(transferPublicIDs[item.TransferID.Int32])
+			}).Else(nil),
+		}
+	}), nil
+}
@@ -0,0 +1,122 @@
+package statements
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/dailyitems"
+)
+
+var (
+	ErrDataInsufficient   = fmt.Errorf("data insufficient")
+	ErrAccountNotFound    = fmt.Errorf("account not found")
+	ErrNotCreditCard      = fmt.Errorf("account is not a credit card with the statement closing day")
+	ErrInvalidClosingDate = fmt.Errorf("date is not a closing date of the credit card")
+	ErrInvalidPeriod      = fmt.Errorf("from must not be after to")
+	ErrStatementPaid      = fmt.Errorf("statement has been paid")
+	ErrStatementNotPaid   = fmt.Errorf("statement is not paid")
+	ErrReferenceNotFound  = fmt.Errorf("referenced account not found")
+	ErrInvalidAmount      = fmt.Errorf("amount must be positive")
+	ErrSameAccount        = fmt.Errorf("credit card cannot pay its own statement")
+	ErrCurrencyMismatch   = fmt.Errorf("paying account is not in the currency of the credit card")
+)
+
+type Service interface {
+	// List returns the statements of the credit card whose closing dates are in the period,
+	// the statements are sorted by the closing date. It returns error:
+	//  - ErrDataInsufficient if any of fields of ListRequest is zero-value,
+	//  - ErrInvalidPeriod if from is after to,
+	//  - ErrAccountNotFound if the account does not exist,
+	//  - ErrNotCreditCard if the account is not a credit card or its statement closing day is not set.
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Pay marks the statement as paid. The amount is moved from the paying account to the
+	// credit card by a transfer if the paying account is provided. It returns error:
+	//  - ErrDataInsufficient if any of required fields of PayRequest is zero-value,
+	//  - ErrAccountNotFound if the account does not exist,
+	//  - ErrNotCreditCard if the account is not a credit card or its statement closing day is not set,
+	//  - ErrInvalidClosingDate if the date is not a closing date of the credit card,
+	//  - ErrSameAccount if the paying account is the credit card,
+	//  - ErrInvalidAmount if the amount to be transferred is not positive,
+	//  - ErrReferenceNotFound if the paying account does not exist,
+	//  - ErrCurrencyMismatch if the paying account is not in the currency of the credit card,
+	//  - ErrStatementPaid if the statement has been paid.
+	Pay(context.Context, *PayRequest) (*PayReply, error)
+	// Unpay marks the statement as unpaid, the transfer of the payment is deleted. It
+	// returns error:
+	//  - ErrDataInsufficient if any of fields of UnpayRequest is zero-value,
+	//  - ErrStatementNotPaid if the statement is not paid or the account does not exist.
+	Unpay(context.Context, *UnpayRequest) (*UnpayReply, error)
+}
+
+type Statement struct {
+	// StartDate is the first date of the cycle, it is the next day of the previous
+	// closing date.
+	StartDate time.Time
+	// ClosingDate is the last date of the cycle.
+	ClosingDate time.Time
+	// DueDate is the first payment due day after the closing date, it is nil if the
+	// payment due day of the credit card is not set.
+	DueDate *time.Time
+	// Balance is the expense minus the income of the credit card in the cycle, it is in
+	// the currency of the credit card.
+	Balance decimal.Decimal
+	// Items are the daily items of the credit card in the cycle, sorted by date and id.
+	Items []*DailyItem
+	// Payment is nil if the statement is not paid.
+	Payment *Payment
+}
+
+type Payment struct {
+	// Date is the date of the payment.
+	Date   time.Time
+	Amount decimal.Decimal
+	// TransferPublicID is the transfer which pays the statement, it is nil if the statement
+	// is marked as paid without the transfer.
+	TransferPublicID *string
+}
+
+type DailyItem = dailyitems.DailyItem
+
+type ListRequest struct {
+	UserID          string
+	AccountPublicID string
+	// From is the first closing date of the period, inclusive.
+	From time.Time
+	// To is the last closing date of the period, inclusive.
+	To time.Time
+}
+
+type ListReply struct {
+	Statements []*Statement
+	// Unpaid is the sum of the balances of the unpaid statements, which is the amount
+	// owed on the credit card.
+	Unpaid decimal.Decimal
+}
+
+type PayRequest struct {
+	UserID          string
+	AccountPublicID string
+	ClosingDate     time.Time
+	// Date is the date of the payment.
+	Date time.Time
+	// Amount is the paid amount, it is the balance of the statement if it is nil.
+	Amount *decimal.Decimal
+	// FromAccountPublicID is the account which pays the statement, the statement is
+	// marked as paid without the transfer if it is nil.
+	FromAccountPublicID *string
+}
+
+type PayReply struct {
+	Payment *Payment
+}
+
+type UnpayRequest struct {
+	UserID          string
+	AccountPublicID string
+	ClosingDate     time.Time
+}
+
+type UnpayReply struct{}
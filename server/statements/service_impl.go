@@ -0,0 +1,347 @@
+package statements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/dailyitems"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	repository          repository.StatementRepository
+	accountRepository   repository.AccountRepository
+	dailyItemRepository repository.DailyItemRepository
+
+	opts *StatementServiceOptions
+}
+
+func NewService(
+	repository repository.StatementRepository,
+	accountRepository repository.AccountRepository,
+	dailyItemRepository repository.DailyItemRepository,
+	opts ...utils.Option[StatementServiceOptions],
+) (Service, error) {
+	return &service{
+		repository:          repository,
+		accountRepository:   accountRepository,
+		dailyItemRepository: dailyItemRepository,
+		opts:                utils.ApplyOptions(defaultStatementServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.AccountPublicID == "" {
+		return nil, fmt.Errorf("%w: missing account id", ErrDataInsufficient)
+	}
+	if r.From.IsZero() {
+		return nil, fmt.Errorf("%w: missing from", ErrDataInsufficient)
+	}
+	if r.To.IsZero() {
+		return nil, fmt.Errorf("%w: missing to", ErrDataInsufficient)
+	}
+	from, to := toDate(r.From), toDate(r.To)
+	if from.After(to) {
+		return nil, ErrInvalidPeriod
+	}
+
+	account, err := s.creditCard(ctx, r.UserID, r.AccountPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []*Statement{}
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(to); month = month.AddDate(0, 1, 0) {
+		statement := newStatement(account, month.Year(), month.Month())
+		if statement.ClosingDate.Before(from) || statement.ClosingDate.After(to) {
+			continue
+		}
+		statements = append(statements, statement)
+	}
+
+	reply := &ListReply{
+		Statements: statements,
+	}
+	if len(statements) == 0 {
+		return reply, nil
+	}
+
+	if err := s.fillItems(ctx, r.UserID, r.AccountPublicID, statements); err != nil {
+		return nil, err
+	}
+	if err := s.fillPayments(ctx, r.UserID, r.AccountPublicID, statements); err != nil {
+		return nil, err
+	}
+	for _, statement := range statements {
+		if statement.Payment == nil {
+			reply.Unpaid = reply.Unpaid.Add(statement.Balance)
+		}
+	}
+	return reply, nil
+}
+
+func (s *service) Pay(ctx context.Context, r *PayRequest) (*PayReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.AccountPublicID == "" {
+		return nil, fmt.Errorf("%w: missing account id", ErrDataInsufficient)
+	}
+	if r.ClosingDate.IsZero() {
+		return nil, fmt.Errorf("%w: missing closing date", ErrDataInsufficient)
+	}
+	if r.Date.IsZero() {
+		return nil, fmt.Errorf("%w: missing date", ErrDataInsufficient)
+	}
+	if r.FromAccountPublicID != nil && *r.FromAccountPublicID == r.AccountPublicID {
+		return nil, ErrSameAccount
+	}
+
+	account, err := s.creditCard(ctx, r.UserID, r.AccountPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	closingDate := toDate(r.ClosingDate)
+	statement := newStatement(account, closingDate.Year(), closingDate.Month())
+	if !statement.ClosingDate.Equal(closingDate) {
+		return nil, ErrInvalidClosingDate
+	}
+
+	payment := &repository.BaseStatementPayment{
+		ClosingDate: closingDate,
+		Date:        toDate(r.Date),
+	}
+	if r.Amount != nil {
+		payment.Amount = *r.Amount
+	} else {
+		if err := s.fillItems(ctx, r.UserID, r.AccountPublicID, []*Statement{statement}); err != nil {
+			return nil, err
+		}
+		payment.Amount = statement.Balance
+	}
+
+	var transfer *repository.CreateTransferRequest
+	if r.FromAccountPublicID != nil {
+		if !payment.Amount.IsPositive() {
+			return nil, ErrInvalidAmount
+		}
+		transfer = &repository.CreateTransferRequest{
+			UserID:   r.UserID,
+			PublicID: s.opts.genTransferPublicID(),
+			Transfer: &repository.BaseTransfer{
+				Date:                payment.Date,
+				FromAccountPublicID: *r.FromAccountPublicID,
+				ToAccountPublicID:   r.AccountPublicID,
+				Amount:              payment.Amount,
+				Memo:                fmt.Sprintf("statement closed on %s", closingDate.Format(time.DateOnly)),
+			},
+		}
+	}
+
+	row, err := s.repository.CreatePayment(ctx, &repository.CreateStatementPaymentRequest{
+		UserID:          r.UserID,
+		AccountPublicID: r.AccountPublicID,
+		Payment:         payment,
+		Transfer:        transfer,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataExists) {
+			return nil, ErrStatementPaid
+		}
+		if errors.Is(err, repository.ErrReferenceNotFound) {
+			return nil, ErrReferenceNotFound
+		}
+		if errors.Is(err, repository.ErrCurrencyMismatch) {
+			return nil, ErrCurrencyMismatch
+		}
+		return nil, err
+	}
+
+	return &PayReply{
+		Payment: parsePayment(row),
+	}, nil
+}
+
+func (s *service) Unpay(ctx context.Context, r *UnpayRequest) (*UnpayReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.AccountPublicID == "" {
+		return nil, fmt.Errorf("%w: missing account id", ErrDataInsufficient)
+	}
+	if r.ClosingDate.IsZero() {
+		return nil, fmt.Errorf("%w: missing closing date", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.DeletePayment(ctx, &repository.DeleteStatementPaymentRequest{
+		UserID:          r.UserID,
+		AccountPublicID: r.AccountPublicID,
+		ClosingDate:     toDate(r.ClosingDate),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrStatementNotPaid
+		}
+		return nil, err
+	}
+	return &UnpayReply{}, nil
+}
+
+// creditCard returns the account if it is a credit card with the statement closing day.
+func (s *service) creditCard(ctx context.Context, userID, publicID string) (*repository.Account, error) {
+	reply, err := s.accountRepository.List(ctx, &repository.ListAccountsRequest{
+		UserID:          userID,
+		AccountPublicID: lo.ToPtr(publicID),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	account := reply.Accounts[0]
+	if account.Type != repository.AccountTypeCreditCard || account.StatementClosingDay == 0 {
+		return nil, ErrNotCreditCard
+	}
+	return account, nil
+}
+
+// fillItems fills the daily items and the balances of the statements which are sorted
+// by the closing date.
+func (s *service) fillItems(ctx context.Context, userID, accountPublicID string, statements []*Statement) error {
+	reply, err := s.dailyItemRepository.List(ctx, &repository.ListDailyItemsRequest{
+		UserID:          userID,
+		From:            lo.ToPtr(statements[0].StartDate),
+		To:              lo.ToPtr(statements[len(statements)-1].ClosingDate.AddDate(0, 0, 1)),
+		AccountPublicID: lo.ToPtr(accountPublicID),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	i := 0
+	for _, item := range reply.Items {
+		for i < len(statements) && item.Date.After(statements[i].ClosingDate) {
+			i++
+		}
+		if i == len(statements) {
+			break
+		}
+		statement := statements[i]
+		if item.Date.Before(statement.StartDate) {
+			continue
+		}
+
+		if item.Type == repository.CategoryTypeIncome {
+			statement.Balance = statement.Balance.Sub(item.Amount())
+		} else {
+			statement.Balance = statement.Balance.Add(item.Amount())
+		}
+		statement.Items = append(statement.Items, &DailyItem{
+			ID:              item.ID,
+			PublicID:        item.PublicID,
+			NormalizedPrice: item.NormalizedPrice,
+			BaseDailyItem:   lo.ToPtr(dailyitems.BaseDailyItem(*item.BaseDailyItem)),
+		})
+	}
+	return nil
+}
+
+// fillPayments fills the payments of the statements which are sorted by the closing date.
+func (s *service) fillPayments(ctx context.Context, userID, accountPublicID string, statements []*Statement) error {
+	reply, err := s.repository.ListPayments(ctx, &repository.ListStatementPaymentsRequest{
+		UserID:          userID,
+		AccountPublicID: accountPublicID,
+		From:            lo.ToPtr(statements[0].ClosingDate),
+		To:              lo.ToPtr(statements[len(statements)-1].ClosingDate.AddDate(0, 0, 1)),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	payments := lo.SliceToMap(reply.Payments, func(item *repository.StatementPayment) (string, *repository.StatementPayment) {
+		return item.ClosingDate.Format(time.DateOnly), item
+	})
+	for _, statement := range statements {
+		if payment, ok := payments[statement.ClosingDate.Format(time.DateOnly)]; ok {
+			statement.Payment = parsePayment(payment)
+		}
+	}
+	return nil
+}
+
+// newStatement returns the statement of the credit card which closes in the month, its
+// daily items and payment are not filled.
+func newStatement(account *repository.Account, year int, month time.Month) *Statement {
+	closingDate := dayOfMonth(year, month, account.StatementClosingDay)
+	statement := &Statement{
+		StartDate:   dayOfMonth(year, month-1, account.StatementClosingDay).AddDate(0, 0, 1),
+		ClosingDate: closingDate,
+		Items:       []*DailyItem{},
+	}
+	if account.PaymentDueDay != 0 {
+		dueDate := dayOfMonth(year, month, account.PaymentDueDay)
+		if !dueDate.After(closingDate) {
+			dueDate = dayOfMonth(year, month+1, account.PaymentDueDay)
+		}
+		statement.DueDate = &dueDate
+	}
+	return statement
+}
+
+// dayOfMonth returns the day of the month, it is the last day of the month if the month
+// does not have the day. The month is normalized as time.Date does.
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return time.Date(year, month, min(day, lastDay), 0, 0, 0, 0, time.UTC)
+}
+
+// toDate truncates the time to the date in UTC.
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func parsePayment(v *repository.StatementPayment) *Payment {
+	return &Payment{
+		Date:             v.Date,
+		Amount:           v.Amount,
+		TransferPublicID: v.TransferPublicID,
+	}
+}
+
+type StatementServiceOptions struct {
+	genTransferPublicID func() string
+}
+
+func defaultStatementServiceOptions() *StatementServiceOptions {
+	return &StatementServiceOptions{
+		genTransferPublicID: func() string {
+			return slugid.New("trf", 11)
+		},
+	}
+}
+
+// WithStatementServiceGenTransferPublicID sets the public id generator of the transfers
+// which pay the statements.
+func WithStatementServiceGenTransferPublicID(f func() string) utils.Option[StatementServiceOptions] {
+	return func(o *StatementServiceOptions) {
+		o.genTransferPublicID = f
+	}
+}
@@ -0,0 +1,492 @@
+package statements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+const (
+	userID        = "user-id"
+	cardID        = "cardID"
+	bankID        = "bankID"
+	transferID    = "transferID"
+	closingDay    = 15
+	paymentDueDay = 5
+)
+
+func newCreditCard() *repository.ListAccountsReply {
+	return &repository.ListAccountsReply{
+		Accounts: []*repository.Account{
+			{
+				ID:       1,
+				PublicID: cardID,
+				BaseAccount: &repository.BaseAccount{
+					Name:                "Card",
+					Type:                repository.AccountTypeCreditCard,
+					StatementClosingDay: closingDay,
+					PaymentDueDay:       paymentDueDay,
+				},
+			},
+		},
+	}
+}
+
+func newDailyItem(id int32, date time.Time, type_ repository.CategoryType, price int64) *repository.DailyItem {
+	return &repository.DailyItem{
+		ID:       id,
+		PublicID: "item",
+		Type:     type_,
+		BaseDailyItem: &repository.BaseDailyItem{
+			Date: date,
+			BaseItem: &repository.BaseItem{
+				Name:            "A",
+				AccountPublicID: lo.ToPtr(cardID),
+				Price:           decimal.NewFromInt(price),
+			},
+		},
+	}
+}
+
+func Test_service_List(t *testing.T) {
+	t.Run("list successful", func(t *testing.T) {
+		var (
+			jan15 = time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+			feb15 = time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockStatementRepository(controller)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().
+				List(gomock.Any(), &repository.ListAccountsRequest{
+					UserID:          userID,
+					AccountPublicID: lo.ToPtr(cardID),
+				}).
+				Return(newCreditCard(), nil),
+			mockDailyItemRepo.EXPECT().
+				List(gomock.Any(), &repository.ListDailyItemsRequest{
+					UserID:          userID,
+					From:            lo.ToPtr(time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)),
+					To:              lo.ToPtr(time.Date(2025, 2, 16, 0, 0, 0, 0, time.UTC)),
+					AccountPublicID: lo.ToPtr(cardID),
+				}).
+				Return(&repository.ListDailyItemsReply{
+					Items: []*repository.DailyItem{
+						newDailyItem(1, time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC), repository.CategoryTypeExpense, 100),
+						newDailyItem(2, jan15, repository.CategoryTypeExpense, 20),
+						newDailyItem(3, time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), repository.CategoryTypeExpense, 50),
+						newDailyItem(4, time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), repository.CategoryTypeIncome, 10),
+					},
+				}, nil),
+			mockRepo.EXPECT().
+				ListPayments(gomock.Any(), &repository.ListStatementPaymentsRequest{
+					UserID:          userID,
+					AccountPublicID: cardID,
+					From:            lo.ToPtr(jan15),
+					To:              lo.ToPtr(time.Date(2025, 2, 16, 0, 0, 0, 0, time.UTC)),
+				}).
+				Return(&repository.ListStatementPaymentsReply{
+					Payments: []*repository.StatementPayment{
+						{
+							ID:              1,
+							AccountPublicID: cardID,
+							BaseStatementPayment: &repository.BaseStatementPayment{
+								ClosingDate: jan15,
+								Date:        time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+								Amount:      decimal.NewFromInt(120),
+							},
+							TransferPublicID: lo.ToPtr(transferID),
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockAccountRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			From:            time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:              time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(40).Equal(reply.Unpaid))
+		assert.Len(reply.Statements, 2)
+
+		assert.Equal(time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC), reply.Statements[0].StartDate)
+		assert.Equal(jan15, reply.Statements[0].ClosingDate)
+		assert.Equal(lo.ToPtr(time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC)), reply.Statements[0].DueDate)
+		assert.True(decimal.NewFromInt(120).Equal(reply.Statements[0].Balance))
+		assert.Equal([]int32{1, 2}, lo.Map(reply.Statements[0].Items, func(item *DailyItem, _ int) int32 {
+			return item.ID
+		}))
+		assert.Equal(&Payment{
+			Date:             time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			Amount:           decimal.NewFromInt(120),
+			TransferPublicID: lo.ToPtr(transferID),
+		}, reply.Statements[0].Payment)
+
+		assert.Equal(time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), reply.Statements[1].StartDate)
+		assert.Equal(feb15, reply.Statements[1].ClosingDate)
+		assert.Equal(lo.ToPtr(time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)), reply.Statements[1].DueDate)
+		assert.True(decimal.NewFromInt(40).Equal(reply.Statements[1].Balance))
+		assert.Len(reply.Statements[1].Items, 2)
+		assert.Nil(reply.Statements[1].Payment)
+	})
+	t.Run("closing day after the last day of the month", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockStatementRepository(controller)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+
+		card := newCreditCard()
+		card.Accounts[0].StatementClosingDay = 31
+		card.Accounts[0].PaymentDueDay = 0
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(card, nil),
+			mockDailyItemRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+			mockRepo.EXPECT().ListPayments(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo, mockAccountRepo, mockDailyItemRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			From:            time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			To:              time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(err)
+		assert.Equal([]*Statement{
+			{
+				StartDate:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				ClosingDate: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+				Items:       []*DailyItem{},
+			},
+		}, reply.Statements)
+		assert.True(reply.Unpaid.IsZero())
+	})
+	t.Run("not a credit card", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+
+		account := newCreditCard()
+		account.Accounts[0].Type = repository.AccountTypeBank
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(account, nil)
+
+		s, err := NewService(repository.NewMockStatementRepository(controller), mockAccountRepo, repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			From:            time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:              time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		})
+		assert.ErrorIs(err, ErrNotCreditCard)
+		assert.Nil(reply)
+	})
+	t.Run("account not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound)
+
+		s, err := NewService(repository.NewMockStatementRepository(controller), mockAccountRepo, repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			From:            time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:              time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		})
+		assert.ErrorIs(err, ErrAccountNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Pay(t *testing.T) {
+	var (
+		jan15 = time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+		feb1  = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("pay the balance from the bank account", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockStatementRepository(controller)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockDailyItemRepo := repository.NewMockDailyItemRepository(controller)
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(newCreditCard(), nil),
+			mockDailyItemRepo.EXPECT().
+				List(gomock.Any(), &repository.ListDailyItemsRequest{
+					UserID:          userID,
+					From:            lo.ToPtr(time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)),
+					To:              lo.ToPtr(time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)),
+					AccountPublicID: lo.ToPtr(cardID),
+				}).
+				Return(&repository.ListDailyItemsReply{
+					Items: []*repository.DailyItem{
+						newDailyItem(1, time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC), repository.CategoryTypeExpense, 100),
+						newDailyItem(2, jan15, repository.CategoryTypeExpense, 20),
+					},
+				}, nil),
+			mockRepo.EXPECT().
+				CreatePayment(gomock.Any(), &repository.CreateStatementPaymentRequest{
+					UserID:          userID,
+					AccountPublicID: cardID,
+					Payment: &repository.BaseStatementPayment{
+						ClosingDate: jan15,
+						Date:        feb1,
+						Amount:      decimal.NewFromInt(120),
+					},
+					Transfer: &repository.CreateTransferRequest{
+						UserID:   userID,
+						PublicID: transferID,
+						Transfer: &repository.BaseTransfer{
+							Date:                feb1,
+							FromAccountPublicID: bankID,
+							ToAccountPublicID:   cardID,
+							Amount:              decimal.NewFromInt(120),
+							Memo:                "statement closed on 2025-01-15",
+						},
+					},
+				}).
+				Return(&repository.StatementPayment{
+					ID:              1,
+					AccountPublicID: cardID,
+					BaseStatementPayment: &repository.BaseStatementPayment{
+						ClosingDate: jan15,
+						Date:        feb1,
+						Amount:      decimal.NewFromInt(120),
+					},
+					TransferPublicID: lo.ToPtr(transferID),
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockAccountRepo, mockDailyItemRepo, WithStatementServiceGenTransferPublicID(func() string {
+			return transferID
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Pay(context.Background(), &PayRequest{
+			UserID:              userID,
+			AccountPublicID:     cardID,
+			ClosingDate:         jan15,
+			Date:                feb1,
+			FromAccountPublicID: lo.ToPtr(bankID),
+		})
+		assert.NoError(err)
+		assert.Equal(&PayReply{
+			Payment: &Payment{
+				Date:             feb1,
+				Amount:           decimal.NewFromInt(120),
+				TransferPublicID: lo.ToPtr(transferID),
+			},
+		}, reply)
+	})
+	t.Run("mark as paid", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockStatementRepository(controller)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(newCreditCard(), nil),
+			mockRepo.EXPECT().
+				CreatePayment(gomock.Any(), &repository.CreateStatementPaymentRequest{
+					UserID:          userID,
+					AccountPublicID: cardID,
+					Payment: &repository.BaseStatementPayment{
+						ClosingDate: jan15,
+						Date:        feb1,
+						Amount:      decimal.NewFromInt(50),
+					},
+				}).
+				Return(&repository.StatementPayment{
+					ID:              1,
+					AccountPublicID: cardID,
+					BaseStatementPayment: &repository.BaseStatementPayment{
+						ClosingDate: jan15,
+						Date:        feb1,
+						Amount:      decimal.NewFromInt(50),
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, mockAccountRepo, repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Pay(context.Background(), &PayRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			ClosingDate:     jan15,
+			Date:            feb1,
+			Amount:          lo.ToPtr(decimal.NewFromInt(50)),
+		})
+		assert.NoError(err)
+		assert.Equal(&PayReply{
+			Payment: &Payment{
+				Date:   feb1,
+				Amount: decimal.NewFromInt(50),
+			},
+		}, reply)
+	})
+	t.Run("invalid closing date", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(newCreditCard(), nil)
+
+		s, err := NewService(repository.NewMockStatementRepository(controller), mockAccountRepo, repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Pay(context.Background(), &PayRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			ClosingDate:     time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+			Date:            feb1,
+		})
+		assert.ErrorIs(err, ErrInvalidClosingDate)
+		assert.Nil(reply)
+	})
+	t.Run("statement has been paid", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockStatementRepository(controller)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		gomock.InOrder(
+			mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(newCreditCard(), nil),
+			mockRepo.EXPECT().CreatePayment(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataExists),
+		)
+
+		s, err := NewService(mockRepo, mockAccountRepo, repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Pay(context.Background(), &PayRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			ClosingDate:     jan15,
+			Date:            feb1,
+			Amount:          lo.ToPtr(decimal.NewFromInt(50)),
+		})
+		assert.ErrorIs(err, ErrStatementPaid)
+		assert.Nil(reply)
+	})
+	t.Run("nothing to transfer", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockAccountRepo := repository.NewMockAccountRepository(controller)
+		mockAccountRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(newCreditCard(), nil)
+
+		s, err := NewService(repository.NewMockStatementRepository(controller), mockAccountRepo, repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Pay(context.Background(), &PayRequest{
+			UserID:              userID,
+			AccountPublicID:     cardID,
+			ClosingDate:         jan15,
+			Date:                feb1,
+			Amount:              lo.ToPtr(decimal.Zero),
+			FromAccountPublicID: lo.ToPtr(bankID),
+		})
+		assert.ErrorIs(err, ErrInvalidAmount)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Unpay(t *testing.T) {
+	closingDate := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("unpay successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockStatementRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				DeletePayment(gomock.Any(), &repository.DeleteStatementPaymentRequest{
+					UserID:          userID,
+					AccountPublicID: cardID,
+					ClosingDate:     closingDate,
+				}).
+				Return(&repository.StatementPayment{}, nil),
+		)
+
+		s, err := NewService(mockRepo, repository.NewMockAccountRepository(controller), repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Unpay(context.Background(), &UnpayRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			ClosingDate:     closingDate,
+		})
+		assert.NoError(err)
+		assert.Equal(&UnpayReply{}, reply)
+	})
+	t.Run("statement is not paid", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockStatementRepository(controller)
+		mockRepo.EXPECT().DeletePayment(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound)
+
+		s, err := NewService(mockRepo, repository.NewMockAccountRepository(controller), repository.NewMockDailyItemRepository(controller))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Unpay(context.Background(), &UnpayRequest{
+			UserID:          userID,
+			AccountPublicID: cardID,
+			ClosingDate:     closingDate,
+		})
+		assert.ErrorIs(err, ErrStatementNotPaid)
+		assert.Nil(reply)
+	})
+}
@@ -0,0 +1,114 @@
+package tags
+
+import (
+	"errors"
+
+	"github.com/kataras/iris/v12"
+	"github.com/samber/lo"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	*irisController.SimpleCreateTemplate[models.BasicTag, CreateRequest, CreateReply, models.ObjectId]
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Tag]
+	*irisController.SimpleUpdateTemplate[models.BasicTag, UpdateRequest, UpdateReply]
+	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicTag, CreateRequest, CreateReply, models.ObjectId]{
+			Service: s,
+			ParseServiceRequest: func(userID string, r *models.BasicTag) (*CreateRequest, error) {
+				return &CreateRequest{
+					UserID: userID,
+					Tag: &BaseTag{
+						Name: r.Name,
+					},
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrTagExists):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *CreateReply) (*models.ObjectId, error) {
+				return &models.ObjectId{
+					Id: lo.ToPtr(models.Id(reply.Tag.PublicID)),
+				}, nil
+			},
+		},
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Tag]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				return &ListRequest{
+					UserID: userID,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.Tag, error) {
+				return lo.ToPtr(lo.Map(reply.Tags, func(item *Tag, _ int) *models.Tag {
+					return &models.Tag{
+						Id:   lo.ToPtr(models.Id(item.PublicID)),
+						Name: item.Name,
+					}
+				})), nil
+			},
+		},
+		SimpleUpdateTemplate: &irisController.SimpleUpdateTemplate[models.BasicTag, UpdateRequest, UpdateReply]{
+			Placeholder: "tagId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string, r *models.BasicTag) (*UpdateRequest, error) {
+				return &UpdateRequest{
+					UserID:      userID,
+					TagPublicID: publicID,
+					Tag: &BaseTag{
+						Name: r.Name,
+					},
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrTagNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrTagExists):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+			Placeholder: "tagId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+				return &DeleteRequest{
+					UserID:      userID,
+					TagPublicID: publicID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrTagNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+	}
+}
@@ -0,0 +1,168 @@
+package tags
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.TagRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Create(ctx context.Context, r *repository.CreateTagsRequest) ([]*repository.Tag, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	return CreateWithSession(session, r)
+}
+
+// CreateWithSession is the same as Create, but it creates the tags with the session
+// so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateTagsRequest) ([]*repository.Tag, error) {
+	rows := lo.Map(r.Tags, func(item *repository.BaseCreateTag, _ int) *postgres.TagsModel {
+		return &postgres.TagsModel{
+			PublicID: item.PublicID,
+			UserID:   r.UserID,
+			Name:     item.Name,
+		}
+	})
+	_, err := session.Insert(rows)
+	if err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	return lo.Map(rows, func(item *postgres.TagsModel, _ int) *repository.Tag {
+		return toTag(item)
+	}), nil
+}
+
+func (repo *postgresRepository) List(ctx context.Context, r *repository.ListTagsRequest) (*repository.ListTagsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var rows []*postgres.TagsModel
+	err := session.Asc("name").Find(&rows, &postgres.TagsModel{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+	return &repository.ListTagsReply{
+		Tags: lo.Map(rows, func(item *postgres.TagsModel, _ int) *repository.Tag {
+			return toTag(item)
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) Update(ctx context.Context, r *repository.UpdateTagRequest) (*repository.Tag, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	row := postgres.TagsModel{
+		PublicID: r.TagPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&row)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrDataNotFound
+	}
+
+	cols := []string{}
+	bean := postgres.TagsModel{}
+	if r.Tag != nil {
+		cols = append(cols, "name")
+
+		row.Name = r.Tag.Name
+		bean.Name = row.Name
+	}
+
+	affected, err := session.Cols(cols...).Update(&bean, &postgres.TagsModel{
+		ID: row.ID,
+	})
+	if err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	return toTag(&row), nil
+}
+
+func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteTagsRequest) ([]*repository.Tag, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	session.Where("user_id = ?", r.UserID)
+	if len(r.TagPublicIDs) > 0 {
+		session.In("public_id", r.TagPublicIDs)
+	}
+
+	var rows []*postgres.TagsModel
+	err := session.Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.TagPublicIDs) > 0 && len(rows) != len(r.TagPublicIDs) {
+		return nil, repository.ErrDataNotFound
+	}
+	if len(r.TagPublicIDs) == 0 && len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	ids := lo.Map(rows, func(item *postgres.TagsModel, _ int) any {
+		return item.ID
+	})
+	_, err = session.In("tag_id", ids).Delete(&postgres.DailyItemTagsModel{})
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.In("id", ids).Delete(&postgres.TagsModel{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return lo.Map(rows, func(item *postgres.TagsModel, _ int) *repository.Tag {
+		return toTag(item)
+	}), nil
+}
+
+func toTag(item *postgres.TagsModel) *repository.Tag {
+	return &repository.Tag{
+		ID:       item.ID,
+		PublicID: item.PublicID,
+		BaseTag: &repository.BaseTag{
+			Name: item.Name,
+		},
+	}
+}
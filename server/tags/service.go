@@ -0,0 +1,76 @@
+package tags
+
+import (
+	"context"
+	"fmt"
+)
+
+var (
+	ErrDataInsufficient = fmt.Errorf("data insufficient")
+	ErrTagNotFound      = fmt.Errorf("tag not found")
+	ErrTagExists        = fmt.Errorf("tag of the same name exists")
+)
+
+type Service interface {
+	// Create returns error:
+	//  - ErrDataInsufficient if any of fields of CreateRequest is zero-value,
+	//  - ErrTagExists if the user has the tag of the same name.
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	// List returns the tags sorted by name, it returns ErrDataInsufficient if any of fields
+	// of ListRequest is zero-value.
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Update returns error:
+	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
+	//  - ErrTagNotFound if the tag does not exist,
+	//  - ErrTagExists if the user has another tag of the same name.
+	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
+	// Delete removes the tag from the daily items, it returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
+	//  - ErrTagNotFound if the tag does not exist.
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+}
+
+type BaseTag struct {
+	// Name is unique of the user, such as "#japan-2026".
+	Name string
+}
+
+type Tag struct {
+	ID       int32
+	PublicID string
+	*BaseTag
+}
+
+type CreateRequest struct {
+	UserID string
+	Tag    *BaseTag
+}
+
+type CreateReply struct {
+	Tag *Tag
+}
+
+type ListRequest struct {
+	UserID string
+}
+
+type ListReply struct {
+	Tags []*Tag
+}
+
+type UpdateRequest struct {
+	UserID      string
+	TagPublicID string
+	Tag         *BaseTag
+}
+
+type UpdateReply struct {
+	Tag *Tag
+}
+
+type DeleteRequest struct {
+	UserID      string
+	TagPublicID string
+}
+
+type DeleteReply struct{}
@@ -0,0 +1,180 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	repository repository.TagRepository
+
+	opts *TagServiceOptions
+}
+
+func NewService(
+	repository repository.TagRepository,
+	opts ...utils.Option[TagServiceOptions],
+) (Service, error) {
+	return &service{
+		repository: repository,
+		opts:       utils.ApplyOptions(defaultTagServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if err := validateBaseTag(r.Tag); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repository.Create(ctx, &repository.CreateTagsRequest{
+		UserID: r.UserID,
+		Tags: []*repository.BaseCreateTag{
+			{
+				PublicID: s.opts.genPublicID(),
+				BaseTag:  parseBaseTag(r.Tag),
+			},
+		},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataExists) {
+			return nil, ErrTagExists
+		}
+		return nil, err
+	}
+
+	return &CreateReply{
+		Tag: parseTag(rows[0]),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListTagsRequest{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ListReply{
+				Tags: []*Tag{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &ListReply{
+		Tags: lo.Map(reply.Tags, func(item *repository.Tag, _ int) *Tag {
+			return parseTag(item)
+		}),
+	}, nil
+}
+
+func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.TagPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+	if err := validateBaseTag(r.Tag); err != nil {
+		return nil, err
+	}
+
+	row, err := s.repository.Update(ctx, &repository.UpdateTagRequest{
+		UserID:      r.UserID,
+		TagPublicID: r.TagPublicID,
+		Tag:         parseBaseTag(r.Tag),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrTagNotFound
+		}
+		if errors.Is(err, repository.ErrDataExists) {
+			return nil, ErrTagExists
+		}
+		return nil, err
+	}
+
+	return &UpdateReply{
+		Tag: parseTag(row),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.TagPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.Delete(ctx, &repository.DeleteTagsRequest{
+		UserID:       r.UserID,
+		TagPublicIDs: []string{r.TagPublicID},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &DeleteReply{}, nil
+}
+
+func validateBaseTag(v *BaseTag) error {
+	if v == nil {
+		return fmt.Errorf("%w: missing tag", ErrDataInsufficient)
+	}
+	if strings.TrimSpace(v.Name) == "" {
+		return fmt.Errorf("%w: missing tag.name", ErrDataInsufficient)
+	}
+	return nil
+}
+
+func parseTag(v *repository.Tag) *Tag {
+	return &Tag{
+		ID:       v.ID,
+		PublicID: v.PublicID,
+		BaseTag: &BaseTag{
+			Name: v.Name,
+		},
+	}
+}
+
+// parseBaseTag trims the spaces around the name.
+func parseBaseTag(v *BaseTag) *repository.BaseTag {
+	return &repository.BaseTag{
+		Name: strings.TrimSpace(v.Name),
+	}
+}
+
+type TagServiceOptions struct {
+	genPublicID func() string
+}
+
+func defaultTagServiceOptions() *TagServiceOptions {
+	return &TagServiceOptions{
+		genPublicID: func() string {
+			return slugid.New("tag", 11)
+		},
+	}
+}
+
+func WithTagServiceGenPublicID(f func() string) utils.Option[TagServiceOptions] {
+	return func(o *TagServiceOptions) {
+		o.genPublicID = f
+	}
+}
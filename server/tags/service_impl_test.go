@@ -0,0 +1,311 @@
+package tags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Create(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "publicID"
+	)
+
+	t.Run("create successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Create(gomock.Any(), &repository.CreateTagsRequest{
+					UserID: userID,
+					Tags: []*repository.BaseCreateTag{
+						{
+							PublicID: publicID,
+							BaseTag:  &repository.BaseTag{Name: "#japan-2026"},
+						},
+					},
+				}).
+				Return([]*repository.Tag{
+					{
+						ID:       1,
+						PublicID: publicID,
+						BaseTag:  &repository.BaseTag{Name: "#japan-2026"},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, WithTagServiceGenPublicID(func() string {
+			return publicID
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Tag:    &BaseTag{Name: " #japan-2026 "},
+		})
+		assert.NoError(err)
+		assert.Equal(&CreateReply{
+			Tag: &Tag{
+				ID:       1,
+				PublicID: publicID,
+				BaseTag:  &BaseTag{Name: "#japan-2026"},
+			},
+		}, reply)
+	})
+	t.Run("tag exists", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataExists),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Tag:    &BaseTag{Name: "#wedding"},
+		})
+		assert.ErrorIs(err, ErrTagExists)
+		assert.Nil(reply)
+	})
+	t.Run("missing name", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Tag:    &BaseTag{Name: " "},
+		})
+		assert.ErrorIs(err, ErrDataInsufficient)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_List(t *testing.T) {
+	const userID = "user-id"
+
+	t.Run("list tags", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListTagsRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListTagsReply{
+					Tags: []*repository.Tag{
+						{ID: 1, PublicID: "tag-1", BaseTag: &repository.BaseTag{Name: "#japan-2026"}},
+						{ID: 2, PublicID: "tag-2", BaseTag: &repository.BaseTag{Name: "#wedding"}},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: userID,
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Tags: []*Tag{
+				{ID: 1, PublicID: "tag-1", BaseTag: &BaseTag{Name: "#japan-2026"}},
+				{ID: 2, PublicID: "tag-2", BaseTag: &BaseTag{Name: "#wedding"}},
+			},
+		}, reply)
+	})
+	t.Run("no tag", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: userID,
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Tags: []*Tag{},
+		}, reply)
+	})
+}
+
+func Test_service_Update(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "tag-1"
+	)
+
+	t.Run("update successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Update(gomock.Any(), &repository.UpdateTagRequest{
+					UserID:      userID,
+					TagPublicID: publicID,
+					Tag:         &repository.BaseTag{Name: "#wedding"},
+				}).
+				Return(&repository.Tag{
+					ID:       1,
+					PublicID: publicID,
+					BaseTag:  &repository.BaseTag{Name: "#wedding"},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:      userID,
+			TagPublicID: publicID,
+			Tag:         &BaseTag{Name: "#wedding"},
+		})
+		assert.NoError(err)
+		assert.Equal(&UpdateReply{
+			Tag: &Tag{
+				ID:       1,
+				PublicID: publicID,
+				BaseTag:  &BaseTag{Name: "#wedding"},
+			},
+		}, reply)
+	})
+	t.Run("tag not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:      userID,
+			TagPublicID: publicID,
+			Tag:         &BaseTag{Name: "#wedding"},
+		})
+		assert.ErrorIs(err, ErrTagNotFound)
+		assert.Nil(reply)
+	})
+	t.Run("tag of the same name exists", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataExists),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:      userID,
+			TagPublicID: publicID,
+			Tag:         &BaseTag{Name: "#wedding"},
+		})
+		assert.ErrorIs(err, ErrTagExists)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Delete(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "tag-1"
+	)
+
+	t.Run("delete successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Delete(gomock.Any(), &repository.DeleteTagsRequest{
+					UserID:       userID,
+					TagPublicIDs: []string{publicID},
+				}).
+				Return([]*repository.Tag{
+					{ID: 1, PublicID: publicID, BaseTag: &repository.BaseTag{Name: "#wedding"}},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:      userID,
+			TagPublicID: publicID,
+		})
+		assert.NoError(err)
+		assert.Equal(&DeleteReply{}, reply)
+	})
+	t.Run("tag not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTagRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:      userID,
+			TagPublicID: publicID,
+		})
+		assert.ErrorIs(err, ErrTagNotFound)
+		assert.Nil(reply)
+	})
+}
@@ -0,0 +1,119 @@
+package transfers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kataras/iris/v12"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/models"
+)
+
+type IrisController struct {
+	*irisController.SimpleCreateTemplate[models.BasicTransfer, CreateRequest, CreateReply, models.ObjectId]
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Transfer]
+	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicTransfer, CreateRequest, CreateReply, models.ObjectId]{
+			Service: s,
+			ParseServiceRequest: func(userID string, r *models.BasicTransfer) (*CreateRequest, error) {
+				amount, err := decimal.NewFromString(r.Amount)
+				if err != nil {
+					return nil, fmt.Errorf("invalid decimal[%s]", r.Amount)
+				}
+				return &CreateRequest{
+					UserID: userID,
+					Transfer: &BaseTransfer{
+						Date:                r.Date.Time,
+						FromAccountPublicID: r.FromAccountId,
+						ToAccountPublicID:   r.ToAccountId,
+						Amount:              amount,
+						FeePublicID:         r.FeeId,
+						Memo:                lo.FromPtr(r.Memo),
+					},
+					FeeCategoryPublicID: r.FeeCategoryId,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrReferenceNotFound),
+					errors.Is(err, ErrCategoryTypeMismatch),
+					errors.Is(err, ErrInvalidAmount),
+					errors.Is(err, ErrSameAccount),
+					errors.Is(err, ErrCurrencyMismatch):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *CreateReply) (*models.ObjectId, error) {
+				return &models.ObjectId{
+					Id: lo.ToPtr(models.Id(reply.Transfer.PublicID)),
+				}, nil
+			},
+		},
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.Transfer]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				accountID := c.URLParam("accountId")
+				return &ListRequest{
+					UserID:          userID,
+					AccountPublicID: lo.EmptyableToPtr(accountID),
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.Transfer, error) {
+				return lo.ToPtr(lo.Map(reply.Transfers, func(item *Transfer, _ int) *models.Transfer {
+					return toTransfer(item)
+				})), nil
+			},
+		},
+		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+			Placeholder: "transferId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+				return &DeleteRequest{
+					UserID:           userID,
+					TransferPublicID: publicID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrTransferNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+	}
+}
+
+func toTransfer(v *Transfer) *models.Transfer {
+	return &models.Transfer{
+		Id:            lo.ToPtr(models.Id(v.PublicID)),
+		Date:          openapi_types.Date{Time: v.Date},
+		FromAccountId: v.FromAccountPublicID,
+		ToAccountId:   v.ToAccountPublicID,
+		Amount:        v.Amount.String(),
+		FeeId:         v.FeePublicID,
+		Fee: lo.IfF(v.Fee != nil, func() *models.Decimal {
+			return lo.ToPtr(models.Decimal(v.Fee.String()))
+		}).Else(nil),
+		Memo: lo.ToPtr(v.Memo),
+	}
+}
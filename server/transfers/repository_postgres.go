@@ -0,0 +1,403 @@
+package transfers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.TransferRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Create(ctx context.Context, r *repository.CreateTransferRequest) (*repository.Transfer, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	result, err := CreateWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWithSession is the same as Create, but it creates the transfer with the session
+// so that it can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateTransferRequest) (*repository.Transfer, error) {
+	accounts, err := resolveAccounts(session, r.UserID, []string{
+		r.Transfer.FromAccountPublicID,
+		r.Transfer.ToAccountPublicID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	from, to := accounts[r.Transfer.FromAccountPublicID], accounts[r.Transfer.ToAccountPublicID]
+	// the same amount is moved out of and into the accounts, so they must be in the same currency.
+	if from.Data.Currency != to.Data.Currency {
+		return nil, repository.ErrCurrencyMismatch
+	}
+
+	row := &postgres.TransfersModel{
+		PublicID:      r.PublicID,
+		UserID:        r.UserID,
+		Date:          r.Transfer.Date,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        decimal.NewNullDecimal(r.Transfer.Amount),
+		Memo:          r.Transfer.Memo,
+	}
+
+	var fee *postgres.FeesModel
+	if r.Transfer.FeePublicID != nil {
+		fee = &postgres.FeesModel{
+			PublicID: *r.Transfer.FeePublicID,
+			UserID:   r.UserID,
+		}
+		has, err := session.Get(fee)
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return nil, repository.ErrReferenceNotFound
+		}
+
+		charged := postgres.FromBaseFee(fee.Name, fee.Data).Charge(r.Transfer.Amount).Round(6)
+		if r.Fee != nil {
+			charged = r.Fee.Round(6)
+		}
+		if !charged.IsZero() && r.FeeItem == nil {
+			return nil, repository.ErrReferenceNotFound
+		}
+
+		row.FeeID = postgres.ToNullInt32(&fee.ID)
+		row.Fee = decimal.NewNullDecimal(charged)
+	}
+
+	if _, err := session.Insert(row); err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+
+	if err := incrBalance(session, row.FromAccountID, row.Amount.Decimal.Neg()); err != nil {
+		return nil, err
+	}
+	if err := incrBalance(session, row.ToAccountID, row.Amount.Decimal); err != nil {
+		return nil, err
+	}
+
+	if fee != nil && !row.Fee.Decimal.IsZero() {
+		if err := insertFeeItem(session, row, fee.Name, from.Data.Currency, r.FeeItem); err != nil {
+			return nil, err
+		}
+	}
+
+	return &repository.Transfer{
+		ID:           row.ID,
+		PublicID:     row.PublicID,
+		BaseTransfer: r.Transfer,
+		Fee:          postgres.FromNullDecimal(row.Fee),
+	}, nil
+}
+
+func (repo *postgresRepository) List(ctx context.Context, r *repository.ListTransfersRequest) (*repository.ListTransfersReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var accountID int32
+	if r.AccountPublicID != nil {
+		accounts, err := resolveAccounts(session, r.UserID, []string{*r.AccountPublicID})
+		if err != nil {
+			if errors.Is(err, repository.ErrReferenceNotFound) {
+				return nil, repository.ErrDataNotFound
+			}
+			return nil, err
+		}
+		accountID = accounts[*r.AccountPublicID].ID
+	}
+
+	session.Where("user_id = ?", r.UserID)
+	if r.TransferPublicID != nil {
+		session.And("public_id = ?", *r.TransferPublicID)
+	}
+	if r.AccountPublicID != nil {
+		session.And("(from_account_id = ? OR to_account_id = ?)", accountID, accountID)
+	}
+
+	var rows []*postgres.TransfersModel
+	err := session.Asc("date", "id").Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	transfers, err := loadTransfers(session, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.ListTransfersReply{
+		Transfers: transfers,
+	}, nil
+}
+
+func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteTransfersRequest) ([]*repository.Transfer, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	result, err := DeleteWithSession(session, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteWithSession is the same as Delete, but it deletes the transfers with the session
+// so that they can be deleted in the transaction of the session.
+func DeleteWithSession(session *xorm.Session, r *repository.DeleteTransfersRequest) ([]*repository.Transfer, error) {
+	session.Where("user_id = ?", r.UserID)
+	if len(r.TransferPublicIDs) > 0 {
+		session.In("public_id", r.TransferPublicIDs)
+	}
+
+	var rows []*postgres.TransfersModel
+	err := session.Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.TransferPublicIDs) > 0 && len(rows) != len(r.TransferPublicIDs) {
+		return nil, repository.ErrDataNotFound
+	}
+	if len(r.TransferPublicIDs) == 0 && len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	transfers, err := loadTransfers(session, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if err := incrBalance(session, row.FromAccountID, row.Amount.Decimal); err != nil {
+			return nil, err
+		}
+		if err := incrBalance(session, row.ToAccountID, row.Amount.Decimal.Neg()); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := lo.Map(rows, func(item *postgres.TransfersModel, _ int) any {
+		return item.ID
+	})
+	if err := deleteFeeItems(session, ids); err != nil {
+		return nil, err
+	}
+	_, err = session.In("transfer_id", ids).Delete(&postgres.StatementPaymentsModel{})
+	if err != nil {
+		return nil, err
+	}
+	_, err = session.In("id", ids).Delete(&postgres.TransfersModel{})
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// resolveAccounts maps public ids of the accounts of the user to their rows.
+func resolveAccounts(session *xorm.Session, userID string, publicIDs []string) (map[string]*postgres.AccountsModel, error) {
+	publicIDs = lo.Uniq(publicIDs)
+
+	var rows []*postgres.AccountsModel
+	err := session.Where("user_id = ?", userID).In("public_id", publicIDs).Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != len(publicIDs) {
+		return nil, repository.ErrReferenceNotFound
+	}
+	return lo.KeyBy(rows, func(item *postgres.AccountsModel) string {
+		return item.PublicID
+	}), nil
+}
+
+func incrBalance(session *xorm.Session, accountID int32, delta decimal.Decimal) error {
+	if delta.IsZero() {
+		return nil
+	}
+
+	affected, err := session.
+		Incr("balance", delta).
+		Update(&postgres.AccountsModel{}, &postgres.AccountsModel{
+			ID: accountID,
+		})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrReferenceNotFound
+	}
+	return nil
+}
+
+// insertFeeItem records the fee of the transfer as an expense daily item of the source
+// account, the item is in the currency of the account.
+func insertFeeItem(session *xorm.Session, transfer *postgres.TransfersModel, name, currency string, feeItem *repository.TransferFeeItem) error {
+	category := postgres.CategoriesModel{
+		PublicID: feeItem.CategoryPublicID,
+		UserID:   transfer.UserID,
+	}
+	has, err := session.Get(&category)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return repository.ErrReferenceNotFound
+	}
+	if category.Type != repository.CategoryTypeExpense {
+		return repository.ErrInvalidReference
+	}
+
+	item := &postgres.DailyItemsModel{
+		PublicID:   feeItem.PublicID,
+		UserID:     transfer.UserID,
+		Date:       transfer.Date,
+		Name:       name,
+		Type:       repository.CategoryTypeExpense,
+		AccountID:  postgres.ToNullInt32(&transfer.FromAccountID),
+		Price:      transfer.Fee,
+		Total:      transfer.Fee,
+		Currency:   currency,
+		TransferID: postgres.ToNullInt32(&transfer.ID),
+	}
+	if _, err := session.Insert(item); err != nil {
+		if postgres.UniqueViolationError(err) {
+			return repository.ErrDataExists
+		}
+		return err
+	}
+
+	_, err = session.Insert(&postgres.DailyItemCategoriesModel{
+		DailyItemID: item.ID,
+		CategoryID:  category.ID,
+	})
+	if err != nil {
+		return err
+	}
+	return incrBalance(session, transfer.FromAccountID, transfer.Fee.Decimal.Neg())
+}
+
+// deleteFeeItems deletes the fee daily items of the transfers and reverts them from their accounts.
+func deleteFeeItems(session *xorm.Session, transferIDs []any) error {
+	var items []*postgres.DailyItemsModel
+	err := session.In("transfer_id", transferIDs).Find(&items)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	for _, item := range items {
+		if !item.AccountID.Valid {
+			continue
+		}
+		amount := (&repository.BaseItem{
+			Quantity: postgres.FromNullDecimal(item.Quantity),
+			Fee:      postgres.FromNullDecimal(item.Fee),
+			Price:    item.Price.Decimal,
+		}).Amount()
+		if item.Type == repository.CategoryTypeIncome {
+			amount = amount.Neg()
+		}
+		if err := incrBalance(session, item.AccountID.Int32, amount); err != nil {
+			return err
+		}
+	}
+
+	ids := lo.Map(items, func(item *postgres.DailyItemsModel, _ int) any {
+		return item.ID
+	})
+	_, err = session.In("daily_item_id", ids).Delete(&postgres.DailyItemCategoriesModel{})
+	if err != nil {
+		return err
+	}
+	_, err = session.In("id", ids).Delete(&postgres.DailyItemsModel{})
+	return err
+}
+
+// loadTransfers fills the referenced public ids of the rows.
+func loadTransfers(session *xorm.Session, rows []*postgres.TransfersModel) ([]*repository.Transfer, error) {
+	var accounts []*postgres.AccountsModel
+	err := session.In("id", lo.Uniq(lo.FlatMap(rows, func(item *postgres.TransfersModel, _ int) []int32 {
+		return []int32{item.FromAccountID, item.ToAccountID}
+	}))).Find(&accounts)
+	if err != nil {
+		return nil, err
+	}
+	accountPublicIDs := lo.SliceToMap(accounts, func(item *postgres.AccountsModel) (int32, string) {
+		return item.ID, item.PublicID
+	})
+
+	feePublicIDs := map[int32]string{}
+	if feeIDs := lo.Uniq(lo.FilterMap(rows, func(item *postgres.TransfersModel, _ int) (int32, bool) {
+		return item.FeeID.Int32, item.FeeID.Valid
+	})); len(feeIDs) > 0 {
+		var fees []*postgres.FeesModel
+		err = session.In("id", feeIDs).Find(&fees)
+		if err != nil {
+			return nil, err
+		}
+		for _, fee := range fees {
+			feePublicIDs[fee.ID] = fee.PublicID
+		}
+	}
+
+	return lo.Map(rows, func(item *postgres.TransfersModel, _ int) *repository.Transfer {
+		return &repository.Transfer{
+			ID:       item.ID,
+			PublicID: item.PublicID,
+			BaseTransfer: &repository.BaseTransfer{
+				Date:                item.Date,
+				FromAccountPublicID: accountPublicIDs[item.FromAccountID],
+				ToAccountPublicID:   accountPublicIDs[item.ToAccountID],
+				Amount:              item.Amount.Decimal,
+				FeePublicID: lo.IfF(item.FeeID.Valid, func() *string {
+					return lo.ToPtr(feePublicIDs[item.FeeID.Int32])
+				}).Else(nil),
+				Memo: item.Memo,
+			},
+			Fee: postgres.FromNullDecimal(item.Fee),
+		}
+	}), nil
+}
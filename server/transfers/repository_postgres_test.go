@@ -0,0 +1,129 @@
+package transfers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+	"github.com/n101661/maney/server/repository/postgres/postgrestest"
+)
+
+func Test_postgresRepository_Create(t *testing.T) {
+	const userID = "user-id"
+
+	newAccount := func(publicID, currency string) *postgres.AccountsModel {
+		return &postgres.AccountsModel{
+			PublicID: publicID,
+			UserID:   userID,
+			Data: &postgres.BaseAccount{BaseAccount: &repository.BaseAccount{
+				Name:           publicID,
+				InitialBalance: decimal.NewFromInt(100),
+				Currency:       currency,
+			}},
+			Balance: decimal.NewNullDecimal(decimal.NewFromInt(100)),
+		}
+	}
+	balanceOf := func(t *testing.T, repo *postgresRepository, publicID string) decimal.Decimal {
+		return postgrestest.Get(t, repo.engine, &postgres.AccountsModel{PublicID: publicID}).Balance.Decimal
+	}
+
+	t.Run("amount is moved between the accounts", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine := postgrestest.NewEngine(t)
+		postgrestest.Insert(t, engine, newAccount("bankID", ""), newAccount("cashID", ""))
+		repo := &postgresRepository{engine: engine}
+
+		_, err := repo.Create(context.Background(), &repository.CreateTransferRequest{
+			UserID:   userID,
+			PublicID: "transferID",
+			Transfer: &repository.BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "bankID",
+				ToAccountPublicID:   "cashID",
+				Amount:              decimal.NewFromInt(30),
+			},
+		})
+		assert.NoError(err)
+		assert.True(decimal.NewFromInt(70).Equal(balanceOf(t, repo, "bankID")))
+		assert.True(decimal.NewFromInt(130).Equal(balanceOf(t, repo, "cashID")))
+	})
+	t.Run("fee item is in the currency of the source account", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine := postgrestest.NewEngine(t)
+		postgrestest.Insert(t, engine,
+			newAccount("usdBankID", "USD"),
+			newAccount("usdCashID", "USD"),
+			&postgres.FeesModel{
+				PublicID: "feeID",
+				UserID:   userID,
+				Name:     "wire fee",
+				Data: &postgres.BaseFee{
+					Type:  repository.FeeTypeFixed,
+					Fixed: lo.ToPtr(decimal.NewFromInt(2)),
+				},
+			},
+			&postgres.CategoriesModel{
+				PublicID: "categoryID",
+				UserID:   userID,
+				Type:     repository.CategoryTypeExpense,
+				Data: &postgres.BaseCategory{BaseCategory: &repository.BaseCategory{
+					Name: "fee",
+				}},
+			},
+		)
+		repo := &postgresRepository{engine: engine}
+
+		reply, err := repo.Create(context.Background(), &repository.CreateTransferRequest{
+			UserID:   userID,
+			PublicID: "transferID",
+			Transfer: &repository.BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "usdBankID",
+				ToAccountPublicID:   "usdCashID",
+				Amount:              decimal.NewFromInt(30),
+				FeePublicID:         lo.ToPtr("feeID"),
+			},
+			FeeItem: &repository.TransferFeeItem{
+				PublicID:         "feeItemID",
+				CategoryPublicID: "categoryID",
+			},
+		})
+		assert.NoError(err)
+		if assert.NotNil(reply.Fee) {
+			assert.True(decimal.NewFromInt(2).Equal(*reply.Fee))
+		}
+		item := postgrestest.Get(t, engine, &postgres.DailyItemsModel{PublicID: "feeItemID"})
+		assert.Equal("USD", item.Currency)
+		assert.True(decimal.NewFromInt(68).Equal(balanceOf(t, repo, "usdBankID")))
+	})
+	t.Run("accounts in different currencies", func(t *testing.T) {
+		assert := assert.New(t)
+
+		engine := postgrestest.NewEngine(t)
+		postgrestest.Insert(t, engine, newAccount("usdID", "USD"), newAccount("twdID", ""))
+		repo := &postgresRepository{engine: engine}
+
+		reply, err := repo.Create(context.Background(), &repository.CreateTransferRequest{
+			UserID:   userID,
+			PublicID: "transferID",
+			Transfer: &repository.BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "usdID",
+				ToAccountPublicID:   "twdID",
+				Amount:              decimal.NewFromInt(30),
+			},
+		})
+		assert.ErrorIs(err, repository.ErrCurrencyMismatch)
+		assert.Nil(reply)
+		assert.True(decimal.NewFromInt(100).Equal(balanceOf(t, repo, "usdID")))
+		assert.True(decimal.NewFromInt(100).Equal(balanceOf(t, repo, "twdID")))
+	})
+}
@@ -0,0 +1,86 @@
+package transfers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrDataInsufficient     = fmt.Errorf("data insufficient")
+	ErrTransferNotFound     = fmt.Errorf("transfer not found")
+	ErrReferenceNotFound    = fmt.Errorf("referenced account, fee or category not found")
+	ErrCategoryTypeMismatch = fmt.Errorf("category of the fee is not an expense category")
+	ErrInvalidAmount        = fmt.Errorf("amount must be positive")
+	ErrSameAccount          = fmt.Errorf("source and destination accounts are the same")
+	ErrCurrencyMismatch     = fmt.Errorf("source and destination accounts are in different currencies")
+)
+
+type Service interface {
+	// Create returns error:
+	//  - ErrDataInsufficient if any of required fields of CreateRequest is zero-value,
+	//  - ErrInvalidAmount if the amount is not positive,
+	//  - ErrSameAccount if the source and the destination accounts are the same,
+	//  - ErrCurrencyMismatch if the source and the destination accounts are in different currencies,
+	//  - ErrReferenceNotFound if any of referenced accounts, fee or category does not exist,
+	//  - ErrCategoryTypeMismatch if the category of the fee is not an expense category.
+	// The amount is moved from the source account to the destination account, and the
+	// fee is charged to the source account as an expense daily item.
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	// List returns ErrDataInsufficient if any of required fields of ListRequest is zero-value,
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Delete returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
+	//  - ErrTransferNotFound if the transfer does not exist.
+	// The amount is moved back and the fee daily item is deleted. The statement paid by the
+	// transfer becomes unpaid.
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+}
+
+type BaseTransfer struct {
+	Date                time.Time
+	FromAccountPublicID string
+	ToAccountPublicID   string
+	Amount              decimal.Decimal
+	FeePublicID         *string
+	Memo                string
+}
+
+type Transfer struct {
+	ID       int32
+	PublicID string
+	*BaseTransfer
+	// Fee is the charged fee, it is nil if there is no fee.
+	Fee *decimal.Decimal
+}
+
+type CreateRequest struct {
+	UserID   string
+	Transfer *BaseTransfer
+	// FeeCategoryPublicID is the expense category of the fee daily item, it is
+	// required if the fee is provided.
+	FeeCategoryPublicID *string
+}
+
+type CreateReply struct {
+	Transfer *Transfer
+}
+
+type ListRequest struct {
+	UserID string
+	// AccountPublicID filters the transfers from or to the account.
+	AccountPublicID *string
+}
+
+type ListReply struct {
+	Transfers []*Transfer
+}
+
+type DeleteRequest struct {
+	UserID           string
+	TransferPublicID string
+}
+
+type DeleteReply struct{}
@@ -0,0 +1,182 @@
+package transfers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	repository repository.TransferRepository
+
+	opts *TransferServiceOptions
+}
+
+func NewService(
+	repository repository.TransferRepository,
+	opts ...utils.Option[TransferServiceOptions],
+) (Service, error) {
+	return &service{
+		repository: repository,
+		opts:       utils.ApplyOptions(defaultTransferServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if err := validateBaseTransfer(r.Transfer); err != nil {
+		return nil, err
+	}
+
+	var feeItem *repository.TransferFeeItem
+	if r.Transfer.FeePublicID != nil {
+		if r.FeeCategoryPublicID == nil {
+			return nil, fmt.Errorf("%w: missing feeCategoryId", ErrDataInsufficient)
+		}
+		feeItem = &repository.TransferFeeItem{
+			PublicID:         s.opts.genDailyItemPublicID(),
+			CategoryPublicID: *r.FeeCategoryPublicID,
+		}
+	}
+
+	row, err := s.repository.Create(ctx, &repository.CreateTransferRequest{
+		UserID:   r.UserID,
+		PublicID: s.opts.genPublicID(),
+		Transfer: parseBaseTransfer(r.Transfer),
+		FeeItem:  feeItem,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrReferenceNotFound) {
+			return nil, ErrReferenceNotFound
+		}
+		if errors.Is(err, repository.ErrInvalidReference) {
+			return nil, ErrCategoryTypeMismatch
+		}
+		if errors.Is(err, repository.ErrCurrencyMismatch) {
+			return nil, ErrCurrencyMismatch
+		}
+		return nil, err
+	}
+
+	return &CreateReply{
+		Transfer: parseTransfer(row),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListTransfersRequest{
+		UserID:          r.UserID,
+		AccountPublicID: r.AccountPublicID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ListReply{
+				Transfers: []*Transfer{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &ListReply{
+		Transfers: lo.Map(reply.Transfers, func(item *repository.Transfer, _ int) *Transfer {
+			return parseTransfer(item)
+		}),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.TransferPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.Delete(ctx, &repository.DeleteTransfersRequest{
+		UserID:            r.UserID,
+		TransferPublicIDs: []string{r.TransferPublicID},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrTransferNotFound
+		}
+		return nil, err
+	}
+	return &DeleteReply{}, nil
+}
+
+func validateBaseTransfer(v *BaseTransfer) error {
+	if v == nil {
+		return fmt.Errorf("%w: missing transfer", ErrDataInsufficient)
+	}
+	if v.Date.IsZero() {
+		return fmt.Errorf("%w: missing transfer.date", ErrDataInsufficient)
+	}
+	if v.FromAccountPublicID == "" {
+		return fmt.Errorf("%w: missing transfer.fromAccountId", ErrDataInsufficient)
+	}
+	if v.ToAccountPublicID == "" {
+		return fmt.Errorf("%w: missing transfer.toAccountId", ErrDataInsufficient)
+	}
+	if v.FromAccountPublicID == v.ToAccountPublicID {
+		return ErrSameAccount
+	}
+	if !v.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+func parseTransfer(v *repository.Transfer) *Transfer {
+	return &Transfer{
+		ID:           v.ID,
+		PublicID:     v.PublicID,
+		BaseTransfer: lo.ToPtr(BaseTransfer(*v.BaseTransfer)),
+		Fee:          v.Fee,
+	}
+}
+
+func parseBaseTransfer(v *BaseTransfer) *repository.BaseTransfer {
+	return lo.ToPtr(repository.BaseTransfer(*v))
+}
+
+type TransferServiceOptions struct {
+	genPublicID          func() string
+	genDailyItemPublicID func() string
+}
+
+func defaultTransferServiceOptions() *TransferServiceOptions {
+	return &TransferServiceOptions{
+		genPublicID: func() string {
+			return slugid.New("trf", 11)
+		},
+		genDailyItemPublicID: func() string {
+			return slugid.New("itm", 11)
+		},
+	}
+}
+
+func WithTransferServiceGenPublicID(f func() string) utils.Option[TransferServiceOptions] {
+	return func(o *TransferServiceOptions) {
+		o.genPublicID = f
+	}
+}
+
+// WithTransferServiceGenDailyItemPublicID sets the public id generator of the fee daily items.
+func WithTransferServiceGenDailyItemPublicID(f func() string) utils.Option[TransferServiceOptions] {
+	return func(o *TransferServiceOptions) {
+		o.genDailyItemPublicID = f
+	}
+}
@@ -0,0 +1,370 @@
+package transfers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Create(t *testing.T) {
+	t.Run("create with fee successful", func(t *testing.T) {
+		const (
+			userID            = "user-id"
+			publicID          = "publicID"
+			dailyItemPublicID = "dailyItemPublicID"
+			fromAccountID     = "fromAccountID"
+			toAccountID       = "toAccountID"
+			feeID             = "feeID"
+			feeCategoryID     = "feeCategoryID"
+
+			returnedTransferID = 9
+		)
+		var (
+			date   = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			amount = decimal.NewFromInt(1000)
+			fee    = decimal.NewFromInt(15)
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Create(gomock.Any(), &repository.CreateTransferRequest{
+					UserID:   userID,
+					PublicID: publicID,
+					Transfer: &repository.BaseTransfer{
+						Date:                date,
+						FromAccountPublicID: fromAccountID,
+						ToAccountPublicID:   toAccountID,
+						Amount:              amount,
+						FeePublicID:         lo.ToPtr(feeID),
+					},
+					FeeItem: &repository.TransferFeeItem{
+						PublicID:         dailyItemPublicID,
+						CategoryPublicID: feeCategoryID,
+					},
+				}).
+				Return(&repository.Transfer{
+					ID:       returnedTransferID,
+					PublicID: publicID,
+					BaseTransfer: &repository.BaseTransfer{
+						Date:                date,
+						FromAccountPublicID: fromAccountID,
+						ToAccountPublicID:   toAccountID,
+						Amount:              amount,
+						FeePublicID:         lo.ToPtr(feeID),
+					},
+					Fee: lo.ToPtr(fee),
+				}, nil),
+		)
+
+		s, err := NewService(
+			mockRepo,
+			WithTransferServiceGenPublicID(func() string {
+				return publicID
+			}),
+			WithTransferServiceGenDailyItemPublicID(func() string {
+				return dailyItemPublicID
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Transfer: &BaseTransfer{
+				Date:                date,
+				FromAccountPublicID: fromAccountID,
+				ToAccountPublicID:   toAccountID,
+				Amount:              amount,
+				FeePublicID:         lo.ToPtr(feeID),
+			},
+			FeeCategoryPublicID: lo.ToPtr(feeCategoryID),
+		})
+		assert.NoError(err)
+		assert.Equal(&CreateReply{
+			Transfer: &Transfer{
+				ID:       returnedTransferID,
+				PublicID: publicID,
+				BaseTransfer: &BaseTransfer{
+					Date:                date,
+					FromAccountPublicID: fromAccountID,
+					ToAccountPublicID:   toAccountID,
+					Amount:              amount,
+					FeePublicID:         lo.ToPtr(feeID),
+				},
+				Fee: lo.ToPtr(fee),
+			},
+		}, reply)
+	})
+	t.Run("missing fee category", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Transfer: &BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "fromAccountID",
+				ToAccountPublicID:   "toAccountID",
+				Amount:              decimal.NewFromInt(1),
+				FeePublicID:         lo.ToPtr("feeID"),
+			},
+		})
+		assert.ErrorIs(err, ErrDataInsufficient)
+		assert.Nil(reply)
+	})
+	t.Run("same account", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Transfer: &BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "accountID",
+				ToAccountPublicID:   "accountID",
+				Amount:              decimal.NewFromInt(1),
+			},
+		})
+		assert.ErrorIs(err, ErrSameAccount)
+		assert.Nil(reply)
+	})
+	t.Run("amount is not positive", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Transfer: &BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "fromAccountID",
+				ToAccountPublicID:   "toAccountID",
+				Amount:              decimal.Zero,
+			},
+		})
+		assert.ErrorIs(err, ErrInvalidAmount)
+		assert.Nil(reply)
+	})
+	t.Run("fee category is not an expense category", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrInvalidReference),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Transfer: &BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "fromAccountID",
+				ToAccountPublicID:   "toAccountID",
+				Amount:              decimal.NewFromInt(1),
+				FeePublicID:         lo.ToPtr("feeID"),
+			},
+			FeeCategoryPublicID: lo.ToPtr("incomeCategoryID"),
+		})
+		assert.ErrorIs(err, ErrCategoryTypeMismatch)
+		assert.Nil(reply)
+	})
+	t.Run("accounts in different currencies", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrCurrencyMismatch),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: "user-id",
+			Transfer: &BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: "usdAccountID",
+				ToAccountPublicID:   "twdAccountID",
+				Amount:              decimal.NewFromInt(1),
+			},
+		})
+		assert.ErrorIs(err, ErrCurrencyMismatch)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_List(t *testing.T) {
+	t.Run("list transfers of the account", func(t *testing.T) {
+		const (
+			userID    = "user-id"
+			accountID = "accountID"
+		)
+		var transfer = &repository.Transfer{
+			ID:       1,
+			PublicID: "publicID",
+			BaseTransfer: &repository.BaseTransfer{
+				Date:                time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				FromAccountPublicID: accountID,
+				ToAccountPublicID:   "toAccountID",
+				Amount:              decimal.NewFromInt(1),
+			},
+		}
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListTransfersRequest{
+					UserID:          userID,
+					AccountPublicID: lo.ToPtr(accountID),
+				}).
+				Return(&repository.ListTransfersReply{
+					Transfers: []*repository.Transfer{transfer},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID:          userID,
+			AccountPublicID: lo.ToPtr(accountID),
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Transfers: []*Transfer{
+				{
+					ID:       transfer.ID,
+					PublicID: transfer.PublicID,
+					BaseTransfer: &BaseTransfer{
+						Date:                transfer.Date,
+						FromAccountPublicID: accountID,
+						ToAccountPublicID:   "toAccountID",
+						Amount:              decimal.NewFromInt(1),
+					},
+				},
+			},
+		}, reply)
+	})
+	t.Run("no transfer", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: "user-id",
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Transfers: []*Transfer{},
+		}, reply)
+	})
+}
+
+func Test_service_Delete(t *testing.T) {
+	t.Run("delete successful", func(t *testing.T) {
+		const (
+			userID   = "user-id"
+			publicID = "publicID"
+		)
+
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Delete(gomock.Any(), &repository.DeleteTransfersRequest{
+					UserID:            userID,
+					TransferPublicIDs: []string{publicID},
+				}).
+				Return([]*repository.Transfer{{}}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:           userID,
+			TransferPublicID: publicID,
+		})
+		assert.NoError(err)
+		assert.Equal(&DeleteReply{}, reply)
+	})
+	t.Run("transfer not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockTransferRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:           "user-id",
+			TransferPublicID: "publicID",
+		})
+		assert.ErrorIs(err, ErrTransferNotFound)
+		assert.Nil(reply)
+	})
+}
@@ -0,0 +1,139 @@
+package unitconversions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kataras/iris/v12"
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+
+	irisController "github.com/n101661/maney/server/controller/iris"
+	"github.com/n101661/maney/server/models"
+	"github.com/n101661/maney/server/repository"
+)
+
+type IrisController struct {
+	*irisController.SimpleCreateTemplate[models.BasicUnitConversion, CreateRequest, CreateReply, models.ObjectId]
+	*irisController.SimpleListTemplate[ListRequest, ListReply, []*models.UnitConversion]
+	*irisController.SimpleUpdateTemplate[models.BasicUnitConversion, UpdateRequest, UpdateReply]
+	*irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]
+}
+
+func NewIrisController(s Service) *IrisController {
+	return &IrisController{
+		SimpleCreateTemplate: &irisController.SimpleCreateTemplate[models.BasicUnitConversion, CreateRequest, CreateReply, models.ObjectId]{
+			Service: s,
+			ParseServiceRequest: func(userID string, r *models.BasicUnitConversion) (*CreateRequest, error) {
+				conversion, err := toServiceBaseUnitConversion(r)
+				if err != nil {
+					return nil, err
+				}
+				return &CreateRequest{
+					UserID:     userID,
+					Conversion: conversion,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrUnitConversionExists):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidUnitConversion),
+					errors.Is(err, ErrCircularUnitConversion):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *CreateReply) (*models.ObjectId, error) {
+				return &models.ObjectId{
+					Id: lo.ToPtr(models.Id(reply.Conversion.PublicID)),
+				}, nil
+			},
+		},
+		SimpleListTemplate: &irisController.SimpleListTemplate[ListRequest, ListReply, []*models.UnitConversion]{
+			Service: s,
+			ParseServiceRequest: func(c iris.Context, userID string) (*ListRequest, error) {
+				return &ListRequest{
+					UserID: userID,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+			ParseAPIResponse: func(reply *ListReply) (*[]*models.UnitConversion, error) {
+				return lo.ToPtr(lo.Map(reply.Conversions, func(item *UnitConversion, _ int) *models.UnitConversion {
+					return &models.UnitConversion{
+						Id:     lo.ToPtr(models.Id(item.PublicID)),
+						Unit:   models.Unit(item.Unit),
+						ToUnit: models.Unit(item.ToUnit),
+						Factor: item.Factor.String(),
+					}
+				})), nil
+			},
+		},
+		SimpleUpdateTemplate: &irisController.SimpleUpdateTemplate[models.BasicUnitConversion, UpdateRequest, UpdateReply]{
+			Placeholder: "unitConversionId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string, r *models.BasicUnitConversion) (*UpdateRequest, error) {
+				conversion, err := toServiceBaseUnitConversion(r)
+				if err != nil {
+					return nil, err
+				}
+				return &UpdateRequest{
+					UserID:                 userID,
+					UnitConversionPublicID: publicID,
+					Conversion:             conversion,
+				}, nil
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrUnitConversionNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrUnitConversionExists):
+					return iris.StatusConflict, true
+				case errors.Is(err, ErrDataInsufficient),
+					errors.Is(err, ErrInvalidUnitConversion),
+					errors.Is(err, ErrCircularUnitConversion):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+		SimpleDeleteTemplate: &irisController.SimpleDeleteTemplate[DeleteRequest, DeleteReply]{
+			Placeholder: "unitConversionId",
+			Service:     s,
+			ParseServiceRequest: func(userID string, publicID string) *DeleteRequest {
+				return &DeleteRequest{
+					UserID:                 userID,
+					UnitConversionPublicID: publicID,
+				}
+			},
+			BadRequest: func(err error) (httpCode int, yes bool) {
+				switch {
+				case errors.Is(err, ErrUnitConversionNotFound):
+					return iris.StatusNotFound, true
+				case errors.Is(err, ErrDataInsufficient):
+					return iris.StatusBadRequest, true
+				}
+				return 0, false
+			},
+		},
+	}
+}
+
+func toServiceBaseUnitConversion(r *models.BasicUnitConversion) (*BaseUnitConversion, error) {
+	factor, err := decimal.NewFromString(r.Factor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decimal[%s]", r.Factor)
+	}
+	return &BaseUnitConversion{
+		Unit:   repository.Unit(r.Unit),
+		ToUnit: repository.Unit(r.ToUnit),
+		Factor: factor,
+	}, nil
+}
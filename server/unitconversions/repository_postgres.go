@@ -0,0 +1,173 @@
+package unitconversions
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"github.com/shopspring/decimal"
+	"xorm.io/xorm"
+
+	"github.com/n101661/maney/server/repository"
+	"github.com/n101661/maney/server/repository/postgres"
+)
+
+type postgresRepository struct {
+	engine *xorm.Engine
+}
+
+func NewPostgresRepository(engine *xorm.Engine) (repository.UnitConversionRepository, error) {
+	return &postgresRepository{
+		engine: engine,
+	}, nil
+}
+
+func (repo *postgresRepository) Create(ctx context.Context, r *repository.CreateUnitConversionsRequest) ([]*repository.UnitConversion, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	return CreateWithSession(session, r)
+}
+
+// CreateWithSession is the same as Create, but it creates the conversions with the
+// session so that they can be created in the transaction of the session.
+func CreateWithSession(session *xorm.Session, r *repository.CreateUnitConversionsRequest) ([]*repository.UnitConversion, error) {
+	rows := lo.Map(r.Conversions, func(item *repository.BaseCreateUnitConversion, _ int) *postgres.UnitConversionsModel {
+		return &postgres.UnitConversionsModel{
+			PublicID: item.PublicID,
+			UserID:   r.UserID,
+			Unit:     item.Unit,
+			ToUnit:   item.ToUnit,
+			Factor:   decimal.NewNullDecimal(item.Factor),
+		}
+	})
+	_, err := session.Insert(rows)
+	if err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	return lo.Map(rows, func(item *postgres.UnitConversionsModel, _ int) *repository.UnitConversion {
+		return toUnitConversion(item)
+	}), nil
+}
+
+func (repo *postgresRepository) List(ctx context.Context, r *repository.ListUnitConversionsRequest) (*repository.ListUnitConversionsReply, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	var rows []*postgres.UnitConversionsModel
+	err := session.Asc("unit").Find(&rows, &postgres.UnitConversionsModel{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+	return &repository.ListUnitConversionsReply{
+		Conversions: lo.Map(rows, func(item *postgres.UnitConversionsModel, _ int) *repository.UnitConversion {
+			return toUnitConversion(item)
+		}),
+	}, nil
+}
+
+func (repo *postgresRepository) Update(ctx context.Context, r *repository.UpdateUnitConversionRequest) (*repository.UnitConversion, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	row := postgres.UnitConversionsModel{
+		PublicID: r.UnitConversionPublicID,
+		UserID:   r.UserID,
+	}
+	has, err := session.Get(&row)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, repository.ErrDataNotFound
+	}
+
+	cols := []string{}
+	bean := postgres.UnitConversionsModel{}
+	if r.Conversion != nil {
+		cols = append(cols, "unit", "to_unit", "factor")
+
+		row.Unit = r.Conversion.Unit
+		row.ToUnit = r.Conversion.ToUnit
+		row.Factor = decimal.NewNullDecimal(r.Conversion.Factor)
+		bean.Unit = row.Unit
+		bean.ToUnit = row.ToUnit
+		bean.Factor = row.Factor
+	}
+
+	affected, err := session.Cols(cols...).Update(&bean, &postgres.UnitConversionsModel{
+		ID: row.ID,
+	})
+	if err != nil {
+		if postgres.UniqueViolationError(err) {
+			return nil, repository.ErrDataExists
+		}
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	return toUnitConversion(&row), nil
+}
+
+func (repo *postgresRepository) Delete(ctx context.Context, r *repository.DeleteUnitConversionsRequest) ([]*repository.UnitConversion, error) {
+	session := repo.engine.NewSession().Context(ctx)
+	defer session.Close()
+
+	if err := session.Begin(); err != nil {
+		return nil, err
+	}
+
+	session.Where("user_id = ?", r.UserID)
+	if len(r.UnitConversionPublicIDs) > 0 {
+		session.In("public_id", r.UnitConversionPublicIDs)
+	}
+
+	var rows []*postgres.UnitConversionsModel
+	err := session.Find(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.UnitConversionPublicIDs) > 0 && len(rows) != len(r.UnitConversionPublicIDs) {
+		return nil, repository.ErrDataNotFound
+	}
+	if len(r.UnitConversionPublicIDs) == 0 && len(rows) == 0 {
+		return nil, repository.ErrDataNotFound
+	}
+
+	ids := lo.Map(rows, func(item *postgres.UnitConversionsModel, _ int) any {
+		return item.ID
+	})
+	_, err = session.In("id", ids).Delete(&postgres.UnitConversionsModel{})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.Commit(); err != nil {
+		return nil, err
+	}
+	return lo.Map(rows, func(item *postgres.UnitConversionsModel, _ int) *repository.UnitConversion {
+		return toUnitConversion(item)
+	}), nil
+}
+
+func toUnitConversion(item *postgres.UnitConversionsModel) *repository.UnitConversion {
+	return &repository.UnitConversion{
+		ID:       item.ID,
+		PublicID: item.PublicID,
+		BaseUnitConversion: &repository.BaseUnitConversion{
+			Unit:   item.Unit,
+			ToUnit: item.ToUnit,
+			Factor: item.Factor.Decimal,
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package unitconversions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+var (
+	ErrDataInsufficient       = fmt.Errorf("data insufficient")
+	ErrUnitConversionNotFound = fmt.Errorf("unit conversion not found")
+	ErrUnitConversionExists   = fmt.Errorf("conversion of the same unit exists")
+	ErrInvalidUnitConversion  = fmt.Errorf("invalid unit conversion")
+	ErrCircularUnitConversion = fmt.Errorf("the unit is converted into itself")
+)
+
+type Service interface {
+	// Create returns error:
+	//  - ErrDataInsufficient if any of fields of CreateRequest is zero-value,
+	//  - ErrInvalidUnitConversion if the unit is not convertible, the unit to convert into is
+	//    not supported or the same as the unit, or the factor is not positive,
+	//  - ErrCircularUnitConversion if the unit is converted into itself by the conversions of the user,
+	//  - ErrUnitConversionExists if the user has the conversion of the same unit.
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	// List returns the unit conversions sorted by unit, it returns ErrDataInsufficient if any
+	// of fields of ListRequest is zero-value.
+	List(context.Context, *ListRequest) (*ListReply, error)
+	// Update returns error:
+	//  - ErrDataInsufficient if any of fields of UpdateRequest is zero-value,
+	//  - ErrInvalidUnitConversion if the unit is not convertible, the unit to convert into is
+	//    not supported or the same as the unit, or the factor is not positive,
+	//  - ErrCircularUnitConversion if the unit is converted into itself by the conversions of the user,
+	//  - ErrUnitConversionNotFound if the unit conversion does not exist,
+	//  - ErrUnitConversionExists if the user has another conversion of the same unit.
+	//
+	// The normalized prices of the existing items are not recomputed.
+	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
+	// Delete returns error:
+	//  - ErrDataInsufficient if any of fields of DeleteRequest is zero-value,
+	//  - ErrUnitConversionNotFound if the unit conversion does not exist.
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+}
+
+// BaseUnitConversion converts the unit into the other unit, 1 Unit is Factor ToUnit, such
+// as 1 pack is 6 pcs.
+type BaseUnitConversion struct {
+	// Unit is unique of the user, only pcs and pack are convertible because the others
+	// have the built-in conversions.
+	Unit   repository.Unit
+	ToUnit repository.Unit
+	Factor decimal.Decimal
+}
+
+type UnitConversion struct {
+	ID       int32
+	PublicID string
+	*BaseUnitConversion
+}
+
+type CreateRequest struct {
+	UserID     string
+	Conversion *BaseUnitConversion
+}
+
+type CreateReply struct {
+	Conversion *UnitConversion
+}
+
+type ListRequest struct {
+	UserID string
+}
+
+type ListReply struct {
+	Conversions []*UnitConversion
+}
+
+type UpdateRequest struct {
+	UserID                 string
+	UnitConversionPublicID string
+	Conversion             *BaseUnitConversion
+}
+
+type UpdateReply struct {
+	Conversion *UnitConversion
+}
+
+type DeleteRequest struct {
+	UserID                 string
+	UnitConversionPublicID string
+}
+
+type DeleteReply struct{}
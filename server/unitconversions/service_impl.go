@@ -0,0 +1,238 @@
+package unitconversions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samber/lo"
+
+	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
+	"github.com/n101661/maney/server/repository"
+)
+
+type service struct {
+	repository repository.UnitConversionRepository
+
+	opts *UnitConversionServiceOptions
+}
+
+func NewService(
+	repository repository.UnitConversionRepository,
+	opts ...utils.Option[UnitConversionServiceOptions],
+) (Service, error) {
+	return &service{
+		repository: repository,
+		opts:       utils.ApplyOptions(defaultUnitConversionServiceOptions(), opts),
+	}, nil
+}
+
+func (s *service) Create(ctx context.Context, r *CreateRequest) (*CreateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if err := validateBaseUnitConversion(r.Conversion); err != nil {
+		return nil, err
+	}
+	if err := s.validateNotCircular(ctx, r.UserID, "", r.Conversion); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repository.Create(ctx, &repository.CreateUnitConversionsRequest{
+		UserID: r.UserID,
+		Conversions: []*repository.BaseCreateUnitConversion{
+			{
+				PublicID:           s.opts.genPublicID(),
+				BaseUnitConversion: parseBaseUnitConversion(r.Conversion),
+			},
+		},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataExists) {
+			return nil, ErrUnitConversionExists
+		}
+		return nil, err
+	}
+
+	return &CreateReply{
+		Conversion: parseUnitConversion(rows[0]),
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, r *ListRequest) (*ListReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+
+	reply, err := s.repository.List(ctx, &repository.ListUnitConversionsRequest{
+		UserID: r.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return &ListReply{
+				Conversions: []*UnitConversion{},
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &ListReply{
+		Conversions: lo.Map(reply.Conversions, func(item *repository.UnitConversion, _ int) *UnitConversion {
+			return parseUnitConversion(item)
+		}),
+	}, nil
+}
+
+func (s *service) Update(ctx context.Context, r *UpdateRequest) (*UpdateReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.UnitConversionPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+	if err := validateBaseUnitConversion(r.Conversion); err != nil {
+		return nil, err
+	}
+	if err := s.validateNotCircular(ctx, r.UserID, r.UnitConversionPublicID, r.Conversion); err != nil {
+		return nil, err
+	}
+
+	row, err := s.repository.Update(ctx, &repository.UpdateUnitConversionRequest{
+		UserID:                 r.UserID,
+		UnitConversionPublicID: r.UnitConversionPublicID,
+		Conversion:             parseBaseUnitConversion(r.Conversion),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrUnitConversionNotFound
+		}
+		if errors.Is(err, repository.ErrDataExists) {
+			return nil, ErrUnitConversionExists
+		}
+		return nil, err
+	}
+
+	return &UpdateReply{
+		Conversion: parseUnitConversion(row),
+	}, nil
+}
+
+func (s *service) Delete(ctx context.Context, r *DeleteRequest) (*DeleteReply, error) {
+	if r.UserID == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrDataInsufficient)
+	}
+	if r.UnitConversionPublicID == "" {
+		return nil, fmt.Errorf("%w: missing public id", ErrDataInsufficient)
+	}
+
+	_, err := s.repository.Delete(ctx, &repository.DeleteUnitConversionsRequest{
+		UserID:                  r.UserID,
+		UnitConversionPublicIDs: []string{r.UnitConversionPublicID},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrDataNotFound) {
+			return nil, ErrUnitConversionNotFound
+		}
+		return nil, err
+	}
+	return &DeleteReply{}, nil
+}
+
+// validateNotCircular follows the conversions of the user from the unit which the conversion
+// converts into, with the conversion replacing the one of publicID, and returns
+// ErrCircularUnitConversion if it reaches the unit of the conversion.
+func (s *service) validateNotCircular(ctx context.Context, userID, publicID string, conversion *BaseUnitConversion) error {
+	reply, err := s.repository.List(ctx, &repository.ListUnitConversionsRequest{
+		UserID: userID,
+	})
+	if err != nil && !errors.Is(err, repository.ErrDataNotFound) {
+		return err
+	}
+
+	toUnits := map[repository.Unit]repository.Unit{}
+	if reply != nil {
+		for _, item := range reply.Conversions {
+			if item.PublicID == publicID {
+				continue
+			}
+			toUnits[item.Unit] = item.ToUnit
+		}
+	}
+	toUnits[conversion.Unit] = conversion.ToUnit
+
+	visited := map[repository.Unit]bool{}
+	for unit := conversion.ToUnit; !visited[unit]; {
+		if unit == conversion.Unit {
+			return ErrCircularUnitConversion
+		}
+		visited[unit] = true
+
+		next, ok := toUnits[unit]
+		if !ok {
+			break
+		}
+		unit = next
+	}
+	return nil
+}
+
+func validateBaseUnitConversion(v *BaseUnitConversion) error {
+	if v == nil {
+		return fmt.Errorf("%w: missing conversion", ErrDataInsufficient)
+	}
+	if v.Unit == repository.UnitNone {
+		return fmt.Errorf("%w: missing conversion.unit", ErrDataInsufficient)
+	}
+	if v.ToUnit == repository.UnitNone {
+		return fmt.Errorf("%w: missing conversion.toUnit", ErrDataInsufficient)
+	}
+	if !v.Unit.Convertible() {
+		return fmt.Errorf("%w: the unit %s is not convertible", ErrInvalidUnitConversion, v.Unit)
+	}
+	if !v.ToUnit.Valid() || v.ToUnit == v.Unit {
+		return fmt.Errorf("%w: invalid unit %s to convert into", ErrInvalidUnitConversion, v.ToUnit)
+	}
+	if !v.Factor.IsPositive() {
+		return fmt.Errorf("%w: the factor must be positive", ErrInvalidUnitConversion)
+	}
+	return nil
+}
+
+func parseUnitConversion(v *repository.UnitConversion) *UnitConversion {
+	return &UnitConversion{
+		ID:       v.ID,
+		PublicID: v.PublicID,
+		BaseUnitConversion: &BaseUnitConversion{
+			Unit:   v.Unit,
+			ToUnit: v.ToUnit,
+			Factor: v.Factor,
+		},
+	}
+}
+
+func parseBaseUnitConversion(v *BaseUnitConversion) *repository.BaseUnitConversion {
+	return &repository.BaseUnitConversion{
+		Unit:   v.Unit,
+		ToUnit: v.ToUnit,
+		Factor: v.Factor,
+	}
+}
+
+type UnitConversionServiceOptions struct {
+	genPublicID func() string
+}
+
+func defaultUnitConversionServiceOptions() *UnitConversionServiceOptions {
+	return &UnitConversionServiceOptions{
+		genPublicID: func() string {
+			return slugid.New("unc", 11)
+		},
+	}
+}
+
+func WithUnitConversionServiceGenPublicID(f func() string) utils.Option[UnitConversionServiceOptions] {
+	return func(o *UnitConversionServiceOptions) {
+		o.genPublicID = f
+	}
+}
@@ -0,0 +1,472 @@
+package unitconversions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/n101661/maney/server/repository"
+)
+
+func Test_service_Create(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "publicID"
+	)
+
+	t.Run("create successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListUnitConversionsRequest{
+					UserID: userID,
+				}).
+				Return(nil, repository.ErrDataNotFound),
+			mockRepo.EXPECT().
+				Create(gomock.Any(), &repository.CreateUnitConversionsRequest{
+					UserID: userID,
+					Conversions: []*repository.BaseCreateUnitConversion{
+						{
+							PublicID: publicID,
+							BaseUnitConversion: &repository.BaseUnitConversion{
+								Unit:   repository.UnitPack,
+								ToUnit: repository.UnitPiece,
+								Factor: decimal.NewFromInt(6),
+							},
+						},
+					},
+				}).
+				Return([]*repository.UnitConversion{
+					{
+						ID:       1,
+						PublicID: publicID,
+						BaseUnitConversion: &repository.BaseUnitConversion{
+							Unit:   repository.UnitPack,
+							ToUnit: repository.UnitPiece,
+							Factor: decimal.NewFromInt(6),
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo, WithUnitConversionServiceGenPublicID(func() string {
+			return publicID
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Conversion: &BaseUnitConversion{
+				Unit:   repository.UnitPack,
+				ToUnit: repository.UnitPiece,
+				Factor: decimal.NewFromInt(6),
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&CreateReply{
+			Conversion: &UnitConversion{
+				ID:       1,
+				PublicID: publicID,
+				BaseUnitConversion: &BaseUnitConversion{
+					Unit:   repository.UnitPack,
+					ToUnit: repository.UnitPiece,
+					Factor: decimal.NewFromInt(6),
+				},
+			},
+		}, reply)
+	})
+	t.Run("conversion of the same unit exists", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+			mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataExists),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Conversion: &BaseUnitConversion{
+				Unit:   repository.UnitPack,
+				ToUnit: repository.UnitPiece,
+				Factor: decimal.NewFromInt(6),
+			},
+		})
+		assert.ErrorIs(err, ErrUnitConversionExists)
+		assert.Nil(reply)
+	})
+	t.Run("circular conversion", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListUnitConversionsReply{
+				Conversions: []*repository.UnitConversion{
+					{
+						ID:       1,
+						PublicID: "unc-1",
+						BaseUnitConversion: &repository.BaseUnitConversion{
+							Unit:   repository.UnitPack,
+							ToUnit: repository.UnitPiece,
+							Factor: decimal.NewFromInt(6),
+						},
+					},
+				},
+			}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Conversion: &BaseUnitConversion{
+				Unit:   repository.UnitPiece,
+				ToUnit: repository.UnitPack,
+				Factor: decimal.RequireFromString("0.5"),
+			},
+		})
+		assert.ErrorIs(err, ErrCircularUnitConversion)
+		assert.Nil(reply)
+	})
+	t.Run("invalid conversion", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			conversion *BaseUnitConversion
+		}{
+			{
+				name: "the unit has the built-in conversion",
+				conversion: &BaseUnitConversion{
+					Unit:   repository.UnitKilogram,
+					ToUnit: repository.UnitPiece,
+					Factor: decimal.NewFromInt(4),
+				},
+			},
+			{
+				name: "unsupported unit to convert into",
+				conversion: &BaseUnitConversion{
+					Unit:   repository.UnitPack,
+					ToUnit: "box",
+					Factor: decimal.NewFromInt(4),
+				},
+			},
+			{
+				name: "convert into the same unit",
+				conversion: &BaseUnitConversion{
+					Unit:   repository.UnitPack,
+					ToUnit: repository.UnitPack,
+					Factor: decimal.NewFromInt(4),
+				},
+			},
+			{
+				name: "factor is not positive",
+				conversion: &BaseUnitConversion{
+					Unit:   repository.UnitPack,
+					ToUnit: repository.UnitGram,
+					Factor: decimal.Zero,
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+
+				controller := gomock.NewController(t)
+				mockRepo := repository.NewMockUnitConversionRepository(controller)
+
+				s, err := NewService(mockRepo)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reply, err := s.Create(context.Background(), &CreateRequest{
+					UserID:     userID,
+					Conversion: tt.conversion,
+				})
+				assert.ErrorIs(err, ErrInvalidUnitConversion)
+				assert.Nil(reply)
+			})
+		}
+	})
+	t.Run("missing unit", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Create(context.Background(), &CreateRequest{
+			UserID: userID,
+			Conversion: &BaseUnitConversion{
+				ToUnit: repository.UnitPiece,
+				Factor: decimal.NewFromInt(6),
+			},
+		})
+		assert.ErrorIs(err, ErrDataInsufficient)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_List(t *testing.T) {
+	const userID = "user-id"
+
+	t.Run("list unit conversions", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				List(gomock.Any(), &repository.ListUnitConversionsRequest{
+					UserID: userID,
+				}).
+				Return(&repository.ListUnitConversionsReply{
+					Conversions: []*repository.UnitConversion{
+						{
+							ID:       1,
+							PublicID: "unc-1",
+							BaseUnitConversion: &repository.BaseUnitConversion{
+								Unit:   repository.UnitPack,
+								ToUnit: repository.UnitPiece,
+								Factor: decimal.NewFromInt(6),
+							},
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: userID,
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Conversions: []*UnitConversion{
+				{
+					ID:       1,
+					PublicID: "unc-1",
+					BaseUnitConversion: &BaseUnitConversion{
+						Unit:   repository.UnitPack,
+						ToUnit: repository.UnitPiece,
+						Factor: decimal.NewFromInt(6),
+					},
+				},
+			},
+		}, reply)
+	})
+	t.Run("no unit conversion", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.List(context.Background(), &ListRequest{
+			UserID: userID,
+		})
+		assert.NoError(err)
+		assert.Equal(&ListReply{
+			Conversions: []*UnitConversion{},
+		}, reply)
+	})
+}
+
+func Test_service_Update(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "unc-1"
+	)
+
+	t.Run("update successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			// the conversion which is updated is not considered circular with its old version.
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(&repository.ListUnitConversionsReply{
+				Conversions: []*repository.UnitConversion{
+					{
+						ID:       1,
+						PublicID: publicID,
+						BaseUnitConversion: &repository.BaseUnitConversion{
+							Unit:   repository.UnitPiece,
+							ToUnit: repository.UnitPack,
+							Factor: decimal.RequireFromString("0.1"),
+						},
+					},
+				},
+			}, nil),
+			mockRepo.EXPECT().
+				Update(gomock.Any(), &repository.UpdateUnitConversionRequest{
+					UserID:                 userID,
+					UnitConversionPublicID: publicID,
+					Conversion: &repository.BaseUnitConversion{
+						Unit:   repository.UnitPack,
+						ToUnit: repository.UnitPiece,
+						Factor: decimal.NewFromInt(10),
+					},
+				}).
+				Return(&repository.UnitConversion{
+					ID:       1,
+					PublicID: publicID,
+					BaseUnitConversion: &repository.BaseUnitConversion{
+						Unit:   repository.UnitPack,
+						ToUnit: repository.UnitPiece,
+						Factor: decimal.NewFromInt(10),
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:                 userID,
+			UnitConversionPublicID: publicID,
+			Conversion: &BaseUnitConversion{
+				Unit:   repository.UnitPack,
+				ToUnit: repository.UnitPiece,
+				Factor: decimal.NewFromInt(10),
+			},
+		})
+		assert.NoError(err)
+		assert.Equal(&UpdateReply{
+			Conversion: &UnitConversion{
+				ID:       1,
+				PublicID: publicID,
+				BaseUnitConversion: &BaseUnitConversion{
+					Unit:   repository.UnitPack,
+					ToUnit: repository.UnitPiece,
+					Factor: decimal.NewFromInt(10),
+				},
+			},
+		}, reply)
+	})
+	t.Run("unit conversion not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Update(context.Background(), &UpdateRequest{
+			UserID:                 userID,
+			UnitConversionPublicID: publicID,
+			Conversion: &BaseUnitConversion{
+				Unit:   repository.UnitPack,
+				ToUnit: repository.UnitPiece,
+				Factor: decimal.NewFromInt(10),
+			},
+		})
+		assert.ErrorIs(err, ErrUnitConversionNotFound)
+		assert.Nil(reply)
+	})
+}
+
+func Test_service_Delete(t *testing.T) {
+	const (
+		userID   = "user-id"
+		publicID = "unc-1"
+	)
+
+	t.Run("delete successful", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				Delete(gomock.Any(), &repository.DeleteUnitConversionsRequest{
+					UserID:                  userID,
+					UnitConversionPublicIDs: []string{publicID},
+				}).
+				Return([]*repository.UnitConversion{
+					{
+						ID:       1,
+						PublicID: publicID,
+						BaseUnitConversion: &repository.BaseUnitConversion{
+							Unit:   repository.UnitPack,
+							ToUnit: repository.UnitPiece,
+							Factor: decimal.NewFromInt(6),
+						},
+					},
+				}, nil),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:                 userID,
+			UnitConversionPublicID: publicID,
+		})
+		assert.NoError(err)
+		assert.Equal(&DeleteReply{}, reply)
+	})
+	t.Run("unit conversion not found", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		mockRepo := repository.NewMockUnitConversionRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, repository.ErrDataNotFound),
+		)
+
+		s, err := NewService(mockRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.Delete(context.Background(), &DeleteRequest{
+			UserID:                 userID,
+			UnitConversionPublicID: publicID,
+		})
+		assert.ErrorIs(err, ErrUnitConversionNotFound)
+		assert.Nil(reply)
+	})
+}
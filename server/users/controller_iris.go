@@ -7,7 +7,9 @@ import (
 
 	"github.com/kataras/golog"
 	"github.com/kataras/iris/v12"
+	"github.com/samber/lo"
 
+	"github.com/n101661/maney/pkg/currency"
 	"github.com/n101661/maney/pkg/utils"
 	httpModels "github.com/n101661/maney/server/models"
 )
@@ -233,6 +235,15 @@ func (controller *IrisController) UpdateUserConfig(c iris.Context) {
 		c.WriteString(err.Error())
 		return
 	}
+	if r.HomeCurrency != nil {
+		homeCurrency, err := currency.Normalize(*r.HomeCurrency)
+		if err != nil {
+			c.StatusCode(iris.StatusBadRequest)
+			c.WriteString(err.Error())
+			return
+		}
+		r.HomeCurrency = lo.EmptyableToPtr(homeCurrency)
+	}
 
 	userID, err := c.User().GetID()
 	if err != nil {
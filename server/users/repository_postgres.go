@@ -7,6 +7,8 @@ import (
 
 	"xorm.io/xorm"
 
+	"github.com/n101661/maney/server/accounts"
+	"github.com/n101661/maney/server/categories"
 	"github.com/n101661/maney/server/repository"
 	"github.com/n101661/maney/server/repository/postgres"
 	"github.com/samber/lo"
@@ -22,15 +24,19 @@ func NewPostgresRepository(engine *xorm.Engine) (repository.UserRepository, erro
 	}, nil
 }
 
-func (repo *postgresRepository) CreateUser(ctx context.Context, user *repository.UserModel) error {
+func (repo *postgresRepository) CreateUser(ctx context.Context, r *repository.CreateUserRequest) error {
 	session := repo.engine.NewSession().Context(ctx)
 	defer session.Close()
 
+	if err := session.Begin(); err != nil {
+		return err
+	}
+
 	_, err := session.Insert(postgres.UsersModel{
-		ID:       user.ID,
-		Password: user.Password,
+		ID:       r.User.ID,
+		Password: r.User.Password,
 		Config: &postgres.UserConfig{
-			UserConfig: user.Config,
+			UserConfig: r.User.Config,
 		},
 	})
 	if err != nil {
@@ -39,7 +45,37 @@ func (repo *postgresRepository) CreateUser(ctx context.Context, user *repository
 		}
 		return err
 	}
-	return nil
+
+	for _, category := range []struct {
+		Type  repository.CategoryType
+		Items []*repository.BaseCreateCategory
+	}{
+		{Type: repository.CategoryTypeExpense, Items: r.ExpenseCategories},
+		{Type: repository.CategoryTypeIncome, Items: r.IncomeCategories},
+	} {
+		if len(category.Items) == 0 {
+			continue
+		}
+		_, err := categories.CreateWithSession(session, &repository.CreateCategoriesRequest{
+			UserID:     r.User.ID,
+			Type:       category.Type,
+			Categories: category.Items,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(r.Accounts) > 0 {
+		_, err := accounts.CreateWithSession(session, &repository.CreateAccountsRequest{
+			UserID:   r.User.ID,
+			Accounts: r.Accounts,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return session.Commit()
 }
 
 func (repo *postgresRepository) GetUser(ctx context.Context, userID string) (*repository.UserModel, error) {
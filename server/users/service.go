@@ -29,8 +29,8 @@ type Service interface {
 	//  - ErrTokenExpired if the token is expired
 	Logout(ctx context.Context, r *LogoutRequest) (*LogoutReply, error)
 
-	// SignUp creates a new user with the given data. If the user already exists it returns
-	// ErrUserExists error.
+	// SignUp creates a new user with the given data and the default categories and accounts
+	// of the sign-up template. If the user already exists it returns ErrUserExists error.
 	SignUp(ctx context.Context, r *SignUpRequest) (*SignUpReply, error)
 
 	// ValidateAccessToken validates if the access token is valid or not. It returns:
@@ -14,6 +14,7 @@ import (
 
 	jwt "github.com/golang-jwt/jwt/v5"
 	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/pkg/utils/slugid"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/n101661/maney/server/repository"
@@ -207,11 +208,22 @@ func (s *service) SignUp(ctx context.Context, r *SignUpRequest) (*SignUpReply, e
 		return nil, err
 	}
 
-	err = s.repository.CreateUser(ctx, &repository.UserModel{
-		ID:       r.UserID,
-		Password: encryptedPassword,
-		Config:   &UserConfig{},
-	})
+	req := &repository.CreateUserRequest{
+		User: &repository.UserModel{
+			ID:       r.UserID,
+			Password: encryptedPassword,
+			Config:   &UserConfig{},
+		},
+	}
+	if t := s.opts.signUpTemplate; t != nil {
+		req.ExpenseCategories = s.newTemplateCategories(t.ExpenseCategories, nil)
+		req.IncomeCategories = s.newTemplateCategories(t.IncomeCategories, nil)
+		for _, account := range t.Accounts {
+			req.Accounts = append(req.Accounts, s.newTemplateAccount(account))
+		}
+	}
+
+	err = s.repository.CreateUser(ctx, req)
 	if err != nil {
 		if errors.Is(err, repository.ErrDataExists) {
 			return nil, ErrUserExists
@@ -221,6 +233,42 @@ func (s *service) SignUp(ctx context.Context, r *SignUpRequest) (*SignUpReply, e
 	return &SignUpReply{}, nil
 }
 
+// newTemplateCategories returns the categories and their descendants in the template, the
+// parent categories are before their children.
+func (s *service) newTemplateCategories(categories []*TemplateCategory, parentPublicID *string) []*repository.BaseCreateCategory {
+	var result []*repository.BaseCreateCategory
+	for _, category := range categories {
+		publicID := s.opts.genPublicID("cat")
+		result = append(result, &repository.BaseCreateCategory{
+			PublicID: publicID,
+			BaseCategory: &repository.BaseCategory{
+				Name:           category.Name,
+				IconID:         category.IconID,
+				ParentPublicID: parentPublicID,
+			},
+		})
+		result = append(result, s.newTemplateCategories(category.Children, &publicID)...)
+	}
+	return result
+}
+
+func (s *service) newTemplateAccount(account *TemplateAccount) *repository.BaseCreateAccount {
+	accountType := repository.AccountTypeCash
+	if account.Type != "" {
+		// the type has been validated when the template is parsed.
+		accountType, _ = repository.ToAccountType(account.Type)
+	}
+	return &repository.BaseCreateAccount{
+		PublicID: s.opts.genPublicID("act"),
+		BaseAccount: &repository.BaseAccount{
+			Name:     account.Name,
+			IconID:   account.IconID,
+			Currency: account.Currency,
+			Type:     accountType,
+		},
+	}
+}
+
 func encryptPassword(pwd string, saltRound int) ([]byte, error) {
 	encrypted := hashValue([]byte(pwd))
 	return bcrypt.GenerateFromPassword(encrypted, saltRound)
@@ -303,6 +351,8 @@ type serviceOptions struct {
 	accessTokenSigningMethod jwt.SigningMethod
 	accessTokenExpireAfter   time.Duration
 	getNonce                 func() int
+	signUpTemplate           *SignUpTemplate
+	genPublicID              func(prefix string) string
 }
 
 func defaultOptions() *serviceOptions {
@@ -314,6 +364,9 @@ func defaultOptions() *serviceOptions {
 		getNonce: func() int {
 			return int(time.Now().UnixNano()) % 9999
 		},
+		genPublicID: func(prefix string) string {
+			return slugid.New(prefix, 11)
+		},
 	}
 }
 
@@ -347,6 +400,22 @@ func WithNonceGenerator(f func() int) utils.Option[serviceOptions] {
 	}
 }
 
+// WithSignUpTemplate sets the template of the default categories and accounts created for
+// the new users, nothing is created if it is nil.
+func WithSignUpTemplate(t *SignUpTemplate) utils.Option[serviceOptions] {
+	return func(o *serviceOptions) {
+		o.signUpTemplate = t
+	}
+}
+
+// WithGenPublicID sets the generator of the public ids of the default categories and
+// accounts, the prefix is the one of the resource, e.g. "act" for the accounts.
+func WithGenPublicID(f func(prefix string) string) utils.Option[serviceOptions] {
+	return func(o *serviceOptions) {
+		o.genPublicID = f
+	}
+}
+
 func hashValue(val []byte) []byte {
 	h := sha512.New()
 	h.Write(val)
@@ -2,6 +2,7 @@ package users
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/n101661/maney/pkg/utils"
+	"github.com/n101661/maney/server/icons"
 	"github.com/n101661/maney/server/repository"
 )
 
@@ -227,6 +229,156 @@ func Test_service_SignUp(t *testing.T) {
 		assert.NoError(err)
 		assert.Equal(&SignUpReply{}, reply)
 	})
+	t.Run("sign up with the default categories and accounts", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		iconValidator := icons.NewMockValidator(controller)
+		iconValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		template, err := ParseSignUpTemplate([]byte(`
+[[expense-categories]]
+name = "food"
+children = [{ name = "breakfast" }]
+
+[[income-categories]]
+name = "salary"
+icon-id = 1
+
+[[accounts]]
+name = "cash"
+
+[[accounts]]
+name = "bank"
+type = "bank"
+currency = "USD"
+`), iconValidator)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var actual *repository.CreateUserRequest
+		mockRepo := repository.NewMockUserRepository(controller)
+		gomock.InOrder(
+			mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, r *repository.CreateUserRequest) error {
+					actual = r
+					return nil
+				},
+			),
+		)
+
+		n := 0
+		s, err := newService(
+			mockRepo,
+			WithSignUpTemplate(template),
+			WithGenPublicID(func(prefix string) string {
+				n++
+				return fmt.Sprintf("%s-%d", prefix, n)
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		reply, err := s.SignUp(context.Background(), &SignUpRequest{
+			UserID:   "id",
+			Password: "password",
+		})
+		assert.NoError(err)
+		assert.Equal(&SignUpReply{}, reply)
+
+		assert.Equal("id", actual.User.ID)
+		assert.Equal([]*repository.BaseCreateCategory{
+			{
+				PublicID:     "cat-1",
+				BaseCategory: &repository.BaseCategory{Name: "food"},
+			},
+			{
+				PublicID: "cat-2",
+				BaseCategory: &repository.BaseCategory{
+					Name:           "breakfast",
+					ParentPublicID: lo.ToPtr("cat-1"),
+				},
+			},
+		}, actual.ExpenseCategories)
+		assert.Equal([]*repository.BaseCreateCategory{
+			{
+				PublicID:     "cat-3",
+				BaseCategory: &repository.BaseCategory{Name: "salary", IconID: 1},
+			},
+		}, actual.IncomeCategories)
+		assert.Equal([]*repository.BaseCreateAccount{
+			{
+				PublicID: "act-4",
+				BaseAccount: &repository.BaseAccount{
+					Name: "cash",
+					Type: repository.AccountTypeCash,
+				},
+			},
+			{
+				PublicID: "act-5",
+				BaseAccount: &repository.BaseAccount{
+					Name:     "bank",
+					Type:     repository.AccountTypeBank,
+					Currency: "USD",
+				},
+			},
+		}, actual.Accounts)
+	})
+	t.Run("built-in templates", func(t *testing.T) {
+		assert := assert.New(t)
+
+		iconService, err := icons.NewService(lo.Must(icons.LoadCatalog()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, locale := range []string{"zh-TW", "en"} {
+			template, err := LoadSignUpTemplate(locale, iconService)
+			assert.NoError(err, locale)
+			assert.Len(template.ExpenseCategories, 9, locale)
+			assert.Len(template.IncomeCategories, 1, locale)
+			assert.Len(template.Accounts, 1, locale)
+		}
+
+		_, err = LoadSignUpTemplate("unknown", iconService)
+		assert.Error(err)
+	})
+	t.Run("invalid template", func(t *testing.T) {
+		assert := assert.New(t)
+
+		controller := gomock.NewController(t)
+		iconValidator := icons.NewMockValidator(controller)
+		iconValidator.EXPECT().
+			Validate(gomock.Any(), &icons.ValidateRequest{
+				Kind:   icons.KindAccount,
+				IconID: 99,
+			}).
+			Return(icons.ErrIconNotFound)
+		iconValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		_, err := ParseSignUpTemplate([]byte(`
+[[expense-categories]]
+name = "food"
+children = [{ icon-id = 1 }]
+`), iconValidator)
+		assert.Error(err)
+
+		_, err = ParseSignUpTemplate([]byte(`
+[[accounts]]
+name = "cash"
+type = "unknown"
+`), iconValidator)
+		assert.Error(err)
+
+		_, err = ParseSignUpTemplate([]byte(`
+[[accounts]]
+name = "cash"
+icon-id = 99
+`), iconValidator)
+		assert.ErrorContains(err, icons.ErrIconNotFound.Error())
+	})
 	t.Run("user already exists", func(t *testing.T) {
 		assert := assert.New(t)
 
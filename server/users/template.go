@@ -0,0 +1,122 @@
+package users
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	toml "github.com/pelletier/go-toml/v2"
+
+	"github.com/n101661/maney/server/icons"
+	"github.com/n101661/maney/server/repository"
+)
+
+// DefaultLocale is the locale of the built-in sign-up template used by default.
+const DefaultLocale = "zh-TW"
+
+//go:embed templates/*.toml
+var templates embed.FS
+
+// SignUpTemplate defines the default categories and accounts created for the new users.
+type SignUpTemplate struct {
+	ExpenseCategories []*TemplateCategory `toml:"expense-categories"`
+	IncomeCategories  []*TemplateCategory `toml:"income-categories"`
+	Accounts          []*TemplateAccount  `toml:"accounts"`
+}
+
+type TemplateCategory struct {
+	Name   string `toml:"name"`
+	IconID int32  `toml:"icon-id"`
+	// Children are the sub-categories of the same type.
+	Children []*TemplateCategory `toml:"children"`
+}
+
+type TemplateAccount struct {
+	Name   string `toml:"name"`
+	IconID int32  `toml:"icon-id"`
+	// Type is the type of the account, such as "cash" and "bank". It is "cash" if it is empty.
+	Type string `toml:"type"`
+	// Currency is the ISO-4217 code of the account, it is empty for the home currency.
+	Currency string `toml:"currency"`
+}
+
+// LoadSignUpTemplate returns the built-in template of the locale, such as "zh-TW" and "en".
+func LoadSignUpTemplate(locale string, iconValidator icons.Validator) (*SignUpTemplate, error) {
+	data, err := templates.ReadFile(path.Join("templates", locale+".toml"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("unsupported locale[%s] of the sign-up template", locale)
+		}
+		return nil, err
+	}
+	return ParseSignUpTemplate(data, iconValidator)
+}
+
+// ReadSignUpTemplate returns the template in the TOML file.
+func ReadSignUpTemplate(name string, iconValidator icons.Validator) (*SignUpTemplate, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSignUpTemplate(data, iconValidator)
+}
+
+// ParseSignUpTemplate parses the template in TOML and validates it, the icons must be in
+// the catalog of the iconValidator.
+func ParseSignUpTemplate(data []byte, iconValidator icons.Validator) (*SignUpTemplate, error) {
+	var t SignUpTemplate
+	if err := toml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("invalid sign-up template: %v", err)
+	}
+
+	if err := validateTemplateCategories(t.ExpenseCategories, icons.KindExpense, iconValidator); err != nil {
+		return nil, err
+	}
+	if err := validateTemplateCategories(t.IncomeCategories, icons.KindIncome, iconValidator); err != nil {
+		return nil, err
+	}
+	for _, account := range t.Accounts {
+		if account.Name == "" {
+			return nil, fmt.Errorf("invalid sign-up template: missing name of account")
+		}
+		if account.Type != "" {
+			if _, err := repository.ToAccountType(account.Type); err != nil {
+				return nil, fmt.Errorf("invalid sign-up template: %v", err)
+			}
+		}
+		if err := validateTemplateIcon(icons.KindAccount, account.IconID, iconValidator); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
+
+func validateTemplateCategories(categories []*TemplateCategory, kind icons.Kind, iconValidator icons.Validator) error {
+	for _, category := range categories {
+		if category.Name == "" {
+			return fmt.Errorf("invalid sign-up template: missing name of category")
+		}
+		if err := validateTemplateIcon(kind, category.IconID, iconValidator); err != nil {
+			return err
+		}
+		if err := validateTemplateCategories(category.Children, kind, iconValidator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTemplateIcon(kind icons.Kind, iconID int32, iconValidator icons.Validator) error {
+	err := iconValidator.Validate(context.Background(), &icons.ValidateRequest{
+		Kind:   kind,
+		IconID: iconID,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid sign-up template: %v", err)
+	}
+	return nil
+}